@@ -187,10 +187,11 @@ var (
 		stakingtypes.NotBondedPoolName: {authtypes.Burner, authtypes.Staking},
 		govtypes.ModuleName:            {authtypes.Burner},
 		ibctransfertypes.ModuleName:    {authtypes.Minter, authtypes.Burner},
-		arkeomoduletypes.ModuleName:    {},
+		arkeomoduletypes.ModuleName:    {authtypes.Burner},
 		arkeomoduletypes.ReserveName:   {},
 		arkeomoduletypes.ProviderName:  {},
 		arkeomoduletypes.ContractName:  {},
+		arkeomoduletypes.VestingName:   {},
 		// this line is used by starport scaffolding # stargate/app/maccPerms
 	}
 )
@@ -436,20 +437,38 @@ func New(
 		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 	)
 
+	// the gov keeper is created below, it is passed by reference so the
+	// claim keeper sees it once it is
 	app.ClaimKeeper = claimmodulekeeper.NewKeeper(
 		appCodec,
 		keys[claimmoduletypes.StoreKey],
 		app.AccountKeeper,
 		app.BankKeeper,
+		&stakingKeeper,
+		&app.GovKeeper,
 		keys[claimmoduletypes.MemStoreKey],
 		app.GetSubspace(claimmoduletypes.ModuleName),
 	)
 	claimModule := claimmodule.NewAppModule(appCodec, app.ClaimKeeper, app.AccountKeeper, app.BankKeeper)
 
+	// the arkeo keeper only reads from the staking keeper, so it does not need
+	// the hooks below
+	arkeoKeeper := arkeomodulekeeper.NewKVStore(
+		appCodec,
+		keys[arkeomoduletypes.StoreKey],
+		keys[arkeomoduletypes.MemStoreKey],
+		app.GetSubspace(arkeomoduletypes.ModuleName),
+		app.BankKeeper,
+		app.AccountKeeper,
+		stakingKeeper,
+		app.DistrKeeper,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+	)
+
 	// register the staking hooks
 	// NOTE: stakingKeeper above is passed by reference, so that it will contain these hooks
 	app.StakingKeeper = *stakingKeeper.SetHooks(
-		stakingtypes.NewMultiStakingHooks(app.DistrKeeper.Hooks(), app.SlashingKeeper.Hooks(), app.ClaimKeeper.Hooks()),
+		stakingtypes.NewMultiStakingHooks(app.DistrKeeper.Hooks(), app.SlashingKeeper.Hooks(), app.ClaimKeeper.Hooks(), arkeoKeeper.Hooks()),
 	)
 
 	// ... other modules keepers
@@ -526,23 +545,18 @@ func New(
 		),
 	)
 
-	app.ArkeoKeeper = *arkeomodulekeeper.NewKVStore(
-		appCodec,
-		keys[arkeomoduletypes.StoreKey],
-		keys[arkeomoduletypes.MemStoreKey],
-		app.GetSubspace(arkeomoduletypes.ModuleName),
-		app.BankKeeper,
-		app.AccountKeeper,
-		app.StakingKeeper,
-	)
+	app.ArkeoKeeper = *arkeoKeeper
 	arkeoModule := arkeomodule.NewAppModule(appCodec, app.ArkeoKeeper, app.AccountKeeper, app.BankKeeper, app.StakingKeeper)
 
 	// this line is used by starport scaffolding # stargate/app/keeperDefinition
 
+	// transfers into arkeo may open a contract, see the transfer memo
+	transferStack := arkeomodule.NewIBCMiddleware(transferIBCModule, app.ArkeoKeeper, app.StakingKeeper)
+
 	// Create static IBC router, add transfer route, then set and seal it
 	ibcRouter := ibcporttypes.NewRouter()
 	ibcRouter.AddRoute(icahosttypes.SubModuleName, icaHostIBCModule).
-		AddRoute(ibctransfertypes.ModuleName, transferIBCModule)
+		AddRoute(ibctransfertypes.ModuleName, transferStack)
 	// this line is used by starport scaffolding # ibc/app/router
 	app.IBCKeeper.SetRouter(ibcRouter)
 
@@ -906,6 +920,7 @@ func initParamsKeeper(appCodec codec.BinaryCodec, legacyAmino *codec.LegacyAmino
 	paramsKeeper.Subspace(arkeomoduletypes.ReserveName)
 	paramsKeeper.Subspace(arkeomoduletypes.ProviderName)
 	paramsKeeper.Subspace(arkeomoduletypes.ContractName)
+	paramsKeeper.Subspace(arkeomoduletypes.VestingName)
 	paramsKeeper.Subspace(claimmoduletypes.ModuleName)
 	// this line is used by starport scaffolding # stargate/app/paramSubspace
 
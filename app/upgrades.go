@@ -0,0 +1,22 @@
+package app
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+// UpgradeName is the name of the on-chain upgrade plan handled by this binary
+const UpgradeName = "v2"
+
+// setupUpgradeHandlers registers the upgrade handlers, which run the in-place
+// store migrations of every module whose consensus version has changed
+func (app *App) setupUpgradeHandlers() {
+	app.UpgradeKeeper.SetUpgradeHandler(
+		UpgradeName,
+		func(ctx sdk.Context, plan upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+			ctx.Logger().Info("running upgrade handler", "name", plan.Name, "height", plan.Height)
+			return app.mm.RunMigrations(ctx, app.configurator, fromVM)
+		},
+	)
+}
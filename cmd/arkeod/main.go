@@ -8,6 +8,7 @@ import (
 
 	"github.com/arkeonetwork/arkeo/app"
 	"github.com/arkeonetwork/arkeo/arkeocli"
+	claimcli "github.com/arkeonetwork/arkeo/x/claim/client/cli"
 
 	svrcmd "github.com/cosmos/cosmos-sdk/server/cmd"
 	"github.com/ignite/cli/ignite/pkg/cosmoscmd"
@@ -26,6 +27,7 @@ func main() {
 	)
 	// add in arkeo specific utilities
 	rootCmd.AddCommand(arkeocli.GetArkeoCmd())
+	rootCmd.AddCommand(claimcli.GetGenesisCmd(app.DefaultNodeHome))
 	if err := svrcmd.Execute(rootCmd, "", app.DefaultNodeHome); err != nil {
 		os.Exit(1)
 	}
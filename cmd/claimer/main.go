@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/arkeonetwork/arkeo/app"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/sentinel"
+	"github.com/arkeonetwork/arkeo/sentinel/conf"
+)
+
+func main() {
+	c := cosmos.GetConfig()
+	c.SetBech32PrefixForAccount(app.AccountAddressPrefix, app.AccountAddressPrefix+"pub")
+
+	config := conf.NewClaimerConfiguration()
+	claimer, err := sentinel.NewClaimer(config)
+	if err != nil {
+		panic(err)
+	}
+	claimer.Run()
+}
@@ -36,8 +36,10 @@ var (
 	NewCoins                     = sdk.NewCoins
 	ParseCoin                    = sdk.ParseCoinNormalized
 	ParseCoins                   = sdk.ParseCoinsNormalized
+	ValidateDenom                = sdk.ValidateDenom
 	NewDecWithPrec               = sdk.NewDecWithPrec
 	NewDecFromBigInt             = sdk.NewDecFromBigInt
+	NewDecFromInt                = sdk.NewDecFromInt
 	NewIntFromBigInt             = sdk.NewIntFromBigInt
 	NewUintFromBigInt            = sdkmath.NewUintFromBigInt
 	ValAddressFromBech32         = sdk.ValAddressFromBech32
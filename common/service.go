@@ -67,6 +67,22 @@ var ServiceLookup = map[string]int32{
 	"ltc-mainnet-blockbook":        48,
 	"bch-mainnet-blockbook":        49,
 	"doge-mainnet-blockbook":       50,
+	"eth-mainnet-websocket":        51,
+	"avax-mainnet-websocket":       52,
+	"bsc-mainnet-websocket":        53,
+	"polygon-mainnet-websocket":    54,
+	"optimism-mainnet-websocket":   55,
+	"sol-mainnet-websocket":        56,
+	"gaia-mainnet-rest":            57,
+	"osmosis-mainnet-rpc":          58,
+	"osmosis-mainnet-rest":         59,
+	"osmosis-mainnet-grpc":         60,
+	"thorchain-mainnet-rpc":        61,
+	"thorchain-mainnet-rest":       62,
+	"thorchain-mainnet-grpc":       63,
+	"arkeo-mainnet-rpc":            64,
+	"arkeo-mainnet-rest":           65,
+	"arkeo-mainnet-grpc":           66,
 }
 
 var ServiceReverseLookup = map[Service]string{
@@ -121,6 +137,22 @@ var ServiceReverseLookup = map[Service]string{
 	48: "ltc-mainnet-blockbook",
 	49: "bch-mainnet-blockbook",
 	50: "doge-mainnet-blockbook",
+	51: "eth-mainnet-websocket",
+	52: "avax-mainnet-websocket",
+	53: "bsc-mainnet-websocket",
+	54: "polygon-mainnet-websocket",
+	55: "optimism-mainnet-websocket",
+	56: "sol-mainnet-websocket",
+	57: "gaia-mainnet-rest",
+	58: "osmosis-mainnet-rpc",
+	59: "osmosis-mainnet-rest",
+	60: "osmosis-mainnet-grpc",
+	61: "thorchain-mainnet-rpc",
+	62: "thorchain-mainnet-rest",
+	63: "thorchain-mainnet-grpc",
+	64: "arkeo-mainnet-rpc",
+	65: "arkeo-mainnet-rest",
+	66: "arkeo-mainnet-grpc",
 }
 
 func (c Service) String() string {
@@ -130,6 +162,39 @@ func (c Service) String() string {
 	return "unknown"
 }
 
+// Chain returns the chain the service is for, such as "eth" for
+// eth-mainnet-fullnode. A provider may offer several services on a chain,
+// each bonded and priced on its own.
+func (c Service) Chain() string {
+	chain, _, _ := c.parts()
+	return chain
+}
+
+// Network returns the network of the chain the service is for, such as
+// "mainnet"
+func (c Service) Network() string {
+	_, network, _ := c.parts()
+	return network
+}
+
+// Type returns the kind of service offered on the chain, such as fullnode,
+// archivenode, rpc, rest, grpc or websocket. Fullnode services of EVM chains
+// serve JSON-RPC.
+func (c Service) Type() string {
+	_, _, serviceType := c.parts()
+	return serviceType
+}
+
+// parts splits the service name into its chain, network and type, services
+// not named after a chain, such as mock, are all chain
+func (c Service) parts() (string, string, string) {
+	parts := strings.SplitN(c.String(), "-", 3)
+	if len(parts) < 3 {
+		return c.String(), "", ""
+	}
+	return parts[0], parts[1], parts[2]
+}
+
 // NewService create a new service
 func NewService(serviceId string) (Service, error) {
 	service := ServiceLookup[strings.ToLower(serviceId)]
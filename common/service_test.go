@@ -18,3 +18,18 @@ func TestString(t *testing.T) {
 	service = 600
 	require.Equal(t, "unknown", service.String())
 }
+
+func TestServiceParts(t *testing.T) {
+	service, err := NewService("eth-mainnet-websocket")
+	require.NoError(t, err)
+	require.Equal(t, "eth", service.Chain())
+	require.Equal(t, "mainnet", service.Network())
+	require.Equal(t, "websocket", service.Type())
+
+	require.Equal(t, "btc", BTCService.Chain())
+	require.Equal(t, "fullnode", BTCService.Type())
+
+	require.Equal(t, "mock", MockService.Chain())
+	require.Equal(t, "", MockService.Network())
+	require.Equal(t, "", MockService.Type())
+}
@@ -112,17 +112,31 @@ func parseArkAuth(raw string) (ArkAuth, error) {
 	return aa, nil
 }
 
-func (aa ArkAuth) Validate(provider common.PubKey) error {
+// Validate checks the ark auth is a claim arkeo will accept, signed by the
+// spender of the contract, its delegate when it has one
+func (aa ArkAuth) Validate(provider, spender common.PubKey) error {
 	creator, err := provider.GetMyAddress()
 	if err != nil {
 		return fmt.Errorf("internal server error: %w", err)
 	}
 	msg := types.NewMsgClaimContractIncome(creator, aa.ContractId, aa.Nonce, aa.Signature)
-	err = msg.ValidateBasic()
-	return err
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	pk, err := cosmos.GetPubKeyFromBech32(cosmos.Bech32PubKeyTypeAccPub, spender.String())
+	if err != nil {
+		return err
+	}
+	if !pk.VerifySignature(msg.GetBytesToSign(), aa.Signature) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
 }
 
-func (auth ContractAuth) Validate(lastTimestamp int64, client common.PubKey) error {
+// Validate checks the contract auth is newer than the last one and signed by
+// one of the signers, the client of the contract or its delegate
+func (auth ContractAuth) Validate(lastTimestamp int64, signers ...common.PubKey) error {
 	if auth.ContractId == 0 {
 		return fmt.Errorf("contract id cannot be zero")
 	}
@@ -130,16 +144,21 @@ func (auth ContractAuth) Validate(lastTimestamp int64, client common.PubKey) err
 		return fmt.Errorf("timestamp must be larger than %d", lastTimestamp)
 	}
 
-	pk, err := cosmos.GetPubKeyFromBech32(cosmos.Bech32PubKeyTypeAccPub, client.String())
-	if err != nil {
-		return err
-	}
 	msg := fmt.Sprintf("%d:%d", auth.ContractId, auth.Timestamp)
-	if !pk.VerifySignature([]byte(msg), auth.Signature) {
-		return fmt.Errorf("invalid signature")
+	for _, signer := range signers {
+		if signer.IsEmpty() {
+			continue
+		}
+		pk, err := cosmos.GetPubKeyFromBech32(cosmos.Bech32PubKeyTypeAccPub, signer.String())
+		if err != nil {
+			return err
+		}
+		if pk.VerifySignature([]byte(msg), auth.Signature) {
+			return nil
+		}
 	}
 
-	return nil
+	return fmt.Errorf("invalid signature")
 }
 
 func (auth ContractAuth) String() string {
@@ -230,7 +249,7 @@ func (p Proxy) auth(next http.Handler) http.Handler {
 			}
 		}
 
-		if err == nil && (contract.IsOpenAuthorization() || aa.Validate(p.Config.ProviderPubKey) == nil) {
+		if err == nil && (contract.IsOpenAuthorization() || aa.Validate(p.Config.ProviderPubKey, contract.GetSpender()) == nil) {
 			p.logger.Info("serving paid requests", "remote-addr", remoteAddr)
 			w.Header().Set("tier", "paid")
 
@@ -317,8 +336,16 @@ func (p Proxy) paidTier(aa ArkAuth, remoteAddr string) (code int, err error) {
 		return http.StatusPaymentRequired, fmt.Errorf("open a contract")
 	}
 
+	if contract.IsPaused() {
+		return http.StatusPaymentRequired, fmt.Errorf("contract paused")
+	}
+
 	sig := hex.EncodeToString(aa.Signature)
-	claim := NewClaim(aa.ContractId, aa.Spender, aa.Nonce, sig)
+	spender := aa.Spender
+	if spender.IsEmpty() {
+		spender = contract.GetSpender()
+	}
+	claim := NewClaim(aa.ContractId, spender, aa.Nonce, sig)
 	if p.ClaimStore.Has(key) {
 		var err error
 		claim, err = p.ClaimStore.Get(key)
@@ -332,11 +359,16 @@ func (p Proxy) paidTier(aa ArkAuth, remoteAddr string) (code int, err error) {
 
 	// check if we've exceed the total number of pay-as-you-go queries
 	if contract.IsPayAsYouGo() {
-		if contract.Deposit.IsNil() || contract.Deposit.LT(cosmos.NewInt(aa.Nonce*contract.Rate.Amount.Int64())) {
+		if contract.Deposit.IsNil() || contract.Deposit.LT(contract.TieredCost(aa.Nonce)) {
 			return http.StatusPaymentRequired, fmt.Errorf("contract spent")
 		}
 	}
 
+	// free tier contracts are capped at the queries the provider gives away
+	if contract.MaxQueries > 0 && aa.Nonce > contract.MaxQueries {
+		return http.StatusPaymentRequired, fmt.Errorf("query limit reached")
+	}
+
 	if ok := p.isRateLimited(contract.Id, key, int(contract.QueriesPerMinute)); ok {
 		return http.StatusTooManyRequests, fmt.Errorf("client is ratelimited," + http.StatusText(429))
 	}
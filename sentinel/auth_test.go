@@ -143,3 +143,45 @@ func TestPaidTier(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, code, http.StatusTooManyRequests)
 }
+
+func TestArkAuthValidateDelegate(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	kb := cKeys.NewInMemory(cdc)
+	pubKeys := make(map[string]common.PubKey)
+	for _, name := range []string{"client", "delegate"} {
+		info, _, err := kb.NewMnemonic(name, cKeys.English, `m/44'/931'/0'/0/0`, "", hd.Secp256k1)
+		require.NoError(t, err)
+		pub, err := info.GetPubKey()
+		require.NoError(t, err)
+		pubKeys[name], err = common.NewPubKeyFromCrypto(pub)
+		require.NoError(t, err)
+	}
+	provider := types.GetRandomPubKey()
+
+	contract := types.NewContract(provider, common.BTCService, pubKeys["client"])
+	contract.Id = 7
+	contract.Delegate = pubKeys["delegate"]
+
+	// the delegate signs usage in place of the client
+	signature, _, err := kb.Sign("delegate", types.GetBytesToSign(contract.Id, 3))
+	require.NoError(t, err)
+	aa := ArkAuth{ContractId: contract.Id, Nonce: 3, Signature: signature}
+	require.NoError(t, aa.Validate(provider, contract.GetSpender()))
+
+	signature, _, err = kb.Sign("client", types.GetBytesToSign(contract.Id, 3))
+	require.NoError(t, err)
+	aa.Signature = signature
+	require.Error(t, aa.Validate(provider, contract.GetSpender()))
+
+	// either key may manage the contract's configuration
+	for _, name := range []string{"client", "delegate"} {
+		signature, _, err = kb.Sign(name, []byte(fmt.Sprintf("%d:%d", contract.Id, 10)))
+		require.NoError(t, err)
+		auth := ContractAuth{ContractId: contract.Id, Timestamp: 10, Signature: signature}
+		require.NoError(t, auth.Validate(5, contract.Client, contract.Delegate))
+	}
+	require.Error(t, ContractAuth{ContractId: contract.Id, Timestamp: 10, Signature: signature}.Validate(5, contract.Client))
+}
@@ -0,0 +1,312 @@
+package sentinel
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/std"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmclient "github.com/tendermint/tendermint/rpc/client/http"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/sentinel/conf"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// Claimer watches the open claims of a sentinel and posts them to arkeo once
+// the income they are owed is worth a transaction, they have been left open
+// too long, or the contract is about to settle without them. The claims due
+// are settled together, in batches of up to the configured size.
+type Claimer struct {
+	Config    conf.ClaimerConfiguration
+	MemStore  *MemStore
+	clientCtx client.Context
+	txFactory tx.Factory
+	firstSeen map[uint64]time.Time
+	client    http.Client
+	logger    log.Logger
+}
+
+func NewClaimer(config conf.ClaimerConfiguration) (*Claimer, error) {
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+
+	registry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(registry)
+	authtypes.RegisterInterfaces(registry)
+	types.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+	txConfig := authtx.NewTxConfig(cdc, authtx.DefaultSignModes)
+
+	kr, err := keyring.New("arkeo", config.KeyringBackend, config.KeyringDir, os.Stdin, cdc)
+	if err != nil {
+		return nil, fmt.Errorf("fail to open keyring: %w", err)
+	}
+	record, err := kr.Key(config.KeyName)
+	if err != nil {
+		return nil, fmt.Errorf("fail to find key %s: %w", config.KeyName, err)
+	}
+	address, err := record.GetAddress()
+	if err != nil {
+		return nil, fmt.Errorf("fail to get address of key %s: %w", config.KeyName, err)
+	}
+
+	nodeURI := fmt.Sprintf("tcp://%s", config.EventStreamHost)
+	rpcClient, err := tmclient.New(nodeURI, "/websocket")
+	if err != nil {
+		return nil, fmt.Errorf("fail to create tendermint client: %w", err)
+	}
+
+	clientCtx := client.Context{
+		Client:            rpcClient,
+		ChainID:           config.ChainID,
+		Codec:             cdc,
+		InterfaceRegistry: registry,
+		Keyring:           kr,
+		BroadcastMode:     flags.BroadcastSync,
+		SkipConfirm:       true,
+		TxConfig:          txConfig,
+		AccountRetriever:  authtypes.AccountRetriever{},
+		NodeURI:           nodeURI,
+		FromAddress:       address,
+		FromName:          config.KeyName,
+	}
+
+	txFactory := tx.Factory{}.
+		WithKeybase(kr).
+		WithTxConfig(txConfig).
+		WithAccountRetriever(clientCtx.AccountRetriever).
+		WithChainID(config.ChainID).
+		WithGas(config.Gas).
+		WithFees(config.Fees).
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
+
+	return &Claimer{
+		Config:    config,
+		MemStore:  NewMemStore(config.SourceChain, logger),
+		clientCtx: clientCtx,
+		txFactory: txFactory,
+		firstSeen: make(map[uint64]time.Time),
+		client: http.Client{
+			Timeout: 10 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+func (c *Claimer) Run() {
+	c.logger.Info("Starting Claimer....")
+	c.Config.Print()
+
+	ticker := time.NewTicker(c.Config.Interval)
+	defer ticker.Stop()
+	for ; true; <-ticker.C {
+		if err := c.process(); err != nil {
+			c.logger.Error("fail to process open claims", "error", err)
+		}
+	}
+}
+
+func (c *Claimer) process() error {
+	claims, err := c.fetchOpenClaims()
+	if err != nil {
+		return err
+	}
+	status, err := c.clientCtx.Client.Status(context.Background())
+	if err != nil {
+		return fmt.Errorf("fail to get node status: %w", err)
+	}
+	height := status.SyncInfo.LatestBlockHeight
+	now := time.Now()
+
+	open := make(map[uint64]bool)
+	due := make([]Claim, 0)
+	for _, claim := range claims {
+		open[claim.ContractId] = true
+		contract, err := c.MemStore.fetchContract(claim.Key())
+		if err != nil {
+			c.logger.Error("fail to fetch contract", "error", err, "id", claim.ContractId)
+			continue
+		}
+		provider, err := contract.Provider.GetMyAddress()
+		if err != nil || !provider.Equals(c.clientCtx.FromAddress) {
+			c.logger.Error("contract is not for the claimer's key", "id", claim.ContractId, "provider", contract.Provider)
+			continue
+		}
+
+		reason := c.claimReason(claim, contract, height, now)
+		if reason == "" {
+			continue
+		}
+		c.logger.Info("claim is due", "id", claim.ContractId, "nonce", claim.Nonce, "reason", reason)
+		due = append(due, claim)
+	}
+
+	for _, batch := range batchClaims(due, c.Config.BatchSize) {
+		if err := c.settle(batch); err != nil {
+			c.logger.Error("fail to settle contract claims", "error", err, "claims", len(batch))
+			continue
+		}
+		for _, claim := range batch {
+			c.logger.Info("claimed contract income", "id", claim.ContractId, "nonce", claim.Nonce)
+			delete(c.firstSeen, claim.ContractId)
+		}
+	}
+
+	// forget claims the sentinel no longer has open
+	for id := range c.firstSeen {
+		if !open[id] {
+			delete(c.firstSeen, id)
+		}
+	}
+	return nil
+}
+
+// claimReason returns why the claim should be posted now, or an empty string
+// while it can wait. Only pay-as-you-go contracts need claims, subscriptions
+// are settled by arkeo itself.
+func (c *Claimer) claimReason(claim Claim, contract types.Contract, height int64, now time.Time) string {
+	if !contract.IsPayAsYouGo() || contract.SettlementHeight > 0 || contract.IsSettled(height) {
+		return ""
+	}
+	debt := pendingDebt(contract, claim.Nonce)
+	if !debt.IsPositive() {
+		return ""
+	}
+
+	first, ok := c.firstSeen[claim.ContractId]
+	if !ok {
+		first = now
+		c.firstSeen[claim.ContractId] = now
+	}
+
+	threshold := c.Config.Threshold.AmountOf(contract.Rate.Denom)
+	switch {
+	case threshold.IsPositive() && debt.GTE(threshold):
+		return "threshold"
+	case contract.SettlementPeriodEnd()-height <= c.Config.ExpiryBuffer:
+		return "expiry"
+	case c.Config.MaxAge > 0 && now.Sub(first) >= c.Config.MaxAge:
+		return "age"
+	}
+	return ""
+}
+
+// pendingDebt returns the income the provider is owed for the contract up to
+// the given nonce, as arkeo would pay it on a claim
+func pendingDebt(contract types.Contract, nonce int64) cosmos.Int {
+	if nonce <= contract.Nonce {
+		return cosmos.ZeroInt()
+	}
+	accrued := contract.AccruedAtRateChange
+	if accrued.IsNil() {
+		accrued = cosmos.ZeroInt()
+	}
+	paid := contract.Paid
+	if paid.IsNil() {
+		paid = cosmos.ZeroInt()
+	}
+	debt := accrued.Add(contract.TieredCost(nonce - contract.RateChangeNonce)).Sub(paid)
+	if !contract.Deposit.IsNil() && paid.Add(debt).GT(contract.Deposit) {
+		debt = contract.Deposit.Sub(paid)
+	}
+	if debt.IsNegative() {
+		return cosmos.ZeroInt()
+	}
+	return debt
+}
+
+// batchClaims splits the claims into batches of at most size claims
+func batchClaims(claims []Claim, size int) [][]Claim {
+	if size < 1 {
+		size = 1
+	}
+	batches := make([][]Claim, 0, (len(claims)+size-1)/size)
+	for len(claims) > size {
+		batches = append(batches, claims[:size])
+		claims = claims[size:]
+	}
+	if len(claims) > 0 {
+		batches = append(batches, claims)
+	}
+	return batches
+}
+
+// settle posts the claims in a single MsgSettleContracts. Arkeo settles each
+// claim on its own, so one bad claim does not fail the others.
+func (c *Claimer) settle(claims []Claim) error {
+	contractClaims := make([]types.ContractClaim, 0, len(claims))
+	for _, claim := range claims {
+		sig, err := hex.DecodeString(claim.Signature)
+		if err != nil {
+			return fmt.Errorf("fail to decode signature of contract %d: %w", claim.ContractId, err)
+		}
+		contractClaims = append(contractClaims, types.ContractClaim{
+			ContractId: claim.ContractId,
+			Nonce:      claim.Nonce,
+			Signature:  sig,
+		})
+	}
+	msg := types.NewMsgSettleContracts(c.clientCtx.FromAddress, contractClaims)
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	txf, err := c.txFactory.Prepare(c.clientCtx)
+	if err != nil {
+		return fmt.Errorf("fail to prepare tx: %w", err)
+	}
+	builder, err := txf.BuildUnsignedTx(msg)
+	if err != nil {
+		return fmt.Errorf("fail to build tx: %w", err)
+	}
+	if err := tx.Sign(txf, c.Config.KeyName, builder, true); err != nil {
+		return fmt.Errorf("fail to sign tx: %w", err)
+	}
+	txBytes, err := c.clientCtx.TxConfig.TxEncoder()(builder.GetTx())
+	if err != nil {
+		return fmt.Errorf("fail to encode tx: %w", err)
+	}
+	res, err := c.clientCtx.BroadcastTx(txBytes)
+	if err != nil {
+		return fmt.Errorf("fail to broadcast tx: %w", err)
+	}
+	if res.Code != 0 {
+		return fmt.Errorf("tx %s failed with code %d: %s", res.TxHash, res.Code, res.RawLog)
+	}
+	return nil
+}
+
+func (c *Claimer) fetchOpenClaims() ([]Claim, error) {
+	requestURL := fmt.Sprintf("%s%s", c.Config.SentinelURL, RoutesOpenClaims)
+	res, err := c.client.Get(requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("fail to fetch open claims: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read from response body: %w", err)
+	}
+
+	claims := make([]Claim, 0)
+	if err := json.Unmarshal(resBody, &claims); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal open claims: %w", err)
+	}
+	return claims, nil
+}
@@ -0,0 +1,90 @@
+package sentinel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/sentinel/conf"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingDebt(t *testing.T) {
+	contract := types.Contract{
+		Type:    types.ContractType_PAY_AS_YOU_GO,
+		Rate:    cosmos.NewInt64Coin("uarkeo", 3),
+		Deposit: cosmos.NewInt(100),
+		Paid:    cosmos.NewInt(15),
+		Nonce:   5,
+	}
+
+	// already claimed
+	require.True(t, pendingDebt(contract, 5).IsZero())
+	// ten queries, five of them paid for
+	require.Equal(t, int64(15), pendingDebt(contract, 10).Int64())
+	// capped at the deposit
+	require.Equal(t, int64(85), pendingDebt(contract, 1000).Int64())
+}
+
+func TestClaimReason(t *testing.T) {
+	claimer := Claimer{
+		Config: conf.ClaimerConfiguration{
+			Threshold:    cosmos.NewCoins(cosmos.NewInt64Coin("uarkeo", 50)),
+			MaxAge:       time.Hour,
+			ExpiryBuffer: 10,
+		},
+		firstSeen: make(map[uint64]time.Time),
+	}
+	contract := types.Contract{
+		Id:                 1,
+		Type:               types.ContractType_PAY_AS_YOU_GO,
+		Height:             100,
+		Duration:           100,
+		SettlementDuration: 10,
+		Rate:               cosmos.NewInt64Coin("uarkeo", 1),
+		Deposit:            cosmos.NewInt(1000),
+		Paid:               cosmos.ZeroInt(),
+	}
+	now := time.Now()
+
+	// below the threshold, young and far from settling
+	claim := NewClaim(contract.Id, types.GetRandomPubKey(), 10, "")
+	require.Equal(t, "", claimer.claimReason(claim, contract, 120, now))
+
+	// left open too long
+	require.Equal(t, "age", claimer.claimReason(claim, contract, 120, now.Add(2*time.Hour)))
+
+	// about to settle
+	delete(claimer.firstSeen, contract.Id)
+	require.Equal(t, "expiry", claimer.claimReason(claim, contract, 205, now))
+
+	// enough income pending
+	claim.Nonce = 60
+	require.Equal(t, "threshold", claimer.claimReason(claim, contract, 120, now))
+
+	// subscriptions are settled by arkeo
+	contract.Type = types.ContractType_SUBSCRIPTION
+	require.Equal(t, "", claimer.claimReason(claim, contract, 120, now))
+
+	// nothing to claim once settled
+	contract.Type = types.ContractType_PAY_AS_YOU_GO
+	require.Equal(t, "", claimer.claimReason(claim, contract, 210, now))
+}
+
+func TestBatchClaims(t *testing.T) {
+	claims := make([]Claim, 5)
+	for i := range claims {
+		claims[i] = NewClaim(uint64(i+1), types.GetRandomPubKey(), 1, "")
+	}
+
+	batches := batchClaims(claims, 2)
+	require.Len(t, batches, 3)
+	require.Len(t, batches[0], 2)
+	require.Len(t, batches[2], 1)
+	require.Equal(t, uint64(5), batches[2][0].ContractId)
+
+	require.Len(t, batchClaims(claims, 5), 1)
+	require.Len(t, batchClaims(claims, 0), 5)
+	require.Empty(t, batchClaims(nil, 2))
+}
@@ -6,8 +6,10 @@ import (
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
 )
 
 type TLSConfiguration struct {
@@ -30,6 +32,25 @@ type Configuration struct {
 	TLS                         TLSConfiguration `json:"tls"`
 }
 
+// ClaimerConfiguration configures the claimer, which posts the sentinel's open
+// claims to arkeo once they are worth claiming
+type ClaimerConfiguration struct {
+	SentinelURL     string        `json:"sentinel_url"` // base url of the provider's sentinel
+	SourceChain     string        `json:"source_chain"` // base url for arceo block chain
+	EventStreamHost string        `json:"event_stream_host"`
+	ChainID         string        `json:"chain_id"`
+	KeyName         string        `json:"key_name"` // provider key the claims are signed with
+	KeyringBackend  string        `json:"keyring_backend"`
+	KeyringDir      string        `json:"keyring_dir"`
+	Gas             uint64        `json:"gas"`
+	Fees            string        `json:"fees"`
+	Threshold       cosmos.Coins  `json:"threshold"`     // pending income, per denom, worth a claim
+	MaxAge          time.Duration `json:"max_age"`       // longest a claim is left open, zero to never claim on age
+	ExpiryBuffer    int64         `json:"expiry_buffer"` // blocks before the settlement period ends to claim in
+	Interval        time.Duration `json:"interval"`
+	BatchSize       int           `json:"batch_size"` // max claims settled in a single transaction
+}
+
 // Simple helper function to read an environment or return a default value
 func getEnv(key, defaultVal string) string {
 	if value, exists := os.LookupEnv(key); exists {
@@ -70,6 +91,38 @@ func loadVarInt(key string) int {
 	return i
 }
 
+func getEnvInt64(key string, defaultVal int64) int64 {
+	val, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultVal
+	}
+	i, err := strconv.ParseInt(strings.TrimSpace(val), 10, 64)
+	if err != nil {
+		panic(fmt.Errorf("env var %s is not an integer: %s", key, err))
+	}
+	return i
+}
+
+func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
+	val, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultVal
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(val))
+	if err != nil {
+		panic(fmt.Errorf("env var %s is not a duration: %s", key, err))
+	}
+	return d
+}
+
+func getEnvCoins(key, defaultVal string) cosmos.Coins {
+	coins, err := cosmos.ParseCoins(getEnv(key, defaultVal))
+	if err != nil {
+		panic(fmt.Errorf("env var %s is not a list of coins: %s", key, err))
+	}
+	return coins
+}
+
 func NewTLSConfiguration() TLSConfiguration {
 	return TLSConfiguration{
 		Cert: getEnv("TLS_CERT", ""),
@@ -115,3 +168,41 @@ func (c Configuration) Print() {
 	fmt.Fprintln(writer, "Free Tier Rate Limit\t", fmt.Sprintf("%d requests per 1m", c.FreeTierRateLimit))
 	writer.Flush()
 }
+
+func NewClaimerConfiguration() ClaimerConfiguration {
+	return ClaimerConfiguration{
+		SentinelURL:     getEnv("SENTINEL_URL", "http://localhost:3636"),
+		SourceChain:     loadVarString("SOURCE_CHAIN"),
+		EventStreamHost: loadVarString("EVENT_STREAM_HOST"),
+		ChainID:         loadVarString("CHAIN_ID"),
+		KeyName:         loadVarString("KEY_NAME"),
+		KeyringBackend:  getEnv("KEYRING_BACKEND", "test"),
+		KeyringDir:      getEnv("KEYRING_DIR", os.ExpandEnv("$HOME/.arkeo")),
+		Gas:             uint64(getEnvInt64("GAS", 200000)),
+		Fees:            getEnv("FEES", ""),
+		Threshold:       getEnvCoins("CLAIM_THRESHOLD", "1000000uarkeo"),
+		MaxAge:          getEnvDuration("CLAIM_MAX_AGE", 24*time.Hour),
+		ExpiryBuffer:    getEnvInt64("CLAIM_EXPIRY_BUFFER", 100),
+		Interval:        getEnvDuration("CLAIM_INTERVAL", time.Minute),
+		BatchSize:       int(getEnvInt64("CLAIM_BATCH_SIZE", 50)),
+	}
+}
+
+func (c ClaimerConfiguration) Print() {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "Sentinel URL\t", c.SentinelURL)
+	fmt.Fprintln(writer, "Source Chain\t", c.SourceChain)
+	fmt.Fprintln(writer, "Event Stream Host\t", c.EventStreamHost)
+	fmt.Fprintln(writer, "Chain ID\t", c.ChainID)
+	fmt.Fprintln(writer, "Key Name\t", c.KeyName)
+	fmt.Fprintln(writer, "Keyring Backend\t", c.KeyringBackend)
+	fmt.Fprintln(writer, "Keyring Dir\t", c.KeyringDir)
+	fmt.Fprintln(writer, "Gas\t", c.Gas)
+	fmt.Fprintln(writer, "Fees\t", c.Fees)
+	fmt.Fprintln(writer, "Claim Threshold\t", c.Threshold)
+	fmt.Fprintln(writer, "Claim Max Age\t", c.MaxAge)
+	fmt.Fprintln(writer, "Claim Expiry Buffer\t", fmt.Sprintf("%d blocks", c.ExpiryBuffer))
+	fmt.Fprintln(writer, "Claim Interval\t", c.Interval)
+	fmt.Fprintln(writer, "Claim Batch Size\t", c.BatchSize)
+	writer.Flush()
+}
@@ -3,6 +3,7 @@ package conf
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -33,3 +34,28 @@ func TestConfiguration(t *testing.T) {
 	require.Equal(t, config.ClaimStoreLocation, "clammy")
 	require.Equal(t, config.ContractConfigStoreLocation, "configy")
 }
+
+func TestClaimerConfiguration(t *testing.T) {
+	os.Setenv("SOURCE_CHAIN", "sourcey")
+	os.Setenv("EVENT_STREAM_HOST", "hosty")
+	os.Setenv("CHAIN_ID", "chainy")
+	os.Setenv("KEY_NAME", "keyy")
+	os.Setenv("CLAIM_THRESHOLD", "500uarkeo")
+	os.Setenv("CLAIM_MAX_AGE", "2h")
+	os.Setenv("CLAIM_EXPIRY_BUFFER", "50")
+	os.Setenv("CLAIM_BATCH_SIZE", "20")
+
+	config := NewClaimerConfiguration()
+
+	require.Equal(t, config.SentinelURL, "http://localhost:3636")
+	require.Equal(t, config.SourceChain, "sourcey")
+	require.Equal(t, config.EventStreamHost, "hosty")
+	require.Equal(t, config.ChainID, "chainy")
+	require.Equal(t, config.KeyName, "keyy")
+	require.Equal(t, config.KeyringBackend, "test")
+	require.Equal(t, config.Threshold.String(), "500uarkeo")
+	require.Equal(t, config.MaxAge, 2*time.Hour)
+	require.Equal(t, config.ExpiryBuffer, int64(50))
+	require.Equal(t, config.Interval, time.Minute)
+	require.Equal(t, config.BatchSize, 20)
+}
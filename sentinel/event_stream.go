@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 
@@ -49,6 +50,9 @@ func (p Proxy) EventListener(host string) {
 	openContractOut := subscribe(client, logger, "tm.event = 'Tx' AND message.action='/arkeo.arkeo.MsgOpenContract'")
 	closeContractOut := subscribe(client, logger, "tm.event = 'Tx' AND message.action='/arkeo.arkeo.MsgCloseContract'")
 	claimContractOut := subscribe(client, logger, "tm.event = 'Tx' AND message.action='/arkeo.arkeo.MsgClaimContractIncome'")
+	settleContractsOut := subscribe(client, logger, "tm.event = 'Tx' AND message.action='/arkeo.arkeo.MsgSettleContracts'")
+	pauseContractOut := subscribe(client, logger, "tm.event = 'Tx' AND message.action='/arkeo.arkeo.MsgPauseContract'")
+	resumeContractOut := subscribe(client, logger, "tm.event = 'Tx' AND message.action='/arkeo.arkeo.MsgResumeContract'")
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -63,27 +67,74 @@ func (p Proxy) EventListener(host string) {
 			p.handleCloseContractEvent(result)
 		case result := <-claimContractOut: // MsgClaimContractIncome emits a contract settlement event
 			p.handleContractSettlementEvent(result)
+		case result := <-settleContractsOut: // MsgSettleContracts emits a contract settlement event per settled claim
+			p.handleContractSettlementEvent(result)
+		case result := <-pauseContractOut:
+			p.handlePauseContractEvent(result)
+		case result := <-resumeContractOut:
+			p.handleResumeContractEvent(result)
 		case <-quit:
 			return
 		}
 	}
 }
 
-// handleContractSettlementEvent
+// handleContractSettlementEvent marks the claims of the settled contracts as
+// claimed, a batch settlement has an event for each claim
 func (p Proxy) handleContractSettlementEvent(result tmCoreTypes.ResultEvent) {
-	typedEvent, err := parseTypedEvent(result, "arkeo.arkeo.EventSettleContract")
+	typedEvents, err := parseTypedEvents(result, "arkeo.arkeo.EventSettleContract")
 	if err != nil {
 		p.logger.Error("failed to parse typed event", "error", err)
 		return
 	}
 
-	evt, ok := typedEvent.(*types.EventSettleContract)
-	if !ok {
-		p.logger.Error(fmt.Sprintf("failed to cast %T to EventSettleContract", typedEvent))
+	for _, typedEvent := range typedEvents {
+		evt, ok := typedEvent.(*types.EventSettleContract)
+		if !ok {
+			p.logger.Error(fmt.Sprintf("failed to cast %T to EventSettleContract", typedEvent))
+			continue
+		}
+
+		if !p.isMyPubKey(evt.Provider) {
+			continue
+		}
+
+		service := common.Service(common.ServiceLookup[evt.Service])
+		contract := types.Contract{
+			Provider: evt.Provider,
+			Service:  service,
+			Client:   evt.Client,
+			Delegate: evt.Delegate,
+			Id:       evt.ContractId,
+		}
+
+		spender := contract.GetSpender()
+		newClaim := NewClaim(contract.Id, spender, evt.Nonce, "")
+		currClaim, err := p.ClaimStore.Get(newClaim.Key())
+		if err != nil {
+			p.logger.Error("failed to get claim", "error", err)
+			continue
+		}
+		if currClaim.Nonce == newClaim.Nonce {
+			currClaim.Claimed = true
+			if err := p.ClaimStore.Set(currClaim); err != nil {
+				p.logger.Error("failed to set claimed", "error", err)
+			}
+		}
+	}
+}
+
+// handleCloseContractEvent
+func (p Proxy) handleCloseContractEvent(result tmCoreTypes.ResultEvent) {
+	typedEvent, err := parseTypedEvent(result, "arkeo.arkeo.EventCloseContract")
+	if err != nil {
+		p.logger.Error("failed to parse typed event", "error", err)
 		return
 	}
 
-	if !p.isMyPubKey(evt.Provider) {
+	evt, ok := typedEvent.(*types.EventCloseContract)
+	if !ok {
+		p.logger.Error(fmt.Sprintf("failed to cast %T to EventCloseContract", typedEvent))
 		return
 	}
 
@@ -95,45 +146,58 @@ func (p Proxy) handleContractSettlementEvent(result tmCoreTypes.ResultEvent) {
 		Delegate: evt.Delegate,
 		Id:       evt.ContractId,
 	}
+	if !p.isMyPubKey(contract.Provider) {
+		return
+	}
+	p.MemStore.Put(contract)
+}
 
-	spender := contract.GetSpender()
-	newClaim := NewClaim(contract.Id, spender, evt.Nonce, "")
-	currClaim, err := p.ClaimStore.Get(newClaim.Key())
+// handlePauseContractEvent refetches a paused contract, its client is no
+// longer served until it is resumed
+func (p Proxy) handlePauseContractEvent(result tmCoreTypes.ResultEvent) {
+	typedEvent, err := parseTypedEvent(result, "arkeo.arkeo.EventPauseContract")
 	if err != nil {
-		p.logger.Error("failed to get claim", "error", err)
+		p.logger.Error("failed to parse typed event", "error", err)
 		return
 	}
-	if currClaim.Nonce == newClaim.Nonce {
-		currClaim.Claimed = true
-		if err := p.ClaimStore.Set(currClaim); err != nil {
-			p.logger.Error("failed to set claimed", "error", err)
-		}
+
+	evt, ok := typedEvent.(*types.EventPauseContract)
+	if !ok {
+		p.logger.Error(fmt.Sprintf("failed to cast %T to EventPauseContract", typedEvent))
+		return
 	}
+
+	if !p.isMyPubKey(evt.Provider) {
+		return
+	}
+	p.refreshContract(evt.ContractId)
 }
 
-// handleCloseContractEvent
-func (p Proxy) handleCloseContractEvent(result tmCoreTypes.ResultEvent) {
-	typedEvent, err := parseTypedEvent(result, "arkeo.arkeo.EventCloseContract")
+// handleResumeContractEvent refetches a resumed contract, its expiration has
+// moved by the blocks it spent paused
+func (p Proxy) handleResumeContractEvent(result tmCoreTypes.ResultEvent) {
+	typedEvent, err := parseTypedEvent(result, "arkeo.arkeo.EventResumeContract")
 	if err != nil {
 		p.logger.Error("failed to parse typed event", "error", err)
 		return
 	}
 
-	evt, ok := typedEvent.(*types.EventCloseContract)
+	evt, ok := typedEvent.(*types.EventResumeContract)
 	if !ok {
-		p.logger.Error(fmt.Sprintf("failed to cast %T to EventCloseContract", typedEvent))
+		p.logger.Error(fmt.Sprintf("failed to cast %T to EventResumeContract", typedEvent))
 		return
 	}
 
-	service := common.Service(common.ServiceLookup[evt.Service])
-	contract := types.Contract{
-		Provider: evt.Provider,
-		Service:  service,
-		Client:   evt.Client,
-		Delegate: evt.Delegate,
-		Id:       evt.ContractId,
+	if !p.isMyPubKey(evt.Provider) {
+		return
 	}
-	if !p.isMyPubKey(contract.Provider) {
+	p.refreshContract(evt.ContractId)
+}
+
+func (p Proxy) refreshContract(id uint64) {
+	contract, err := p.MemStore.fetchContract(strconv.FormatUint(id, 10))
+	if err != nil {
+		p.logger.Error("failed to fetch contract", "error", err, "id", id)
 		return
 	}
 	p.MemStore.Put(contract)
@@ -167,12 +231,15 @@ func (p Proxy) handleOpenContractEvent(result tmCoreTypes.ResultEvent) {
 		SettlementDuration: evt.SettlementDuration,
 		Authorization:      evt.Authorization,
 		QueriesPerMinute:   evt.QueriesPerMinute,
+		RateTiers:          evt.RateTiers,
+		MaxQueries:         evt.MaxQueries,
 	}
 
 	if !p.isMyPubKey(evt.Provider) {
 		return
 	}
-	if evt.Deposit.IsZero() {
+	// only free tier contracts, at a zero rate, are opened without a deposit
+	if evt.Deposit.IsZero() && !evt.Rate.Amount.IsZero() {
 		p.logger.Error("contract's deposit is zero")
 		return
 	}
@@ -242,3 +309,28 @@ func parseTypedEvent(result tmCoreTypes.ResultEvent, eventType string) (proto.Me
 
 	return msg, fmt.Errorf("event %s not found", eventType)
 }
+
+// parseTypedEvents returns every event of the given type in the transaction
+func parseTypedEvents(result tmCoreTypes.ResultEvent, eventType string) ([]proto.Message, error) {
+	eventDataTx, ok := result.Data.(tmtypes.EventDataTx)
+	if !ok {
+		return nil, fmt.Errorf("failed cast %T to EventDataTx", result.Data)
+	}
+
+	msgs := make([]proto.Message, 0)
+	for _, evt := range eventDataTx.TxResult.Result.Events {
+		if evt.Type != eventType {
+			continue
+		}
+		msg, err := sdk.ParseTypedEvent(evt)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("event %s not found", eventType)
+	}
+
+	return msgs, nil
+}
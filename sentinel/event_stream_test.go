@@ -203,7 +203,7 @@ func TestHandleHandleContractSettlementEvent(t *testing.T) {
 	// confirm is a settlement event is emitted with a lower nonce, we handle it correctly, by not setting our claim to Claimed.
 	inputContract.Nonce = 8
 	proxy.MemStore.SetHeight(150)
-	settlementEvent := types.NewContractSettlementEvent(sdk.NewInt(8), sdk.NewInt(1), &inputContract)
+	settlementEvent := types.NewContractSettlementEvent(sdk.NewInt(8), sdk.NewInt(1), sdk.ZeroInt(), false, &inputContract)
 	sdkEvt, err = sdk.TypedEventToEvent(&settlementEvent)
 	require.NoError(t, err)
 
@@ -219,7 +219,7 @@ func TestHandleHandleContractSettlementEvent(t *testing.T) {
 	// confirm is a settlement event is emitted with the samce nonce, we handle it correctly, by setting our claim to Claimed.
 	inputContract.Nonce = 10
 	proxy.MemStore.SetHeight(160)
-	settlementEvent = types.NewContractSettlementEvent(sdk.NewInt(10), sdk.NewInt(1), &inputContract)
+	settlementEvent = types.NewContractSettlementEvent(sdk.NewInt(10), sdk.NewInt(1), sdk.ZeroInt(), false, &inputContract)
 	sdkEvt, err = sdk.TypedEventToEvent(&settlementEvent)
 	require.NoError(t, err)
 
@@ -232,6 +232,66 @@ func TestHandleHandleContractSettlementEvent(t *testing.T) {
 	require.True(t, claim.Claimed)
 }
 
+func TestHandleBatchContractSettlementEvent(t *testing.T) {
+	testConfig := newTestConfig()
+	proxy := NewProxy(testConfig)
+
+	contracts := make([]types.Contract, 2)
+	for i := range contracts {
+		contracts[i] = types.Contract{
+			Provider:           testConfig.ProviderPubKey,
+			Service:            common.BTCService,
+			Client:             types.GetRandomPubKey(),
+			Delegate:           common.EmptyPubKey,
+			Type:               types.ContractType_PAY_AS_YOU_GO,
+			Height:             100,
+			Duration:           100,
+			Rate:               cosmos.NewInt64Coin("uarkeo", 1),
+			Deposit:            sdk.NewInt(100),
+			Id:                 uint64(i + 1),
+			SettlementDuration: 10,
+			QueriesPerMinute:   1,
+		}
+		openEvent := types.NewOpenContractEvent(100, &contracts[i])
+		sdkEvt, err := sdk.TypedEventToEvent(&openEvent)
+		require.NoError(t, err)
+		proxy.handleOpenContractEvent(makeResultEvent(sdkEvt, openEvent.Height))
+
+		_, err = proxy.paidTier(ArkAuth{
+			ContractId: contracts[i].Id,
+			Spender:    contracts[i].Client,
+			Nonce:      10,
+		}, "")
+		require.NoError(t, err)
+	}
+
+	// a batch settlement emits an event for each claim it settles
+	var resultEvent tmCoreTypes.ResultEvent
+	for i := range contracts {
+		contracts[i].Nonce = 10
+		settlementEvent := types.NewContractSettlementEvent(sdk.NewInt(10), sdk.NewInt(1), sdk.ZeroInt(), false, &contracts[i])
+		sdkEvt, err := sdk.TypedEventToEvent(&settlementEvent)
+		require.NoError(t, err)
+		if i == 0 {
+			resultEvent = makeResultEvent(sdkEvt, 151)
+			continue
+		}
+		data := resultEvent.Data.(tmtypes.EventDataTx)
+		data.TxResult.Result.Events = append(data.TxResult.Result.Events, abciTypes.Event{
+			Type:       sdkEvt.Type,
+			Attributes: sdkEvt.Attributes,
+		})
+		resultEvent.Data = data
+	}
+	proxy.handleContractSettlementEvent(resultEvent)
+
+	for _, contract := range contracts {
+		claim, err := proxy.ClaimStore.Get(Claim{ContractId: contract.Id}.Key())
+		require.NoError(t, err)
+		require.True(t, claim.Claimed)
+	}
+}
+
 func TestHandleNewBlockHeaderEvent(t *testing.T) {
 	// TODO: add tests
 }
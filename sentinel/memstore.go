@@ -102,22 +102,33 @@ func (k *MemStore) fetchContract(key string) (types.Contract, error) {
 	// TODO: this should cache a "miss" for 5 seconds, to stop DoS/thrashing
 	var contract types.Contract
 
+	type fetchRateTier struct {
+		Threshold string      `json:"threshold,omitempty"`
+		Rate      cosmos.Coin `json:"rate"`
+	}
+
 	type fetchContract struct {
-		Id               string                      `protobuf:"varint,13,opt,name=id,proto3" json:"id,omitempty"`
-		ProviderPubKey   common.PubKey               `protobuf:"bytes,1,opt,name=provider_pub_key,json=providerPubKey,proto3,casttype=github.com/arkeonetwork/arkeo/common.PubKey" json:"provider_pub_key,omitempty"`
-		Service          common.Service              `protobuf:"varint,2,opt,name=service,proto3,casttype=github.com/arkeonetwork/arkeo/common.Service" json:"service,omitempty"`
-		Client           common.PubKey               `protobuf:"bytes,3,opt,name=client,proto3,casttype=github.com/arkeonetwork/arkeo/common.PubKey" json:"client,omitempty"`
-		Delegate         common.PubKey               `protobuf:"bytes,4,opt,name=delegate,proto3,casttype=github.com/arkeonetwork/arkeo/common.PubKey" json:"delegate,omitempty"`
-		Type             types.ContractType          `protobuf:"varint,5,opt,name=type,proto3,enum=arkeo.arkeo.ContractType" json:"type,omitempty"`
-		Height           string                      `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
-		Duration         string                      `protobuf:"varint,7,opt,name=duration,proto3" json:"duration,omitempty"`
-		Rate             cosmos.Coin                 `protobuf:"varint,8,opt,name=rate,proto3" json:"rate,omitempty"`
-		Deposit          string                      `protobuf:"varint,9,opt,name=deposit,proto3" json:"deposit,omitempty"`
-		Paid             string                      `protobuf:"varint,10,opt,name=paid,proto3" json:"paid,omitempty"`
-		Nonce            string                      `protobuf:"varint,11,opt,name=nonce,proto3" json:"nonce,omitempty"`
-		SettlementHeight string                      `protobuf:"varint,12,opt,name=settlement_height,json=settlementHeight,proto3" json:"settlement_height,omitempty"`
-		Authorization    types.ContractAuthorization `protobuf:"varint,15,opt,name=authorization,proto3,enum=arkeo.arkeo.ContractAuthorization" json:"authorization,omitempty"`
-		QueriesPerMinute string                      `protobuf:"varint,16,opt,name=queries_per_minute,json=queriesPerMinute,proto3" json:"queries_per_minute,omitempty"`
+		Id                  string                      `protobuf:"varint,13,opt,name=id,proto3" json:"id,omitempty"`
+		ProviderPubKey      common.PubKey               `protobuf:"bytes,1,opt,name=provider_pub_key,json=providerPubKey,proto3,casttype=github.com/arkeonetwork/arkeo/common.PubKey" json:"provider_pub_key,omitempty"`
+		Service             common.Service              `protobuf:"varint,2,opt,name=service,proto3,casttype=github.com/arkeonetwork/arkeo/common.Service" json:"service,omitempty"`
+		Client              common.PubKey               `protobuf:"bytes,3,opt,name=client,proto3,casttype=github.com/arkeonetwork/arkeo/common.PubKey" json:"client,omitempty"`
+		Delegate            common.PubKey               `protobuf:"bytes,4,opt,name=delegate,proto3,casttype=github.com/arkeonetwork/arkeo/common.PubKey" json:"delegate,omitempty"`
+		Type                types.ContractType          `protobuf:"varint,5,opt,name=type,proto3,enum=arkeo.arkeo.ContractType" json:"type,omitempty"`
+		Height              string                      `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+		Duration            string                      `protobuf:"varint,7,opt,name=duration,proto3" json:"duration,omitempty"`
+		Rate                cosmos.Coin                 `protobuf:"varint,8,opt,name=rate,proto3" json:"rate,omitempty"`
+		Deposit             string                      `protobuf:"varint,9,opt,name=deposit,proto3" json:"deposit,omitempty"`
+		Paid                string                      `protobuf:"varint,10,opt,name=paid,proto3" json:"paid,omitempty"`
+		Nonce               string                      `protobuf:"varint,11,opt,name=nonce,proto3" json:"nonce,omitempty"`
+		SettlementHeight    string                      `protobuf:"varint,12,opt,name=settlement_height,json=settlementHeight,proto3" json:"settlement_height,omitempty"`
+		Authorization       types.ContractAuthorization `protobuf:"varint,15,opt,name=authorization,proto3,enum=arkeo.arkeo.ContractAuthorization" json:"authorization,omitempty"`
+		SettlementDuration  string                      `protobuf:"varint,14,opt,name=settlement_duration,json=settlementDuration,proto3" json:"settlement_duration,omitempty"`
+		QueriesPerMinute    string                      `protobuf:"varint,16,opt,name=queries_per_minute,json=queriesPerMinute,proto3" json:"queries_per_minute,omitempty"`
+		RateChangeNonce     string                      `protobuf:"varint,24,opt,name=rate_change_nonce,json=rateChangeNonce,proto3" json:"rate_change_nonce,omitempty"`
+		AccruedAtRateChange string                      `protobuf:"bytes,25,opt,name=accrued_at_rate_change,json=accruedAtRateChange,proto3" json:"accrued_at_rate_change,omitempty"`
+		PausedHeight        string                      `protobuf:"varint,26,opt,name=paused_height,json=pausedHeight,proto3" json:"paused_height,omitempty"`
+		PausedBlocks        string                      `protobuf:"varint,27,opt,name=paused_blocks,json=pausedBlocks,proto3" json:"paused_blocks,omitempty"`
+		RateTiers           []fetchRateTier             `protobuf:"bytes,30,rep,name=rate_tiers,json=rateTiers,proto3" json:"rate_tiers"`
 	}
 
 	type fetch struct {
@@ -167,7 +178,16 @@ func (k *MemStore) fetchContract(key string) (types.Contract, error) {
 	contract.Nonce, _ = strconv.ParseInt(data.Contract.Nonce, 10, 64)
 	contract.SettlementHeight, _ = strconv.ParseInt(data.Contract.SettlementHeight, 10, 64)
 	contract.Authorization = data.Contract.Authorization
+	contract.SettlementDuration, _ = strconv.ParseInt(data.Contract.SettlementDuration, 10, 64)
 	contract.QueriesPerMinute, _ = strconv.ParseInt(data.Contract.QueriesPerMinute, 10, 64)
+	contract.RateChangeNonce, _ = strconv.ParseInt(data.Contract.RateChangeNonce, 10, 64)
+	contract.AccruedAtRateChange, _ = cosmos.NewIntFromString(data.Contract.AccruedAtRateChange)
+	contract.PausedHeight, _ = strconv.ParseInt(data.Contract.PausedHeight, 10, 64)
+	contract.PausedBlocks, _ = strconv.ParseInt(data.Contract.PausedBlocks, 10, 64)
+	for _, tier := range data.Contract.RateTiers {
+		threshold, _ := strconv.ParseInt(tier.Threshold, 10, 64)
+		contract.RateTiers = append(contract.RateTiers, types.RateTier{Threshold: threshold, Rate: tier.Rate})
+	}
 
 	return contract, nil
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
 	"github.com/cosmos/cosmos-sdk/store"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -18,6 +19,9 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
 	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	typesparams "github.com/cosmos/cosmos-sdk/x/params/types"
@@ -34,6 +38,7 @@ func ArkeoKeeper(t testing.TB) (cosmos.Context, keeper.Keeper) {
 	keyAcc := cosmos.NewKVStoreKey(authtypes.StoreKey)
 	keyBank := cosmos.NewKVStoreKey(banktypes.StoreKey)
 	keyStake := cosmos.NewKVStoreKey(stakingtypes.StoreKey)
+	keyDistr := cosmos.NewKVStoreKey(distrtypes.StoreKey)
 	keyParams := cosmos.NewKVStoreKey(paramstypes.StoreKey)
 	tkeyParams := cosmos.NewTransientStoreKey(paramstypes.TStoreKey)
 	memStoreKey := storetypes.NewMemoryStoreKey(types.MemStoreKey)
@@ -63,11 +68,14 @@ func ArkeoKeeper(t testing.TB) (cosmos.Context, keeper.Keeper) {
 		types.ReserveName:              {},
 		types.ProviderName:             {},
 		types.ContractName:             {},
+		types.VestingName:              {},
+		distrtypes.ModuleName:          {},
 	}, sdk.Bech32PrefixAccAddr)
 
 	bk := bankkeeper.NewBaseKeeper(cdc, keyBank, ak, pk.Subspace(banktypes.ModuleName), nil)
 
 	sk := stakingkeeper.NewKeeper(cdc, keyStake, ak, bk, pk.Subspace(stakingtypes.ModuleName))
+	dk := distrkeeper.NewKeeper(cdc, keyDistr, pk.Subspace(distrtypes.ModuleName), ak, bk, sk, authtypes.FeeCollectorName)
 	k := keeper.NewKVStore(
 		cdc,
 		storeKey,
@@ -76,6 +84,8 @@ func ArkeoKeeper(t testing.TB) (cosmos.Context, keeper.Keeper) {
 		bk,
 		ak,
 		sk,
+		dk,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 	)
 	ctx := sdk.NewContext(stateStore, tmproto.Header{}, false, log.NewNopLogger())
 
@@ -86,3 +96,82 @@ func ArkeoKeeper(t testing.TB) (cosmos.Context, keeper.Keeper) {
 
 	return ctx, k
 }
+
+// ArkeoKeeperWithStaking returns a keeper backed by working account, bank,
+// staking and distribution keepers, for tests outside the keeper package that
+// move funds
+func ArkeoKeeperWithStaking(t testing.TB) (cosmos.Context, keeper.Keeper, stakingkeeper.Keeper) {
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+	keyAcc := cosmos.NewKVStoreKey(authtypes.StoreKey)
+	keyBank := cosmos.NewKVStoreKey(banktypes.StoreKey)
+	keyStake := cosmos.NewKVStoreKey(stakingtypes.StoreKey)
+	keyDistr := cosmos.NewKVStoreKey(distrtypes.StoreKey)
+	keyParams := cosmos.NewKVStoreKey(paramstypes.StoreKey)
+	tkeyParams := cosmos.NewTransientStoreKey(paramstypes.TStoreKey)
+	memStoreKey := storetypes.NewMemoryStoreKey(types.MemStoreKey)
+
+	db := tmdb.NewMemDB()
+	stateStore := store.NewCommitMultiStore(db)
+	stateStore.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(keyAcc, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(keyBank, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(keyStake, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(keyDistr, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(keyParams, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(tkeyParams, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(memStoreKey, storetypes.StoreTypeMemory, nil)
+	require.NoError(t, stateStore.LoadLatestVersion())
+
+	encodingConfig := simappparams.MakeTestEncodingConfig()
+	types.RegisterInterfaces(encodingConfig.InterfaceRegistry)
+	cdc := utils.MakeTestMarshaler()
+
+	paramsSubspace := typesparams.NewSubspace(cdc,
+		types.Amino,
+		storeKey,
+		memStoreKey,
+		"ArkeoParams",
+	)
+
+	ctx := sdk.NewContext(stateStore, tmproto.Header{}, false, log.NewNopLogger())
+
+	pk := paramskeeper.NewKeeper(cdc, encodingConfig.Amino, keyParams, tkeyParams)
+	ak := authkeeper.NewAccountKeeper(cdc, keyAcc, pk.Subspace(authtypes.ModuleName), authtypes.ProtoBaseAccount, map[string][]string{
+		stakingtypes.BondedPoolName:    {authtypes.Burner, authtypes.Staking},
+		stakingtypes.NotBondedPoolName: {authtypes.Burner, authtypes.Staking},
+		types.ModuleName:               {authtypes.Minter, authtypes.Burner},
+		types.ReserveName:              {},
+		types.ProviderName:             {},
+		types.ContractName:             {},
+		types.VestingName:              {},
+		distrtypes.ModuleName:          {},
+		authtypes.FeeCollectorName:     nil,
+	}, sdk.Bech32PrefixAccAddr)
+	ak.SetParams(ctx, authtypes.DefaultParams())
+
+	bk := bankkeeper.NewBaseKeeper(cdc, keyBank, ak, pk.Subspace(banktypes.ModuleName), nil)
+	bk.SetParams(ctx, banktypes.DefaultParams())
+
+	sk := stakingkeeper.NewKeeper(cdc, keyStake, ak, bk, pk.Subspace(stakingtypes.ModuleName))
+	sk.SetParams(ctx, stakingtypes.DefaultParams())
+	dk := distrkeeper.NewKeeper(cdc, keyDistr, pk.Subspace(distrtypes.ModuleName), ak, bk, sk, authtypes.FeeCollectorName)
+	dk.SetFeePool(ctx, distrtypes.InitialFeePool())
+
+	k := keeper.NewKVStore(
+		cdc,
+		storeKey,
+		memStoreKey,
+		paramsSubspace,
+		bk,
+		ak,
+		sk,
+		dk,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+	)
+	k.SetVersion(ctx, common.GetCurrentVersion())
+
+	// Initialize params
+	k.SetParams(ctx, types.DefaultParams())
+
+	return ctx, k, sk
+}
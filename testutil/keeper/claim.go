@@ -10,6 +10,7 @@ import (
 	"github.com/arkeonetwork/arkeo/x/claim/types"
 	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
 
+	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/store"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -17,8 +18,13 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govkeeper "github.com/cosmos/cosmos-sdk/x/gov/keeper"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
 	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/log"
@@ -31,6 +37,8 @@ type (
 		ClaimKeeper   keeper.Keeper
 		AccountKeeper authkeeper.AccountKeeper
 		BankKeeper    bankkeeper.Keeper
+		StakingKeeper stakingkeeper.Keeper
+		GovKeeper     govkeeper.Keeper
 	}
 )
 
@@ -39,6 +47,8 @@ func CreateTestClaimKeepers(t testing.TB) (TestKeepers, sdk.Context) {
 	storeKey := sdk.NewKVStoreKey(types.StoreKey)
 	keyAcc := sdk.NewKVStoreKey(authtypes.StoreKey)
 	keyBank := sdk.NewKVStoreKey(banktypes.StoreKey)
+	keyStaking := sdk.NewKVStoreKey(stakingtypes.StoreKey)
+	keyGov := sdk.NewKVStoreKey(govtypes.StoreKey)
 	keyParams := sdk.NewKVStoreKey(paramstypes.StoreKey)
 	tkeyParams := sdk.NewTransientStoreKey(paramstypes.TStoreKey)
 	memStoreKey := storetypes.NewMemoryStoreKey(types.MemStoreKey)
@@ -49,6 +59,8 @@ func CreateTestClaimKeepers(t testing.TB) (TestKeepers, sdk.Context) {
 	stateStore.MountStoreWithDB(memStoreKey, storetypes.StoreTypeMemory, nil)
 	stateStore.MountStoreWithDB(keyAcc, storetypes.StoreTypeIAVL, db)
 	stateStore.MountStoreWithDB(keyBank, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(keyStaking, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(keyGov, storetypes.StoreTypeIAVL, db)
 	stateStore.MountStoreWithDB(tkeyParams, storetypes.StoreTypeIAVL, db)
 	stateStore.MountStoreWithDB(keyParams, storetypes.StoreTypeIAVL, db)
 	require.NoError(t, stateStore.LoadLatestVersion())
@@ -71,20 +83,37 @@ func CreateTestClaimKeepers(t testing.TB) (TestKeepers, sdk.Context) {
 	accountKeeper := authkeeper.NewAccountKeeper(cdc, keyAcc, paramsKeeper.Subspace(authtypes.ModuleName), authtypes.ProtoBaseAccount, map[string][]string{
 		stakingtypes.BondedPoolName:    {authtypes.Burner, authtypes.Staking},
 		stakingtypes.NotBondedPoolName: {authtypes.Burner, authtypes.Staking},
+		govtypes.ModuleName:            {authtypes.Burner},
 		types.ModuleName:               {authtypes.Minter},
 		arkeotypes.ReserveName:         {},
 		arkeotypes.ProviderName:        {},
 		arkeotypes.ContractName:        {},
+		arkeotypes.VestingName:         {},
 	}, sdk.Bech32PrefixAccAddr)
 	accountKeeper.SetParams(ctx, authtypes.DefaultParams())
 	bankKeeper := bankkeeper.NewBaseKeeper(cdc, keyBank, accountKeeper, paramsKeeper.Subspace(banktypes.ModuleName), nil)
 	bankKeeper.SetParams(ctx, banktypes.DefaultParams())
+	stakingKeeper := stakingkeeper.NewKeeper(cdc, keyStaking, accountKeeper, bankKeeper, paramsKeeper.Subspace(stakingtypes.ModuleName))
+	stakingKeeper.SetParams(ctx, stakingtypes.DefaultParams())
+	govKeeper := govkeeper.NewKeeper(
+		cdc,
+		keyGov,
+		paramsKeeper.Subspace(govtypes.ModuleName).WithKeyTable(govv1.ParamKeyTable()),
+		accountKeeper,
+		bankKeeper,
+		stakingKeeper,
+		govv1beta1.NewRouter(),
+		baseapp.NewMsgServiceRouter(),
+		govtypes.DefaultConfig(),
+	)
 
 	k := keeper.NewKeeper(
 		cdc,
 		storeKey,
 		accountKeeper,
 		bankKeeper,
+		stakingKeeper,
+		govKeeper,
 		memStoreKey,
 		paramsSubspace,
 	)
@@ -103,5 +132,7 @@ func CreateTestClaimKeepers(t testing.TB) (TestKeepers, sdk.Context) {
 		ClaimKeeper:   k,
 		AccountKeeper: accountKeeper,
 		BankKeeper:    bankKeeper,
+		StakingKeeper: stakingKeeper,
+		GovKeeper:     govKeeper,
 	}, ctx
 }
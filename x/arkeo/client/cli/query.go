@@ -25,6 +25,25 @@ func GetQueryCmd(queryRoute string) *cobra.Command {
 
 	cmd.AddCommand(CmdQueryParams())
 	cmd.AddCommand(CmdActiveContract())
+	cmd.AddCommand(CmdContractTimeRemaining())
+	cmd.AddCommand(CmdSettlementPreview())
+	cmd.AddCommand(CmdReserveHistory())
+	cmd.AddCommand(CmdEmissionAPR())
+	cmd.AddCommand(CmdEmissionSchedule())
+	cmd.AddCommand(CmdRewardVesting())
+	cmd.AddCommand(CmdConfigs())
+	cmd.AddCommand(CmdServices())
+	cmd.AddCommand(CmdExpiringContracts())
+	cmd.AddCommand(CmdListClientContracts())
+	cmd.AddCommand(CmdClientStats())
+	cmd.AddCommand(CmdShowProviderById())
+	cmd.AddCommand(CmdProviderStats())
+	cmd.AddCommand(CmdProviderUptime())
+	cmd.AddCommand(CmdProviderMetadata())
+	cmd.AddCommand(CmdProviderEarnings())
+	cmd.AddCommand(CmdProviderSlashes())
+	cmd.AddCommand(CmdContractEscrow())
+	cmd.AddCommand(CmdDisputes())
 
 	// this line is used by starport scaffolding # 1
 
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+)
+
+func CmdContractTimeRemaining() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract-time-remaining [provider] [service] [client]",
+		Short: "Query the time remaining on a client's latest contract with a provider",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			reqProvider := args[0]
+			reqService := args[1]
+			reqClient := args[2]
+
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryContractTimeRemainingRequest{
+				Provider: reqProvider,
+				Service:  reqService,
+				Client:   reqClient,
+			}
+
+			res, err := queryClient.ContractTimeRemaining(cmd.Context(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -76,3 +76,66 @@ func CmdShowContract() *cobra.Command {
 
 	return cmd
 }
+
+func CmdListClientContracts() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-client-contracts [client-pubkey]",
+		Short: "list the contracts of a client, with their current debt",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryClientContractsRequest{
+				Client:     args[0],
+				Pagination: pageReq,
+			}
+
+			res, err := queryClient.ClientContracts(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdClientStats() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "client-stats [client-pubkey]",
+		Short: "shows the usage history of a client across all its contracts",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryClientStatsRequest{
+				Client: args[0],
+			}
+
+			res, err := queryClient.ClientStats(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagProvider = "provider"
+	flagClient   = "client"
+)
+
+func CmdExpiringContracts() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "expiring-contracts [blocks]",
+		Short: "Query the contracts closing within the given number of blocks",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			reqBlocks, err := cast.ToInt64E(args[0])
+			if err != nil {
+				return err
+			}
+
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryExpiringContractsRequest{
+				Blocks: reqBlocks,
+			}
+			params.Provider, err = cmd.Flags().GetString(flagProvider)
+			if err != nil {
+				return err
+			}
+			params.Client, err = cmd.Flags().GetString(flagClient)
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.ExpiringContracts(cmd.Context(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().String(flagProvider, "", "only list the contracts of this provider pubkey")
+	cmd.Flags().String(flagClient, "", "only list the contracts of this client pubkey")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagService    = "service"
+	flagFromHeight = "from-height"
+	flagToHeight   = "to-height"
+)
+
+func CmdProviderEarnings() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider-earnings [pubkey]",
+		Short: "list the settlements paid to a provider",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			service, err := cmd.Flags().GetString(flagService)
+			if err != nil {
+				return err
+			}
+			fromHeight, err := cmd.Flags().GetInt64(flagFromHeight)
+			if err != nil {
+				return err
+			}
+			toHeight, err := cmd.Flags().GetInt64(flagToHeight)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryProviderEarningsRequest{
+				Pubkey:     args[0],
+				Service:    service,
+				FromHeight: fromHeight,
+				ToHeight:   toHeight,
+				Pagination: pageReq,
+			}
+
+			res, err := queryClient.ProviderEarnings(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().String(flagService, "", "only list the earnings of the given service")
+	cmd.Flags().Int64(flagFromHeight, 0, "only list the earnings from the given height")
+	cmd.Flags().Int64(flagToHeight, 0, "only list the earnings up to the given height")
+	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+)
+
+func CmdProviderSlashes() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider-slashes [pubkey]",
+		Short: "list the slashes taken from a provider's bond",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			service, err := cmd.Flags().GetString(flagService)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryProviderSlashesRequest{
+				Pubkey:     args[0],
+				Service:    service,
+				Pagination: pageReq,
+			}
+
+			res, err := queryClient.ProviderSlashes(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().String(flagService, "", "only list the slashes of the given service")
+	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
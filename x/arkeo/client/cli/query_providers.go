@@ -7,9 +7,15 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
 )
 
+const (
+	flagChain       = "chain"
+	flagServiceType = "service-type"
+)
+
 func CmdListProviders() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list-providers",
@@ -22,10 +28,26 @@ func CmdListProviders() *cobra.Command {
 				return err
 			}
 
+			provider, err := cmd.Flags().GetString(flagProvider)
+			if err != nil {
+				return err
+			}
+			chain, err := cmd.Flags().GetString(flagChain)
+			if err != nil {
+				return err
+			}
+			serviceType, err := cmd.Flags().GetString(flagServiceType)
+			if err != nil {
+				return err
+			}
+
 			queryClient := types.NewQueryClient(clientCtx)
 
 			params := &types.QueryAllProviderRequest{
-				Pagination: pageReq,
+				Pagination:  pageReq,
+				Pubkey:      provider,
+				Chain:       chain,
+				ServiceType: serviceType,
 			}
 
 			res, err := queryClient.ProviderAll(context.Background(), params)
@@ -37,6 +59,9 @@ func CmdListProviders() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().String(flagProvider, "", "only list the services bonded by the given provider pubkey")
+	cmd.Flags().String(flagChain, "", "only list the services on the given chain, such as eth")
+	cmd.Flags().String(flagServiceType, "", "only list the given type of service, such as archivenode or websocket")
 	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
 	flags.AddQueryFlagsToCmd(cmd)
 
@@ -74,3 +99,119 @@ func CmdShowProvider() *cobra.Command {
 
 	return cmd
 }
+
+func CmdShowProviderById() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-provider-by-id [id]",
+		Short: "shows a provider by its numeric id",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			argId, err := cast.ToUint64E(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.ProviderById(context.Background(), &types.QueryProviderByIdRequest{Id: argId})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdProviderStats() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider-stats [pubkey] [service]",
+		Short: "shows the settlement history and reputation score of a provider",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryProviderStatsRequest{
+				Pubkey:  args[0],
+				Service: args[1],
+			}
+
+			res, err := queryClient.ProviderStats(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdProviderUptime() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider-uptime [pubkey] [service]",
+		Short: "shows the validator observed uptime and uptime score of a provider",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryProviderUptimeRequest{
+				Pubkey:  args[0],
+				Service: args[1],
+			}
+
+			res, err := queryClient.ProviderUptime(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdProviderMetadata() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider-metadata [pubkey] [service]",
+		Short: "shows the metadata document a provider points to",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryProviderMetadataRequest{
+				Pubkey:  args[0],
+				Service: args[1],
+			}
+
+			res, err := queryClient.ProviderMetadata(context.Background(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+)
+
+func CmdSettlementPreview() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settlement-preview [provider] [service] [client] [nonce]",
+		Short: "Preview the payout of settling a client's latest contract with a provider at a nonce",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			reqProvider := args[0]
+			reqService := args[1]
+			reqClient := args[2]
+			reqNonce, err := cast.ToInt64E(args[3])
+			if err != nil {
+				return err
+			}
+
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QuerySettlementPreviewRequest{
+				Provider: reqProvider,
+				Service:  reqService,
+				Client:   reqClient,
+				Nonce:    reqNonce,
+			}
+
+			res, err := queryClient.SettlementPreview(cmd.Context(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
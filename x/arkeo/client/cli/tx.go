@@ -33,10 +33,25 @@ func GetTxCmd() *cobra.Command {
 
 	cmd.AddCommand(CmdBondProvider())
 	cmd.AddCommand(CmdModProvider())
+	cmd.AddCommand(CmdModProviderMetadata())
 	cmd.AddCommand(CmdOpenContract())
 	cmd.AddCommand(CmdCloseContract())
+	cmd.AddCommand(CmdCloseContractByProvider())
 	cmd.AddCommand(CmdClaimContractIncome())
+	cmd.AddCommand(CmdSetContractMemo())
+	cmd.AddCommand(CmdTransferContract())
+	cmd.AddCommand(CmdDisputeContract())
+	cmd.AddCommand(CmdProposeRateChange())
+	cmd.AddCommand(CmdAcceptRateChange())
+	cmd.AddCommand(CmdRenegotiateRate())
+	cmd.AddCommand(CmdPauseContract())
+	cmd.AddCommand(CmdResumeContract())
+	cmd.AddCommand(CmdRenewContract())
+	cmd.AddCommand(CmdDepositContract())
+	cmd.AddCommand(CmdSettleContracts())
+	cmd.AddCommand(CmdReportUptime())
 	cmd.AddCommand(CmdSetVersion())
+	cmd.AddCommand(CmdGrant())
 	// this line is used by starport scaffolding # 1
 
 	return cmd
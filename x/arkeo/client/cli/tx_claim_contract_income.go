@@ -3,6 +3,7 @@ package cli
 import (
 	"encoding/hex"
 
+	"github.com/arkeonetwork/arkeo/common"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -42,6 +43,22 @@ func CmdClaimContractIncome() *cobra.Command {
 				argNonce,
 				signature,
 			)
+			// claims under an authz grant restricted to providers or
+			// services need to give them
+			provider, err := cmd.Flags().GetString(flagProvider)
+			if err != nil {
+				return err
+			}
+			if provider != "" {
+				msg.Provider, err = common.NewPubKey(provider)
+				if err != nil {
+					return err
+				}
+			}
+			msg.Service, err = cmd.Flags().GetString(flagService)
+			if err != nil {
+				return err
+			}
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -49,6 +66,8 @@ func CmdClaimContractIncome() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().String(flagProvider, "", "provider pubkey of the contract, required by grants restricted to providers")
+	cmd.Flags().String(flagService, "", "service of the contract, required by grants restricted to services")
 	flags.AddTxFlagsToCmd(cmd)
 
 	return cmd
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagProviders  = "providers"
+	flagServices   = "services"
+	flagSpendLimit = "spend-limit"
+	flagExpiration = "expiration"
+)
+
+func CmdGrant() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee] [claim-contract-income|mod-provider|open-contract]",
+		Short: "Grant a hot key an authorization to send arkeo messages for you",
+		Long: `Grant the grantee an authz authorization to send arkeo messages on behalf of
+the sender:
+  claim-contract-income  claim contract income, limited to --providers and --services when given
+  mod-provider           modify provider bonds, limited to --providers and --services when given
+  open-contract          open contracts with deposits and open costs up to --spend-limit,
+                         limited to --providers and --services when given`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			providerArgs, err := cmd.Flags().GetStringSlice(flagProviders)
+			if err != nil {
+				return err
+			}
+			providers := make([]common.PubKey, 0, len(providerArgs))
+			for _, arg := range providerArgs {
+				pk, err := common.NewPubKey(arg)
+				if err != nil {
+					return err
+				}
+				providers = append(providers, pk)
+			}
+			serviceArgs, err := cmd.Flags().GetStringSlice(flagServices)
+			if err != nil {
+				return err
+			}
+			services := make([]common.Service, 0, len(serviceArgs))
+			for _, arg := range serviceArgs {
+				service, err := common.NewService(arg)
+				if err != nil {
+					return err
+				}
+				services = append(services, service)
+			}
+
+			var authorization authz.Authorization
+			switch args[1] {
+			case "claim-contract-income":
+				authorization = types.NewClaimContractIncomeAuthorization(providers, services)
+			case "mod-provider":
+				authorization = types.NewModProviderAuthorization(providers, services)
+			case "open-contract":
+				limit, err := cmd.Flags().GetString(flagSpendLimit)
+				if err != nil {
+					return err
+				}
+				spendLimit, err := sdk.ParseCoinsNormalized(limit)
+				if err != nil {
+					return err
+				}
+				authorization = types.NewOpenContractAuthorization(spendLimit, providers, services)
+			default:
+				return fmt.Errorf("invalid authorization type %s", args[1])
+			}
+
+			var expiration *time.Time
+			exp, err := cmd.Flags().GetInt64(flagExpiration)
+			if err != nil {
+				return err
+			}
+			if exp > 0 {
+				t := time.Unix(exp, 0)
+				expiration = &t
+			}
+
+			msg, err := authz.NewMsgGrant(clientCtx.GetFromAddress(), grantee, authorization, expiration)
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().StringSlice(flagProviders, nil, "provider pubkeys the grantee may act for, comma separated")
+	cmd.Flags().StringSlice(flagServices, nil, "services the grantee may act for, comma separated")
+	cmd.Flags().String(flagSpendLimit, "", "total of deposits and open costs the grantee may open contracts with")
+	cmd.Flags().Int64(flagExpiration, 0, "unix timestamp the grant expires at, none when zero")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
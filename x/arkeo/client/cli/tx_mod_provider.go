@@ -12,6 +12,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	flagSubscriptionRateTiers = "subscription-rate-tiers"
+	flagPayAsYouGoRateTiers   = "pay-as-you-go-rate-tiers"
+	flagFreeTierMaxDuration   = "free-tier-max-duration"
+	flagFreeTierMaxQueries    = "free-tier-max-queries"
+	flagMaxOpenContracts      = "max-open-contracts"
+)
+
 func CmdModProvider() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "mod-provider [pubkey] [service] [metatadata-uri] [metadata-nonce] [status] [min-contract-duration] [max-contract-duration] [subscription-rates] [pay-as-you-go-rates] [settlement-duration]",
@@ -56,6 +64,36 @@ func CmdModProvider() *cobra.Command {
 				return err
 			}
 
+			subscriptionRateTiers, err := cmd.Flags().GetString(flagSubscriptionRateTiers)
+			if err != nil {
+				return err
+			}
+			argSubscriptionRateTiers, err := types.ParseRateTiers(subscriptionRateTiers)
+			if err != nil {
+				return err
+			}
+			payAsYouGoRateTiers, err := cmd.Flags().GetString(flagPayAsYouGoRateTiers)
+			if err != nil {
+				return err
+			}
+			argPayAsYouGoRateTiers, err := types.ParseRateTiers(payAsYouGoRateTiers)
+			if err != nil {
+				return err
+			}
+
+			freeTierMaxDuration, err := cmd.Flags().GetInt64(flagFreeTierMaxDuration)
+			if err != nil {
+				return err
+			}
+			freeTierMaxQueries, err := cmd.Flags().GetInt64(flagFreeTierMaxQueries)
+			if err != nil {
+				return err
+			}
+			maxOpenContracts, err := cmd.Flags().GetInt64(flagMaxOpenContracts)
+			if err != nil {
+				return err
+			}
+
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
@@ -74,6 +112,13 @@ func CmdModProvider() *cobra.Command {
 				argPayAsYouGoRate,
 				argSettlementDuration,
 			)
+			msg.SubscriptionRateTiers = argSubscriptionRateTiers
+			msg.PayAsYouGoRateTiers = argPayAsYouGoRateTiers
+			msg.FreeTier = types.FreeTier{
+				MaxDuration: freeTierMaxDuration,
+				MaxQueries:  freeTierMaxQueries,
+			}
+			msg.MaxOpenContracts = maxOpenContracts
 
 			if err := msg.ValidateBasic(); err != nil {
 				return err
@@ -82,6 +127,11 @@ func CmdModProvider() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().String(flagSubscriptionRateTiers, "", "subscription volume discounts, as threshold:rate pairs with the threshold in blocks (e.g. 10000:8uarkeo,50000:5uarkeo)")
+	cmd.Flags().String(flagPayAsYouGoRateTiers, "", "pay-as-you-go volume discounts, as threshold:rate pairs with the threshold in queries (e.g. 10000:8uarkeo,50000:5uarkeo)")
+	cmd.Flags().Int64(flagFreeTierMaxDuration, 0, "longest free tier contract offered at a zero pay-as-you-go rate, in blocks (0 offers no free tier)")
+	cmd.Flags().Int64(flagFreeTierMaxQueries, 0, "most queries a free tier contract may make (0 is only limited by its queries per minute)")
+	cmd.Flags().Int64(flagMaxOpenContracts, 0, "most contracts that may be open at once (0 is only limited by the network cap)")
 	flags.AddTxFlagsToCmd(cmd)
 
 	return cmd
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+)
+
+func CmdModProviderMetadata() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mod-provider-metadata [pubkey] [service] [metadata-uri] [metadata-sha256-hex] [metadata-nonce]",
+		Short: "Broadcast message modProviderMetadata",
+		Args:  cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			pubkey, err := common.NewPubKey(args[0])
+			if err != nil {
+				return err
+			}
+
+			argHash, err := hex.DecodeString(args[3])
+			if err != nil {
+				return fmt.Errorf("bad metadata hash: %w", err)
+			}
+
+			argNonce, err := cast.ToUint64E(args[4])
+			if err != nil {
+				return err
+			}
+
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgModProviderMetadata(
+				clientCtx.GetFromAddress(),
+				pubkey,
+				args[1],
+				args[2],
+				argHash,
+				argNonce,
+			)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
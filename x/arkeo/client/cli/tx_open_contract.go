@@ -14,9 +14,18 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	flagAutoRenew          = "auto-renew"
+	flagRefundAddress      = "refund-address"
+	flagAffiliate          = "affiliate"
+	flagAffiliateFee       = "affiliate-fee"
+	flagSettlementInterval = "settlement-interval"
+	flagMaxOpenCost        = "max-open-cost"
+)
+
 func CmdOpenContract() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "open-contract [provider_pubkey] [service] [client_pubkey] [c-type] [deposit] [duration] [rate] [queries-per-minute] [settlement-duration] [authorization-optional] [delegation-optional]",
+		Use:   "open-contract [provider_pubkey] [service] [client_pubkey] [c-type] [deposit] [duration] [rate] [queries-per-minute] [settlement-duration] [authorization-optional] [delegation-optional] [memo-optional]",
 		Short: "Broadcast message openContract",
 		Args:  cobra.MinimumNArgs(9),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
@@ -80,6 +89,11 @@ func CmdOpenContract() *cobra.Command {
 				}
 			}
 
+			argMemo := ""
+			if len(args) > 11 {
+				argMemo = args[11]
+			}
+
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
@@ -99,6 +113,50 @@ func CmdOpenContract() *cobra.Command {
 				types.ContractAuthorization(argContractAuth),
 				argQPM,
 			)
+			msg.Memo = argMemo
+			msg.AutoRenew, err = cmd.Flags().GetBool(flagAutoRenew)
+			if err != nil {
+				return err
+			}
+			refundAddress, err := cmd.Flags().GetString(flagRefundAddress)
+			if err != nil {
+				return err
+			}
+			if refundAddress != "" {
+				msg.RefundAddress, err = cosmos.AccAddressFromBech32(refundAddress)
+				if err != nil {
+					return err
+				}
+			}
+			msg.SettlementInterval, err = cmd.Flags().GetInt64(flagSettlementInterval)
+			if err != nil {
+				return err
+			}
+			affiliate, err := cmd.Flags().GetString(flagAffiliate)
+			if err != nil {
+				return err
+			}
+			if affiliate != "" {
+				msg.Affiliate, err = cosmos.AccAddressFromBech32(affiliate)
+				if err != nil {
+					return err
+				}
+			}
+			msg.AffiliateFee, err = cmd.Flags().GetInt64(flagAffiliateFee)
+			if err != nil {
+				return err
+			}
+			maxOpenCost, err := cmd.Flags().GetString(flagMaxOpenCost)
+			if err != nil {
+				return err
+			}
+			if maxOpenCost != "" {
+				var ok bool
+				msg.MaxOpenCost, ok = cosmos.NewIntFromString(maxOpenCost)
+				if !ok {
+					return fmt.Errorf("invalid max open cost: %s", maxOpenCost)
+				}
+			}
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -106,6 +164,12 @@ func CmdOpenContract() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Bool(flagAutoRenew, false, "renew the subscription on expiration while the client can afford it")
+	cmd.Flags().String(flagRefundAddress, "", "account the rest of the deposit is refunded to, defaults to the client")
+	cmd.Flags().Int64(flagSettlementInterval, 0, "settle a subscription with the provider every so many blocks, zero only settles on close or expiry")
+	cmd.Flags().String(flagAffiliate, "", "account that referred the client, paid a share of the provider's income on each settlement")
+	cmd.Flags().Int64(flagAffiliateFee, 0, "basis points of the provider's income paid to the affiliate")
+	cmd.Flags().String(flagMaxOpenCost, "", "most to pay to open the contract on top of the deposit, required when opening under an authorization")
 	flags.AddTxFlagsToCmd(cmd)
 
 	return cmd
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"encoding/hex"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+)
+
+func CmdRenegotiateRate() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "renegotiate-rate [contract-id] [rate] [deadline] [client-signature] [provider-signature]",
+		Short: "Broadcast message renegotiateRate",
+		Args:  cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			argContractId, err := cast.ToUint64E(args[0])
+			if err != nil {
+				return err
+			}
+
+			argRate, err := cosmos.ParseCoin(args[1])
+			if err != nil {
+				return err
+			}
+
+			argDeadline, err := cast.ToInt64E(args[2])
+			if err != nil {
+				return err
+			}
+
+			clientSignature, err := hex.DecodeString(args[3])
+			if err != nil {
+				return err
+			}
+
+			providerSignature, err := hex.DecodeString(args[4])
+			if err != nil {
+				return err
+			}
+
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRenegotiateRate(
+				clientCtx.GetFromAddress(),
+				argContractId,
+				argRate,
+				argDeadline,
+			)
+			msg.ClientSignature = clientSignature
+			msg.ProviderSignature = providerSignature
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+)
+
+func CmdReportUptime() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report-uptime [pubkey:service:online] ...",
+		Short: "Broadcast message reportUptime, from a validator operator account",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			observations := make([]types.UptimeObservation, 0, len(args))
+			for _, arg := range args {
+				parts := strings.Split(arg, ":")
+				if len(parts) != 3 {
+					return fmt.Errorf("invalid observation (%s), expected pubkey:service:online", arg)
+				}
+				pubkey, err := common.NewPubKey(parts[0])
+				if err != nil {
+					return err
+				}
+				online, err := cast.ToBoolE(parts[2])
+				if err != nil {
+					return err
+				}
+				observations = append(observations, types.UptimeObservation{
+					Provider: pubkey,
+					Service:  parts[1],
+					Online:   online,
+				})
+			}
+
+			msg := types.NewMsgReportUptime(
+				clientCtx.GetFromAddress(),
+				observations,
+			)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
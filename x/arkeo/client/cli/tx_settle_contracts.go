@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+)
+
+func CmdSettleContracts() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settle-contracts [contract-id:nonce:signature] ...",
+		Short: "Broadcast message settleContracts",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			claims := make([]types.ContractClaim, 0, len(args))
+			for _, arg := range args {
+				parts := strings.Split(arg, ":")
+				if len(parts) != 3 {
+					return fmt.Errorf("invalid claim (%s), expected contract-id:nonce:signature", arg)
+				}
+				argContractId, err := cast.ToUint64E(parts[0])
+				if err != nil {
+					return err
+				}
+				argNonce, err := cast.ToInt64E(parts[1])
+				if err != nil {
+					return err
+				}
+				signature, err := hex.DecodeString(parts[2])
+				if err != nil {
+					return err
+				}
+				claims = append(claims, types.ContractClaim{
+					ContractId: argContractId,
+					Nonce:      argNonce,
+					Signature:  signature,
+				})
+			}
+
+			msg := types.NewMsgSettleContracts(
+				clientCtx.GetFromAddress(),
+				claims,
+			)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
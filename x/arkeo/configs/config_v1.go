@@ -6,23 +6,67 @@ import "github.com/arkeonetwork/arkeo/common"
 func NewConfigValue010() *ConfigVals {
 	return &ConfigVals{
 		int64values: map[ConfigName]int64{
-			HandlerBondProvider:        0,                          // enable/disable bond provider handler
-			HandlerModProvider:         0,                          // enable/disable mod provider handler
-			HandlerOpenContract:        0,                          // enable/disable open contract handler
-			HandlerCloseContract:       0,                          // enable/disable close contract handler
-			HandlerClaimContractIncome: 0,                          // enable/disable claim contract income handler
-			HandlerSetVersion:          0,                          // enable/disable set version handler
-			MaxContractLength:          5256000,                    // one year
-			MaxSupply:                  common.Tokens(121_000_000), // max supply of tokens
-			OpenContractCost:           common.Tokens(1),           // cost to open a contract
-			MinProviderBond:            common.Tokens(1),           // min bond for a data provider to be able to open contracts with
-			ReserveTax:                 1000,                       // reserve income off provider income, in basis points
-			BlocksPerYear:              5256666,                    // blocks per year
-			EmissionCurve:              6,                          // rate in which the reserve is depleted to pay validators
-			ValidatorPayoutCycle:       1,                          // how often validators are paid out rewards
-			VersionConsensus:           90,                         // out of 100, percentage of nodes on a specific version before it is accepted
+			HandlerBondProvider:            0,                          // enable/disable bond provider handler
+			HandlerModProvider:             0,                          // enable/disable mod provider handler
+			HandlerOpenContract:            0,                          // enable/disable open contract handler
+			HandlerCloseContract:           0,                          // enable/disable close contract handler
+			HandlerClaimContractIncome:     0,                          // enable/disable claim contract income handler
+			HandlerSetVersion:              0,                          // enable/disable set version handler
+			MaxContractLength:              5256000,                    // one year
+			MaxSupply:                      common.Tokens(121_000_000), // max supply of tokens
+			OpenContractCost:               common.Tokens(1),           // cost to open a contract
+			MinProviderBond:                common.Tokens(1),           // min bond for a data provider to be able to open contracts with
+			ReserveTax:                     1000,                       // reserve income off provider income, in basis points
+			BlocksPerYear:                  5256666,                    // blocks per year
+			EmissionCurve:                  6,                          // rate in which the reserve is depleted to pay validators
+			ValidatorPayoutCycle:           1,                          // how often validators are paid out rewards
+			VersionConsensus:               90,                         // out of 100, percentage of nodes on a specific version before it is accepted
+			MaxTotalDeposit:                0,                          // max sum of deposits across a client's open contracts, zero is unlimited
+			SubscriptionRatePerDay:         0,                          // when enabled, subscription rates providers set from then on are per day instead of per block
+			ReserveHistoryLength:           100,                        // number of payout cycles to keep reserve snapshots for
+			HandlerSetContractMemo:         0,                          // enable/disable set contract memo handler
+			HandlerSettleContracts:         0,                          // enable/disable settle contracts handler
+			MaxSettleBatchSize:             100,                        // max number of contract claims in a single settle contracts message
+			HaltContractOpening:            0,                          // emergency halt of new contracts, existing contracts still settle
+			PermissionedProviders:          0,                          // when enabled, only allow listed providers may bond and have contracts opened against them
+			MinReserveFloor:                0,                          // no validator rewards are emitted while the reserve is at or below this amount
+			HandlerRateChange:              0,                          // enable/disable propose and accept rate change handlers
+			MinPeriodsFunded:               1,                          // min number of blocks a subscription deposit must cover at the contract rate
+			MinPayAsYouGoDeposit:           0,                          // min deposit of a pay-as-you-go contract, zero is no minimum
+			CommunityTaxShare:              0,                          // share of the reserve tax sent to the community pool, in basis points
+			HandlerPauseContract:           0,                          // enable/disable pause and resume contract handlers
+			AvgBlockTime:                   6000,                       // average block time in milliseconds, used to estimate the time left on contracts
+			HandlerCloseContractByProvider: 0,                          // enable/disable close contract by provider handler
+			ProviderCancelPenalty:          500,                        // share of the provider's bond taken when they close a contract early, in basis points
+			ProviderEarningsHistory:        432000,                     // number of blocks provider earnings are kept for, zero keeps them forever
+			HandlerTransferContract:        0,                          // enable/disable transfer contract handler
+			DisputeWindow:                  0,                          // number of blocks settled provider income is held in escrow and may be disputed, zero pays providers straight away
+			DisputeTimeout:                 100800,                     // number of blocks a dispute may go unresolved before the escrow is released to the provider, zero never times out
+			HandlerDisputeContract:         0,                          // enable/disable dispute contract handler
+			MinSettlementInterval:          600,                        // min number of blocks between the periodic settlements of a subscription
+			HandlerModProviderMetadata:     0,                          // enable/disable mod provider metadata handler
+			ClaimRateLeeway:                1000,                       // share of queries a pay-as-you-go claim may add beyond its queries per minute, in basis points, to allow for block time drift
+			HandlerRenewContract:           0,                          // enable/disable renew contract handler
+			ContractGracePeriod:            0,                          // number of blocks after a subscription expires that the client may still renew it before it is finally settled, zero settles straight away
+			ContractRetention:              432000,                     // number of blocks a closed contract is kept for before it is pruned, zero keeps them forever
+			RewardVestingPeriod:            0,                          // number of blocks validator and delegator rewards vest over, zero pays them out liquid
+			ProviderIncentive:              0,                          // tokens paid out of the reserve to active providers each incentive cycle, zero disables the incentive program
+			ProviderIncentiveCycle:         14400,                      // how often the provider incentive is paid out
+			ProviderIncentiveEnd:           0,                          // block height the provider incentive program ends at, zero runs it until governance disables it
+			MaxDepositMultiple:             0,                          // max multiple of the most a pay-as-you-go contract can spend over its duration that may be deposited, zero is unlimited
+			HandlerDepositContract:         0,                          // enable/disable deposit contract handler
+			MaxAffiliateFee:                1000,                       // max basis points of a provider's contract income that may be paid to the affiliate that referred the client
+			HandlerReportUptime:            0,                          // enable/disable report uptime handler
+			UptimeCycle:                    600,                        // number of blocks validators report provider liveness over before the votes are aggregated
+			MinUptimeObservations:          24,                         // number of observed uptime cycles before a provider's uptime score is applied
+			MinProviderUptime:              0,                          // min uptime score, in basis points, a provider needs to earn incentives and avoid the uptime slash, zero disables it
+			UptimeSlash:                    0,                          // basis points of bond slashed each cycle a provider is found offline while below the min uptime, zero disables it
+			MaxOpenContracts:               0,                          // max number of contracts open against a provider for a service at once, caps what providers may set, zero is unlimited
+			FeeReserveShare:                0,                          // basis points of the tx fees collected each block moved into the reserve instead of going to validators
+		},
+		boolValues: map[ConfigName]bool{},
+		stringValues: map[ConfigName]string{
+			ChainEmissionWeights: "", // comma separated service:weight (basis points) pairs to weight validator rewards by chain activity, empty pays by stake alone
 		},
-		boolValues:   map[ConfigName]bool{},
-		stringValues: map[ConfigName]string{},
 	}
 }
@@ -23,24 +23,130 @@ const (
 	EmissionCurve
 	ValidatorPayoutCycle
 	VersionConsensus
+	MaxTotalDeposit
+	SubscriptionRatePerDay
+	ReserveHistoryLength
+	HandlerSetContractMemo
+	HandlerSettleContracts
+	MaxSettleBatchSize
+	HaltContractOpening
+	PermissionedProviders
+	MinReserveFloor
+	HandlerRateChange
+	ChainEmissionWeights
+	MinPeriodsFunded
+	MinPayAsYouGoDeposit
+	CommunityTaxShare
+	HandlerPauseContract
+	AvgBlockTime
+	HandlerCloseContractByProvider
+	ProviderCancelPenalty
+	ProviderEarningsHistory
+	HandlerTransferContract
+	DisputeWindow
+	DisputeTimeout
+	HandlerDisputeContract
+	MinSettlementInterval
+	HandlerModProviderMetadata
+	ClaimRateLeeway
+	HandlerRenewContract
+	ContractGracePeriod
+	ContractRetention
+	RewardVestingPeriod
+	ProviderIncentive
+	ProviderIncentiveCycle
+	ProviderIncentiveEnd
+	MaxDepositMultiple
+	HandlerDepositContract
+	MaxAffiliateFee
+	HandlerReportUptime
+	UptimeCycle
+	MinUptimeObservations
+	MinProviderUptime
+	UptimeSlash
+	MaxOpenContracts
+	FeeReserveShare
 )
 
 var nameToString = map[ConfigName]string{
-	HandlerBondProvider:        "HandlerBondProvider",
-	HandlerModProvider:         "HandlerModProvider",
-	HandlerOpenContract:        "HandlerOpenContract",
-	HandlerCloseContract:       "HandlerCloseContract",
-	HandlerClaimContractIncome: "HandlerClaimContractIncome",
-	HandlerSetVersion:          "HandlerSetVersion",
-	MaxSupply:                  "MaxSupply",
-	MaxContractLength:          "MaxContractLength",
-	OpenContractCost:           "OpenContractCost",
-	MinProviderBond:            "MinProviderBond",
-	ReserveTax:                 "ReserveTax",
-	BlocksPerYear:              "BlocksPerYear",
-	EmissionCurve:              "EmissionCurve",
-	ValidatorPayoutCycle:       "ValidatorPayoutCycle",
-	VersionConsensus:           "VersionConsensus",
+	HandlerBondProvider:            "HandlerBondProvider",
+	HandlerModProvider:             "HandlerModProvider",
+	HandlerOpenContract:            "HandlerOpenContract",
+	HandlerCloseContract:           "HandlerCloseContract",
+	HandlerClaimContractIncome:     "HandlerClaimContractIncome",
+	HandlerSetVersion:              "HandlerSetVersion",
+	MaxSupply:                      "MaxSupply",
+	MaxContractLength:              "MaxContractLength",
+	OpenContractCost:               "OpenContractCost",
+	MinProviderBond:                "MinProviderBond",
+	ReserveTax:                     "ReserveTax",
+	BlocksPerYear:                  "BlocksPerYear",
+	EmissionCurve:                  "EmissionCurve",
+	ValidatorPayoutCycle:           "ValidatorPayoutCycle",
+	VersionConsensus:               "VersionConsensus",
+	MaxTotalDeposit:                "MaxTotalDeposit",
+	SubscriptionRatePerDay:         "SubscriptionRatePerDay",
+	ReserveHistoryLength:           "ReserveHistoryLength",
+	HandlerSetContractMemo:         "HandlerSetContractMemo",
+	HandlerSettleContracts:         "HandlerSettleContracts",
+	MaxSettleBatchSize:             "MaxSettleBatchSize",
+	HaltContractOpening:            "HaltContractOpening",
+	PermissionedProviders:          "PermissionedProviders",
+	MinReserveFloor:                "MinReserveFloor",
+	HandlerRateChange:              "HandlerRateChange",
+	ChainEmissionWeights:           "ChainEmissionWeights",
+	MinPeriodsFunded:               "MinPeriodsFunded",
+	MinPayAsYouGoDeposit:           "MinPayAsYouGoDeposit",
+	CommunityTaxShare:              "CommunityTaxShare",
+	HandlerPauseContract:           "HandlerPauseContract",
+	AvgBlockTime:                   "AvgBlockTime",
+	HandlerCloseContractByProvider: "HandlerCloseContractByProvider",
+	ProviderCancelPenalty:          "ProviderCancelPenalty",
+	ProviderEarningsHistory:        "ProviderEarningsHistory",
+	HandlerTransferContract:        "HandlerTransferContract",
+	DisputeWindow:                  "DisputeWindow",
+	DisputeTimeout:                 "DisputeTimeout",
+	HandlerDisputeContract:         "HandlerDisputeContract",
+	MinSettlementInterval:          "MinSettlementInterval",
+	HandlerModProviderMetadata:     "HandlerModProviderMetadata",
+	ClaimRateLeeway:                "ClaimRateLeeway",
+	HandlerRenewContract:           "HandlerRenewContract",
+	ContractGracePeriod:            "ContractGracePeriod",
+	ContractRetention:              "ContractRetention",
+	RewardVestingPeriod:            "RewardVestingPeriod",
+	ProviderIncentive:              "ProviderIncentive",
+	ProviderIncentiveCycle:         "ProviderIncentiveCycle",
+	ProviderIncentiveEnd:           "ProviderIncentiveEnd",
+	MaxDepositMultiple:             "MaxDepositMultiple",
+	HandlerDepositContract:         "HandlerDepositContract",
+	MaxAffiliateFee:                "MaxAffiliateFee",
+	HandlerReportUptime:            "HandlerReportUptime",
+	UptimeCycle:                    "UptimeCycle",
+	MinUptimeObservations:          "MinUptimeObservations",
+	MinProviderUptime:              "MinProviderUptime",
+	UptimeSlash:                    "UptimeSlash",
+	MaxOpenContracts:               "MaxOpenContracts",
+	FeeReserveShare:                "FeeReserveShare",
+}
+
+// GetConfigName returns the config with the given name
+func GetConfigName(name string) (ConfigName, bool) {
+	// analyze-ignore(map-iteration)
+	for cn, s := range nameToString {
+		if s == name {
+			return cn, true
+		}
+	}
+	return 0, false
+}
+
+// GetConfigNames returns every known config, in declaration order
+func GetConfigNames() []ConfigName {
+	names := make([]ConfigName, 0, len(nameToString))
+	for cn := ConfigName(0); int(cn) < len(nameToString); cn++ {
+		names = append(names, cn)
+	}
+	return names
 }
 
 // String implement fmt.stringer
@@ -62,6 +168,11 @@ type ConfigValues interface {
 
 // GetConfigValues will return an  implementation of ConfigValues which provide ways to get constant values
 func GetConfigValues(ver int64) ConfigValues {
-	// TODO: create a switcher based on version
-	return NewConfigValue010()
+	switch {
+	// new config versions should be added here, newest first, ie
+	// case ver >= 2:
+	//	return NewConfigValue020()
+	default:
+		return NewConfigValue010()
+	}
 }
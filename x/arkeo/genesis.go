@@ -1,6 +1,7 @@
 package arkeo
 
 import (
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
 	"github.com/arkeonetwork/arkeo/x/arkeo/keeper"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
 
@@ -12,6 +13,9 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState)
 	// this line is used by starport scaffolding # genesis/module/init
 	k.SetParams(ctx, genState.Params)
 
+	// set before the providers, so providers without an id are assigned one
+	// after the ids already taken
+	k.SetNextProviderId(ctx, genState.NextProviderId)
 	for _, provider := range genState.Providers {
 		if err := k.SetProvider(ctx, provider); err != nil {
 			ctx.Logger().Error("unable to set provider", "provider", provider.PubKey, "service", provider.Service, "error", err)
@@ -37,6 +41,107 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState)
 			ctx.Logger().Error("unable to set user contract set", "user", userContractSet.User, "error", err)
 		}
 	}
+
+	for _, override := range genState.ConfigOverrides {
+		name, ok := configs.GetConfigName(override.Name)
+		if !ok {
+			ctx.Logger().Error("unable to set config override", "name", override.Name)
+			continue
+		}
+		k.SetConfigOverride(ctx, name, override.Value)
+	}
+
+	for _, allowed := range genState.AllowedProviders {
+		if err := k.AddAllowedProvider(ctx, allowed.PubKey); err != nil {
+			ctx.Logger().Error("unable to set allowed provider", "provider", allowed.PubKey, "error", err)
+		}
+	}
+
+	for _, stats := range genState.ProviderStats {
+		if err := k.SetProviderStats(ctx, stats); err != nil {
+			ctx.Logger().Error("unable to set provider stats", "provider", stats.PubKey, "service", stats.Service, "error", err)
+		}
+	}
+
+	for _, uptime := range genState.ProviderUptimes {
+		if err := k.SetProviderUptime(ctx, uptime); err != nil {
+			ctx.Logger().Error("unable to set provider uptime", "provider", uptime.PubKey, "service", uptime.Service, "error", err)
+		}
+	}
+
+	for _, stats := range genState.ClientStats {
+		if err := k.SetClientStats(ctx, stats); err != nil {
+			ctx.Logger().Error("unable to set client stats", "client", stats.PubKey, "error", err)
+		}
+	}
+
+	for _, allowed := range genState.AllowedDenoms {
+		if err := k.AddAllowedDenom(ctx, allowed.Denom); err != nil {
+			ctx.Logger().Error("unable to set allowed denom", "denom", allowed.Denom, "error", err)
+		}
+	}
+
+	for _, earning := range genState.ProviderEarnings {
+		if err := k.SetProviderEarning(ctx, earning); err != nil {
+			ctx.Logger().Error("unable to set provider earning", "provider", earning.PubKey, "contract_id", earning.ContractId, "error", err)
+		}
+	}
+
+	for _, slash := range genState.ProviderSlashes {
+		if err := k.SetProviderSlash(ctx, slash); err != nil {
+			ctx.Logger().Error("unable to set provider slash", "provider", slash.PubKey, "height", slash.Height, "error", err)
+		}
+	}
+
+	for _, escrow := range genState.ContractEscrows {
+		if err := k.SetContractEscrow(ctx, escrow); err != nil {
+			ctx.Logger().Error("unable to set contract escrow", "contract_id", escrow.ContractId, "error", err)
+		}
+	}
+
+	for _, arbiter := range genState.Arbiters {
+		if err := k.AddArbiter(ctx, arbiter.Address); err != nil {
+			ctx.Logger().Error("unable to set arbiter", "arbiter", arbiter.Address, "error", err)
+		}
+	}
+
+	for _, settlementSet := range genState.ContractSettlementSets {
+		if err := k.SetContractSettlementSet(ctx, settlementSet); err != nil {
+			ctx.Logger().Error("unable to set contract settlement set", "height", settlementSet.Height, "error", err)
+		}
+	}
+
+	for _, service := range genState.Services {
+		if err := k.SetServiceInfo(ctx, service); err != nil {
+			ctx.Logger().Error("unable to set service", "service", service.Service, "error", err)
+		}
+	}
+
+	for _, snapshot := range genState.ReserveSnapshots {
+		if err := k.SetReserveSnapshot(ctx, snapshot); err != nil {
+			ctx.Logger().Error("unable to set reserve snapshot", "height", snapshot.Height, "error", err)
+		}
+	}
+
+	for _, units := range genState.BondUnits {
+		if err := k.SetBondUnits(ctx, units); err != nil {
+			ctx.Logger().Error("unable to set bond units", "validator", units.Validator, "error", err)
+		}
+	}
+	if genState.TotalBondUnits != nil {
+		k.SetTotalBondUnits(ctx, *genState.TotalBondUnits)
+	}
+	for _, val := range genState.BondUnitsPending {
+		k.SetBondUnitsPending(ctx, val)
+	}
+
+	k.SetEmissionSchedule(ctx, genState.EmissionSchedule)
+
+	for _, vesting := range genState.RewardVestings {
+		if err := k.SetRewardVesting(ctx, vesting); err != nil {
+			ctx.Logger().Error("unable to set reward vesting", "address", vesting.Address, "error", err)
+		}
+	}
 }
 
 // ExportGenesis returns the module's exported genesis
@@ -68,18 +173,15 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 	}
 	iter.Close()
 	genesis.NextContractId = k.GetNextContractId(ctx)
+	genesis.NextProviderId = k.GetNextProviderId(ctx)
 	genesis.Version = k.GetVersion(ctx)
 
 	// contract expiration sets
-	iter = k.GetContractExpirationSetIterator(ctx)
-	for ; iter.Valid(); iter.Next() {
-		var expirationSet types.ContractExpirationSet
-		if err := k.Cdc().Unmarshal(iter.Value(), &expirationSet); err != nil {
-			ctx.Logger().Error("unable to get contract expiration set", "contract", iter.Key(), "error", err)
-			continue
-		}
-		genesis.ContractExpirationSets = append(genesis.ContractExpirationSets, expirationSet)
+	expirationSets, err := k.GetContractExpirationSets(ctx)
+	if err != nil {
+		ctx.Logger().Error("unable to get contract expiration sets", "error", err)
 	}
+	genesis.ContractExpirationSets = append(genesis.ContractExpirationSets, expirationSets...)
 
 	// user contract sets
 	iter = k.GetUserContractSetIterator(ctx)
@@ -91,6 +193,206 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 		}
 		genesis.UserContractSets = append(genesis.UserContractSets, userContractSet)
 	}
+	iter.Close()
+
+	// config overrides
+	iter = k.GetConfigOverrideIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var override types.ConfigOverride
+		if err := k.Cdc().Unmarshal(iter.Value(), &override); err != nil {
+			ctx.Logger().Error("unable to get config override", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.ConfigOverrides = append(genesis.ConfigOverrides, override)
+	}
+	iter.Close()
+
+	// allowed providers
+	iter = k.GetAllowedProviderIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var allowed types.AllowedProvider
+		if err := k.Cdc().Unmarshal(iter.Value(), &allowed); err != nil {
+			ctx.Logger().Error("unable to get allowed provider", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.AllowedProviders = append(genesis.AllowedProviders, allowed)
+	}
+	iter.Close()
+
+	// provider stats
+	iter = k.GetProviderStatsIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var stats types.ProviderStats
+		if err := k.Cdc().Unmarshal(iter.Value(), &stats); err != nil {
+			ctx.Logger().Error("unable to get provider stats", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.ProviderStats = append(genesis.ProviderStats, stats)
+	}
+	iter.Close()
+
+	// provider uptimes
+	iter = k.GetProviderUptimeIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var uptime types.ProviderUptime
+		if err := k.Cdc().Unmarshal(iter.Value(), &uptime); err != nil {
+			ctx.Logger().Error("unable to get provider uptime", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.ProviderUptimes = append(genesis.ProviderUptimes, uptime)
+	}
+	iter.Close()
+
+	// client stats
+	iter = k.GetClientStatsIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var stats types.ClientStats
+		if err := k.Cdc().Unmarshal(iter.Value(), &stats); err != nil {
+			ctx.Logger().Error("unable to get client stats", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.ClientStats = append(genesis.ClientStats, stats)
+	}
+	iter.Close()
+
+	// allowed denoms
+	iter = k.GetAllowedDenomIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var allowed types.AllowedDenom
+		if err := k.Cdc().Unmarshal(iter.Value(), &allowed); err != nil {
+			ctx.Logger().Error("unable to get allowed denom", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.AllowedDenoms = append(genesis.AllowedDenoms, allowed)
+	}
+	iter.Close()
+
+	// provider earnings
+	iter = k.GetProviderEarningIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var earning types.ProviderEarning
+		if err := k.Cdc().Unmarshal(iter.Value(), &earning); err != nil {
+			ctx.Logger().Error("unable to get provider earning", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.ProviderEarnings = append(genesis.ProviderEarnings, earning)
+	}
+	iter.Close()
+
+	// provider slashes
+	iter = k.GetProviderSlashIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var slash types.ProviderSlash
+		if err := k.Cdc().Unmarshal(iter.Value(), &slash); err != nil {
+			ctx.Logger().Error("unable to get provider slash", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.ProviderSlashes = append(genesis.ProviderSlashes, slash)
+	}
+	iter.Close()
+
+	// contract escrows
+	iter = k.GetContractEscrowIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var escrow types.ContractEscrow
+		if err := k.Cdc().Unmarshal(iter.Value(), &escrow); err != nil {
+			ctx.Logger().Error("unable to get contract escrow", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.ContractEscrows = append(genesis.ContractEscrows, escrow)
+	}
+	iter.Close()
+
+	// arbiters
+	iter = k.GetArbiterIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var arbiter types.Arbiter
+		if err := k.Cdc().Unmarshal(iter.Value(), &arbiter); err != nil {
+			ctx.Logger().Error("unable to get arbiter", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.Arbiters = append(genesis.Arbiters, arbiter)
+	}
+	iter.Close()
+
+	// contract settlement sets
+	iter = k.GetContractSettlementSetIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var settlementSet types.ContractExpirationSet
+		if err := k.Cdc().Unmarshal(iter.Value(), &settlementSet); err != nil {
+			ctx.Logger().Error("unable to get contract settlement set", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.ContractSettlementSets = append(genesis.ContractSettlementSets, settlementSet)
+	}
+	iter.Close()
+
+	// service registry
+	iter = k.GetServiceInfoIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var service types.ServiceInfo
+		if err := k.Cdc().Unmarshal(iter.Value(), &service); err != nil {
+			ctx.Logger().Error("unable to get service", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.Services = append(genesis.Services, service)
+	}
+	iter.Close()
+
+	// reserve history
+	iter = k.GetReserveSnapshotIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var snapshot types.ReserveSnapshot
+		if err := k.Cdc().Unmarshal(iter.Value(), &snapshot); err != nil {
+			ctx.Logger().Error("unable to get reserve snapshot", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.ReserveSnapshots = append(genesis.ReserveSnapshots, snapshot)
+	}
+	iter.Close()
+
+	// bond units
+	iter = k.GetBondUnitsIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var units types.BondUnits
+		if err := k.Cdc().Unmarshal(iter.Value(), &units); err != nil {
+			ctx.Logger().Error("unable to get bond units", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.BondUnits = append(genesis.BondUnits, units)
+	}
+	iter.Close()
+	total, ok, err := k.GetTotalBondUnits(ctx)
+	if err != nil {
+		ctx.Logger().Error("unable to get total bond units", "error", err)
+	} else if ok {
+		genesis.TotalBondUnits = &total
+	}
+	iter = k.GetBondUnitsPendingIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		genesis.BondUnitsPending = append(genesis.BondUnitsPending, sdk.ValAddress(iter.Value()))
+	}
+	iter.Close()
+
+	// emission schedule
+	schedule, err := k.GetEmissionSchedule(ctx)
+	if err != nil {
+		ctx.Logger().Error("unable to get emission schedule", "error", err)
+	} else {
+		genesis.EmissionSchedule = schedule
+	}
+
+	// reward vesting
+	iter = k.GetRewardVestingIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var vesting types.RewardVesting
+		if err := k.Cdc().Unmarshal(iter.Value(), &vesting); err != nil {
+			ctx.Logger().Error("unable to get reward vesting", "key", iter.Key(), "error", err)
+			continue
+		}
+		genesis.RewardVestings = append(genesis.RewardVestings, vesting)
+	}
+	iter.Close()
 
 	return genesis
 }
@@ -48,6 +48,7 @@ func TestGenesisWithContracts(t *testing.T) {
 	provider.PayAsYouGoRate, _ = cosmos.ParseCoins("100uarkeo")
 	err := k.SetProvider(ctx, provider)
 	require.NoError(t, err)
+	provider.Id = 1 // assigned on save
 
 	rate, _ := cosmos.ParseCoin("100uarkeo")
 
@@ -132,14 +133,45 @@ func TestGenesisWithContracts(t *testing.T) {
 	err = k.SetContractExpirationSet(ctx, contractExpirationSet2)
 	require.NoError(t, err)
 
+	// record some settlement history for the provider
+	stats := types.NewProviderStats(providerPubkey, common.BTCService)
+	stats.ContractsServed = 4
+	stats.Breaches = 1
+	stats.SettledAmount = cosmos.NewInt(1200)
+	require.NoError(t, k.SetProviderStats(ctx, stats))
+	clientStats := types.NewClientStats(user1PubKey)
+	clientStats.ContractsOpened = 2
+	clientStats.TotalSpent = cosmos.NewCoins(rate)
+	clientStats.QueriesClaimed = 40
+	require.NoError(t, k.SetClientStats(ctx, clientStats))
+	k.SetNextContractId(ctx, 3)
+
+	// reserve history and validator bond units
+	snapshot := types.ReserveSnapshot{Height: 90, Reserve: cosmos.NewCoins(rate), BlockReward: cosmos.NewCoins(rate)}
+	require.NoError(t, k.SetReserveSnapshot(ctx, snapshot))
+	user1Address, err := user1PubKey.GetMyAddress()
+	require.NoError(t, err)
+	val := cosmos.ValAddress(user1Address)
+	units := types.NewBondUnits(val)
+	units.Bond = cosmos.NewInt(1000)
+	units.Units = cosmos.NewInt(1000)
+	require.NoError(t, k.SetBondUnits(ctx, units))
+	total := types.NewBondUnits(nil)
+	total.Bond = units.Bond
+	total.Units = units.Units
+	k.SetTotalBondUnits(ctx, total)
+	k.SetBondUnitsPending(ctx, val)
+
 	exportedGenesis := arkeo.ExportGenesis(ctx, k)
 	require.NotNil(t, exportedGenesis)
+	require.NoError(t, exportedGenesis.Validate())
 
 	// check if the exported genesis is the same as the state we just set.
 	require.ElementsMatch(t, exportedGenesis.Providers, []types.Provider{provider})
 	require.ElementsMatch(t, exportedGenesis.Contracts, contracts)
 	require.ElementsMatch(t, exportedGenesis.UserContractSets, []types.UserContractSet{user1ContractSet, user2ContractSet})
 	require.ElementsMatch(t, exportedGenesis.ContractExpirationSets, []types.ContractExpirationSet{contractExpirationSet1, contractExpirationSet2})
+	require.ElementsMatch(t, exportedGenesis.ProviderStats, []types.ProviderStats{stats})
 
 	ctx, freshKeeper := keepertest.ArkeoKeeper(t)
 	contract, err := freshKeeper.GetContract(ctx, 0)
@@ -156,4 +188,15 @@ func TestGenesisWithContracts(t *testing.T) {
 	require.ElementsMatch(t, exportedGenesis2.Contracts, contracts)
 	require.ElementsMatch(t, exportedGenesis2.UserContractSets, []types.UserContractSet{user1ContractSet, user2ContractSet})
 	require.ElementsMatch(t, exportedGenesis2.ContractExpirationSets, []types.ContractExpirationSet{contractExpirationSet1, contractExpirationSet2})
+	require.ElementsMatch(t, exportedGenesis2.ProviderStats, []types.ProviderStats{stats})
+	require.ElementsMatch(t, exportedGenesis2.ClientStats, []types.ClientStats{clientStats})
+	require.Equal(t, uint64(3), exportedGenesis2.NextContractId)
+	require.Equal(t, uint64(2), exportedGenesis2.NextProviderId)
+	byId, err := freshKeeper.GetProviderById(ctx, provider.Id)
+	require.NoError(t, err)
+	require.True(t, byId.PubKey.Equals(provider.PubKey))
+	require.Equal(t, []types.ReserveSnapshot{snapshot}, exportedGenesis2.ReserveSnapshots)
+	require.Equal(t, []types.BondUnits{units}, exportedGenesis2.BondUnits)
+	require.Equal(t, &total, exportedGenesis2.TotalBondUnits)
+	require.Equal(t, []cosmos.ValAddress{val}, exportedGenesis2.BondUnitsPending)
 }
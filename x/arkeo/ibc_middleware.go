@@ -0,0 +1,164 @@
+package arkeo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
+	transfertypes "github.com/cosmos/ibc-go/v5/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v5/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v5/modules/core/05-port/types"
+	ibcexported "github.com/cosmos/ibc-go/v5/modules/core/exported"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/keeper"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// IBCMiddleware wraps the ICS-20 transfer app, opening a contract with the
+// transferred tokens when the transfer memo asks for it. A user on another
+// chain can so fund and open a contract in a single transfer, without a
+// funded local wallet.
+type IBCMiddleware struct {
+	porttypes.IBCModule
+	keeper    keeper.Keeper
+	mgr       keeper.Manager
+	msgServer types.MsgServer
+}
+
+var _ porttypes.IBCModule = IBCMiddleware{}
+
+func NewIBCMiddleware(app porttypes.IBCModule, k keeper.Keeper, sk stakingkeeper.Keeper) IBCMiddleware {
+	return IBCMiddleware{
+		IBCModule: app,
+		keeper:    k,
+		mgr:       keeper.NewManager(k, sk),
+		msgServer: keeper.NewMsgServerImpl(k, sk),
+	}
+}
+
+// transferPacketData is the ICS-20 packet data along with the memo, which the
+// transfer app does not know about
+type transferPacketData struct {
+	Denom    string `json:"denom"`
+	Amount   string `json:"amount"`
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+	Memo     string `json:"memo,omitempty"`
+}
+
+// OnRecvPacket passes the transfer on to the transfer app, then opens the
+// contract in the memo. If the contract cannot be opened an error
+// acknowledgement is returned, which reverts the transfer so the tokens are
+// refunded on the sending chain.
+func (im IBCMiddleware) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) ibcexported.Acknowledgement {
+	var data transferPacketData
+	if err := json.Unmarshal(packet.GetData(), &data); err != nil || data.Memo == "" {
+		return im.IBCModule.OnRecvPacket(ctx, packet, relayer)
+	}
+
+	memo, ok, err := types.ParseTransferMemo(data.Memo)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+
+	// the transfer app rejects fields it does not know, so it is handed the
+	// packet without the memo
+	packet.Data = transfertypes.NewFungibleTokenPacketData(data.Denom, data.Amount, data.Sender, data.Receiver).GetBytes()
+	ack := im.IBCModule.OnRecvPacket(ctx, packet, relayer)
+	if !ok || !ack.Success() {
+		return ack
+	}
+
+	if err := im.openContract(ctx, packet, data, *memo.Arkeo.OpenContract); err != nil {
+		ctx.Logger().Error("unable to open contract from transfer", "sender", data.Sender, "error", err)
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+	return ack
+}
+
+func (im IBCMiddleware) openContract(ctx sdk.Context, packet channeltypes.Packet, data transferPacketData, memo types.OpenContractMemo) error {
+	amount, ok := cosmos.NewIntFromString(data.Amount)
+	if !ok {
+		return fmt.Errorf("invalid transfer amount (%s)", data.Amount)
+	}
+	denom := receivedDenom(packet, data.Denom)
+	if memo.Denom != denom {
+		return fmt.Errorf("memo rate denom %s is not the denom %s the transfer is received as", memo.Denom, denom)
+	}
+
+	// native tokens coming home pay the open cost, otherwise it is paid from
+	// the client's account as for any other contract
+	deposit := amount
+	if denom == configs.Denom {
+		deposit = amount.SubRaw(im.mgr.FetchConfig(ctx, configs.OpenContractCost))
+		if !deposit.IsPositive() {
+			return fmt.Errorf("transfer of %s does not cover the open contract cost", amount)
+		}
+	}
+
+	msg, err := memo.Msg(denom, deposit)
+	if err != nil {
+		return err
+	}
+	receiver, err := cosmos.AccAddressFromBech32(data.Receiver)
+	if err != nil {
+		return err
+	}
+	// the tokens must have landed in the account that pays for the contract
+	if !receiver.Equals(msg.MustGetSigner()) {
+		return fmt.Errorf("transfer receiver %s is not the client account %s", receiver, msg.MustGetSigner())
+	}
+	if msg.RefundAddress.Empty() {
+		msg.RefundAddress = receiver
+	}
+	if err := im.verifyClientSignature(ctx, msg.Client, memo); err != nil {
+		return err
+	}
+
+	_, err = im.msgServer.OpenContract(sdk.WrapSDKContext(ctx), msg)
+	return err
+}
+
+// verifyClientSignature checks the client signed the memo for this chain,
+// anyone may send a transfer to the client's account, and uses up the nonce
+// so the memo cannot be replayed
+func (im IBCMiddleware) verifyClientSignature(ctx sdk.Context, client common.PubKey, memo types.OpenContractMemo) error {
+	pk, err := cosmos.GetPubKeyFromBech32(cosmos.Bech32PubKeyTypeAccPub, client.String())
+	if err != nil {
+		return err
+	}
+	if !pk.VerifySignature(memo.GetBytesToSign(ctx.ChainID()), memo.Signature) {
+		return errors.Wrap(types.ErrOpenContractMemoSignature, "memo is not signed by the client")
+	}
+
+	stats, err := im.keeper.GetClientStats(ctx, client)
+	if err != nil {
+		return err
+	}
+	if memo.Nonce <= stats.TransferNonce {
+		return errors.Wrapf(types.ErrOpenContractMemoSignature, "nonce %d is not greater than the last used nonce %d", memo.Nonce, stats.TransferNonce)
+	}
+	stats.TransferNonce = memo.Nonce
+	return im.keeper.SetClientStats(ctx, stats)
+}
+
+// receivedDenom returns the denom the transferred tokens are received as, the
+// same way the transfer app derives it
+func receivedDenom(packet channeltypes.Packet, denom string) string {
+	if transfertypes.ReceiverChainIsSource(packet.GetSourcePort(), packet.GetSourceChannel(), denom) {
+		// the tokens are coming back, drop the hop they took to leave
+		unprefixed := denom[len(transfertypes.GetDenomPrefix(packet.GetSourcePort(), packet.GetSourceChannel())):]
+		trace := transfertypes.ParseDenomTrace(unprefixed)
+		if trace.Path == "" {
+			return unprefixed
+		}
+		return trace.IBCDenom()
+	}
+	prefixed := transfertypes.GetDenomPrefix(packet.GetDestPort(), packet.GetDestChannel()) + denom
+	return transfertypes.ParseDenomTrace(prefixed).IBCDenom()
+}
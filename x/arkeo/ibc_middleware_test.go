@@ -0,0 +1,191 @@
+package arkeo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v5/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v5/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v5/modules/core/05-port/types"
+	ibcexported "github.com/cosmos/ibc-go/v5/modules/core/exported"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	keepertest "github.com/arkeonetwork/arkeo/testutil/keeper"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/keeper"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+func TestReceivedDenom(t *testing.T) {
+	packet := channeltypes.Packet{
+		SourcePort:         "transfer",
+		SourceChannel:      "channel-7",
+		DestinationPort:    "transfer",
+		DestinationChannel: "channel-0",
+	}
+
+	// a token of the sending chain is received as a voucher
+	require.Equal(t, transfertypes.ParseDenomTrace("transfer/channel-0/uatom").IBCDenom(), receivedDenom(packet, "uatom"))
+
+	// native tokens coming home are unwrapped
+	require.Equal(t, "uarkeo", receivedDenom(packet, "transfer/channel-7/uarkeo"))
+
+	// as are vouchers that took more than one hop to get here
+	require.Equal(t, transfertypes.ParseDenomTrace("transfer/channel-3/uosmo").IBCDenom(), receivedDenom(packet, "transfer/channel-7/transfer/channel-3/uosmo"))
+}
+
+// mockTransferApp credits the receiver of a transfer, as the transfer app does
+type mockTransferApp struct {
+	porttypes.IBCModule
+	k keeper.Keeper
+}
+
+func (app mockTransferApp) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) ibcexported.Acknowledgement {
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+	receiver, err := cosmos.AccAddressFromBech32(data.Receiver)
+	if err != nil {
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+	amount, _ := cosmos.NewIntFromString(data.Amount)
+	if err := app.k.MintAndSendToAccount(ctx, receiver, cosmos.NewCoin(receivedDenom(packet, data.Denom), amount)); err != nil {
+		return channeltypes.NewErrorAcknowledgement(err)
+	}
+	return channeltypes.NewResultAcknowledgement([]byte{byte(1)})
+}
+
+func TestOnRecvPacketOpenContract(t *testing.T) {
+	ctx, k, sk := keepertest.ArkeoKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10).WithChainID("arkeo")
+	im := NewIBCMiddleware(mockTransferApp{k: k}, k, sk)
+
+	service := common.BTCService
+	providerPubKey := types.GetRandomPubKey()
+	provider := types.NewProvider(providerPubKey, service)
+	provider.Bond = cosmos.NewInt(500_00000000)
+	provider.Status = types.ProviderStatus_ONLINE
+	provider.MinContractDuration = 10
+	provider.MaxContractDuration = 1000
+	provider.PayAsYouGoRate = cosmos.NewCoins(cosmos.NewInt64Coin(configs.Denom, 5))
+	provider.LastUpdate = 1
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	priv := secp256k1.GenPrivKey()
+	clientPubKey, err := common.NewPubKeyFromCrypto(priv.PubKey())
+	require.NoError(t, err)
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	signedMemo := func(nonce int64) types.OpenContractMemo {
+		memo := types.OpenContractMemo{
+			Provider:         providerPubKey.String(),
+			Service:          service.String(),
+			Client:           clientPubKey.String(),
+			ContractType:     types.ContractType_PAY_AS_YOU_GO,
+			Duration:         100,
+			Rate:             "5",
+			Denom:            configs.Denom,
+			QueriesPerMinute: 10,
+			Nonce:            nonce,
+		}
+		memo.Signature, err = priv.Sign(memo.GetBytesToSign(ctx.ChainID()))
+		require.NoError(t, err)
+		return memo
+	}
+	// delivers the transfer the way core IBC does, keeping the state changes
+	// only on a successful acknowledgement
+	recv := func(receiver cosmos.AccAddress, memo types.OpenContractMemo) ibcexported.Acknowledgement {
+		transferMemo, err := json.Marshal(types.TransferMemo{Arkeo: &types.ArkeoTransferMemo{OpenContract: &memo}})
+		require.NoError(t, err)
+		data, err := json.Marshal(transferPacketData{
+			Denom:    "transfer/channel-7/uarkeo",
+			Amount:   cosmos.NewInt(common.Tokens(1) + 500).String(),
+			Sender:   "cosmos1sender",
+			Receiver: receiver.String(),
+			Memo:     string(transferMemo),
+		})
+		require.NoError(t, err)
+		packet := channeltypes.Packet{
+			Data:               data,
+			SourcePort:         "transfer",
+			SourceChannel:      "channel-7",
+			DestinationPort:    "transfer",
+			DestinationChannel: "channel-0",
+		}
+		cacheCtx, write := ctx.CacheContext()
+		ack := im.OnRecvPacket(cacheCtx, packet, types.GetRandomBech32Addr())
+		if ack.Success() {
+			write()
+		}
+		return ack
+	}
+	requireNoContract := func() {
+		contract, err := k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, service)
+		require.NoError(t, err)
+		require.True(t, contract.IsEmpty())
+		require.True(t, k.GetBalance(ctx, clientAcct).IsZero())
+	}
+
+	// a memo not signed by the client, whoever pays for the transfer
+	memo := signedMemo(1)
+	memo.Signature, err = secp256k1.GenPrivKey().Sign(memo.GetBytesToSign(ctx.ChainID()))
+	require.NoError(t, err)
+	require.False(t, recv(clientAcct, memo).Success())
+	requireNoContract()
+
+	// signed terms cannot be changed, nor can a delegate or refund address
+	// be slipped in
+	memo = signedMemo(1)
+	memo.Duration = 1000
+	require.False(t, recv(clientAcct, memo).Success())
+	requireNoContract()
+	memo = signedMemo(1)
+	memo.Delegate = types.GetRandomPubKey().String()
+	require.False(t, recv(clientAcct, memo).Success())
+	requireNoContract()
+	memo = signedMemo(1)
+	memo.RefundAddress = types.GetRandomBech32Addr().String()
+	require.False(t, recv(clientAcct, memo).Success())
+	requireNoContract()
+
+	// a memo signed for another chain
+	memo = signedMemo(1)
+	memo.Signature, err = priv.Sign(memo.GetBytesToSign("arkeo-testnet"))
+	require.NoError(t, err)
+	require.False(t, recv(clientAcct, memo).Success())
+	requireNoContract()
+
+	// the tokens must land in the client's account
+	require.False(t, recv(types.GetRandomBech32Addr(), signedMemo(1)).Success())
+	requireNoContract()
+
+	// a signed memo the contract cannot be opened with is refunded, and its
+	// nonce is not used up
+	provider.Status = types.ProviderStatus_OFFLINE
+	require.NoError(t, k.SetProvider(ctx, provider))
+	require.False(t, recv(clientAcct, signedMemo(1)).Success())
+	requireNoContract()
+	stats, err := k.GetClientStats(ctx, clientPubKey)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), stats.TransferNonce)
+
+	provider.Status = types.ProviderStatus_ONLINE
+	require.NoError(t, k.SetProvider(ctx, provider))
+	require.True(t, recv(clientAcct, signedMemo(1)).Success())
+	contract, err := k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, service)
+	require.NoError(t, err)
+	require.Equal(t, int64(500), contract.Deposit.Int64())
+	stats, err = k.GetClientStats(ctx, clientPubKey)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats.TransferNonce)
+
+	// the memo cannot be replayed
+	k.RemoveContract(ctx, contract.Id)
+	require.False(t, recv(clientAcct, signedMemo(1)).Success())
+}
@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"errors"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// GetBondUnitsIterator iterate the bond units of each validator
+func (k KVStore) GetBondUnitsIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixBondUnits)
+}
+
+// GetBondUnits get the bond units of the given validator
+func (k KVStore) GetBondUnits(ctx cosmos.Context, val cosmos.ValAddress) (types.BondUnits, error) {
+	record := types.NewBondUnits(val)
+	key := k.GetKey(ctx, prefixBondUnits, val.String())
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has([]byte(key)) {
+		return record, nil
+	}
+	err := k.cdc.Unmarshal(store.Get([]byte(key)), &record)
+	return record, err
+}
+
+// SetBondUnits save the bond units of a validator, validators without units
+// are removed
+func (k KVStore) SetBondUnits(ctx cosmos.Context, record types.BondUnits) error {
+	if record.Validator.Empty() {
+		return errors.New("cannot save bond units with an empty validator")
+	}
+	key := k.GetKey(ctx, prefixBondUnits, record.Validator.String())
+	if record.Units.IsZero() && record.Bond.IsZero() {
+		k.del(ctx, key)
+		return nil
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(key), k.cdc.MustMarshal(&record))
+	return nil
+}
+
+// GetTotalBondUnits get the bond units summed across all validators, returns
+// false if they have never been assigned
+func (k KVStore) GetTotalBondUnits(ctx cosmos.Context) (types.BondUnits, bool, error) {
+	record := types.NewBondUnits(nil)
+	key := k.GetKey(ctx, prefixBondUnitsTotal, "")
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has([]byte(key)) {
+		return record, false, nil
+	}
+	err := k.cdc.Unmarshal(store.Get([]byte(key)), &record)
+	return record, true, err
+}
+
+// SetTotalBondUnits save the bond units summed across all validators
+func (k KVStore) SetTotalBondUnits(ctx cosmos.Context, record types.BondUnits) {
+	key := k.GetKey(ctx, prefixBondUnitsTotal, "")
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(key), k.cdc.MustMarshal(&record))
+}
+
+// GetBondUnitsPendingIterator iterate the validators whose stake changed since
+// their bond units were last assigned, the values are the validator addresses
+func (k KVStore) GetBondUnitsPendingIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixBondUnitsPending)
+}
+
+// SetBondUnitsPending mark the bond units of a validator as out of date
+func (k KVStore) SetBondUnitsPending(ctx cosmos.Context, val cosmos.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(k.GetKey(ctx, prefixBondUnitsPending, val.String())), val)
+}
+
+// RemoveBondUnitsPending clear the out of date mark of a validator
+func (k KVStore) RemoveBondUnitsPending(ctx cosmos.Context, val cosmos.ValAddress) {
+	k.del(ctx, k.GetKey(ctx, prefixBondUnitsPending, val.String()))
+}
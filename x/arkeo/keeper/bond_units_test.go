@@ -0,0 +1,117 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+func TestAssignBondUnits(t *testing.T) {
+	valAddrs := simapp.ConvertAddrsToValAddrs([]cosmos.AccAddress{
+		types.GetRandomBech32Addr(),
+		types.GetRandomBech32Addr(),
+	})
+	total := types.NewBondUnits(nil)
+	a := types.NewBondUnits(valAddrs[0])
+	b := types.NewBondUnits(valAddrs[1])
+
+	// the first bond is assigned a unit per token
+	total, a = assignBondUnits(total, a, cosmos.NewInt(100))
+	require.Equal(t, int64(100), a.Units.Int64())
+	total, b = assignBondUnits(total, b, cosmos.NewInt(300))
+	require.Equal(t, int64(300), b.Units.Int64())
+	require.Equal(t, int64(400), total.Units.Int64())
+	require.Equal(t, int64(400), total.Bond.Int64())
+
+	// units = U / (T / t)
+	total.Units = cosmos.NewInt(800)
+	b.Units = cosmos.NewInt(600)
+	a.Units = cosmos.NewInt(200)
+	total, a = assignBondUnits(total, a, cosmos.NewInt(200))
+	require.Equal(t, int64(400), a.Units.Int64())
+	require.Equal(t, int64(1000), total.Units.Int64())
+	require.Equal(t, int64(500), total.Bond.Int64())
+
+	// removed bond takes its share of the validator's units
+	total, b = assignBondUnits(total, b, cosmos.NewInt(150))
+	require.Equal(t, int64(300), b.Units.Int64())
+	require.Equal(t, int64(700), total.Units.Int64())
+	require.Equal(t, int64(350), total.Bond.Int64())
+
+	// unchanged bond leaves the units alone
+	total, b = assignBondUnits(total, b, cosmos.NewInt(150))
+	require.Equal(t, int64(300), b.Units.Int64())
+
+	// unbonding removes all of them
+	total, a = assignBondUnits(total, a, cosmos.ZeroInt())
+	require.True(t, a.Units.IsZero())
+	require.True(t, a.Bond.IsZero())
+	require.Equal(t, b.Units, total.Units)
+	require.Equal(t, b.Bond, total.Bond)
+}
+
+func TestSyncBondUnits(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	mgr := NewManager(k, sk)
+	hooks := k.(KVStore).Hooks()
+
+	pks := simapp.CreateTestPubKeys(2)
+	valAddrs := simapp.ConvertAddrsToValAddrs([]cosmos.AccAddress{
+		cosmos.AccAddress(pks[0].Address()),
+		cosmos.AccAddress(pks[1].Address()),
+	})
+	vals := make([]stakingtypes.Validator, len(pks))
+	for i, pk := range pks {
+		val, err := stakingtypes.NewValidator(valAddrs[i], pk, stakingtypes.Description{})
+		require.NoError(t, err)
+		val.Tokens = cosmos.NewInt(int64(100 * (i + 1)))
+		val.DelegatorShares = cosmos.NewDec(int64(100 * (i + 1)))
+		val.Status = stakingtypes.Bonded
+		sk.SetValidator(ctx, val)
+		vals[i] = val
+	}
+
+	// every validator is assigned units the first time around
+	total, err := mgr.syncBondUnits(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), total.Int64())
+	record, err := k.GetBondUnits(ctx, valAddrs[1])
+	require.NoError(t, err)
+	require.Equal(t, int64(200), record.Units.Int64())
+
+	// changes to the stake are only picked up once the hooks mark the
+	// validator pending
+	vals[0].DelegatorShares = cosmos.NewDec(400)
+	sk.SetValidator(ctx, vals[0])
+	total, err = mgr.syncBondUnits(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), total.Int64())
+
+	require.NoError(t, hooks.AfterDelegationModified(ctx, types.GetRandomBech32Addr(), valAddrs[0]))
+	total, err = mgr.syncBondUnits(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(600), total.Int64())
+	record, err = k.GetBondUnits(ctx, valAddrs[0])
+	require.NoError(t, err)
+	require.Equal(t, int64(400), record.Units.Int64())
+
+	iter := k.GetBondUnitsPendingIterator(ctx)
+	require.False(t, iter.Valid())
+	iter.Close()
+
+	// jailed validators lose their units
+	vals[1].Jailed = true
+	sk.SetValidator(ctx, vals[1])
+	require.NoError(t, hooks.BeforeValidatorSlashed(ctx, valAddrs[1], cosmos.NewDecWithPrec(1, 2)))
+	total, err = mgr.syncBondUnits(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(400), total.Int64())
+	record, err = k.GetBondUnits(ctx, valAddrs[1])
+	require.NoError(t, err)
+	require.True(t, record.Units.IsZero())
+}
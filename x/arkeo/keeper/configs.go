@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"sync"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// configCache holds the config values of the last version they were resolved
+// for, so they are not rebuilt on every lookup. Overrides are not cached, the
+// override store is read on every fetch.
+type configCache struct {
+	mu      sync.Mutex
+	version int64
+	values  configs.ConfigValues
+}
+
+func newConfigCache() *configCache {
+	return &configCache{}
+}
+
+// get returns the config values of the version, rebuilding them only when the
+// version has changed since the last call
+func (c *configCache) get(ver int64) configs.ConfigValues {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.values == nil || c.version != ver {
+		c.values = configs.GetConfigValues(ver)
+		c.version = ver
+	}
+	return c.values
+}
+
+// GetConfigValues returns the config values of the current version
+func (k KVStore) GetConfigValues(ctx cosmos.Context) configs.ConfigValues {
+	ver := k.GetVersion(ctx)
+	if k.configCache == nil {
+		return configs.GetConfigValues(ver)
+	}
+	return k.configCache.get(ver)
+}
+
+// GetConfigOverrideIterator iterate config overrides
+func (k KVStore) GetConfigOverrideIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixConfigOverride)
+}
+
+// GetConfigOverride get the on chain override of a config, if there is one
+func (k KVStore) GetConfigOverride(ctx cosmos.Context, name configs.ConfigName) (int64, bool) {
+	key := k.GetKey(ctx, prefixConfigOverride, name.String())
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has([]byte(key)) {
+		return 0, false
+	}
+	var override types.ConfigOverride
+	k.cdc.MustUnmarshal(store.Get([]byte(key)), &override)
+	return override.Value, true
+}
+
+// SetConfigOverride save an on chain override of a config, which takes
+// precedence over the value of the config version
+func (k KVStore) SetConfigOverride(ctx cosmos.Context, name configs.ConfigName, value int64) {
+	key := k.GetKey(ctx, prefixConfigOverride, name.String())
+	store := ctx.KVStore(k.storeKey)
+	override := types.ConfigOverride{Name: name.String(), Value: value}
+	store.Set([]byte(key), k.cdc.MustMarshal(&override))
+}
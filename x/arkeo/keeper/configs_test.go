@@ -0,0 +1,46 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigCache(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	mgr := NewManager(k, sk)
+
+	// the config values are only resolved once per version
+	values := k.GetConfigValues(ctx)
+	require.Same(t, values, k.GetConfigValues(ctx))
+	require.Same(t, values, mgr.Configs(ctx))
+	require.Same(t, values, NewManager(k, sk).Configs(ctx))
+
+	// and rebuilt when the version changes
+	k.SetVersion(ctx, k.GetVersion(ctx)+1)
+	require.NotSame(t, values, k.GetConfigValues(ctx))
+	require.Equal(t, values.String(), k.GetConfigValues(ctx).String())
+
+	// the override store, not the cache, is authoritative for overrides
+	reserveTax := mgr.FetchConfig(ctx, configs.ReserveTax)
+	k.SetConfigOverride(ctx, configs.ReserveTax, reserveTax+1)
+	require.Equal(t, reserveTax+1, mgr.FetchConfig(ctx, configs.ReserveTax))
+	require.Equal(t, reserveTax, k.GetConfigValues(ctx).GetInt64Value(configs.ReserveTax))
+}
+
+func BenchmarkFetchConfig(b *testing.B) {
+	ctx, k, sk := SetupKeeperWithStaking(b)
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			configs.GetConfigValues(k.GetVersion(ctx)).GetInt64Value(configs.ReserveTax)
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewManager(k, sk).Configs(ctx).GetInt64Value(configs.ReserveTax)
+		}
+	})
+}
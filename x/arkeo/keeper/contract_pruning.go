@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	gogotypes "github.com/gogo/protobuf/types"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// maxContractPrunesPerBlock caps the closed contracts pruned in a block, so a
+// backlog is worked off over several blocks
+const maxContractPrunesPerBlock = 100
+
+// ContractPruneEndBlock deletes the contracts closed more than the
+// ContractRetention config ago. Each contract is emitted whole in an
+// EventPruneContract before it is deleted, so indexers can archive it.
+// Contracts with income held in escrow are kept until the escrow is released.
+func (mgr Manager) ContractPruneEndBlock(ctx cosmos.Context) error {
+	retention := mgr.FetchConfig(ctx, configs.ContractRetention)
+	if retention <= 0 {
+		return nil
+	}
+
+	for _, contract := range mgr.prunableContracts(ctx, ctx.BlockHeight()-retention) {
+		escrow, err := mgr.keeper.GetContractEscrow(ctx, contract.Id)
+		if err != nil {
+			return err
+		}
+		if !escrow.IsEmpty() {
+			continue
+		}
+		if err := mgr.EmitPruneContractEvent(ctx, contract); err != nil {
+			return err
+		}
+		mgr.keeper.RemoveContract(ctx, contract.Id)
+	}
+	return nil
+}
+
+// prunableContracts returns the contracts settled before the given height,
+// oldest first
+func (mgr Manager) prunableContracts(ctx cosmos.Context, before int64) []types.Contract {
+	var contracts []types.Contract
+	iter := mgr.keeper.GetClosedContractIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid() && len(contracts) < maxContractPrunesPerBlock; iter.Next() {
+		var id gogotypes.UInt64Value
+		if err := mgr.keeper.Cdc().Unmarshal(iter.Value(), &id); err != nil {
+			ctx.Logger().Error("fail to unmarshal closed contract", "error", err)
+			continue
+		}
+		contract, err := mgr.keeper.GetContract(ctx, id.Value)
+		if err != nil {
+			ctx.Logger().Error("unable to fetch contract", "id", id.Value, "error", err)
+			continue
+		}
+		if contract.SettlementHeight >= before {
+			break
+		}
+		contracts = append(contracts, contract)
+	}
+	return contracts
+}
@@ -2,7 +2,9 @@ package keeper
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/arkeonetwork/arkeo/common"
 	"github.com/arkeonetwork/arkeo/common/cosmos"
@@ -18,9 +20,36 @@ func (k KVStore) setContract(ctx cosmos.Context, contract types.Contract) {
 		store.Delete([]byte(key))
 	} else {
 		store.Set([]byte(key), buf)
+		k.setClientContractIndex(ctx, contract)
+		k.setProviderContractIndex(ctx, contract)
+		if contract.SettlementHeight > 0 {
+			store.Set([]byte(k.getClosedContractKey(ctx, contract.SettlementHeight, contract.Id)), k.cdc.MustMarshal(&gogotypes.UInt64Value{Value: contract.Id}))
+		}
 	}
 }
 
+// setClientContractIndex index the contract under its client, so the
+// contracts of a client can be listed without scanning every contract
+func (k KVStore) setClientContractIndex(ctx cosmos.Context, contract types.Contract) {
+	store := ctx.KVStore(k.storeKey)
+	buf := k.cdc.MustMarshal(&gogotypes.UInt64Value{Value: contract.Id})
+	store.Set([]byte(k.getClientContractKey(ctx, contract.Client, contract.Id)), buf)
+}
+
+// setProviderContractIndex index the contract under its provider and service
+// until it is settled, so the contracts open against a provider can be counted
+// without scanning every contract
+func (k KVStore) setProviderContractIndex(ctx cosmos.Context, contract types.Contract) {
+	store := ctx.KVStore(k.storeKey)
+	key := k.getProviderContractKey(ctx, contract.Provider, contract.Service, contract.Id)
+	if contract.SettlementHeight > 0 {
+		store.Delete([]byte(key))
+		return
+	}
+	buf := k.cdc.MustMarshal(&gogotypes.UInt64Value{Value: contract.Id})
+	store.Set([]byte(key), buf)
+}
+
 func (k KVStore) getContract(ctx cosmos.Context, id uint64, contract *types.Contract) (bool, error) {
 	store := ctx.KVStore(k.storeKey)
 	key := k.GetContractKey(ctx, id)
@@ -62,9 +91,32 @@ func (k KVStore) ContractExists(ctx cosmos.Context, id uint64) bool {
 }
 
 func (k KVStore) RemoveContract(ctx cosmos.Context, id uint64) {
+	contract, err := k.GetContract(ctx, id)
+	if err == nil && !contract.Client.IsEmpty() {
+		k.del(ctx, k.getClientContractKey(ctx, contract.Client, id))
+	}
+	if err == nil && !contract.Provider.IsEmpty() {
+		k.del(ctx, k.getProviderContractKey(ctx, contract.Provider, contract.Service, id))
+	}
+	if err == nil && contract.SettlementHeight > 0 {
+		k.del(ctx, k.getClosedContractKey(ctx, contract.SettlementHeight, id))
+	}
 	k.del(ctx, k.GetContractKey(ctx, id))
 }
 
+// getClosedContractKey index the closed contract by the height it was
+// settled, heights are zero padded so the index is ordered by settlement
+// height
+func (k KVStore) getClosedContractKey(ctx cosmos.Context, height int64, id uint64) string {
+	return k.GetKey(ctx, prefixClosedContract, fmt.Sprintf("%020d/%020d", height, id))
+}
+
+// GetClosedContractIterator iterate the ids of closed contracts, ordered by
+// the height they were settled
+func (k KVStore) GetClosedContractIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixClosedContract)
+}
+
 func (k KVStore) setContractExpirationSet(ctx cosmos.Context, key string, record types.ContractExpirationSet) {
 	store := ctx.KVStore(k.storeKey)
 	buf := k.cdc.MustMarshal(&record)
@@ -88,46 +140,189 @@ func (k KVStore) getContractExpirationSet(ctx cosmos.Context, key string, record
 	return true, nil
 }
 
-func (k KVStore) getContractExpirationSetKey(ctx cosmos.Context, height int64) string {
-	return k.GetKey(ctx, prefixContractExpirationSet, strconv.FormatInt(height, 10))
+// getContractExpirationPrefix returns the prefix of the contracts expiring at
+// the given height. Each contract has its own key, heights and ids are zero
+// padded so the index is ordered by height then id
+func (k KVStore) getContractExpirationPrefix(ctx cosmos.Context, height int64) string {
+	return k.GetKey(ctx, prefixContractExpiration, fmt.Sprintf("%020d", height)) + "/"
+}
+
+func (k KVStore) getContractExpirationKey(ctx cosmos.Context, height int64, id uint64) string {
+	return fmt.Sprintf("%s%020d", k.getContractExpirationPrefix(ctx, height), id)
 }
 
 func (k KVStore) getUserContractSetKey(ctx cosmos.Context, userPubKey common.PubKey) string {
 	return k.GetKey(ctx, prefixUserContractSet, userPubKey.String())
 }
 
+// GetClientContractPrefix returns the prefix of the client contract index
+// for the given client. Ids are zero padded, so the index is ordered by id
+func (k KVStore) GetClientContractPrefix(ctx cosmos.Context, client common.PubKey) string {
+	return k.GetKey(ctx, prefixClientContract, client.String()) + "/"
+}
+
+func (k KVStore) getClientContractKey(ctx cosmos.Context, client common.PubKey, id uint64) string {
+	return fmt.Sprintf("%s%020d", k.GetClientContractPrefix(ctx, client), id)
+}
+
+// GetProviderContractPrefix returns the prefix of the index of the contracts
+// not yet settled against the provider for the service
+func (k KVStore) GetProviderContractPrefix(ctx cosmos.Context, provider common.PubKey, service common.Service) string {
+	return k.GetKey(ctx, prefixProviderContract, fmt.Sprintf("%s/%s", provider, service)) + "/"
+}
+
+func (k KVStore) getProviderContractKey(ctx cosmos.Context, provider common.PubKey, service common.Service, id uint64) string {
+	return fmt.Sprintf("%s%020d", k.GetProviderContractPrefix(ctx, provider, service), id)
+}
+
 func (k KVStore) GetContractKey(ctx cosmos.Context, id uint64) string {
 	return k.GetKey(ctx, prefixContract, strconv.FormatUint(id, 10))
 }
 
-// GetContractExpirationSetIterator iterate contract expiration sets
-func (k KVStore) GetContractExpirationSetIterator(ctx cosmos.Context) cosmos.Iterator {
+// GetLegacyContractExpirationSetIterator iterate the contract expiration sets
+// saved as a single record per height, before each expiration had its own key
+func (k KVStore) GetLegacyContractExpirationSetIterator(ctx cosmos.Context) cosmos.Iterator {
 	return k.getIterator(ctx, prefixContractExpirationSet)
 }
 
-// GetContractExpirationSet get a series of contract expirations
+// RemoveLegacyContractExpirationSet remove the contract expiration set saved
+// as a single record for the given height
+func (k KVStore) RemoveLegacyContractExpirationSet(ctx cosmos.Context, height int64) {
+	k.del(ctx, k.GetKey(ctx, prefixContractExpirationSet, strconv.FormatInt(height, 10)))
+}
+
+// GetContractExpirationIterator iterate the ids of the contracts expiring at
+// the given height, ordered by id
+func (k KVStore) GetContractExpirationIterator(ctx cosmos.Context, height int64) cosmos.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return cosmos.KVStorePrefixIterator(store, []byte(k.getContractExpirationPrefix(ctx, height)))
+}
+
+// AddContractExpiration add the contract to the contracts expiring at the
+// given height
+func (k KVStore) AddContractExpiration(ctx cosmos.Context, height int64, id uint64) error {
+	if height <= 0 {
+		return errors.New("cannot save a contract expiration with an invalid height (less than or equal to zero)")
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(k.getContractExpirationKey(ctx, height, id)), k.cdc.MustMarshal(&gogotypes.UInt64Value{Value: id}))
+	return nil
+}
+
+// RemoveContractExpiration remove the contract from the contracts expiring at
+// the given height
+func (k KVStore) RemoveContractExpiration(ctx cosmos.Context, height int64, id uint64) {
+	k.del(ctx, k.getContractExpirationKey(ctx, height, id))
+}
+
+// GetContractExpirationSet get the contracts expiring at the given height.
+// This loads every expiration at the height, the end blocker iterates them
+// with GetContractExpirationIterator instead.
 func (k KVStore) GetContractExpirationSet(ctx cosmos.Context, height int64) (types.ContractExpirationSet, error) {
+	record := types.ContractExpirationSet{
+		Height:      height,
+		ContractSet: &types.ContractSet{},
+	}
+	iter := k.GetContractExpirationIterator(ctx, height)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var id gogotypes.UInt64Value
+		if err := k.cdc.Unmarshal(iter.Value(), &id); err != nil {
+			return record, err
+		}
+		record.Append(id.Value)
+	}
+	return record, nil
+}
+
+// SetContractExpirationSet replace the contracts expiring at the set's height
+// with the contracts of the set
+func (k KVStore) SetContractExpirationSet(ctx cosmos.Context, record types.ContractExpirationSet) error {
+	if record.Height <= 0 {
+		return errors.New("cannot save a contract expiration set with an invalid height (less than or equal to zero)")
+	}
+	k.RemoveContractExpirationSet(ctx, record.Height)
+	if record.ContractSet == nil {
+		return nil
+	}
+	for _, id := range record.ContractSet.ContractIds {
+		if err := k.AddContractExpiration(ctx, record.Height, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveContractExpirationSet remove every contract expiring at the given
+// height
+func (k KVStore) RemoveContractExpirationSet(ctx cosmos.Context, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	// collect first, deleting while iterating is not safe
+	var keys [][]byte
+	iter := k.GetContractExpirationIterator(ctx, height)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// GetContractExpirationSets get every contract expiration grouped in a set per
+// height, ordered by height
+func (k KVStore) GetContractExpirationSets(ctx cosmos.Context) ([]types.ContractExpirationSet, error) {
+	var sets []types.ContractExpirationSet
+	prefix := k.GetKey(ctx, prefixContractExpiration, "")
+	iter := k.getIterator(ctx, prefixContractExpiration)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		parts := strings.SplitN(strings.TrimPrefix(string(iter.Key()), prefix), "/", 2)
+		height, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract expiration key %s: %w", iter.Key(), err)
+		}
+		var id gogotypes.UInt64Value
+		if err := k.cdc.Unmarshal(iter.Value(), &id); err != nil {
+			return nil, err
+		}
+		if len(sets) == 0 || sets[len(sets)-1].Height != height {
+			sets = append(sets, types.ContractExpirationSet{Height: height, ContractSet: &types.ContractSet{}})
+		}
+		sets[len(sets)-1].Append(id.Value)
+	}
+	return sets, nil
+}
+
+// GetContractSettlementSetIterator iterate contract settlement sets
+func (k KVStore) GetContractSettlementSetIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixContractSettlementSet)
+}
+
+// GetContractSettlementSet get the subscriptions due a periodic settlement at
+// the given height
+func (k KVStore) GetContractSettlementSet(ctx cosmos.Context, height int64) (types.ContractExpirationSet, error) {
 	record := types.ContractExpirationSet{
 		Height: height,
 	}
-	_, err := k.getContractExpirationSet(ctx, k.getContractExpirationSetKey(ctx, height), &record)
+	_, err := k.getContractExpirationSet(ctx, k.GetKey(ctx, prefixContractSettlementSet, strconv.FormatInt(height, 10)), &record)
 	if record.ContractSet == nil {
 		record.ContractSet = &types.ContractSet{}
 	}
 	return record, err
 }
 
-// SetContractExpirationSet save the series of Contract Expirations
-func (k KVStore) SetContractExpirationSet(ctx cosmos.Context, record types.ContractExpirationSet) error {
+// SetContractSettlementSet save the subscriptions due a periodic settlement
+func (k KVStore) SetContractSettlementSet(ctx cosmos.Context, record types.ContractExpirationSet) error {
 	if record.Height <= 0 {
-		return errors.New("cannot save a contract expiration set with an invalid height (less than or equal to zero)")
+		return errors.New("cannot save a contract settlement set with an invalid height (less than or equal to zero)")
 	}
-	k.setContractExpirationSet(ctx, k.getContractExpirationSetKey(ctx, record.Height), record)
+	k.setContractExpirationSet(ctx, k.GetKey(ctx, prefixContractSettlementSet, strconv.FormatInt(record.Height, 10)), record)
 	return nil
 }
 
-func (k KVStore) RemoveContractExpirationSet(ctx cosmos.Context, height int64) {
-	k.del(ctx, k.GetKey(ctx, prefixContractExpirationSet, strconv.FormatInt(height, 10)))
+func (k KVStore) RemoveContractSettlementSet(ctx cosmos.Context, height int64) {
+	k.del(ctx, k.GetKey(ctx, prefixContractSettlementSet, strconv.FormatInt(height, 10)))
 }
 
 func (kvStore KVStore) GetAndIncrementNextContractId(ctx cosmos.Context) uint64 {
@@ -223,6 +418,57 @@ func (k KVStore) GetActiveContractForUser(ctx cosmos.Context, user, provider com
 	return types.Contract{}, nil
 }
 
+// SumClientDeposits returns the sum of deposits, by denom, across all of the
+// client's contracts that have not yet been settled
+func (k KVStore) SumClientDeposits(ctx cosmos.Context, client common.PubKey) (cosmos.Coins, error) {
+	sums := cosmos.NewCoins()
+	contractSet, err := k.GetUserContractSet(ctx, client)
+	if err != nil {
+		return sums, err
+	}
+
+	if contractSet.ContractSet == nil {
+		return sums, nil
+	}
+
+	for _, contractId := range contractSet.ContractSet.ContractIds {
+		contract, err := k.GetContract(ctx, contractId)
+		if err != nil {
+			return sums, err
+		}
+		if contract.IsEmpty() || !contract.Client.Equals(client) || contract.IsSettled(ctx.BlockHeight()) {
+			continue
+		}
+		sums = sums.Add(cosmos.NewCoin(contract.Rate.Denom, contract.Deposit))
+	}
+
+	return sums, nil
+}
+
+// CountOpenProviderContracts returns the number of contracts open against the
+// provider for the service
+func (k KVStore) CountOpenProviderContracts(ctx cosmos.Context, provider common.PubKey, service common.Service) (int64, error) {
+	store := ctx.KVStore(k.storeKey)
+	iter := cosmos.KVStorePrefixIterator(store, []byte(k.GetProviderContractPrefix(ctx, provider, service)))
+	defer iter.Close()
+
+	var count int64
+	for ; iter.Valid(); iter.Next() {
+		var id gogotypes.UInt64Value
+		if err := k.cdc.Unmarshal(iter.Value(), &id); err != nil {
+			return count, err
+		}
+		contract, err := k.GetContract(ctx, id.Value)
+		if err != nil {
+			return count, err
+		}
+		if contract.IsOpen(ctx.BlockHeight()) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // RemoveFromUserContractSet remove a contract from a user's contract set and saves the updated set to the store
 func (k KVStore) RemoveFromUserContractSet(ctx cosmos.Context, user common.PubKey, contractId uint64) error {
 	contractSet, err := k.GetUserContractSet(ctx, user)
@@ -247,3 +493,158 @@ func (k KVStore) RemoveFromUserContractSet(ctx cosmos.Context, user common.PubKe
 func (k KVStore) GetUserContractSetIterator(ctx cosmos.Context) cosmos.Iterator {
 	return k.getIterator(ctx, prefixUserContractSet)
 }
+
+// GetAllowedDenomIterator iterate the denoms contracts may be paid in, besides
+// the native denom
+func (k KVStore) GetAllowedDenomIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixAllowedDenom)
+}
+
+// IsAllowedDenom check whether the given denom is on the allow list. Keys are
+// case insensitive while denoms are not, so the stored denom must match too
+func (k KVStore) IsAllowedDenom(ctx cosmos.Context, denom string) bool {
+	store := ctx.KVStore(k.storeKey)
+	buf := store.Get([]byte(k.GetKey(ctx, prefixAllowedDenom, denom)))
+	if buf == nil {
+		return false
+	}
+	var record types.AllowedDenom
+	if err := k.cdc.Unmarshal(buf, &record); err != nil {
+		return false
+	}
+	return record.Denom == denom
+}
+
+// AddAllowedDenom add the given denom to the allow list
+func (k KVStore) AddAllowedDenom(ctx cosmos.Context, denom string) error {
+	if err := cosmos.ValidateDenom(denom); err != nil {
+		return err
+	}
+	store := ctx.KVStore(k.storeKey)
+	record := types.AllowedDenom{Denom: denom}
+	store.Set([]byte(k.GetKey(ctx, prefixAllowedDenom, denom)), k.cdc.MustMarshal(&record))
+	return nil
+}
+
+// RemoveAllowedDenom remove the given denom from the allow list
+func (k KVStore) RemoveAllowedDenom(ctx cosmos.Context, denom string) {
+	k.del(ctx, k.GetKey(ctx, prefixAllowedDenom, denom))
+}
+
+func (k KVStore) getContractEscrowKey(ctx cosmos.Context, id uint64) string {
+	return k.GetKey(ctx, prefixContractEscrow, strconv.FormatUint(id, 10))
+}
+
+// getEscrowReleaseKey index the escrow by the height it is released, heights
+// are zero padded so the index is ordered by release height
+func (k KVStore) getEscrowReleaseKey(ctx cosmos.Context, height int64, id uint64) string {
+	return k.GetKey(ctx, prefixEscrowRelease, fmt.Sprintf("%020d/%020d", height, id))
+}
+
+// GetContractEscrowIterator iterate contract escrows
+func (k KVStore) GetContractEscrowIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixContractEscrow)
+}
+
+// GetEscrowReleaseIterator iterate the ids of escrowed contracts, ordered by
+// the height their escrow is released
+func (k KVStore) GetEscrowReleaseIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixEscrowRelease)
+}
+
+// GetContractEscrow get the escrowed income of the given contract, the escrow
+// is empty when there is none
+func (k KVStore) GetContractEscrow(ctx cosmos.Context, id uint64) (types.ContractEscrow, error) {
+	var record types.ContractEscrow
+	store := ctx.KVStore(k.storeKey)
+	key := k.getContractEscrowKey(ctx, id)
+	if !store.Has([]byte(key)) {
+		return record, nil
+	}
+	err := k.cdc.Unmarshal(store.Get([]byte(key)), &record)
+	return record, err
+}
+
+// SetContractEscrow save the contract escrow and move it in the release
+// index. An escrow without a release height is never released on its own.
+func (k KVStore) SetContractEscrow(ctx cosmos.Context, record types.ContractEscrow) error {
+	if record.Provider.IsEmpty() || record.Client.IsEmpty() {
+		return errors.New("cannot save a contract escrow with an empty provider or client pubkey")
+	}
+	old, err := k.GetContractEscrow(ctx, record.ContractId)
+	if err != nil {
+		return err
+	}
+	store := ctx.KVStore(k.storeKey)
+	if !old.IsEmpty() && old.ReleaseHeight > 0 {
+		store.Delete([]byte(k.getEscrowReleaseKey(ctx, old.ReleaseHeight, old.ContractId)))
+	}
+	store.Set([]byte(k.getContractEscrowKey(ctx, record.ContractId)), k.cdc.MustMarshal(&record))
+	if record.ReleaseHeight > 0 {
+		buf := k.cdc.MustMarshal(&gogotypes.UInt64Value{Value: record.ContractId})
+		store.Set([]byte(k.getEscrowReleaseKey(ctx, record.ReleaseHeight, record.ContractId)), buf)
+	}
+	return nil
+}
+
+// RemoveContractEscrow remove the contract escrow along with its release index
+func (k KVStore) RemoveContractEscrow(ctx cosmos.Context, id uint64) {
+	escrow, err := k.GetContractEscrow(ctx, id)
+	if err == nil && escrow.ReleaseHeight > 0 {
+		k.del(ctx, k.getEscrowReleaseKey(ctx, escrow.ReleaseHeight, id))
+	}
+	k.del(ctx, k.getContractEscrowKey(ctx, id))
+}
+
+// GetArbiterIterator iterate the arbiters that may resolve disputes
+func (k KVStore) GetArbiterIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixArbiter)
+}
+
+// IsArbiter check whether the given account may resolve disputes
+func (k KVStore) IsArbiter(ctx cosmos.Context, addr cosmos.AccAddress) bool {
+	return k.has(ctx, k.GetKey(ctx, prefixArbiter, addr.String()))
+}
+
+// AddArbiter add the given account to the arbiters
+func (k KVStore) AddArbiter(ctx cosmos.Context, addr cosmos.AccAddress) error {
+	if addr.Empty() {
+		return errors.New("cannot add an arbiter with an empty address")
+	}
+	store := ctx.KVStore(k.storeKey)
+	record := types.Arbiter{Address: addr}
+	store.Set([]byte(k.GetKey(ctx, prefixArbiter, addr.String())), k.cdc.MustMarshal(&record))
+	return nil
+}
+
+// RemoveArbiter remove the given account from the arbiters
+func (k KVStore) RemoveArbiter(ctx cosmos.Context, addr cosmos.AccAddress) {
+	k.del(ctx, k.GetKey(ctx, prefixArbiter, addr.String()))
+}
+
+// GetClientStatsIterator iterate client stats
+func (k KVStore) GetClientStatsIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixClientStats)
+}
+
+// GetClientStats get the usage history of the given client
+func (k KVStore) GetClientStats(ctx cosmos.Context, pubkey common.PubKey) (types.ClientStats, error) {
+	record := types.NewClientStats(pubkey)
+	key := k.GetKey(ctx, prefixClientStats, pubkey.String())
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has([]byte(key)) {
+		return record, nil
+	}
+	err := k.cdc.Unmarshal(store.Get([]byte(key)), &record)
+	return record, err
+}
+
+// SetClientStats save the usage history of a client
+func (k KVStore) SetClientStats(ctx cosmos.Context, stats types.ClientStats) error {
+	if stats.PubKey.IsEmpty() {
+		return errors.New("cannot save client stats with an empty pubkey")
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(k.GetKey(ctx, prefixClientStats, stats.PubKey.String())), k.cdc.MustMarshal(&stats))
+	return nil
+}
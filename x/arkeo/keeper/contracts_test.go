@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
 	"github.com/stretchr/testify/require"
 )
@@ -49,4 +50,68 @@ func TestContractExpirationSet(t *testing.T) {
 	set, err = k.GetContractExpirationSet(ctx, set.Height)
 	require.NoError(t, err)
 	require.Len(t, set.ContractSet.ContractIds, 0)
+
+	// each expiration is saved on its own, ordered by id within a height
+	require.Error(t, k.AddContractExpiration(ctx, 0, 1))
+	require.NoError(t, k.AddContractExpiration(ctx, 100, 3))
+	require.NoError(t, k.AddContractExpiration(ctx, 100, 2))
+	require.NoError(t, k.AddContractExpiration(ctx, 10, 1))
+	require.NoError(t, k.AddContractExpiration(ctx, 1000, 4))
+	set, err = k.GetContractExpirationSet(ctx, 100)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, set.ContractSet.ContractIds)
+
+	k.RemoveContractExpiration(ctx, 100, 3)
+	set, err = k.GetContractExpirationSet(ctx, 100)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, set.ContractSet.ContractIds)
+
+	// setting a set replaces the expirations at its height
+	require.NoError(t, k.SetContractExpirationSet(ctx, types.ContractExpirationSet{
+		Height:      100,
+		ContractSet: &types.ContractSet{ContractIds: []uint64{5, 6}},
+	}))
+	sets, err := k.GetContractExpirationSets(ctx)
+	require.NoError(t, err)
+	require.Len(t, sets, 3)
+	require.Equal(t, int64(10), sets[0].Height)
+	require.Equal(t, []uint64{1}, sets[0].ContractSet.ContractIds)
+	require.Equal(t, int64(100), sets[1].Height)
+	require.Equal(t, []uint64{5, 6}, sets[1].ContractSet.ContractIds)
+	require.Equal(t, int64(1000), sets[2].Height)
+	require.Equal(t, []uint64{4}, sets[2].ContractSet.ContractIds)
+}
+
+func TestSumClientDeposits(t *testing.T) {
+	ctx, k := SetupKeeper(t)
+	ctx = ctx.WithBlockHeight(10)
+
+	client := types.GetRandomPubKey()
+	sums, err := k.SumClientDeposits(ctx, client)
+	require.NoError(t, err)
+	require.True(t, sums.IsZero())
+
+	userSet := types.UserContractSet{
+		User:        client,
+		ContractSet: &types.ContractSet{},
+	}
+	for i, deposit := range []int64{100, 250, 400} {
+		contract := types.NewContract(types.GetRandomPubKey(), common.BTCService, client)
+		contract.Id = uint64(i + 1)
+		contract.Height = 5
+		contract.Duration = 100
+		contract.Rate = cosmos.NewInt64Coin("uarkeo", 1)
+		contract.Deposit = cosmos.NewInt(deposit)
+		if i == 2 {
+			// expired and settled contracts do not count towards the sum
+			contract.Duration = 1
+		}
+		require.NoError(t, k.SetContract(ctx, contract))
+		userSet.ContractSet.ContractIds = append(userSet.ContractSet.ContractIds, contract.Id)
+	}
+	require.NoError(t, k.SetUserContractSet(ctx, userSet))
+
+	sums, err = k.SumClientDeposits(ctx, client)
+	require.NoError(t, err)
+	require.Equal(t, int64(350), sums.AmountOf("uarkeo").Int64())
 }
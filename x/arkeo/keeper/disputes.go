@@ -0,0 +1,179 @@
+package keeper
+
+import (
+	gogotypes "github.com/gogo/protobuf/types"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// escrowProviderIncome holds the provider's income from a settlement in the
+// contract module until the dispute window of the settlement closes. Returns
+// false when there is no dispute window, in which case the provider should be
+// paid straight away.
+func (mgr Manager) escrowProviderIncome(ctx cosmos.Context, contract types.Contract, amount cosmos.Int) (bool, error) {
+	window := mgr.FetchConfig(ctx, configs.DisputeWindow)
+	if window <= 0 {
+		return false, nil
+	}
+
+	escrow, err := mgr.keeper.GetContractEscrow(ctx, contract.Id)
+	if err != nil {
+		return false, err
+	}
+	if escrow.IsEmpty() {
+		refundTo, err := contract.RefundTo()
+		if err != nil {
+			return false, err
+		}
+		escrow = types.NewContractEscrow(contract, refundTo)
+	}
+	escrow.Amount = escrow.Amount.Add(cosmos.NewCoin(contract.Rate.Denom, amount))
+	// each settlement has its own window, so a steady stream of settlements
+	// does not keep holding back the earlier ones
+	escrow.Tranches = append(escrow.Tranches, types.EscrowTranche{
+		Amount:        amount,
+		ReleaseHeight: ctx.BlockHeight() + window,
+	})
+	// a disputed escrow stays frozen until the dispute is resolved
+	if !escrow.Disputed {
+		escrow.ReleaseHeight = escrow.NextReleaseHeight()
+	}
+	return true, mgr.keeper.SetContractEscrow(ctx, escrow)
+}
+
+// EscrowEndBlock releases the escrowed income whose dispute window closed to
+// the provider. Disputes nobody resolved in time are settled in the provider's
+// favour, as if they were never raised.
+func (mgr Manager) EscrowEndBlock(ctx cosmos.Context) error {
+	for _, escrow := range mgr.dueEscrows(ctx) {
+		// a failed release must not leave the escrow half paid out
+		cacheCtx, commit := ctx.CacheContext()
+		var err error
+		if escrow.Disputed {
+			err = mgr.resolveDispute(cacheCtx, escrow, configs.MaxBasisPoints, "")
+		} else {
+			err = mgr.releaseEscrow(cacheCtx, escrow)
+		}
+		if err != nil {
+			ctx.Logger().Error("unable to release contract escrow", "id", escrow.ContractId, "error", err)
+			continue
+		}
+		commit()
+	}
+	return nil
+}
+
+func (mgr Manager) dueEscrows(ctx cosmos.Context) []types.ContractEscrow {
+	var escrows []types.ContractEscrow
+	iter := mgr.keeper.GetEscrowReleaseIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var id gogotypes.UInt64Value
+		if err := mgr.keeper.Cdc().Unmarshal(iter.Value(), &id); err != nil {
+			ctx.Logger().Error("fail to unmarshal escrow release", "error", err)
+			continue
+		}
+		escrow, err := mgr.keeper.GetContractEscrow(ctx, id.Value)
+		if err != nil {
+			ctx.Logger().Error("unable to fetch contract escrow", "id", id.Value, "error", err)
+			continue
+		}
+		if escrow.ReleaseHeight > ctx.BlockHeight() {
+			break
+		}
+		escrows = append(escrows, escrow)
+	}
+	return escrows
+}
+
+// releaseEscrow pays the tranches whose window closed to the provider, the
+// escrow is removed once nothing is left in it
+func (mgr Manager) releaseEscrow(ctx cosmos.Context, escrow types.ContractEscrow) error {
+	due := cosmos.ZeroInt()
+	held := make([]types.EscrowTranche, 0, len(escrow.Tranches))
+	for _, tranche := range escrow.Tranches {
+		if tranche.ReleaseHeight <= ctx.BlockHeight() {
+			due = due.Add(tranche.Amount)
+		} else {
+			held = append(held, tranche)
+		}
+	}
+	if len(held) == 0 || due.GT(escrow.Amount.Amount) {
+		due = escrow.Amount.Amount
+	}
+	released := cosmos.NewCoin(escrow.Amount.Denom, due)
+
+	if err := mgr.payEscrowToProvider(ctx, escrow, due); err != nil {
+		return err
+	}
+	if len(held) == 0 {
+		mgr.keeper.RemoveContractEscrow(ctx, escrow.ContractId)
+		return mgr.EmitReleaseEscrowEvent(ctx, escrow, released)
+	}
+
+	escrow.Amount = escrow.Amount.Sub(released)
+	escrow.Tranches = held
+	escrow.ReleaseHeight = escrow.NextReleaseHeight()
+	if err := mgr.keeper.SetContractEscrow(ctx, escrow); err != nil {
+		return err
+	}
+	return mgr.EmitReleaseEscrowEvent(ctx, escrow, released)
+}
+
+// resolveDispute pays the given share of a disputed escrow to the provider
+// and refunds the rest to the client. The arbiter is empty when the dispute
+// timed out.
+func (mgr Manager) resolveDispute(ctx cosmos.Context, escrow types.ContractEscrow, providerBasisPoints int64, arbiter string) error {
+	denom := escrow.Amount.Denom
+	providerAmount := common.GetSafeShare(cosmos.NewInt(providerBasisPoints), cosmos.NewInt(configs.MaxBasisPoints), escrow.Amount.Amount)
+	clientAmount := escrow.Amount.Amount.Sub(providerAmount)
+
+	if err := mgr.payEscrowToProvider(ctx, escrow, providerAmount); err != nil {
+		return err
+	}
+	if clientAmount.IsPositive() {
+		refundTo := escrow.RefundAddress
+		if refundTo.Empty() {
+			client, err := escrow.Client.GetMyAddress()
+			if err != nil {
+				return err
+			}
+			refundTo = client
+		}
+		if err := mgr.keeper.SendFromModuleToAccount(ctx, types.ContractName, refundTo, cosmos.NewCoins(cosmos.NewCoin(denom, clientAmount))); err != nil {
+			return err
+		}
+	}
+
+	mgr.keeper.RemoveContractEscrow(ctx, escrow.ContractId)
+	return mgr.EmitResolveDisputeEvent(ctx, escrow, cosmos.NewCoin(denom, providerAmount), cosmos.NewCoin(denom, clientAmount), arbiter)
+}
+
+// payEscrowToProvider pays the given amount of an escrow to the provider, less
+// the fee of the contract's affiliate
+func (mgr Manager) payEscrowToProvider(ctx cosmos.Context, escrow types.ContractEscrow, amount cosmos.Int) error {
+	if !amount.IsPositive() {
+		return nil
+	}
+	denom := escrow.Amount.Denom
+
+	fee := affiliateShare(escrow.Affiliate, escrow.AffiliateFee, amount)
+	if fee.IsPositive() {
+		coin := cosmos.NewCoin(denom, fee)
+		if err := mgr.keeper.SendFromModuleToAccount(ctx, types.ContractName, escrow.Affiliate, cosmos.NewCoins(coin)); err != nil {
+			return err
+		}
+		if err := mgr.EmitEscrowAffiliatePaymentEvent(ctx, coin, escrow); err != nil {
+			return err
+		}
+	}
+
+	provider, err := escrow.Provider.GetMyAddress()
+	if err != nil {
+		return err
+	}
+	return mgr.keeper.SendFromModuleToAccount(ctx, types.ContractName, provider, cosmos.NewCoins(cosmos.NewCoin(denom, amount.Sub(fee))))
+}
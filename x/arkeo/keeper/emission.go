@@ -0,0 +1,31 @@
+package keeper
+
+import (
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// GetEmissionSchedule get the emission schedule set by governance, the
+// schedule has no epochs when there is none
+func (k KVStore) GetEmissionSchedule(ctx cosmos.Context) (types.EmissionSchedule, error) {
+	var record types.EmissionSchedule
+	store := ctx.KVStore(k.storeKey)
+	key := k.GetKey(ctx, prefixEmissionSchedule, "")
+	if !store.Has([]byte(key)) {
+		return record, nil
+	}
+	err := k.cdc.Unmarshal(store.Get([]byte(key)), &record)
+	return record, err
+}
+
+// SetEmissionSchedule save the emission schedule, a schedule without epochs
+// is removed
+func (k KVStore) SetEmissionSchedule(ctx cosmos.Context, record types.EmissionSchedule) {
+	key := k.GetKey(ctx, prefixEmissionSchedule, "")
+	if len(record.Epochs) == 0 {
+		k.del(ctx, key)
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(key), k.cdc.MustMarshal(&record))
+}
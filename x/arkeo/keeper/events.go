@@ -1,95 +1,186 @@
 package keeper
 
 import (
+	"github.com/arkeonetwork/arkeo/common"
 	"github.com/arkeonetwork/arkeo/common/cosmos"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
 )
 
+// All arkeo events are emitted as typed events, built by the constructors in
+// the types package, so indexers can decode them without relying on the
+// order of the attributes
+
 func (k msgServer) EmitBondProviderEvent(ctx cosmos.Context, bond cosmos.Int, msg *types.MsgBondProvider) error {
-	return ctx.EventManager().EmitTypedEvent(
-		&types.EventBondProvider{
-			Provider: msg.Provider,
-			Service:  msg.Service,
-			BondRel:  msg.Bond,
-			BondAbs:  bond,
-		},
-	)
+	evt := types.NewBondProviderEvent(bond, msg)
+	return ctx.EventManager().EmitTypedEvent(&evt)
 }
 
 func (k msgServer) EmitCloseContractEvent(ctx cosmos.Context, contract *types.Contract) error {
-	return ctx.EventManager().EmitTypedEvent(
-		&types.EventCloseContract{
-			ContractId: contract.Id,
-			Provider:   contract.Provider,
-			Service:    contract.Service.String(),
-			Client:     contract.Client,
-			Delegate:   contract.Delegate,
-		},
-	)
+	evt := types.NewCloseContractEvent(contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitCloseContractByProviderEvent(ctx cosmos.Context, penalty cosmos.Int, contract *types.Contract) error {
+	evt := types.NewCloseContractByProviderEvent(penalty, contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitTransferContractEvent(ctx cosmos.Context, oldClient common.PubKey, contract *types.Contract) error {
+	evt := types.NewTransferContractEvent(oldClient, contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitRateChangeProposalEvent(ctx cosmos.Context, contract *types.Contract) error {
+	evt := types.NewRateChangeProposalEvent(contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitPauseContractEvent(ctx cosmos.Context, contract *types.Contract) error {
+	evt := types.NewPauseContractEvent(contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitResumeContractEvent(ctx cosmos.Context, contract *types.Contract) error {
+	evt := types.NewResumeContractEvent(contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitRateChangeEvent(ctx cosmos.Context, contract *types.Contract) error {
+	evt := types.NewRateChangeEvent(contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
 }
 
 func (k msgServer) EmitModProviderEvent(ctx cosmos.Context, msg *types.MsgModProvider, provider *types.Provider) error {
-	return ctx.EventManager().EmitTypedEvent(
-		&types.EventModProvider{
-			Creator:             msg.Creator,
-			Provider:            provider.PubKey,
-			Service:             provider.Service.String(),
-			MetadataUri:         provider.MetadataUri,
-			MetadataNonce:       provider.MetadataNonce,
-			Status:              provider.Status,
-			MinContractDuration: provider.MinContractDuration,
-			MaxContractDuration: provider.MaxContractDuration,
-			SubscriptionRate:    provider.SubscriptionRate,
-			PayAsYouGoRate:      provider.PayAsYouGoRate,
-			Bond:                provider.Bond,
-			SettlementDuration:  provider.SettlementDuration,
-		},
-	)
+	evt := types.NewModProviderEvent(msg, provider)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitModProviderMetadataEvent(ctx cosmos.Context, provider *types.Provider) error {
+	evt := types.NewModProviderMetadataEvent(provider)
+	return ctx.EventManager().EmitTypedEvent(&evt)
 }
 
 func (k msgServer) EmitOpenContractEvent(ctx cosmos.Context, openCost int64, contract *types.Contract) error {
-	return ctx.EventManager().EmitTypedEvent(
-		&types.EventOpenContract{
-			Provider:           contract.Provider,
-			ContractId:         contract.Id,
-			Service:            contract.Service.String(),
-			Client:             contract.Client,
-			Delegate:           contract.Delegate,
-			Type:               contract.Type,
-			Height:             contract.Height,
-			Duration:           contract.Duration,
-			Rate:               contract.Rate,
-			OpenCost:           openCost,
-			Deposit:            contract.Deposit,
-			SettlementDuration: contract.SettlementDuration,
-			Authorization:      contract.Authorization,
-			QueriesPerMinute:   contract.QueriesPerMinute,
-		},
-	)
-}
-
-func (mgr Manager) EmitContractSettlementEvent(ctx cosmos.Context, debt, valIncome cosmos.Int, contract *types.Contract) error {
-	return ctx.EventManager().EmitTypedEvent(
-		&types.EventSettleContract{
-			Provider:   contract.Provider,
-			ContractId: contract.Id,
-			Service:    contract.Service.String(),
-			Client:     contract.Client,
-			Delegate:   contract.Delegate,
-			Type:       contract.Type,
-			Nonce:      contract.Nonce,
-			Height:     contract.Height,
-			Paid:       debt,
-			Reserve:    valIncome,
-		},
-	)
-}
-
-func (mgr Manager) EmitValidatorPayoutEvent(ctx cosmos.Context, acc cosmos.AccAddress, rwd cosmos.Int) error {
-	return ctx.EventManager().EmitTypedEvent(
-		&types.EventValidatorPayout{
-			Validator: acc,
-			Reward:    rwd,
-		},
-	)
+	evt := types.NewOpenContractEvent(openCost, contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitContractSettlementEvent(ctx cosmos.Context, debt, valIncome, refunded cosmos.Int, closed bool, contract *types.Contract) error {
+	evt := types.NewContractSettlementEvent(debt, valIncome, refunded, closed, contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitReserveTaxSplitEvent(ctx cosmos.Context, denom string, reserve, communityPool cosmos.Int, contract *types.Contract) error {
+	evt := types.NewReserveTaxSplitEvent(denom, reserve, communityPool, contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitReserveFeesEvent(ctx cosmos.Context, fees cosmos.Coins) error {
+	evt := types.NewReserveFeesEvent(fees)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitValidatorPayoutEvent(ctx cosmos.Context, acc cosmos.AccAddress, denom string, rwd, delegatorsRwd cosmos.Int) error {
+	evt := types.NewValidatorPayoutEvent(acc, denom, rwd, delegatorsRwd)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitContractRenewalEvent(ctx cosmos.Context, deposit cosmos.Int, contract *types.Contract) error {
+	evt := types.NewContractRenewalEvent(deposit, contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitContractGracePeriodEvent(ctx cosmos.Context, contract *types.Contract) error {
+	evt := types.NewContractGracePeriodEvent(contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitPruneContractEvent(ctx cosmos.Context, contract types.Contract) error {
+	evt := types.EventPruneContract{Contract: contract}
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitSlashProviderEvent(ctx cosmos.Context, slash types.ProviderSlash) error {
+	evt := types.NewSlashProviderEvent(slash)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitDisputeContractEvent(ctx cosmos.Context, escrow types.ContractEscrow) error {
+	evt := types.NewDisputeContractEvent(escrow)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitResolveDisputeEvent(ctx cosmos.Context, escrow types.ContractEscrow, providerAmount, clientAmount cosmos.Coin, arbiter string) error {
+	evt := types.NewResolveDisputeEvent(escrow, providerAmount, clientAmount, arbiter)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitReleaseVestedRewardsEvent(ctx cosmos.Context, addr cosmos.AccAddress, amount cosmos.Coins) error {
+	evt := types.NewReleaseVestedRewardsEvent(addr, amount)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitDepositContractEvent(ctx cosmos.Context, deposit cosmos.Int, contract *types.Contract) error {
+	evt := types.NewDepositContractEvent(deposit, contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitAffiliatePaymentEvent(ctx cosmos.Context, paid cosmos.Coin, contract *types.Contract) error {
+	evt := types.NewAffiliatePaymentEvent(paid, contract)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitEscrowAffiliatePaymentEvent(ctx cosmos.Context, paid cosmos.Coin, escrow types.ContractEscrow) error {
+	evt := types.NewEscrowAffiliatePaymentEvent(paid, escrow)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitProviderUptimeEvent(ctx cosmos.Context, online bool, uptime *types.ProviderUptime) error {
+	evt := types.NewProviderUptimeEvent(online, uptime)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitProviderIncentiveEvent(ctx cosmos.Context, provider *types.Provider, openContracts int64, reward cosmos.Coin) error {
+	evt := types.NewProviderIncentiveEvent(provider, openContracts, reward)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (mgr Manager) EmitReleaseEscrowEvent(ctx cosmos.Context, escrow types.ContractEscrow, amount cosmos.Coin) error {
+	evt := types.NewReleaseEscrowEvent(escrow, amount)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitArbiterEvent(ctx cosmos.Context, arbiter cosmos.AccAddress, allowed bool) error {
+	evt := types.NewArbiterEvent(arbiter, allowed)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitConfigChangeEvent(ctx cosmos.Context, name string, oldValue, newValue int64) error {
+	evt := types.NewConfigChangeEvent(name, oldValue, newValue)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitContractOpeningHaltEvent(ctx cosmos.Context, halted bool) error {
+	evt := types.NewContractOpeningHaltEvent(halted)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitAllowedDenomEvent(ctx cosmos.Context, denom string, allowed bool) error {
+	evt := types.NewAllowedDenomEvent(denom, allowed)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitServiceEvent(ctx cosmos.Context, service string, supported bool) error {
+	evt := types.NewServiceEvent(service, supported)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitEmissionScheduleEvent(ctx cosmos.Context, schedule types.EmissionSchedule) error {
+	evt := types.NewEmissionScheduleEvent(schedule)
+	return ctx.EventManager().EmitTypedEvent(&evt)
+}
+
+func (k msgServer) EmitAllowedProviderEvent(ctx cosmos.Context, provider common.PubKey, allowed bool) error {
+	evt := types.NewAllowedProviderEvent(provider, allowed)
+	return ctx.EventManager().EmitTypedEvent(&evt)
 }
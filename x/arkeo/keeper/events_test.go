@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestEmitTypedEvents(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+
+	contract := types.NewContract(types.GetRandomPubKey(), common.BTCService, types.GetRandomPubKey())
+	contract.Id = 7
+	contract.Height = 10
+	contract.Duration = 100
+	contract.Rate = cosmos.NewInt64Coin("uarkeo", 15)
+	contract.Deposit = cosmos.NewInt(1500)
+
+	require.NoError(t, s.EmitOpenContractEvent(ctx, 100, &contract))
+	require.NoError(t, mgr.EmitContractSettlementEvent(ctx, cosmos.NewInt(90), cosmos.NewInt(10), cosmos.NewInt(1400), true, &contract))
+	validator := types.GetRandomBech32Addr()
+	require.NoError(t, mgr.EmitValidatorPayoutEvent(ctx, validator, "uarkeo", cosmos.NewInt(5), cosmos.NewInt(45)))
+
+	events := ctx.EventManager().ABCIEvents()
+	require.Len(t, events, 3)
+
+	require.Equal(t, types.EventTypeOpenContract, events[0].Type)
+	msg, err := sdk.ParseTypedEvent(events[0])
+	require.NoError(t, err)
+	openEvt, ok := msg.(*types.EventOpenContract)
+	require.True(t, ok)
+	require.Equal(t, contract.Id, openEvt.ContractId)
+	require.True(t, contract.Provider.Equals(openEvt.Provider))
+	require.True(t, contract.Client.Equals(openEvt.Client))
+	require.Equal(t, common.BTCService.String(), openEvt.Service)
+	require.Equal(t, int64(100), openEvt.OpenCost)
+	require.Equal(t, contract.Rate, openEvt.Rate)
+	require.Equal(t, int64(1500), openEvt.Deposit.Int64())
+
+	require.Equal(t, types.EventTypeSettleContract, events[1].Type)
+	msg, err = sdk.ParseTypedEvent(events[1])
+	require.NoError(t, err)
+	settleEvt, ok := msg.(*types.EventSettleContract)
+	require.True(t, ok)
+	require.Equal(t, contract.Id, settleEvt.ContractId)
+	require.Equal(t, int64(90), settleEvt.Paid.Int64())
+	require.Equal(t, int64(10), settleEvt.Reserve.Int64())
+	require.Equal(t, "uarkeo", settleEvt.Denom)
+	require.Equal(t, int64(1400), settleEvt.Refunded.Int64())
+	require.True(t, settleEvt.Closed)
+
+	require.Equal(t, types.EventTypeValidatorPayout, events[2].Type)
+	msg, err = sdk.ParseTypedEvent(events[2])
+	require.NoError(t, err)
+	payoutEvt, ok := msg.(*types.EventValidatorPayout)
+	require.True(t, ok)
+	require.Equal(t, validator, payoutEvt.Validator)
+	require.Equal(t, "uarkeo", payoutEvt.Denom)
+	require.Equal(t, int64(5), payoutEvt.Reward.Int64())
+	require.Equal(t, int64(45), payoutEvt.DelegatorsReward.Int64())
+}
@@ -0,0 +1,41 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k KVStore) Configs(c context.Context, req *types.QueryConfigsRequest) (*types.QueryConfigsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	// resolve through the manager so overrides are reflected exactly as the
+	// block logic sees them
+	mgr := NewManager(k, k.stakingKeeper)
+	defaults := k.GetConfigValues(ctx)
+	names := configs.GetConfigNames()
+	values := make([]types.ConfigValue, 0, len(names))
+	for _, name := range names {
+		_, overridden := k.GetConfigOverride(ctx, name)
+		values = append(values, types.ConfigValue{
+			Name:         name.String(),
+			Value:        mgr.FetchConfig(ctx, name),
+			DefaultValue: defaults.GetInt64Value(name),
+			Overridden:   overridden,
+			StringValue:  defaults.GetStringValue(name),
+		})
+	}
+
+	return &types.QueryConfigsResponse{
+		Configs: values,
+		Version: k.GetVersion(ctx),
+	}, nil
+}
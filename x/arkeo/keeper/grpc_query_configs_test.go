@@ -0,0 +1,38 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryConfigs(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	mgr := NewManager(k, sk)
+
+	_, err := k.Configs(ctx, nil)
+	require.Error(t, err)
+
+	resp, err := k.Configs(ctx, &types.QueryConfigsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Configs, len(configs.GetConfigNames()))
+	for i, name := range configs.GetConfigNames() {
+		require.Equal(t, name.String(), resp.Configs[i].Name)
+		require.Equal(t, mgr.FetchConfig(ctx, name), resp.Configs[i].Value)
+		require.Equal(t, resp.Configs[i].Value, resp.Configs[i].DefaultValue)
+		require.False(t, resp.Configs[i].Overridden)
+	}
+	require.Equal(t, k.GetVersion(ctx), resp.Version)
+	require.Equal(t, mgr.Configs(ctx).GetStringValue(configs.ChainEmissionWeights), resp.Configs[configs.ChainEmissionWeights].StringValue)
+
+	// overrides are reflected
+	k.SetConfigOverride(ctx, configs.ReserveTax, 250)
+	resp, err = k.Configs(ctx, &types.QueryConfigsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, configs.ReserveTax.String(), resp.Configs[configs.ReserveTax].Name)
+	require.EqualValues(t, 250, resp.Configs[configs.ReserveTax].Value)
+	require.Equal(t, mgr.Configs(ctx).GetInt64Value(configs.ReserveTax), resp.Configs[configs.ReserveTax].DefaultValue)
+	require.True(t, resp.Configs[configs.ReserveTax].Overridden)
+}
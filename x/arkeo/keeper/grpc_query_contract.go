@@ -4,11 +4,13 @@ import (
 	"context"
 
 	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
 
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	gogotypes "github.com/gogo/protobuf/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -88,3 +90,285 @@ func (k KVStore) ActiveContract(goCtx context.Context, req *types.QueryActiveCon
 
 	return &types.QueryActiveContractResponse{Contract: activeContract}, nil
 }
+
+func (k KVStore) ClientContracts(goCtx context.Context, req *types.QueryClientContractsRequest) (*types.QueryClientContractsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	clientPubKey, err := common.NewPubKey(req.Client)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid client pubkey")
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	indexStore := prefix.NewStore(store, []byte(k.GetClientContractPrefix(ctx, clientPubKey)))
+
+	var contracts []types.ClientContract
+	pageRes, err := query.Paginate(indexStore, req.Pagination, func(key, value []byte) error {
+		var id gogotypes.UInt64Value
+		if err := k.cdc.Unmarshal(value, &id); err != nil {
+			return err
+		}
+		contract, err := k.GetContract(ctx, id.Value)
+		if err != nil {
+			return err
+		}
+		if contract.IsEmpty() {
+			return nil
+		}
+		debt, err := calcContractDebt(contract, ctx.BlockHeight())
+		if err != nil {
+			return err
+		}
+		contracts = append(contracts, types.ClientContract{Contract: contract, Debt: debt})
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryClientContractsResponse{Contracts: contracts, Pagination: pageRes}, nil
+}
+
+func (k KVStore) ClientStats(goCtx context.Context, req *types.QueryClientStatsRequest) (*types.QueryClientStatsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	clientPubKey, err := common.NewPubKey(req.Client)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid client pubkey")
+	}
+
+	stats, err := k.GetClientStats(ctx, clientPubKey)
+	if err != nil {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+
+	return &types.QueryClientStatsResponse{Stats: stats}, nil
+}
+
+func (k KVStore) ContractTimeRemaining(goCtx context.Context, req *types.QueryContractTimeRemainingRequest) (*types.QueryContractTimeRemainingResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	providerPubKey, err := common.NewPubKey(req.Provider)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid provider pubkey")
+	}
+	service, err := common.NewService(req.Service)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid service")
+	}
+	clientPubKey, err := common.NewPubKey(req.Client)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid client pubkey")
+	}
+
+	contract, err := k.getLatestClientContract(ctx, clientPubKey, providerPubKey, service)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if contract.IsEmpty() {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	blocks, queries, err := calcContractTimeRemaining(contract, ctx.BlockHeight())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	mgr := NewManager(k, k.stakingKeeper)
+	avgBlockTime := mgr.FetchConfig(ctx, configs.AvgBlockTime)
+
+	return &types.QueryContractTimeRemainingResponse{
+		ContractId:       contract.Id,
+		Expired:          contract.IsExpired(ctx.BlockHeight()) || contract.SettlementHeight > 0,
+		Blocks:           blocks,
+		Seconds:          blocks * avgBlockTime / 1000,
+		RemainingQueries: queries,
+	}, nil
+}
+
+func (k KVStore) SettlementPreview(goCtx context.Context, req *types.QuerySettlementPreviewRequest) (*types.QuerySettlementPreviewResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	providerPubKey, err := common.NewPubKey(req.Provider)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid provider pubkey")
+	}
+	service, err := common.NewService(req.Service)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid service")
+	}
+	clientPubKey, err := common.NewPubKey(req.Client)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid client pubkey")
+	}
+
+	contract, err := k.getLatestClientContract(ctx, clientPubKey, providerPubKey, service)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if contract.IsEmpty() {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	// reject what a claim of contract income would reject
+	if contract.Nonce >= req.Nonce {
+		return nil, status.Errorf(codes.InvalidArgument, "contract nonce (%d) is greater than msg nonce (%d)", contract.Nonce, req.Nonce)
+	}
+	if contract.IsSettled(ctx.BlockHeight()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "settled on block: %d", contract.SettlementPeriodEnd())
+	}
+
+	contract.Nonce = req.Nonce
+	mgr := NewManager(k, k.stakingKeeper)
+	debt, err := mgr.contractDebt(ctx, contract)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	accrued, err := calcContractAccrued(contract, ctx.BlockHeight())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	tax := mgr.reserveTax(ctx, debt)
+
+	return &types.QuerySettlementPreviewResponse{
+		ContractId:     contract.Id,
+		ProviderPayout: debt.Sub(tax),
+		ReserveTax:     tax,
+		Capped:         accrued.Sub(contract.Paid).GT(debt),
+	}, nil
+}
+
+// getLatestClientContract returns the latest contract of the client with the
+// provider, open or not. The client index is ordered by contract id, so it is
+// walked backwards.
+func (k KVStore) getLatestClientContract(ctx sdk.Context, client, provider common.PubKey, service common.Service) (types.Contract, error) {
+	store := ctx.KVStore(k.storeKey)
+	indexStore := prefix.NewStore(store, []byte(k.GetClientContractPrefix(ctx, client)))
+	iterator := indexStore.ReverseIterator(nil, nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var id gogotypes.UInt64Value
+		if err := k.cdc.Unmarshal(iterator.Value(), &id); err != nil {
+			return types.Contract{}, err
+		}
+		contract, err := k.GetContract(ctx, id.Value)
+		if err != nil {
+			return types.Contract{}, err
+		}
+		if contract.Provider.Equals(provider) && contract.Service.Equals(service) {
+			return contract, nil
+		}
+	}
+	return types.Contract{}, nil
+}
+
+func (k KVStore) ContractEscrow(c context.Context, req *types.QueryContractEscrowRequest) (*types.QueryContractEscrowResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	escrow, err := k.GetContractEscrow(ctx, req.ContractId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if escrow.IsEmpty() {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	return &types.QueryContractEscrowResponse{Escrow: escrow}, nil
+}
+
+func (k KVStore) Disputes(c context.Context, req *types.QueryDisputesRequest) (*types.QueryDisputesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	store := ctx.KVStore(k.storeKey)
+	escrowStore := prefix.NewStore(store, types.KeyPrefix(prefixContractEscrow.String()))
+
+	var escrows []types.ContractEscrow
+	pageRes, err := query.FilteredPaginate(escrowStore, req.Pagination, func(key, value []byte, accumulate bool) (bool, error) {
+		var escrow types.ContractEscrow
+		if err := k.cdc.Unmarshal(value, &escrow); err != nil {
+			return false, err
+		}
+		if !escrow.Disputed {
+			return false, nil
+		}
+		if accumulate {
+			escrows = append(escrows, escrow)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryDisputesResponse{Escrows: escrows, Pagination: pageRes}, nil
+}
+
+// MaxExpiringContractsWindow is the most blocks ExpiringContracts looks ahead
+const MaxExpiringContractsWindow = 100_000
+
+func (k KVStore) ExpiringContracts(c context.Context, req *types.QueryExpiringContractsRequest) (*types.QueryExpiringContractsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	if req.Blocks <= 0 || req.Blocks > MaxExpiringContractsWindow {
+		return nil, status.Errorf(codes.InvalidArgument, "blocks must be between 1 and %d", MaxExpiringContractsWindow)
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	provider, err := common.NewPubKey(req.Provider)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid provider pubkey")
+	}
+	client, err := common.NewPubKey(req.Client)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid client pubkey")
+	}
+
+	contracts := make([]types.ExpiringContract, 0)
+	for height := ctx.BlockHeight(); height <= ctx.BlockHeight()+req.Blocks; height++ {
+		set, err := k.GetContractExpirationSet(ctx, height)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if set.ContractSet == nil {
+			continue
+		}
+		for _, id := range set.ContractSet.ContractIds {
+			contract, err := k.GetContract(ctx, id)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			if contract.IsEmpty() || contract.ClosingHeight() != height {
+				continue
+			}
+			if !provider.IsEmpty() && !contract.Provider.Equals(provider) {
+				continue
+			}
+			if !client.IsEmpty() && !contract.Client.Equals(client) {
+				continue
+			}
+			contracts = append(contracts, types.ExpiringContract{Contract: contract, Height: height})
+		}
+	}
+
+	return &types.QueryExpiringContractsResponse{Contracts: contracts}, nil
+}
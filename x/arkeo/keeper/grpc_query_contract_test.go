@@ -0,0 +1,330 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+func TestClientContracts(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	service := common.BTCService
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+	provider := types.NewProvider(providerPubKey, service)
+	provider.Bond = cosmos.NewInt(10000000000)
+	provider.Status = types.ProviderStatus_ONLINE
+	provider.MinContractDuration = 10
+	provider.MaxContractDuration = 500
+	provider.SubscriptionRate = rates
+	provider.PayAsYouGoRate = rates
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAddress, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAddress, getCoin(common.Tokens(10))))
+
+	msg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          service.String(),
+		Creator:          clientAddress,
+		Client:           clientPubKey,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         100,
+		Rate:             rates[0],
+		Deposit:          cosmos.NewInt(100 * 15),
+		QueriesPerMinute: 1,
+	}
+
+	// open, close, then reopen a contract with the same provider
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(20)
+	_, err = s.CloseContract(ctx, &types.MsgCloseContract{Creator: clientAddress, ContractId: 1})
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(21)
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+
+	// another client's contract
+	otherPubKey := types.GetRandomPubKey()
+	other := types.NewContract(providerPubKey, service, otherPubKey)
+	other.Id = 3
+	other.Height = 10
+	other.Duration = 100
+	other.Rate = rates[0]
+	other.Deposit = cosmos.NewInt(1500)
+	require.NoError(t, k.SetContract(ctx, other))
+
+	ctx = ctx.WithBlockHeight(31)
+	resp, err := k.ClientContracts(ctx, &types.QueryClientContractsRequest{Client: clientPubKey.String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Contracts, 2)
+
+	// the closed contract is settled, nothing is owed
+	require.Equal(t, uint64(1), resp.Contracts[0].Contract.Id)
+	require.True(t, resp.Contracts[0].Debt.IsZero())
+
+	// the reopened contract owes 10 blocks
+	require.Equal(t, uint64(2), resp.Contracts[1].Contract.Id)
+	require.Equal(t, int64(150), resp.Contracts[1].Debt.Int64())
+
+	// pagination
+	resp, err = k.ClientContracts(ctx, &types.QueryClientContractsRequest{
+		Client:     clientPubKey.String(),
+		Pagination: &query.PageRequest{Limit: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Contracts, 1)
+	require.Equal(t, uint64(1), resp.Contracts[0].Contract.Id)
+	resp, err = k.ClientContracts(ctx, &types.QueryClientContractsRequest{
+		Client:     clientPubKey.String(),
+		Pagination: &query.PageRequest{Key: resp.Pagination.NextKey, Limit: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Contracts, 1)
+	require.Equal(t, uint64(2), resp.Contracts[0].Contract.Id)
+
+	// the index is ordered by id, so the newest contracts can be paged first
+	resp, err = k.ClientContracts(ctx, &types.QueryClientContractsRequest{
+		Client:     clientPubKey.String(),
+		Pagination: &query.PageRequest{Limit: 1, Reverse: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Contracts, 1)
+	require.Equal(t, uint64(2), resp.Contracts[0].Contract.Id)
+
+	// removed contracts are dropped from the index
+	k.RemoveContract(ctx, 1)
+	resp, err = k.ClientContracts(ctx, &types.QueryClientContractsRequest{Client: clientPubKey.String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Contracts, 1)
+	require.Equal(t, uint64(2), resp.Contracts[0].Contract.Id)
+
+	_, err = k.ClientContracts(ctx, &types.QueryClientContractsRequest{Client: "bogus"})
+	require.Error(t, err)
+}
+
+func TestContractTimeRemaining(t *testing.T) {
+	ctx, k, _ := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(30)
+
+	providerPubKey := types.GetRandomPubKey()
+	clientPubKey := types.GetRandomPubKey()
+	service := common.BTCService
+	req := &types.QueryContractTimeRemainingRequest{
+		Provider: providerPubKey.String(),
+		Service:  service.String(),
+		Client:   clientPubKey.String(),
+	}
+
+	_, err := k.ContractTimeRemaining(ctx, req)
+	require.Error(t, err)
+
+	// a subscription with 80 of its 100 blocks left
+	contract := types.NewContract(providerPubKey, service, clientPubKey)
+	contract.Type = types.ContractType_SUBSCRIPTION
+	contract.Id = 1
+	contract.Height = 10
+	contract.Duration = 100
+	contract.Rate = getCoin(10)
+	contract.Deposit = cosmos.NewInt(1000)
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	resp, err := k.ContractTimeRemaining(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), resp.ContractId)
+	require.False(t, resp.Expired)
+	require.EqualValues(t, 80, resp.Blocks)
+	require.EqualValues(t, 480, resp.Seconds)
+	require.EqualValues(t, 0, resp.RemainingQueries)
+
+	// an underfunded subscription runs out of deposit before it expires
+	contract.Deposit = cosmos.NewInt(500)
+	require.NoError(t, k.SetContract(ctx, contract))
+	resp, err = k.ContractTimeRemaining(ctx, req)
+	require.NoError(t, err)
+	require.EqualValues(t, 30, resp.Blocks)
+
+	// past expiry there is nothing left, never a negative amount
+	resp, err = k.ContractTimeRemaining(ctx.WithBlockHeight(200), req)
+	require.NoError(t, err)
+	require.True(t, resp.Expired)
+	require.EqualValues(t, 0, resp.Blocks)
+	require.EqualValues(t, 0, resp.Seconds)
+
+	// a closed contract is expired
+	contract.Deposit = cosmos.NewInt(1000)
+	contract.SettlementHeight = 25
+	require.NoError(t, k.SetContract(ctx, contract))
+	resp, err = k.ContractTimeRemaining(ctx, req)
+	require.NoError(t, err)
+	require.True(t, resp.Expired)
+	require.EqualValues(t, 0, resp.Blocks)
+
+	// the latest contract with the provider is reported, pay-as-you-go
+	// contracts also report the queries their deposit pays for
+	paygo := types.NewContract(providerPubKey, service, clientPubKey)
+	paygo.Type = types.ContractType_PAY_AS_YOU_GO
+	paygo.Id = 2
+	paygo.Height = 25
+	paygo.Duration = 100
+	paygo.Rate = getCoin(10)
+	paygo.Deposit = cosmos.NewInt(1000)
+	paygo.Nonce = 40
+	require.NoError(t, k.SetContract(ctx, paygo))
+
+	resp, err = k.ContractTimeRemaining(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), resp.ContractId)
+	require.False(t, resp.Expired)
+	require.EqualValues(t, 95, resp.Blocks)
+	require.EqualValues(t, 570, resp.Seconds)
+	require.EqualValues(t, 60, resp.RemainingQueries)
+
+	// contracts with other providers are ignored
+	req.Provider = types.GetRandomPubKey().String()
+	_, err = k.ContractTimeRemaining(ctx, req)
+	require.Error(t, err)
+}
+
+func TestSettlementPreview(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAddress, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	clientPubKey := types.GetRandomPubKey()
+	service := common.BTCService
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(common.Tokens(10*100*2))))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(cosmos.NewInt(10*100))))
+
+	contract := types.NewContract(providerPubKey, service, clientPubKey)
+	contract.Type = types.ContractType_PAY_AS_YOU_GO
+	contract.Id = 1
+	contract.Height = 10
+	contract.Duration = 100
+	contract.Rate = getCoin(10)
+	contract.Deposit = cosmos.NewInt(1000)
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	req := &types.QuerySettlementPreviewRequest{
+		Provider: providerPubKey.String(),
+		Service:  service.String(),
+		Client:   clientPubKey.String(),
+		Nonce:    20,
+	}
+	resp, err := k.SettlementPreview(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, contract.Id, resp.ContractId)
+	require.Equal(t, int64(180), resp.ProviderPayout.Int64())
+	require.Equal(t, int64(20), resp.ReserveTax.Int64())
+	require.False(t, resp.Capped)
+
+	// nothing is settled by a preview
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, contract.Nonce)
+	require.True(t, contract.Paid.IsZero())
+
+	// the preview matches the settlement
+	require.NoError(t, s.ClaimContractIncomeHandle(ctx, &types.MsgClaimContractIncome{
+		ContractId: contract.Id,
+		Creator:    providerAddress,
+		Nonce:      req.Nonce,
+	}))
+	require.Equal(t, resp.ProviderPayout.Int64(), k.GetBalance(ctx, providerAddress).AmountOf(configs.Denom).Int64())
+
+	// nonces already claimed are rejected
+	_, err = k.SettlementPreview(ctx, req)
+	require.Error(t, err)
+	req.Nonce = 10
+	_, err = k.SettlementPreview(ctx, req)
+	require.Error(t, err)
+
+	// the payout is capped at what is left of the deposit
+	req.Nonce = 1000
+	resp, err = k.SettlementPreview(ctx, req)
+	require.NoError(t, err)
+	require.True(t, resp.Capped)
+	require.Equal(t, int64(720), resp.ProviderPayout.Int64())
+	require.Equal(t, int64(80), resp.ReserveTax.Int64())
+
+	req.Client = types.GetRandomPubKey().String()
+	_, err = k.SettlementPreview(ctx, req)
+	require.Error(t, err)
+}
+
+func TestExpiringContracts(t *testing.T) {
+	ctx, k := SetupKeeper(t)
+	ctx = ctx.WithBlockHeight(100)
+
+	providerPubKey := types.GetRandomPubKey()
+	clientPubKey := types.GetRandomPubKey()
+	for i, end := range []int64{105, 120, 160} {
+		contract := types.NewContract(providerPubKey, common.BTCService, clientPubKey)
+		contract.Id = uint64(i + 1)
+		contract.Type = types.ContractType_SUBSCRIPTION
+		contract.Height = end - 50
+		contract.Duration = 50
+		if i == 1 {
+			contract.Client = types.GetRandomPubKey()
+		}
+		require.NoError(t, k.SetContract(ctx, contract))
+		set, err := k.GetContractExpirationSet(ctx, end)
+		require.NoError(t, err)
+		set.Append(contract.Id)
+		require.NoError(t, k.SetContractExpirationSet(ctx, set))
+	}
+
+	// a contract in its grace period closes at the end of it
+	grace, err := k.GetContract(ctx, 3)
+	require.NoError(t, err)
+	grace.GraceEnd = 110
+	require.NoError(t, k.SetContract(ctx, grace))
+	set, err := k.GetContractExpirationSet(ctx, 110)
+	require.NoError(t, err)
+	set.Append(grace.Id)
+	require.NoError(t, k.SetContractExpirationSet(ctx, set))
+
+	resp, err := k.ExpiringContracts(ctx, &types.QueryExpiringContractsRequest{Blocks: 20})
+	require.NoError(t, err)
+	require.Len(t, resp.Contracts, 3)
+	require.Equal(t, uint64(1), resp.Contracts[0].Contract.Id)
+	require.Equal(t, int64(105), resp.Contracts[0].Height)
+	require.Equal(t, uint64(3), resp.Contracts[1].Contract.Id)
+	require.Equal(t, int64(110), resp.Contracts[1].Height)
+	require.Equal(t, uint64(2), resp.Contracts[2].Contract.Id)
+
+	// the contract left in the set of its old closing height is skipped
+	resp, err = k.ExpiringContracts(ctx, &types.QueryExpiringContractsRequest{Blocks: 100})
+	require.NoError(t, err)
+	require.Len(t, resp.Contracts, 3)
+
+	resp, err = k.ExpiringContracts(ctx, &types.QueryExpiringContractsRequest{Blocks: 20, Client: clientPubKey.String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Contracts, 2)
+
+	resp, err = k.ExpiringContracts(ctx, &types.QueryExpiringContractsRequest{Blocks: 20, Provider: types.GetRandomPubKey().String()})
+	require.NoError(t, err)
+	require.Empty(t, resp.Contracts)
+
+	_, err = k.ExpiringContracts(ctx, &types.QueryExpiringContractsRequest{Blocks: 0})
+	require.Error(t, err)
+	_, err = k.ExpiringContracts(ctx, &types.QueryExpiringContractsRequest{Blocks: MaxExpiringContractsWindow + 1})
+	require.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k KVStore) EmissionAPR(c context.Context, req *types.QueryEmissionAPRRequest) (*types.QueryEmissionAPRResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	mgr := NewManager(k, k.stakingKeeper)
+	valCycle := mgr.FetchConfig(ctx, configs.ValidatorPayoutCycle)
+	blockReward := mgr.NextBlockReward(ctx)
+	annualEmission := cosmos.ZeroInt()
+	if valCycle > 0 {
+		annualEmission = blockReward.MulRaw(mgr.FetchConfig(ctx, configs.BlocksPerYear) / valCycle)
+	}
+	return &types.QueryEmissionAPRResponse{
+		Apr:            mgr.EmissionAPR(ctx),
+		BlockReward:    blockReward,
+		PayoutCycle:    valCycle,
+		AnnualEmission: annualEmission,
+		Reserve:        k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom),
+		BondedTokens:   k.stakingKeeper.TotalBondedTokens(ctx),
+	}, nil
+}
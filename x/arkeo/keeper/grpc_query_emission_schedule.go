@@ -0,0 +1,32 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k KVStore) EmissionSchedule(c context.Context, req *types.QueryEmissionScheduleRequest) (*types.QueryEmissionScheduleResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	schedule, err := k.GetEmissionSchedule(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	mgr := NewManager(k, k.stakingKeeper)
+	curve, halvings := mgr.emissionAt(ctx, ctx.BlockHeight())
+	return &types.QueryEmissionScheduleResponse{
+		Epochs:        schedule.Epochs,
+		EmissionCurve: curve,
+		Halvings:      halvings,
+		BlockReward:   mgr.NextBlockReward(ctx),
+	}, nil
+}
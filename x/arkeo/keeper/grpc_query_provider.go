@@ -2,8 +2,10 @@ package keeper
 
 import (
 	"context"
+	"strings"
 
 	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
 
 	"github.com/cosmos/cosmos-sdk/store/prefix"
@@ -21,17 +23,35 @@ func (k KVStore) ProviderAll(c context.Context, req *types.QueryAllProviderReque
 	var providers []types.Provider
 	ctx := sdk.UnwrapSDKContext(c)
 
+	// a provider bonds each of the services it offers on a chain separately,
+	// its services are listed together under its pubkey
+	keyPrefix := types.KeyPrefix(prefixProvider.String())
+	if req.Pubkey != "" {
+		pk, err := common.NewPubKey(req.Pubkey)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid pubkey")
+		}
+		keyPrefix = []byte(k.GetKey(ctx, prefixProvider, pk.String()+"/"))
+	}
+
 	store := ctx.KVStore(k.storeKey)
-	providerStore := prefix.NewStore(store, types.KeyPrefix(prefixProvider.String()))
+	providerStore := prefix.NewStore(store, keyPrefix)
 
-	pageRes, err := query.Paginate(providerStore, req.Pagination, func(key, value []byte) error {
+	pageRes, err := query.FilteredPaginate(providerStore, req.Pagination, func(key, value []byte, accumulate bool) (bool, error) {
 		var provider types.Provider
 		if err := k.cdc.Unmarshal(value, &provider); err != nil {
-			return err
+			return false, err
 		}
-
-		providers = append(providers, provider)
-		return nil
+		if req.Chain != "" && !strings.EqualFold(provider.Service.Chain(), req.Chain) {
+			return false, nil
+		}
+		if req.ServiceType != "" && !strings.EqualFold(provider.Service.Type(), req.ServiceType) {
+			return false, nil
+		}
+		if accumulate {
+			providers = append(providers, provider)
+		}
+		return true, nil
 	})
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -67,3 +87,195 @@ func (k KVStore) FetchProvider(c context.Context, req *types.QueryFetchProviderR
 
 	return &types.QueryFetchProviderResponse{Provider: val}, nil
 }
+
+func (k KVStore) ProviderById(c context.Context, req *types.QueryProviderByIdRequest) (*types.QueryProviderByIdResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	provider, err := k.GetProviderById(ctx, req.Id)
+	if err != nil {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if provider.PubKey.IsEmpty() {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	return &types.QueryProviderByIdResponse{Provider: provider}, nil
+}
+
+func (k KVStore) ProviderMetadata(c context.Context, req *types.QueryProviderMetadataRequest) (*types.QueryProviderMetadataResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	pk, err := common.NewPubKey(req.Pubkey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid pubkey")
+	}
+
+	service, err := common.NewService(req.Service)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid service")
+	}
+
+	provider, err := k.GetProvider(ctx, pk, service)
+	if err != nil {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+
+	if provider.MetadataUri == "" {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	return &types.QueryProviderMetadataResponse{
+		MetadataUri:   provider.MetadataUri,
+		MetadataHash:  provider.MetadataHash,
+		MetadataNonce: provider.MetadataNonce,
+	}, nil
+}
+
+func (k KVStore) ProviderUptime(c context.Context, req *types.QueryProviderUptimeRequest) (*types.QueryProviderUptimeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	pk, err := common.NewPubKey(req.Pubkey)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "pubkey not found")
+	}
+
+	service, err := common.NewService(req.Service)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "service not found")
+	}
+
+	uptime, err := k.GetProviderUptime(ctx, pk, service)
+	if err != nil {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+
+	mgr := NewManager(k, k.stakingKeeper)
+	score := uptime.Score(mgr.FetchConfig(ctx, configs.MinUptimeObservations))
+	return &types.QueryProviderUptimeResponse{Uptime: uptime, Score: score}, nil
+}
+
+func (k KVStore) ProviderStats(c context.Context, req *types.QueryProviderStatsRequest) (*types.QueryProviderStatsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	pk, err := common.NewPubKey(req.Pubkey)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "pubkey not found")
+	}
+
+	service, err := common.NewService(req.Service)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "service not found")
+	}
+
+	stats, err := k.GetProviderStats(ctx, pk, service)
+	if err != nil {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+
+	return &types.QueryProviderStatsResponse{Stats: stats, Score: stats.Score()}, nil
+}
+
+func (k KVStore) ProviderEarnings(c context.Context, req *types.QueryProviderEarningsRequest) (*types.QueryProviderEarningsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	pk, err := common.NewPubKey(req.Pubkey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid pubkey")
+	}
+
+	var service common.Service
+	if req.Service != "" {
+		service, err = common.NewService(req.Service)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid service")
+		}
+	}
+
+	if req.FromHeight < 0 || req.ToHeight < 0 || (req.ToHeight > 0 && req.ToHeight < req.FromHeight) {
+		return nil, status.Error(codes.InvalidArgument, "invalid block range")
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	earningStore := prefix.NewStore(store, []byte(k.GetProviderEarningPrefix(ctx, pk)))
+
+	var earnings []types.ProviderEarning
+	pageRes, err := query.FilteredPaginate(earningStore, req.Pagination, func(key, value []byte, accumulate bool) (bool, error) {
+		var earning types.ProviderEarning
+		if err := k.cdc.Unmarshal(value, &earning); err != nil {
+			return false, err
+		}
+		if req.Service != "" && !earning.Service.Equals(service) {
+			return false, nil
+		}
+		if earning.Height < req.FromHeight || (req.ToHeight > 0 && earning.Height > req.ToHeight) {
+			return false, nil
+		}
+		if accumulate {
+			earnings = append(earnings, earning)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryProviderEarningsResponse{Earnings: earnings, Pagination: pageRes}, nil
+}
+
+func (k KVStore) ProviderSlashes(c context.Context, req *types.QueryProviderSlashesRequest) (*types.QueryProviderSlashesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	pk, err := common.NewPubKey(req.Pubkey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid pubkey")
+	}
+
+	var service common.Service
+	if req.Service != "" {
+		service, err = common.NewService(req.Service)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid service")
+		}
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	slashStore := prefix.NewStore(store, []byte(k.GetProviderSlashPrefix(ctx, pk)))
+
+	var slashes []types.ProviderSlash
+	pageRes, err := query.FilteredPaginate(slashStore, req.Pagination, func(key, value []byte, accumulate bool) (bool, error) {
+		var slash types.ProviderSlash
+		if err := k.cdc.Unmarshal(value, &slash); err != nil {
+			return false, err
+		}
+		if req.Service != "" && !slash.Service.Equals(service) {
+			return false, nil
+		}
+		if accumulate {
+			slashes = append(slashes, slash)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryProviderSlashesResponse{Slashes: slashes, Pagination: pageRes}, nil
+}
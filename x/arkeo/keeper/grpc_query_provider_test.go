@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+func TestProviderEarnings(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	mgr := NewManager(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(2000)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(cosmos.NewInt(2000))))
+
+	newContract := func(id uint64, service common.Service) types.Contract {
+		contract := types.NewContract(providerPubKey, service, types.GetRandomPubKey())
+		contract.Type = types.ContractType_SUBSCRIPTION
+		contract.Id = id
+		contract.Height = 10
+		contract.Duration = 100
+		contract.Rate = getCoin(10)
+		contract.Deposit = cosmos.NewInt(1000)
+		require.NoError(t, k.SetContract(ctx, contract))
+		return contract
+	}
+	btc := newContract(1, common.BTCService)
+	eth := newContract(2, common.ETHService)
+
+	var err error
+	btc, err = mgr.SettleContract(ctx.WithBlockHeight(20), btc, 0, false)
+	require.NoError(t, err)
+	btc, err = mgr.SettleContract(ctx.WithBlockHeight(30), btc, 0, false)
+	require.NoError(t, err)
+	_, err = mgr.SettleContract(ctx.WithBlockHeight(30), eth, 0, false)
+	require.NoError(t, err)
+
+	// each settlement is recorded net of the reserve tax
+	resp, err := k.ProviderEarnings(ctx, &types.QueryProviderEarningsRequest{Pubkey: providerPubKey.String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Earnings, 3)
+	require.EqualValues(t, 20, resp.Earnings[0].Height)
+	require.Equal(t, int64(90), resp.Earnings[0].Amount.Amount.Int64())
+	require.Equal(t, int64(10), resp.Earnings[0].ReserveTax.Amount.Int64())
+	require.Equal(t, configs.Denom, resp.Earnings[0].Amount.Denom)
+
+	// by service
+	resp, err = k.ProviderEarnings(ctx, &types.QueryProviderEarningsRequest{
+		Pubkey:  providerPubKey.String(),
+		Service: common.ETHService.String(),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Earnings, 1)
+	require.Equal(t, eth.Id, resp.Earnings[0].ContractId)
+
+	// by block range
+	resp, err = k.ProviderEarnings(ctx, &types.QueryProviderEarningsRequest{
+		Pubkey:     providerPubKey.String(),
+		FromHeight: 25,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Earnings, 2)
+	resp, err = k.ProviderEarnings(ctx, &types.QueryProviderEarningsRequest{
+		Pubkey:   providerPubKey.String(),
+		ToHeight: 20,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Earnings, 1)
+
+	// pagination applies after the filters
+	resp, err = k.ProviderEarnings(ctx, &types.QueryProviderEarningsRequest{
+		Pubkey:     providerPubKey.String(),
+		Service:    common.BTCService.String(),
+		Pagination: &query.PageRequest{Limit: 1, Reverse: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Earnings, 1)
+	require.EqualValues(t, 30, resp.Earnings[0].Height)
+
+	_, err = k.ProviderEarnings(ctx, &types.QueryProviderEarningsRequest{
+		Pubkey:     providerPubKey.String(),
+		FromHeight: 30,
+		ToHeight:   20,
+	})
+	require.Error(t, err)
+	_, err = k.ProviderEarnings(ctx, &types.QueryProviderEarningsRequest{Pubkey: "bogus"})
+	require.Error(t, err)
+
+	// records older than the history are dropped as new ones are written
+	k.SetConfigOverride(ctx, configs.ProviderEarningsHistory, 5)
+	_, err = mgr.SettleContract(ctx.WithBlockHeight(40), btc, 0, false)
+	require.NoError(t, err)
+	resp, err = k.ProviderEarnings(ctx, &types.QueryProviderEarningsRequest{Pubkey: providerPubKey.String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Earnings, 1)
+	require.EqualValues(t, 40, resp.Earnings[0].Height)
+}
+
+func TestProviderAllFilters(t *testing.T) {
+	ctx, k, _ := SetupKeeperWithStaking(t)
+
+	ethArchive, err := common.NewService("eth-mainnet-archivenode")
+	require.NoError(t, err)
+	ethWebsocket, err := common.NewService("eth-mainnet-websocket")
+	require.NoError(t, err)
+
+	// one provider offers several services on eth, each with its own rates
+	pubkey := types.GetRandomPubKey()
+	for i, service := range []common.Service{common.ETHService, ethArchive, ethWebsocket, common.BTCService} {
+		provider := types.NewProvider(pubkey, service)
+		provider.Bond = cosmos.NewInt(100)
+		provider.SubscriptionRate = cosmos.NewCoins(getCoin(int64(10 * (i + 1))))
+		require.NoError(t, k.SetProvider(ctx, provider))
+	}
+	other := types.NewProvider(types.GetRandomPubKey(), ethArchive)
+	other.Bond = cosmos.NewInt(100)
+	require.NoError(t, k.SetProvider(ctx, other))
+
+	list := func(req *types.QueryAllProviderRequest) []types.Provider {
+		res, err := k.ProviderAll(ctx, req)
+		require.NoError(t, err)
+		return res.Provider
+	}
+	require.Len(t, list(&types.QueryAllProviderRequest{}), 5)
+	require.Len(t, list(&types.QueryAllProviderRequest{Pubkey: pubkey.String()}), 4)
+	require.Len(t, list(&types.QueryAllProviderRequest{Pubkey: pubkey.String(), Chain: "eth"}), 3)
+	require.Len(t, list(&types.QueryAllProviderRequest{Chain: "eth", ServiceType: "archivenode"}), 2)
+
+	providers := list(&types.QueryAllProviderRequest{Pubkey: pubkey.String(), ServiceType: "websocket"})
+	require.Len(t, providers, 1)
+	require.Equal(t, ethWebsocket, providers[0].Service)
+	require.Equal(t, int64(30), providers[0].SubscriptionRate[0].Amount.Int64())
+
+	_, err = k.ProviderAll(ctx, &types.QueryAllProviderRequest{Pubkey: "bogus"})
+	require.Error(t, err)
+}
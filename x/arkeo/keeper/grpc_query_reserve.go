@@ -0,0 +1,40 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k KVStore) ReserveHistory(c context.Context, req *types.QueryReserveHistoryRequest) (*types.QueryReserveHistoryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	snapshots := make([]types.ReserveSnapshot, 0)
+	iter := k.GetReserveSnapshotIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var snapshot types.ReserveSnapshot
+		if err := k.cdc.Unmarshal(iter.Value(), &snapshot); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	// if the history length was shortened, the older snapshots are only
+	// pruned on the next payout cycle
+	mgr := NewManager(k, k.stakingKeeper)
+	length := mgr.FetchConfig(ctx, configs.ReserveHistoryLength)
+	if int64(len(snapshots)) > length {
+		snapshots = snapshots[int64(len(snapshots))-length:]
+	}
+
+	return &types.QueryReserveHistoryResponse{Snapshots: snapshots}, nil
+}
@@ -0,0 +1,34 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k KVStore) RewardVesting(c context.Context, req *types.QueryRewardVestingRequest) (*types.QueryRewardVestingResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	vesting, err := k.GetRewardVesting(ctx, addr)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	vested := vesting.Vested(ctx.BlockHeight())
+	return &types.QueryRewardVestingResponse{
+		Vesting: vesting,
+		Vested:  vested,
+		Pending: vesting.Unvested.Sub(vested...),
+	}, nil
+}
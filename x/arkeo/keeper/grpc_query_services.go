@@ -0,0 +1,31 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k KVStore) Services(c context.Context, req *types.QueryServicesRequest) (*types.QueryServicesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+
+	services := make([]types.ServiceInfo, 0)
+	iter := k.GetServiceInfoIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var info types.ServiceInfo
+		if err := k.cdc.Unmarshal(iter.Value(), &info); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		services = append(services, info)
+	}
+
+	return &types.QueryServicesResponse{Services: services}, nil
+}
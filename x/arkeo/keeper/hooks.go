@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// Hooks wrapper struct. The stake of a validator is not final until the
+// staking keeper is done with it, so the hooks only mark the validator's bond
+// units as pending, they are reassigned before the next validator payout.
+type Hooks struct {
+	k KVStore
+}
+
+// Return the wrapper struct
+func (k KVStore) Hooks() Hooks {
+	return Hooks{k}
+}
+
+var _ stakingtypes.StakingHooks = Hooks{}
+
+// staking hooks
+func (h Hooks) AfterValidatorCreated(ctx sdk.Context, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+func (h Hooks) BeforeValidatorModified(ctx sdk.Context, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+func (h Hooks) AfterValidatorRemoved(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) error {
+	h.k.SetBondUnitsPending(ctx, valAddr)
+	return nil
+}
+
+func (h Hooks) AfterValidatorBonded(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) error {
+	h.k.SetBondUnitsPending(ctx, valAddr)
+	return nil
+}
+
+func (h Hooks) AfterValidatorBeginUnbonding(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) error {
+	h.k.SetBondUnitsPending(ctx, valAddr)
+	return nil
+}
+
+func (h Hooks) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+func (h Hooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+func (h Hooks) BeforeDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	h.k.SetBondUnitsPending(ctx, valAddr)
+	return nil
+}
+
+func (h Hooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	h.k.SetBondUnitsPending(ctx, valAddr)
+	return nil
+}
+
+// validators are slashed before they are jailed, which takes them out of the
+// rewards
+func (h Hooks) BeforeValidatorSlashed(ctx sdk.Context, valAddr sdk.ValAddress, fraction sdk.Dec) error {
+	h.k.SetBondUnitsPending(ctx, valAddr)
+	return nil
+}
@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInvariants registers the arkeo module invariants, so they can be
+// asserted by the crisis module and the simulator
+func RegisterInvariants(ir sdk.InvariantRegistry, mgr Manager) {
+	ir.RegisterRoute(types.ModuleName, "bond-module", BondModuleInvariant(mgr))
+	ir.RegisterRoute(types.ModuleName, "contract-module", ContractModuleInvariant(mgr))
+	ir.RegisterRoute(types.ModuleName, "vesting-module", VestingModuleInvariant(mgr))
+	ir.RegisterRoute(types.ModuleName, "max-supply", MaxSupplyInvariant(mgr))
+}
+
+// AllInvariants runs all invariants of the arkeo module
+func AllInvariants(mgr Manager) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		for _, inv := range []sdk.Invariant{
+			BondModuleInvariant(mgr),
+			ContractModuleInvariant(mgr),
+			VestingModuleInvariant(mgr),
+			MaxSupplyInvariant(mgr),
+		} {
+			if msg, broken := inv(ctx); broken {
+				return msg, broken
+			}
+		}
+		return "", false
+	}
+}
+
+// BondModuleInvariant checks the provider module holds enough to back all
+// provider bonds
+func BondModuleInvariant(mgr Manager) sdk.Invariant {
+	return newInvariant("bond-module", mgr.invariantBondModule)
+}
+
+// ContractModuleInvariant checks the contract module holds enough to back
+// the outstanding deposits of all unsettled contracts and the income held in
+// escrow, and that no contract paid out more than its deposit
+func ContractModuleInvariant(mgr Manager) sdk.Invariant {
+	return newInvariant("contract-module", mgr.invariantContractModule)
+}
+
+// VestingModuleInvariant checks the vesting module holds enough to back the
+// validator and delegator rewards still vesting
+func VestingModuleInvariant(mgr Manager) sdk.Invariant {
+	return newInvariant("vesting-module", mgr.invariantVestingModule)
+}
+
+// MaxSupplyInvariant checks the supply has not surpassed the max supply
+func MaxSupplyInvariant(mgr Manager) sdk.Invariant {
+	return newInvariant("max-supply", mgr.invariantMaxSupply)
+}
+
+func newInvariant(route string, check func(sdk.Context) error) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		msg := "invariant holds"
+		err := check(ctx)
+		if err != nil {
+			msg = fmt.Sprintf("invariant broken: %s", err)
+		}
+		return sdk.FormatInvariant(types.ModuleName, route, msg), err != nil
+	}
+}
@@ -0,0 +1,33 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllInvariants(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	mgr := NewManager(k, sk)
+
+	_, broken := AllInvariants(mgr)(ctx)
+	require.False(t, broken)
+
+	// a provider bond that is not backed by the provider module
+	provider := types.NewProvider(types.GetRandomPubKey(), common.BTCService)
+	provider.Bond = cosmos.NewInt(500)
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	_, broken = BondModuleInvariant(mgr)(ctx)
+	require.True(t, broken)
+	_, broken = AllInvariants(mgr)(ctx)
+	require.True(t, broken)
+
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(500)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ProviderName, getCoins(cosmos.NewInt(500))))
+	_, broken = AllInvariants(mgr)(ctx)
+	require.False(t, broken)
+}
@@ -12,6 +12,7 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
@@ -34,6 +35,8 @@ type Keeper interface {
 	GetParams(ctx sdk.Context) types.Params
 	SetParams(ctx sdk.Context, params types.Params)
 	Cdc() codec.BinaryCodec
+	GetAuthority() string
+	ContractHooks() types.ContractHooks
 	GetComputedVersion(ctx cosmos.Context) int64
 	GetVersion(ctx cosmos.Context) int64
 	SetVersion(ctx cosmos.Context, ver int64)
@@ -58,26 +61,75 @@ type Keeper interface {
 	GetActiveValidators(ctx cosmos.Context) []stakingtypes.Validator
 	GetAccount(ctx cosmos.Context, addr cosmos.AccAddress) cosmos.Account
 	StakingSetParams(ctx cosmos.Context, params stakingtypes.Params)
+	FundCommunityPool(ctx cosmos.Context, amount cosmos.Coins, sender cosmos.AccAddress) error
 
 	// Query
 	Params(c context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error)
 	FetchProvider(c context.Context, req *types.QueryFetchProviderRequest) (*types.QueryFetchProviderResponse, error)
+	ProviderById(c context.Context, req *types.QueryProviderByIdRequest) (*types.QueryProviderByIdResponse, error)
 	ProviderAll(c context.Context, req *types.QueryAllProviderRequest) (*types.QueryAllProviderResponse, error)
+	ProviderStats(c context.Context, req *types.QueryProviderStatsRequest) (*types.QueryProviderStatsResponse, error)
+	ProviderUptime(c context.Context, req *types.QueryProviderUptimeRequest) (*types.QueryProviderUptimeResponse, error)
+	ProviderEarnings(c context.Context, req *types.QueryProviderEarningsRequest) (*types.QueryProviderEarningsResponse, error)
+	ProviderSlashes(c context.Context, req *types.QueryProviderSlashesRequest) (*types.QueryProviderSlashesResponse, error)
+	ProviderMetadata(c context.Context, req *types.QueryProviderMetadataRequest) (*types.QueryProviderMetadataResponse, error)
 	FetchContract(c context.Context, req *types.QueryFetchContractRequest) (*types.QueryFetchContractResponse, error)
+	ContractEscrow(c context.Context, req *types.QueryContractEscrowRequest) (*types.QueryContractEscrowResponse, error)
+	Disputes(c context.Context, req *types.QueryDisputesRequest) (*types.QueryDisputesResponse, error)
 	ContractAll(c context.Context, req *types.QueryAllContractRequest) (*types.QueryAllContractResponse, error)
 	ActiveContract(goCtx context.Context, req *types.QueryActiveContractRequest) (*types.QueryActiveContractResponse, error)
+	ClientContracts(goCtx context.Context, req *types.QueryClientContractsRequest) (*types.QueryClientContractsResponse, error)
+	ClientStats(goCtx context.Context, req *types.QueryClientStatsRequest) (*types.QueryClientStatsResponse, error)
+	ContractTimeRemaining(goCtx context.Context, req *types.QueryContractTimeRemainingRequest) (*types.QueryContractTimeRemainingResponse, error)
+	SettlementPreview(goCtx context.Context, req *types.QuerySettlementPreviewRequest) (*types.QuerySettlementPreviewResponse, error)
+	ReserveHistory(goCtx context.Context, req *types.QueryReserveHistoryRequest) (*types.QueryReserveHistoryResponse, error)
+	EmissionAPR(goCtx context.Context, req *types.QueryEmissionAPRRequest) (*types.QueryEmissionAPRResponse, error)
+	Configs(goCtx context.Context, req *types.QueryConfigsRequest) (*types.QueryConfigsResponse, error)
+	Services(goCtx context.Context, req *types.QueryServicesRequest) (*types.QueryServicesResponse, error)
 
 	// Keeper Interfaces
 	KeeperProvider
 	KeeperContract
+	KeeperReserve
+	KeeperConfig
 }
 
 type KeeperProvider interface {
 	GetProviderIterator(_ cosmos.Context) cosmos.Iterator
 	GetProvider(_ cosmos.Context, _ common.PubKey, _ common.Service) (types.Provider, error)
 	SetProvider(_ cosmos.Context, _ types.Provider) error
+	GetProviderById(_ cosmos.Context, _ uint64) (types.Provider, error)
+	GetNextProviderId(_ cosmos.Context) uint64
+	SetNextProviderId(_ cosmos.Context, _ uint64)
 	ProviderExists(_ cosmos.Context, _ common.PubKey, _ common.Service) bool
 	RemoveProvider(_ cosmos.Context, _ common.PubKey, _ common.Service)
+	GetAllowedProviderIterator(_ cosmos.Context) cosmos.Iterator
+	IsAllowedProvider(_ cosmos.Context, _ common.PubKey) bool
+	AddAllowedProvider(_ cosmos.Context, _ common.PubKey) error
+	GetServiceInfoIterator(_ cosmos.Context) cosmos.Iterator
+	GetServiceInfo(_ cosmos.Context, _ common.Service) (types.ServiceInfo, error)
+	SetServiceInfo(_ cosmos.Context, _ types.ServiceInfo) error
+	ServiceInfoExists(_ cosmos.Context, _ common.Service) bool
+	RemoveServiceInfo(_ cosmos.Context, _ common.Service)
+	GetEmissionSchedule(_ cosmos.Context) (types.EmissionSchedule, error)
+	SetEmissionSchedule(_ cosmos.Context, _ types.EmissionSchedule)
+	GetRewardVestingIterator(_ cosmos.Context) cosmos.Iterator
+	GetRewardVesting(_ cosmos.Context, _ cosmos.AccAddress) (types.RewardVesting, error)
+	SetRewardVesting(_ cosmos.Context, _ types.RewardVesting) error
+	RemoveAllowedProvider(_ cosmos.Context, _ common.PubKey)
+	GetProviderStatsIterator(_ cosmos.Context) cosmos.Iterator
+	GetProviderStats(_ cosmos.Context, _ common.PubKey, _ common.Service) (types.ProviderStats, error)
+	SetProviderStats(_ cosmos.Context, _ types.ProviderStats) error
+	GetProviderUptimeIterator(_ cosmos.Context) cosmos.Iterator
+	GetProviderUptime(_ cosmos.Context, _ common.PubKey, _ common.Service) (types.ProviderUptime, error)
+	SetProviderUptime(_ cosmos.Context, _ types.ProviderUptime) error
+	GetProviderEarningIterator(_ cosmos.Context) cosmos.Iterator
+	GetProviderEarning(_ cosmos.Context, _ common.PubKey, height int64, contractId uint64) (types.ProviderEarning, bool, error)
+	SetProviderEarning(_ cosmos.Context, _ types.ProviderEarning) error
+	PruneProviderEarnings(_ cosmos.Context, _ common.PubKey, before int64)
+	GetProviderSlashIterator(_ cosmos.Context) cosmos.Iterator
+	NextProviderSlashSequence(_ cosmos.Context, _ common.PubKey, height int64) uint64
+	SetProviderSlash(_ cosmos.Context, _ types.ProviderSlash) error
 }
 
 type KeeperContract interface {
@@ -86,11 +138,21 @@ type KeeperContract interface {
 	SetContract(_ cosmos.Context, _ types.Contract) error
 	ContractExists(_ cosmos.Context, _ uint64) bool
 	RemoveContract(_ cosmos.Context, _ uint64)
-	GetContractExpirationSetIterator(_ cosmos.Context) cosmos.Iterator
+	GetClosedContractIterator(_ cosmos.Context) cosmos.Iterator
+	GetLegacyContractExpirationSetIterator(_ cosmos.Context) cosmos.Iterator
+	RemoveLegacyContractExpirationSet(_ cosmos.Context, _ int64)
 	GetUserContractSetIterator(_ cosmos.Context) cosmos.Iterator
+	GetContractExpirationIterator(_ cosmos.Context, _ int64) cosmos.Iterator
+	AddContractExpiration(_ cosmos.Context, _ int64, _ uint64) error
+	RemoveContractExpiration(_ cosmos.Context, _ int64, _ uint64)
 	GetContractExpirationSet(_ cosmos.Context, _ int64) (types.ContractExpirationSet, error)
 	SetContractExpirationSet(_ cosmos.Context, _ types.ContractExpirationSet) error
 	RemoveContractExpirationSet(_ cosmos.Context, _ int64)
+	GetContractExpirationSets(_ cosmos.Context) ([]types.ContractExpirationSet, error)
+	GetContractSettlementSetIterator(_ cosmos.Context) cosmos.Iterator
+	GetContractSettlementSet(_ cosmos.Context, _ int64) (types.ContractExpirationSet, error)
+	SetContractSettlementSet(_ cosmos.Context, _ types.ContractExpirationSet) error
+	RemoveContractSettlementSet(_ cosmos.Context, _ int64)
 	RemoveFromUserContractSet(ctx cosmos.Context, user common.PubKey, contractId uint64) error
 	GetNextContractId(_ cosmos.Context) uint64
 	SetNextContractId(ctx cosmos.Context, contractId uint64)
@@ -98,6 +160,45 @@ type KeeperContract interface {
 	SetUserContractSet(ctx cosmos.Context, contractSet types.UserContractSet) error
 	GetUserContractSet(ctx cosmos.Context, pubkey common.PubKey) (types.UserContractSet, error)
 	GetActiveContractForUser(ctx cosmos.Context, user, provider common.PubKey, service common.Service) (types.Contract, error)
+	SumClientDeposits(ctx cosmos.Context, client common.PubKey) (cosmos.Coins, error)
+	CountOpenProviderContracts(ctx cosmos.Context, provider common.PubKey, service common.Service) (int64, error)
+	GetAllowedDenomIterator(_ cosmos.Context) cosmos.Iterator
+	IsAllowedDenom(_ cosmos.Context, _ string) bool
+	AddAllowedDenom(_ cosmos.Context, _ string) error
+	RemoveAllowedDenom(_ cosmos.Context, _ string)
+	GetContractEscrowIterator(_ cosmos.Context) cosmos.Iterator
+	GetEscrowReleaseIterator(_ cosmos.Context) cosmos.Iterator
+	GetContractEscrow(_ cosmos.Context, _ uint64) (types.ContractEscrow, error)
+	SetContractEscrow(_ cosmos.Context, _ types.ContractEscrow) error
+	RemoveContractEscrow(_ cosmos.Context, _ uint64)
+	GetArbiterIterator(_ cosmos.Context) cosmos.Iterator
+	IsArbiter(_ cosmos.Context, _ cosmos.AccAddress) bool
+	AddArbiter(_ cosmos.Context, _ cosmos.AccAddress) error
+	RemoveArbiter(_ cosmos.Context, _ cosmos.AccAddress)
+	GetClientStatsIterator(_ cosmos.Context) cosmos.Iterator
+	GetClientStats(_ cosmos.Context, _ common.PubKey) (types.ClientStats, error)
+	SetClientStats(_ cosmos.Context, _ types.ClientStats) error
+}
+
+type KeeperReserve interface {
+	GetReserveSnapshotIterator(_ cosmos.Context) cosmos.Iterator
+	SetReserveSnapshot(_ cosmos.Context, _ types.ReserveSnapshot) error
+	RemoveReserveSnapshot(_ cosmos.Context, height int64)
+	GetBondUnitsIterator(_ cosmos.Context) cosmos.Iterator
+	GetBondUnits(_ cosmos.Context, _ cosmos.ValAddress) (types.BondUnits, error)
+	SetBondUnits(_ cosmos.Context, _ types.BondUnits) error
+	GetTotalBondUnits(_ cosmos.Context) (types.BondUnits, bool, error)
+	SetTotalBondUnits(_ cosmos.Context, _ types.BondUnits)
+	GetBondUnitsPendingIterator(_ cosmos.Context) cosmos.Iterator
+	SetBondUnitsPending(_ cosmos.Context, _ cosmos.ValAddress)
+	RemoveBondUnitsPending(_ cosmos.Context, _ cosmos.ValAddress)
+}
+
+type KeeperConfig interface {
+	GetConfigValues(_ cosmos.Context) configs.ConfigValues
+	GetConfigOverrideIterator(_ cosmos.Context) cosmos.Iterator
+	GetConfigOverride(_ cosmos.Context, _ configs.ConfigName) (int64, bool)
+	SetConfigOverride(_ cosmos.Context, _ configs.ConfigName, _ int64)
 }
 
 const (
@@ -105,8 +206,33 @@ const (
 	prefixProvider              dbPrefix = "p/"
 	prefixContract              dbPrefix = "c/"
 	prefixContractNextId        dbPrefix = "cni/"
-	prefixContractExpirationSet dbPrefix = "ces/"
+	prefixContractExpirationSet dbPrefix = "ces/" // legacy, one record per height
 	prefixUserContractSet       dbPrefix = "ucs/"
+	prefixReserveSnapshot       dbPrefix = "rs/"
+	prefixConfigOverride        dbPrefix = "co/"
+	prefixAllowedProvider       dbPrefix = "ap/"
+	prefixClientContract        dbPrefix = "cc/"
+	prefixProviderStats         dbPrefix = "ps/"
+	prefixBondUnits             dbPrefix = "bu/"
+	prefixBondUnitsTotal        dbPrefix = "tbu/"
+	prefixBondUnitsPending      dbPrefix = "pbu/"
+	prefixAllowedDenom          dbPrefix = "ad/"
+	prefixProviderEarning       dbPrefix = "pe/"
+	prefixProviderSlash         dbPrefix = "psl/"
+	prefixContractEscrow        dbPrefix = "esc/"
+	prefixEscrowRelease         dbPrefix = "escr/"
+	prefixArbiter               dbPrefix = "arb/"
+	prefixContractSettlementSet dbPrefix = "css/"
+	prefixServiceInfo           dbPrefix = "svc/"
+	prefixClosedContract        dbPrefix = "ccl/"
+	prefixEmissionSchedule      dbPrefix = "ems/"
+	prefixRewardVesting         dbPrefix = "rv/"
+	prefixClientStats           dbPrefix = "cst/"
+	prefixProviderUptime        dbPrefix = "pu/"
+	prefixProviderContract      dbPrefix = "pcn/"
+	prefixProviderNextId        dbPrefix = "pni/"
+	prefixProviderId            dbPrefix = "pid/"
+	prefixContractExpiration    dbPrefix = "cex/"
 )
 
 type KVStore struct {
@@ -117,6 +243,10 @@ type KVStore struct {
 	coinKeeper    bankkeeper.Keeper
 	accountKeeper authkeeper.AccountKeeper
 	stakingKeeper stakingkeeper.Keeper
+	distrKeeper   distrkeeper.Keeper
+	authority     string
+	configCache   *configCache
+	contractHooks types.ContractHooks
 }
 
 func NewKVStore(
@@ -127,6 +257,8 @@ func NewKVStore(
 	coinKeeper bankkeeper.Keeper,
 	accountKeeper authkeeper.AccountKeeper,
 	stakingKeeper stakingkeeper.Keeper,
+	distrKeeper distrkeeper.Keeper,
+	authority string,
 ) *KVStore {
 	// set KeyTable if it has not already been set
 	if !ps.HasKeyTable() {
@@ -141,6 +273,9 @@ func NewKVStore(
 		coinKeeper:    coinKeeper,
 		accountKeeper: accountKeeper,
 		stakingKeeper: stakingKeeper,
+		distrKeeper:   distrKeeper,
+		authority:     authority,
+		configCache:   newConfigCache(),
 	}
 }
 
@@ -158,6 +293,31 @@ func (k KVStore) Cdc() codec.BinaryCodec {
 	return k.cdc
 }
 
+// GetAuthority returns the address allowed to change governable configs,
+// which is the gov module account
+func (k KVStore) GetAuthority() string {
+	return k.authority
+}
+
+// SetContractHooks sets the hooks called on the contract lifecycle, it may
+// only be called once, before the keeper is handed to the module
+func (k *KVStore) SetContractHooks(hooks types.ContractHooks) *KVStore {
+	if k.contractHooks != nil {
+		panic("cannot set arkeo contract hooks twice")
+	}
+	k.contractHooks = hooks
+	return k
+}
+
+// ContractHooks returns the hooks called on the contract lifecycle, which do
+// nothing if none were set
+func (k KVStore) ContractHooks() types.ContractHooks {
+	if k.contractHooks == nil {
+		return types.NewMultiContractHooks()
+	}
+	return k.contractHooks
+}
+
 // GetParams get all parameters as types.Params
 func (k KVStore) GetParams(ctx sdk.Context) types.Params {
 	return types.NewParams()
@@ -180,7 +340,7 @@ func (k KVStore) GetComputedVersion(ctx cosmos.Context) int64 {
 	}
 
 	currentVersion := k.GetVersion(ctx)
-	minNum := configs.GetConfigValues(currentVersion).GetInt64Value(configs.VersionConsensus)
+	minNum := k.GetConfigValues(ctx).GetInt64Value(configs.VersionConsensus)
 	min := int64(len(validators)) * minNum / 100
 
 	for _, val := range validators {
@@ -341,3 +501,8 @@ func (k KVStore) GetActiveValidators(ctx cosmos.Context) []stakingtypes.Validato
 func (k KVStore) StakingSetParams(ctx cosmos.Context, params stakingtypes.Params) {
 	k.stakingKeeper.SetParams(ctx, params)
 }
+
+// FundCommunityPool send the given coins from the sender to the community pool
+func (k KVStore) FundCommunityPool(ctx cosmos.Context, amount cosmos.Coins, sender cosmos.AccAddress) error {
+	return k.distrKeeper.FundCommunityPool(ctx, amount, sender)
+}
@@ -17,6 +17,9 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
 	typesparams "github.com/cosmos/cosmos-sdk/x/params/types"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
@@ -31,6 +34,7 @@ func SetupKeeper(t testing.TB) (cosmos.Context, Keeper) {
 	keyAcc := cosmos.NewKVStoreKey(authtypes.StoreKey)
 	keyBank := cosmos.NewKVStoreKey(banktypes.StoreKey)
 	keyStake := cosmos.NewKVStoreKey(stakingtypes.StoreKey)
+	keyDistr := cosmos.NewKVStoreKey(distrtypes.StoreKey)
 	keyParams := cosmos.NewKVStoreKey(typesparams.StoreKey)
 	tkeyParams := cosmos.NewTransientStoreKey(typesparams.TStoreKey)
 	memStoreKey := storetypes.NewMemoryStoreKey(types.MemStoreKey)
@@ -67,6 +71,9 @@ func SetupKeeper(t testing.TB) (cosmos.Context, Keeper) {
 		types.ReserveName:              {},
 		types.ProviderName:             {},
 		types.ContractName:             {},
+		types.VestingName:              {},
+		distrtypes.ModuleName:          {},
+		authtypes.FeeCollectorName:     nil,
 	}, sdk.Bech32PrefixAccAddr)
 	ak.SetParams(ctx, authtypes.DefaultParams())
 
@@ -74,6 +81,7 @@ func SetupKeeper(t testing.TB) (cosmos.Context, Keeper) {
 	bk.SetParams(ctx, banktypes.DefaultParams())
 
 	sk := stakingkeeper.NewKeeper(cdc, keyStake, ak, bk, pk.Subspace(stakingtypes.ModuleName))
+	dk := distrkeeper.NewKeeper(cdc, keyDistr, pk.Subspace(distrtypes.ModuleName), ak, bk, sk, authtypes.FeeCollectorName)
 	k := NewKVStore(
 		cdc,
 		storeKey,
@@ -82,6 +90,8 @@ func SetupKeeper(t testing.TB) (cosmos.Context, Keeper) {
 		bk,
 		ak,
 		sk,
+		dk,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 	)
 	k.SetVersion(ctx, common.GetCurrentVersion())
 
@@ -96,6 +106,7 @@ func SetupKeeperWithStaking(t testing.TB) (cosmos.Context, Keeper, stakingkeeper
 	keyAcc := cosmos.NewKVStoreKey(authtypes.StoreKey)
 	keyBank := cosmos.NewKVStoreKey(banktypes.StoreKey)
 	keyStake := cosmos.NewKVStoreKey(stakingtypes.StoreKey)
+	keyDistr := cosmos.NewKVStoreKey(distrtypes.StoreKey)
 	keyParams := cosmos.NewKVStoreKey(typesparams.StoreKey)
 	tkeyParams := cosmos.NewTransientStoreKey(typesparams.TStoreKey)
 	memStoreKey := storetypes.NewMemoryStoreKey(types.MemStoreKey)
@@ -106,6 +117,7 @@ func SetupKeeperWithStaking(t testing.TB) (cosmos.Context, Keeper, stakingkeeper
 	stateStore.MountStoreWithDB(keyAcc, storetypes.StoreTypeIAVL, db)
 	stateStore.MountStoreWithDB(keyBank, storetypes.StoreTypeIAVL, db)
 	stateStore.MountStoreWithDB(keyStake, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(keyDistr, storetypes.StoreTypeIAVL, db)
 	stateStore.MountStoreWithDB(keyParams, storetypes.StoreTypeIAVL, db)
 	stateStore.MountStoreWithDB(tkeyParams, storetypes.StoreTypeIAVL, db)
 	stateStore.MountStoreWithDB(memStoreKey, storetypes.StoreTypeMemory, nil)
@@ -133,6 +145,9 @@ func SetupKeeperWithStaking(t testing.TB) (cosmos.Context, Keeper, stakingkeeper
 		types.ReserveName:              {},
 		types.ProviderName:             {},
 		types.ContractName:             {},
+		types.VestingName:              {},
+		distrtypes.ModuleName:          {},
+		authtypes.FeeCollectorName:     nil,
 	}, sdk.Bech32PrefixAccAddr)
 	ak.SetParams(ctx, authtypes.DefaultParams())
 
@@ -141,6 +156,8 @@ func SetupKeeperWithStaking(t testing.TB) (cosmos.Context, Keeper, stakingkeeper
 
 	sk := stakingkeeper.NewKeeper(cdc, keyStake, ak, bk, pk.Subspace(stakingtypes.ModuleName))
 	sk.SetParams(ctx, stakingtypes.DefaultParams())
+	dk := distrkeeper.NewKeeper(cdc, keyDistr, pk.Subspace(distrtypes.ModuleName), ak, bk, sk, authtypes.FeeCollectorName)
+	dk.SetFeePool(ctx, distrtypes.InitialFeePool())
 
 	k := NewKVStore(
 		cdc,
@@ -150,6 +167,8 @@ func SetupKeeperWithStaking(t testing.TB) (cosmos.Context, Keeper, stakingkeeper
 		bk,
 		ak,
 		sk,
+		dk,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 	)
 	k.SetVersion(ctx, common.GetCurrentVersion())
 
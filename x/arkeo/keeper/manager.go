@@ -2,9 +2,16 @@ package keeper
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
 
 	"cosmossdk.io/errors"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	gogotypes "github.com/gogo/protobuf/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/arkeonetwork/arkeo/common"
@@ -49,6 +56,21 @@ func (mgr Manager) EndBlock(ctx cosmos.Context) error {
 	if err := mgr.ContractEndBlock(ctx); err != nil {
 		ctx.Logger().Error("unable to settle contracts", "error", err)
 	}
+	if err := mgr.EscrowEndBlock(ctx); err != nil {
+		ctx.Logger().Error("unable to release contract escrows", "error", err)
+	}
+	if err := mgr.ContractPruneEndBlock(ctx); err != nil {
+		ctx.Logger().Error("unable to prune closed contracts", "error", err)
+	}
+	if err := mgr.UptimeEndBlock(ctx); err != nil {
+		ctx.Logger().Error("unable to aggregate provider uptime", "error", err)
+	}
+	if err := mgr.ProviderIncentiveEndBlock(ctx); err != nil {
+		ctx.Logger().Error("unable to pay provider incentives", "error", err)
+	}
+	if err := mgr.ReserveFeesEndBlock(ctx); err != nil {
+		ctx.Logger().Error("unable to move tx fees to the reserve", "error", err)
+	}
 
 	// invariant checks
 	if err := mgr.invariantBondModule(ctx); err != nil {
@@ -64,7 +86,40 @@ func (mgr Manager) EndBlock(ctx cosmos.Context) error {
 }
 
 func (mgr Manager) Configs(ctx cosmos.Context) configs.ConfigValues {
-	return configs.GetConfigValues(mgr.keeper.GetVersion(ctx))
+	return mgr.keeper.GetConfigValues(ctx)
+}
+
+// isSupportedService check the service against the service registry. Once
+// governance has registered a service, only registered services are
+// supported. Until then all known services are supported.
+func (mgr Manager) isSupportedService(ctx cosmos.Context, service common.Service) bool {
+	if mgr.hasServiceRegistry(ctx) {
+		return mgr.keeper.ServiceInfoExists(ctx, service)
+	}
+	return true
+}
+
+// hasServiceRegistry check whether governance has registered any service
+func (mgr Manager) hasServiceRegistry(ctx cosmos.Context) bool {
+	iter := mgr.keeper.GetServiceInfoIterator(ctx)
+	defer iter.Close()
+	return iter.Valid()
+}
+
+// isProviderAllowed check the provider against the allow list. When
+// permissioned mode is disabled, every provider is allowed.
+func (mgr Manager) isProviderAllowed(ctx cosmos.Context, provider common.PubKey) bool {
+	if mgr.FetchConfig(ctx, configs.PermissionedProviders) == 0 {
+		return true
+	}
+	return mgr.keeper.IsAllowedProvider(ctx, provider)
+}
+
+// isDenomAllowed check whether contracts may be paid in the given denom. The
+// native denom is always allowed, any other, such as an IBC voucher, must be
+// added to the allow list by governance.
+func (mgr Manager) isDenomAllowed(ctx cosmos.Context, denom string) bool {
+	return denom == configs.Denom || mgr.keeper.IsAllowedDenom(ctx, denom)
 }
 
 // test that the bond module has enough bond in it
@@ -91,7 +146,13 @@ func (mgr Manager) invariantBondModule(ctx cosmos.Context) error {
 	return nil
 }
 
-// test that the contract module has enough bond in it
+// test that the contract module has enough bond in it, to back both the
+// unspent deposits and the escrowed provider income. Every contract is
+// counted, a closed contract has its unspent deposit refunded, leaving its
+// deposit equal to what it paid, while one past its settlement period holds
+// its deposit until ContractEndBlock closes it. The balance only has to cover
+// the sum rather than equal it, since the module account can be given tokens
+// at genesis, and such a surplus puts no deposit at risk.
 func (mgr Manager) invariantContractModule(ctx cosmos.Context) error {
 	sums := cosmos.NewCoins()
 	iter := mgr.keeper.GetContractIterator(ctx)
@@ -99,15 +160,25 @@ func (mgr Manager) invariantContractModule(ctx cosmos.Context) error {
 	for ; iter.Valid(); iter.Next() {
 		var contract types.Contract
 		if err := mgr.keeper.Cdc().Unmarshal(iter.Value(), &contract); err != nil {
-			ctx.Logger().Error("fail to unmarshal contract", "error", err)
-			continue
+			return errors.Wrapf(types.ErrInvariantContractModule, "fail to unmarshal contract: %s", err)
 		}
-		if contract.IsSettled(ctx.BlockHeight()) {
-			continue
+		if contract.Paid.GT(contract.Deposit) {
+			return errors.Wrapf(types.ErrInvariantContractModule, "contract %d paid out more than its deposit (%s/%s)", contract.Id, contract.Paid.String(), contract.Deposit.String())
 		}
 		sums = sums.Add(cosmos.NewCoin(contract.Rate.Denom, contract.Deposit.Sub(contract.Paid)))
 	}
 
+	// income held for the dispute window is already counted as paid
+	escrowIter := mgr.keeper.GetContractEscrowIterator(ctx)
+	defer escrowIter.Close()
+	for ; escrowIter.Valid(); escrowIter.Next() {
+		var escrow types.ContractEscrow
+		if err := mgr.keeper.Cdc().Unmarshal(escrowIter.Value(), &escrow); err != nil {
+			return errors.Wrapf(types.ErrInvariantContractModule, "fail to unmarshal contract escrow: %s", err)
+		}
+		sums = sums.Add(escrow.Amount)
+	}
+
 	for _, sum := range sums {
 		if sum.Amount.IsZero() {
 			continue
@@ -121,6 +192,27 @@ func (mgr Manager) invariantContractModule(ctx cosmos.Context) error {
 	return nil
 }
 
+// test that the vesting module holds enough to back the rewards still vesting
+func (mgr Manager) invariantVestingModule(ctx cosmos.Context) error {
+	unvested := cosmos.NewCoins()
+	iter := mgr.keeper.GetRewardVestingIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var vesting types.RewardVesting
+		if err := mgr.keeper.Cdc().Unmarshal(iter.Value(), &vesting); err != nil {
+			ctx.Logger().Error("fail to unmarshal reward vesting", "error", err)
+			continue
+		}
+		unvested = unvested.Add(vesting.Unvested...)
+	}
+
+	balance := mgr.keeper.GetBalance(ctx, mgr.keeper.GetModuleAccAddress(types.VestingName))
+	if !balance.IsAllGTE(unvested) {
+		return errors.Wrapf(types.ErrInvariantVestingModule, "vesting module does not have enough token in it to back the unvested rewards (%s/%s)", unvested.String(), balance.String())
+	}
+	return nil
+}
+
 // test that the total supply does not surpass max supply
 func (mgr Manager) invariantMaxSupply(ctx cosmos.Context) error {
 	supply := mgr.keeper.GetSupply(ctx, configs.Denom)
@@ -134,14 +226,82 @@ func (mgr Manager) invariantMaxSupply(ctx cosmos.Context) error {
 }
 
 func (mgr Manager) ContractEndBlock(ctx cosmos.Context) error {
-	set, err := mgr.keeper.GetContractExpirationSet(ctx, ctx.BlockHeight())
-	if err != nil {
-		return err
+	settled := 0
+	defer func() { recordContractEndBlockMetrics(settled) }()
+
+	if err := mgr.settleSubscriptions(ctx); err != nil {
+		ctx.Logger().Error("unable to periodically settle contracts", "error", err)
 	}
 
-	if set.ContractSet == nil || len(set.ContractSet.ContractIds) == 0 {
-		return nil
+	var contracts []types.Contract
+	iter := mgr.keeper.GetContractExpirationIterator(ctx, ctx.BlockHeight())
+	for ; iter.Valid(); iter.Next() {
+		var contractId gogotypes.UInt64Value
+		if err := mgr.keeper.Cdc().Unmarshal(iter.Value(), &contractId); err != nil {
+			ctx.Logger().Error("fail to unmarshal contract expiration", "error", err)
+			continue
+		}
+		contract, err := mgr.keeper.GetContract(ctx, contractId.Value)
+		if err != nil {
+			ctx.Logger().Error("unable to fetch contract", "id", contractId.Value, "error", err)
+			continue
+		}
+		// a paused contract does not expire, resuming it queues it again at
+		// its new expiration
+		if contract.Client.IsEmpty() || contract.IsPaused() {
+			continue
+		}
+		contracts = append(contracts, contract)
 	}
+	iter.Close()
+
+	for _, contract := range sortContracts(contracts) {
+		// a contract at the end of its grace period was already renewed, or
+		// tried to, when it expired
+		inGrace := contract.GraceEnd > 0
+		if contract.IsSubscription() && contract.AutoRenew && !inGrace {
+			// renewal is all or nothing, a partial renewal must not leak into
+			// the final settlement below
+			cacheCtx, commit := ctx.CacheContext()
+			renewed, err := mgr.renewContract(cacheCtx, contract)
+			if err != nil {
+				ctx.Logger().Error("unable to renew contract", "id", contract.Id, "error", err)
+			} else if renewed {
+				commit()
+				continue
+			}
+		}
+		if contract.IsSubscription() && contract.SettlementHeight == 0 && !inGrace {
+			cacheCtx, commit := ctx.CacheContext()
+			started, err := mgr.startGracePeriod(cacheCtx, contract)
+			if err != nil {
+				ctx.Logger().Error("unable to start contract grace period", "id", contract.Id, "error", err)
+			} else if started {
+				commit()
+				continue
+			}
+		}
+		_, err := mgr.SettleContract(ctx, contract, 0, true)
+		if err != nil {
+			ctx.Logger().Error("unable to settle contract", "id", contract.Id, "error", err)
+			continue
+		}
+		settled++
+	}
+
+	return nil
+}
+
+// settleSubscriptions pays providers what has accrued so far on the
+// subscriptions due a periodic settlement at this height, and schedules their
+// next one. Contracts that have been closed, or are at the end of their
+// term, are left to the final settlement.
+func (mgr Manager) settleSubscriptions(ctx cosmos.Context) error {
+	set, err := mgr.keeper.GetContractSettlementSet(ctx, ctx.BlockHeight())
+	if err != nil {
+		return err
+	}
+	mgr.keeper.RemoveContractSettlementSet(ctx, ctx.BlockHeight())
 
 	for _, contractId := range set.ContractSet.ContractIds {
 		contract, err := mgr.keeper.GetContract(ctx, contractId)
@@ -149,49 +309,283 @@ func (mgr Manager) ContractEndBlock(ctx cosmos.Context) error {
 			ctx.Logger().Error("unable to fetch contract", "id", contractId, "error", err)
 			continue
 		}
-		if contract.Client.IsEmpty() {
+		if contract.Client.IsEmpty() || contract.SettlementHeight > 0 || ctx.BlockHeight() >= contract.ExpirationAt(ctx.BlockHeight()) {
 			continue
 		}
 
-		_, err = mgr.SettleContract(ctx, contract, 0, true)
-		if err != nil {
-			ctx.Logger().Error("unable to settle contract", "id", contractId, "error", err)
-			continue
+		cacheCtx, commit := ctx.CacheContext()
+		if _, err := mgr.SettleContract(cacheCtx, contract, 0, false); err != nil {
+			ctx.Logger().Error("unable to periodically settle contract", "id", contract.Id, "error", err)
+		} else {
+			commit()
+		}
+
+		if err := mgr.scheduleContractSettlement(ctx, contract); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-// This function pays out rewards to validators.
-// TODO: the method of accomplishing this is admittedly quite inefficient. The
-// better approach would be to track live allocation via assigning "units" to
-// validators when they bond and unbond. The math for this is as follows
-// U = total bond units
-// T = tokens bonded
-// t = new tokens being bonded
-// units = U / (T / t)
-// Since the development goal at the moment is to get this chain up and
-// running, we can save this optimization for another day.
+// scheduleContractSettlement queues the next periodic settlement of a
+// subscription with a settlement interval
+func (mgr Manager) scheduleContractSettlement(ctx cosmos.Context, contract types.Contract) error {
+	if !contract.IsSubscription() || contract.SettlementInterval <= 0 {
+		return nil
+	}
+	set, err := mgr.keeper.GetContractSettlementSet(ctx, ctx.BlockHeight()+contract.SettlementInterval)
+	if err != nil {
+		return err
+	}
+	set.Append(contract.Id)
+	return mgr.keeper.SetContractSettlementSet(ctx, set)
+}
+
+// renewContract extends an auto renewing subscription by another period. The
+// unspent deposit is put towards the new period and any shortfall is pulled
+// from the client. Returns false when the contract
+// is not eligible for renewal, in which case it should be settled as usual.
+func (mgr Manager) renewContract(ctx cosmos.Context, contract types.Contract) (bool, error) {
+	if mgr.FetchConfig(ctx, configs.HaltContractOpening) > 0 {
+		return false, nil
+	}
+
+	if !mgr.isSupportedService(ctx, contract.Service) {
+		return false, nil
+	}
+
+	if !mgr.isProviderAllowed(ctx, contract.Provider) {
+		return false, nil
+	}
+
+	if !mgr.isDenomAllowed(ctx, contract.Rate.Denom) {
+		return false, nil
+	}
+
+	provider, err := mgr.keeper.GetProvider(ctx, contract.Provider, contract.Service)
+	if err != nil {
+		return false, err
+	}
+	if provider.LastUpdate == 0 || provider.Status != types.ProviderStatus_ONLINE {
+		return false, nil
+	}
+	if provider.Bond.LT(cosmos.NewInt(mgr.FetchConfig(ctx, configs.MinProviderBond))) {
+		return false, nil
+	}
+
+	// the provider may have changed their rates since the contract was opened,
+	// only renew on the same terms
+	rate := contract.Rate
+	if provider.SubscriptionRatePerDay {
+		rate = contract.RatePerDay
+	}
+	if !cosmos.NewCoins(provider.SubscriptionRate...).AmountOf(rate.Denom).Equal(rate.Amount) {
+		return false, nil
+	}
+
+	// duration accumulates with each renewal, the period is the original
+	// duration of the contract
+	period := contract.RenewalPeriod()
+	if period <= 0 {
+		return false, nil
+	}
+	qpm := contract.QueriesPerMinute
+	if qpm < 1 {
+		qpm = 1
+	}
+
+	contract, err = mgr.SettleContract(ctx, contract, 0, false)
+	if err != nil {
+		return false, err
+	}
+
+	// whatever is left of the deposit carries over into the next period, the
+	// client only tops up the difference
+	deposit := contract.Rate.Amount.MulRaw(period).MulRaw(qpm)
+	topUp := deposit.Sub(contract.Deposit.Sub(contract.Paid))
+	if topUp.IsPositive() {
+		coins := cosmos.NewCoins(cosmos.NewCoin(contract.Rate.Denom, topUp))
+		if !mgr.keeper.HasCoins(ctx, contract.ClientAddress(), coins) {
+			return false, nil
+		}
+		if err := mgr.keeper.SendFromAccountToModule(ctx, contract.ClientAddress(), types.ContractName, coins); err != nil {
+			return false, err
+		}
+		contract.Deposit = contract.Deposit.Add(topUp)
+	} else {
+		topUp = cosmos.ZeroInt()
+	}
+
+	contract.Duration += period
+	contract.Renewals++
+	if err := mgr.keeper.SetContract(ctx, contract); err != nil {
+		return false, err
+	}
+
+	if err := mgr.keeper.AddContractExpiration(ctx, contract.SettlementPeriodEnd(), contract.Id); err != nil {
+		return false, err
+	}
+
+	return true, mgr.EmitContractRenewalEvent(ctx, topUp, &contract)
+}
+
+// startGracePeriod holds back the final settlement of an expired
+// subscription for the configured grace period, during which the client may
+// still renew it. The provider is paid what has accrued, the rest of the
+// deposit is refunded when the grace period ends. Returns false when there is
+// no grace period, in which case the contract should be settled as usual.
+func (mgr Manager) startGracePeriod(ctx cosmos.Context, contract types.Contract) (bool, error) {
+	grace := mgr.FetchConfig(ctx, configs.ContractGracePeriod)
+	if grace <= 0 {
+		return false, nil
+	}
+
+	contract, err := mgr.SettleContract(ctx, contract, 0, false)
+	if err != nil {
+		return false, err
+	}
+
+	contract.GraceEnd = ctx.BlockHeight() + grace
+	if err := mgr.keeper.SetContract(ctx, contract); err != nil {
+		return false, err
+	}
+
+	if err := mgr.keeper.AddContractExpiration(ctx, contract.GraceEnd, contract.Id); err != nil {
+		return false, err
+	}
+
+	return true, mgr.EmitContractGracePeriodEvent(ctx, &contract)
+}
+
+// sortContracts orders contracts by provider, service, client and id, so
+// settlement side effects happen in the same order on every node regardless
+// of how the contract ids were inserted into the expiration set
+func sortContracts(contracts []types.Contract) []types.Contract {
+	sort.SliceStable(contracts, func(i, j int) bool {
+		a, b := contracts[i], contracts[j]
+		if !a.Provider.Equals(b.Provider) {
+			return a.Provider.String() < b.Provider.String()
+		}
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		if !a.Client.Equals(b.Client) {
+			return a.Client.String() < b.Client.String()
+		}
+		return a.Id < b.Id
+	})
+	return contracts
+}
+
+// This function pays out rewards to validators. Each validator is paid by its
+// share of the bond units, see assignBondUnits.
 func (mgr Manager) ValidatorPayout(ctx cosmos.Context, votes []abci.VoteInfo) error {
 	valCycle := mgr.FetchConfig(ctx, configs.ValidatorPayoutCycle)
 	if valCycle == 0 || ctx.BlockHeight()%valCycle != 0 {
 		return nil
 	}
-	emissionCurve := mgr.FetchConfig(ctx, configs.EmissionCurve)
+	mgr.releaseAllVestedRewards(ctx)
+
+	emissionCurve, halvings := mgr.emissionAt(ctx, ctx.BlockHeight())
 	blocksPerYear := mgr.FetchConfig(ctx, configs.BlocksPerYear)
+	reserveFloor := mgr.FetchConfig(ctx, configs.MinReserveFloor)
 
 	reserveBal := mgr.keeper.GetBalance(ctx, mgr.keeper.GetModuleAccAddress(types.ReserveName))
+	weights, err := mgr.validatorEmissionWeights(ctx)
+	if err != nil {
+		// a bad weights config should not stop validators being paid
+		ctx.Logger().Error("unable to calculate validator emission weights", "error", err)
+		weights = nil
+	}
+
+	rewards := cosmos.NewCoins()
+	emitted := cosmos.NewCoins()
 	for _, bal := range reserveBal {
 		reserve := bal.Amount
-		blockReward := mgr.calcBlockReward(reserve.Int64(), emissionCurve, (blocksPerYear / valCycle))
+		if reserve.LTE(cosmos.NewInt(reserveFloor)) {
+			ctx.Logger().Info("reserve floor reached", "denom", bal.Denom, "reserve", reserve, "floor", reserveFloor)
+			continue
+		}
+		blockReward := halveReward(mgr.calcBlockReward(reserve, emissionCurve, blocksPerYear/valCycle), halvings)
 
 		if blockReward.IsZero() {
 			continue
 		}
+		rewards = rewards.Add(cosmos.NewCoin(bal.Denom, blockReward))
+
+		paid := mgr.payValidators(ctx, votes, bal.Denom, blockReward, weights)
+		emitted = emitted.Add(cosmos.NewCoin(bal.Denom, paid))
+
+		// any dust left over from rounding the validator and delegate shares
+		// is never sent out, and so it remains in the reserve
+		dust := blockReward.Sub(paid)
+		if !dust.IsZero() {
+			ctx.Logger().Info("validator rewards dust returned to reserve", "denom", bal.Denom, "dust", dust)
+		}
+	}
+
+	mgr.snapshotReserve(ctx, reserveBal, rewards)
+	recordValidatorPayoutMetrics(reserveBal, emitted)
+
+	return nil
+}
+
+// snapshotReserve records the reserve balance and block rewards of a payout
+// cycle, pruning all but the last ReserveHistoryLength snapshots. Snapshots
+// are keyed by height, so the history stays in order when the payout cycle
+// changes.
+func (mgr Manager) snapshotReserve(ctx cosmos.Context, reserve, rewards cosmos.Coins) {
+	length := mgr.FetchConfig(ctx, configs.ReserveHistoryLength)
+	if length <= 0 {
+		return
+	}
+	snapshot := types.ReserveSnapshot{
+		Height:      ctx.BlockHeight(),
+		Reserve:     reserve,
+		BlockReward: rewards,
+	}
+	if err := mgr.keeper.SetReserveSnapshot(ctx, snapshot); err != nil {
+		ctx.Logger().Error("unable to save reserve snapshot", "height", ctx.BlockHeight(), "error", err)
+		return
+	}
+
+	// collect first, the snapshots are removed from the prefix being iterated
+	var heights []int64
+	iter := mgr.keeper.GetReserveSnapshotIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var snapshot types.ReserveSnapshot
+		if err := mgr.keeper.Cdc().Unmarshal(iter.Value(), &snapshot); err != nil {
+			ctx.Logger().Error("fail to unmarshal reserve snapshot", "error", err)
+			continue
+		}
+		heights = append(heights, snapshot.Height)
+	}
+	iter.Close()
+
+	for i := 0; int64(len(heights)-i) > length; i++ {
+		mgr.keeper.RemoveReserveSnapshot(ctx, heights[i])
+	}
+}
+
+// payValidators distributes the block reward of the given denom to the
+// validators (and their delegates) that signed the last block. Each
+// validator's share is proportional to its bond units, scaled by its emission
+// weight when weights are given. Returns the amount that was actually paid
+// out of the reserve.
+func (mgr Manager) payValidators(ctx cosmos.Context, votes []abci.VoteInfo, denom string, blockReward cosmos.Int, weights map[string]int64) cosmos.Int {
+	paid := cosmos.ZeroInt()
+
+	total, err := mgr.syncBondUnits(ctx)
+	if err != nil {
+		ctx.Logger().Error("unable to assign bond units", "error", err)
+		return paid
+	}
 
-		// sum tokens
-		total := cosmos.ZeroInt()
+	// weights change with the open contracts, so the weighted units are
+	// summed every payout
+	if weights != nil {
+		total = cosmos.ZeroInt()
 		for _, vote := range votes {
 			val := mgr.sk.ValidatorByConsAddr(ctx, vote.Validator.Address)
 			if val == nil {
@@ -201,128 +595,439 @@ func (mgr Manager) ValidatorPayout(ctx cosmos.Context, votes []abci.VoteInfo) er
 			if !val.IsBonded() || val.IsJailed() {
 				continue
 			}
-			total = total.Add(val.GetDelegatorShares().RoundInt())
+			record, err := mgr.keeper.GetBondUnits(ctx, val.GetOperator())
+			if err != nil {
+				ctx.Logger().Error("unable to get bond units", "validator", val.GetOperator().String(), "error", err)
+				continue
+			}
+			total = total.Add(emissionUnits(val, record.Units, weights))
 		}
-		if total.IsZero() {
-			return nil
+	}
+	if total.IsZero() {
+		return paid
+	}
+
+	for _, vote := range votes {
+		if !vote.SignedLastBlock {
+			ctx.Logger().Info("validator rewards skipped due to lack of signature", "validator", string(vote.Validator.Address))
+			continue
 		}
 
-		for _, vote := range votes {
-			if !vote.SignedLastBlock {
-				ctx.Logger().Info("validator rewards skipped due to lack of signature", "validator", string(vote.Validator.Address))
+		val := mgr.sk.ValidatorByConsAddr(ctx, vote.Validator.Address)
+		if val == nil {
+			ctx.Logger().Info("unable to find validator", "validator", string(vote.Validator.Address))
+			continue
+		}
+		if !val.IsBonded() || val.IsJailed() {
+			ctx.Logger().Info("validator rewards skipped due to status or jailed", "validator", val.GetOperator().String())
+			continue
+		}
+
+		valVersion := mgr.keeper.GetVersionForAddress(ctx, val.GetOperator())
+		curVersion := mgr.keeper.GetVersion(ctx)
+		if valVersion < curVersion {
+			continue
+		}
+		acc := cosmos.AccAddress(val.GetOperator())
+
+		record, err := mgr.keeper.GetBondUnits(ctx, val.GetOperator())
+		if err != nil {
+			ctx.Logger().Error("unable to get bond units", "validator", val.GetOperator().String(), "error", err)
+			continue
+		}
+		totalReward := common.GetSafeShare(emissionUnits(val, record.Units, weights), total, blockReward)
+		if totalReward.IsZero() {
+			// validators without tokens (ie mid slash or unbond) earn nothing,
+			// don't bother sending zero coins to them or their delegates
+			continue
+		}
+		validatorReward := cosmos.ZeroInt()
+		delegatorsReward := cosmos.ZeroInt()
+		// the commission is a fraction, the validator keeps that share of
+		// its delegates' rewards
+		rateBasisPts := val.GetCommission().MulInt64(configs.MaxBasisPoints).RoundInt()
+
+		delegates := mgr.sk.GetValidatorDelegations(ctx, val.GetOperator())
+		for _, delegate := range delegates {
+			delegateAcc, err := cosmos.AccAddressFromBech32(delegate.DelegatorAddress)
+			if err != nil {
+				ctx.Logger().Error("unable to fetch delegate address", "delegate", delegate.DelegatorAddress, "error", err)
 				continue
 			}
-
-			val := mgr.sk.ValidatorByConsAddr(ctx, vote.Validator.Address)
-			if val == nil {
-				ctx.Logger().Info("unable to find validator", "validator", string(vote.Validator.Address))
+			delegateReward := common.GetSafeShare(delegate.GetShares().RoundInt(), val.GetDelegatorShares().RoundInt(), totalReward)
+			if acc.String() != delegate.DelegatorAddress {
+				valFee := common.GetSafeShare(rateBasisPts, cosmos.NewInt(configs.MaxBasisPoints), delegateReward)
+				delegateReward = delegateReward.Sub(valFee)
+				validatorReward = validatorReward.Add(valFee)
+			}
+			if delegateReward.IsZero() {
 				continue
 			}
-			if !val.IsBonded() || val.IsJailed() {
-				ctx.Logger().Info("validator rewards skipped due to status or jailed", "validator", val.GetOperator().String())
+			if err := mgr.payReward(ctx, delegateAcc, cosmos.NewCoins(cosmos.NewCoin(denom, delegateReward))); err != nil {
+				ctx.Logger().Error("unable to pay rewards to delegate", "delegate", delegate.DelegatorAddress, "error", err)
 				continue
 			}
+			paid = paid.Add(delegateReward)
+			delegatorsReward = delegatorsReward.Add(delegateReward)
+			ctx.Logger().Info("delegate rewarded", "delegate", delegateAcc.String(), "amount", delegateReward)
+		}
 
-			valVersion := mgr.keeper.GetVersionForAddress(ctx, val.GetOperator())
-			curVersion := mgr.keeper.GetVersion(ctx)
-			if valVersion < curVersion {
+		if !validatorReward.IsZero() {
+			if err := mgr.payReward(ctx, acc, cosmos.NewCoins(cosmos.NewCoin(denom, validatorReward))); err != nil {
+				ctx.Logger().Error("unable to pay rewards to validator", "validator", val.GetOperator().String(), "error", err)
 				continue
 			}
-			acc := cosmos.AccAddress(val.GetOperator())
-
-			totalReward := common.GetSafeShare(val.GetDelegatorShares().RoundInt(), total, blockReward)
-			validatorReward := cosmos.ZeroInt()
-			rateBasisPts := val.GetCommission().MulInt64(100).RoundInt()
-
-			delegates := mgr.sk.GetValidatorDelegations(ctx, val.GetOperator())
-			for _, delegate := range delegates {
-				delegateAcc, err := cosmos.AccAddressFromBech32(delegate.DelegatorAddress)
-				if err != nil {
-					ctx.Logger().Error("unable to fetch delegate address", "delegate", delegate.DelegatorAddress, "error", err)
-					continue
-				}
-				delegateReward := common.GetSafeShare(delegate.GetShares().RoundInt(), val.GetDelegatorShares().RoundInt(), totalReward)
-				if acc.String() != delegate.DelegatorAddress {
-					valFee := common.GetSafeShare(rateBasisPts, cosmos.NewInt(configs.MaxBasisPoints), delegateReward)
-					delegateReward = delegateReward.Sub(valFee)
-					validatorReward = validatorReward.Add(valFee)
-				}
-				if err := mgr.keeper.SendFromModuleToAccount(ctx, types.ReserveName, delegateAcc, cosmos.NewCoins(cosmos.NewCoin(bal.Denom, delegateReward))); err != nil {
-					ctx.Logger().Error("unable to pay rewards to delegate", "delegate", delegate.DelegatorAddress, "error", err)
-				}
-				ctx.Logger().Info("delegate rewarded", "delegate", delegateAcc.String(), "amount", delegateReward)
-			}
+			paid = paid.Add(validatorReward)
+			ctx.Logger().Info("validator additional rewards", "validator", acc.String(), "amount", validatorReward)
+		}
 
-			if !validatorReward.IsZero() {
-				if err := mgr.keeper.SendFromModuleToAccount(ctx, types.ReserveName, acc, cosmos.NewCoins(cosmos.NewCoin(bal.Denom, validatorReward))); err != nil {
-					ctx.Logger().Error("unable to pay rewards to validator", "validator", val.GetOperator().String(), "error", err)
-					continue
-				}
-				ctx.Logger().Info("validator additional rewards", "validator", acc.String(), "amount", validatorReward)
-			}
+		if err := mgr.EmitValidatorPayoutEvent(ctx, acc, denom, validatorReward, delegatorsReward); err != nil {
+			ctx.Logger().Error("unable to emit validator payout event", "validator", acc.String(), "error", err)
+		}
+	}
 
-			if err := mgr.EmitValidatorPayoutEvent(ctx, acc, validatorReward); err != nil {
-				ctx.Logger().Error("unable to emit validator payout event", "validator", acc.String(), "error", err)
+	return paid
+}
+
+// emissionUnits returns the units a validator's share of the block reward is
+// based on. Without weights these are its bond units, otherwise the units are
+// scaled by the validator's weight, so uniform weights pay the same split.
+func emissionUnits(val stakingtypes.ValidatorI, units cosmos.Int, weights map[string]int64) cosmos.Int {
+	if weights == nil {
+		return units
+	}
+	weight, ok := weights[cosmos.AccAddress(val.GetOperator()).String()]
+	if !ok {
+		weight = configs.MaxBasisPoints
+	}
+	return units.MulRaw(weight)
+}
+
+// syncBondUnits reassigns the bond units of the validators whose stake
+// changed since the last payout, and returns the total units. The first time
+// around every validator is assigned units.
+func (mgr Manager) syncBondUnits(ctx cosmos.Context) (cosmos.Int, error) {
+	total, ok, err := mgr.keeper.GetTotalBondUnits(ctx)
+	if err != nil {
+		return cosmos.ZeroInt(), err
+	}
+	if !ok {
+		mgr.sk.IterateValidators(ctx, func(_ int64, val stakingtypes.ValidatorI) bool {
+			mgr.keeper.SetBondUnitsPending(ctx, val.GetOperator())
+			return false
+		})
+	}
+
+	pending := make([]cosmos.ValAddress, 0)
+	iter := mgr.keeper.GetBondUnitsPendingIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		pending = append(pending, cosmos.ValAddress(iter.Value()))
+	}
+	iter.Close()
+	if ok && len(pending) == 0 {
+		return total.Units, nil
+	}
+
+	for _, addr := range pending {
+		record, err := mgr.keeper.GetBondUnits(ctx, addr)
+		if err != nil {
+			return cosmos.ZeroInt(), err
+		}
+		// only bonded validators earn rewards
+		bond := cosmos.ZeroInt()
+		if val := mgr.sk.Validator(ctx, addr); val != nil && val.IsBonded() && !val.IsJailed() {
+			bond = val.GetDelegatorShares().RoundInt()
+		}
+		total, record = assignBondUnits(total, record, bond)
+		if err := mgr.keeper.SetBondUnits(ctx, record); err != nil {
+			return cosmos.ZeroInt(), err
+		}
+		mgr.keeper.RemoveBondUnitsPending(ctx, addr)
+	}
+	mgr.keeper.SetTotalBondUnits(ctx, total)
+
+	return total.Units, nil
+}
+
+// assignBondUnits moves the validator's bond to the given amount, and the
+// total with it. New bond is assigned units at the going rate, so that units
+// stay proportional to bond without touching the other validators:
+// U = total bond units
+// T = tokens bonded
+// t = new tokens being bonded
+// units = U / (T / t)
+// Bond that is removed takes its share of the validator's units with it.
+func assignBondUnits(total, record types.BondUnits, bond cosmos.Int) (types.BondUnits, types.BondUnits) {
+	switch {
+	case bond.GT(record.Bond):
+		added := bond.Sub(record.Bond)
+		units := added
+		if total.Bond.IsPositive() && total.Units.IsPositive() {
+			units = common.GetUncappedShare(added, total.Bond, total.Units)
+		}
+		record.Units = record.Units.Add(units)
+		total.Units = total.Units.Add(units)
+		total.Bond = total.Bond.Add(added)
+	case bond.LT(record.Bond):
+		removed := record.Bond.Sub(bond)
+		units := record.Units
+		if bond.IsPositive() {
+			units = common.GetSafeShare(removed, record.Bond, record.Units)
+		}
+		record.Units = record.Units.Sub(units)
+		total.Units = total.Units.Sub(units)
+		total.Bond = total.Bond.Sub(removed)
+	}
+	record.Bond = bond
+	return total, record
+}
+
+// validatorEmissionWeights returns the emission weight, in basis points, of
+// each validator that serves open contracts as a provider, keyed by the
+// operator account. A validator's weight is the average of the
+// ChainEmissionWeights of the chains it serves, weighted by its open contracts
+// on each chain. Chains without a configured weight, and validators that are
+// not serving any contracts, are neutral (MaxBasisPoints). Returns nil when no
+// weights are configured.
+func (mgr Manager) validatorEmissionWeights(ctx cosmos.Context) (map[string]int64, error) {
+	chainWeights, err := parseChainEmissionWeights(mgr.Configs(ctx).GetStringValue(configs.ChainEmissionWeights))
+	if err != nil {
+		return nil, err
+	}
+	if len(chainWeights) == 0 {
+		return nil, nil
+	}
+	return mgr.calcEmissionWeights(ctx, chainWeights), nil
+}
+
+// calcEmissionWeights calculates the validator emission weights from the open
+// contracts, for the given chain weights
+func (mgr Manager) calcEmissionWeights(ctx cosmos.Context, chainWeights map[common.Service]int64) map[string]int64 {
+	// tally the open contracts of each provider account per chain
+	activity := make(map[string]map[common.Service]int64)
+	iter := mgr.keeper.GetContractIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var contract types.Contract
+		if err := mgr.keeper.Cdc().Unmarshal(iter.Value(), &contract); err != nil {
+			ctx.Logger().Error("fail to unmarshal contract", "error", err)
+			continue
+		}
+		if contract.IsExpired(ctx.BlockHeight()) {
+			continue
+		}
+		addr, err := contract.Provider.GetMyAddress()
+		if err != nil {
+			ctx.Logger().Error("fail to get provider address", "provider", contract.Provider, "error", err)
+			continue
+		}
+		if _, ok := activity[addr.String()]; !ok {
+			activity[addr.String()] = make(map[common.Service]int64)
+		}
+		activity[addr.String()][contract.Service]++
+	}
+
+	weights := make(map[string]int64, len(activity))
+	// analyze-ignore(map-iteration)
+	for addr, chains := range activity {
+		var sum, count int64
+		// analyze-ignore(map-iteration)
+		for service, contracts := range chains {
+			weight, ok := chainWeights[service]
+			if !ok {
+				weight = configs.MaxBasisPoints
 			}
+			sum += weight * contracts
+			count += contracts
 		}
+		weights[addr] = sum / count
 	}
+	return weights
+}
 
-	return nil
+// parseChainEmissionWeights parses a comma separated list of service:weight
+// pairs, where the weight is in basis points
+func parseChainEmissionWeights(value string) (map[common.Service]int64, error) {
+	weights := make(map[common.Service]int64)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+		parts := strings.Split(pair, ":")
+		if len(parts) != 2 {
+			return nil, errors.Wrapf(types.ErrInvalidConfig, "invalid chain emission weight: %s", pair)
+		}
+		service, err := common.NewService(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, err
+		}
+		weight, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(types.ErrInvalidConfig, "invalid chain emission weight: %s", pair)
+		}
+		if weight < 0 {
+			return nil, errors.Wrapf(types.ErrInvalidConfig, "chain emission weight cannot be negative: %s", pair)
+		}
+		weights[service] = weight
+	}
+	return weights, nil
 }
 
-func (mgr Manager) calcBlockReward(totalReserve, emissionCurve, blocksPerYear int64) cosmos.Int {
+func (mgr Manager) calcBlockReward(totalReserve cosmos.Int, emissionCurve, blocksPerYear int64) cosmos.Int {
 	// Block Rewards will take the latest reserve, divide it by the emission
 	// curve factor, then divide by blocks per year
 	if emissionCurve == 0 || blocksPerYear == 0 {
 		return cosmos.ZeroInt()
 	}
-	trD := cosmos.NewDec(totalReserve)
+	trD := cosmos.NewDecFromInt(totalReserve)
 	ecD := cosmos.NewDec(emissionCurve)
 	bpyD := cosmos.NewDec(blocksPerYear)
 	return trD.Quo(ecD).Quo(bpyD).RoundInt()
 }
 
+// EmissionAPR projects the annual validator reward rate from the current
+// reserve, using the same block reward math as ValidatorPayout, relative to
+// the total bonded tokens
+func (mgr Manager) EmissionAPR(ctx cosmos.Context) cosmos.Dec {
+	valCycle := mgr.FetchConfig(ctx, configs.ValidatorPayoutCycle)
+	if valCycle <= 0 {
+		return cosmos.ZeroDec()
+	}
+	blocksPerYear := mgr.FetchConfig(ctx, configs.BlocksPerYear)
+	blockReward := mgr.NextBlockReward(ctx)
+	return calcAPR(blockReward, blocksPerYear/valCycle, mgr.sk.TotalBondedTokens(ctx))
+}
+
+// NextBlockReward returns the reward the next validator payout cycle emits
+// out of the native denom reserve, using the same math as ValidatorPayout
+func (mgr Manager) NextBlockReward(ctx cosmos.Context) cosmos.Int {
+	valCycle := mgr.FetchConfig(ctx, configs.ValidatorPayoutCycle)
+	if valCycle <= 0 {
+		return cosmos.ZeroInt()
+	}
+	emissionCurve, halvings := mgr.emissionAt(ctx, ctx.BlockHeight())
+	blocksPerYear := mgr.FetchConfig(ctx, configs.BlocksPerYear)
+
+	reserve := mgr.keeper.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom)
+	if reserve.LTE(cosmos.NewInt(mgr.FetchConfig(ctx, configs.MinReserveFloor))) {
+		return cosmos.ZeroInt()
+	}
+	return halveReward(mgr.calcBlockReward(reserve, emissionCurve, blocksPerYear/valCycle), halvings)
+}
+
+// emissionAt returns the emission curve and the number of times the block
+// reward has halved at the given height. The emission schedule set by
+// governance takes precedence over the EmissionCurve config, which also
+// applies before the first epoch starts.
+func (mgr Manager) emissionAt(ctx cosmos.Context, height int64) (int64, int64) {
+	schedule, err := mgr.keeper.GetEmissionSchedule(ctx)
+	if err != nil {
+		ctx.Logger().Error("unable to fetch emission schedule", "error", err)
+	}
+	if epoch, ok := schedule.EpochAt(height); ok {
+		return epoch.EmissionCurve, epoch.Halvings(height)
+	}
+	return mgr.FetchConfig(ctx, configs.EmissionCurve), 0
+}
+
+// halveReward halves the block reward the given number of times
+func halveReward(reward cosmos.Int, halvings int64) cosmos.Int {
+	if halvings <= 0 {
+		return reward
+	}
+	if halvings >= int64(reward.BigInt().BitLen()) {
+		return cosmos.ZeroInt()
+	}
+	return cosmos.NewIntFromBigInt(new(big.Int).Rsh(reward.BigInt(), uint(halvings)))
+}
+
+// calcAPR annualizes a per cycle reward against the bonded tokens
+func calcAPR(blockReward cosmos.Int, cyclesPerYear int64, bonded cosmos.Int) cosmos.Dec {
+	if blockReward.IsZero() || cyclesPerYear <= 0 || bonded.IsNil() || !bonded.IsPositive() {
+		return cosmos.ZeroDec()
+	}
+	annual := cosmos.NewDecFromInt(blockReward.MulRaw(cyclesPerYear))
+	return annual.Quo(cosmos.NewDecFromInt(bonded))
+}
+
 func (mgr Manager) FetchConfig(ctx cosmos.Context, name configs.ConfigName) int64 {
-	// TODO: create a handler for admins to be able to change configs on the
-	// fly and check them here before returning
+	// configs changed by governance take precedence
+	if value, ok := mgr.keeper.GetConfigOverride(ctx, name); ok {
+		return value
+	}
 	return mgr.Configs(ctx).GetInt64Value(name)
 }
 
 // any owed debt is paid to data provider
 func (mgr Manager) SettleContract(ctx cosmos.Context, contract types.Contract, nonce int64, isFinal bool) (types.Contract, error) {
+	queries := int64(0)
 	if nonce > contract.Nonce {
+		queries = nonce - contract.Nonce
 		contract.Nonce = nonce
 	}
 	totalDebt, err := mgr.contractDebt(ctx, contract)
-	valIncome := common.GetSafeShare(cosmos.NewInt(mgr.FetchConfig(ctx, configs.ReserveTax)), cosmos.NewInt(configs.MaxBasisPoints), totalDebt)
+	valIncome := mgr.reserveTax(ctx, totalDebt)
 	debt := totalDebt.Sub(valIncome)
 	if err != nil {
 		return contract, err
 	}
 	if !debt.IsZero() {
-		provider, err := contract.Provider.GetMyAddress()
+		escrowed, err := mgr.escrowProviderIncome(ctx, contract, debt)
 		if err != nil {
 			return contract, err
 		}
-		if err := mgr.keeper.SendFromModuleToAccount(ctx, types.ContractName, provider, cosmos.NewCoins(cosmos.NewCoin(contract.Rate.Denom, debt))); err != nil {
+		if escrowed {
+			// the affiliate is paid once the income is released, so its fee
+			// is refunded along with the rest if a dispute goes the client's
+			// way
+			debt = debt.Sub(affiliateShare(contract.Affiliate, contract.AffiliateFee, debt))
+		} else {
+			affiliateFee, err := mgr.payAffiliateFee(ctx, contract, debt)
+			if err != nil {
+				return contract, err
+			}
+			debt = debt.Sub(affiliateFee)
+			provider, err := contract.Provider.GetMyAddress()
+			if err != nil {
+				return contract, err
+			}
+			if err := mgr.keeper.SendFromModuleToAccount(ctx, types.ContractName, provider, cosmos.NewCoins(cosmos.NewCoin(contract.Rate.Denom, debt))); err != nil {
+				return contract, err
+			}
+		}
+		if err := mgr.payReserveTax(ctx, contract, valIncome); err != nil {
 			return contract, err
 		}
-		if err := mgr.keeper.SendFromModuleToModule(ctx, types.ContractName, types.ReserveName, cosmos.NewCoins(cosmos.NewCoin(contract.Rate.Denom, valIncome))); err != nil {
+		if err := mgr.recordProviderEarning(ctx, contract, debt, valIncome); err != nil {
 			return contract, err
 		}
 	}
 
 	contract.Paid = contract.Paid.Add(totalDebt)
+	refunded := cosmos.ZeroInt()
+
+	// a contract only counts as served once, when it is finally settled after
+	// running its full course
+	served := isFinal && contract.SettlementHeight == 0 && contract.IsExpired(ctx.BlockHeight())
+	if err := mgr.recordProviderSettlement(ctx, contract, totalDebt, served); err != nil {
+		return contract, err
+	}
+	if err := mgr.recordClientUsage(ctx, contract, totalDebt, queries); err != nil {
+		return contract, err
+	}
+
 	if isFinal {
 		remainder := contract.Deposit.Sub(contract.Paid)
 		if !remainder.IsZero() {
-			client, err := contract.Client.GetMyAddress()
+			refundTo, err := contract.RefundTo()
 			if err != nil {
 				return contract, err
 			}
-			if err := mgr.keeper.SendFromModuleToAccount(ctx, types.ContractName, client, cosmos.NewCoins(cosmos.NewCoin(contract.Rate.Denom, remainder))); err != nil {
+			if err := mgr.keeper.SendFromModuleToAccount(ctx, types.ContractName, refundTo, cosmos.NewCoins(cosmos.NewCoin(contract.Rate.Denom, remainder))); err != nil {
 				return contract, err
 			}
+			refunded = remainder
 			// now that the user has some of their funds refunded, the deposit
 			// amount should be updated (to reflect that). This also sets Paid
 			// == Deposit, which causes the record to be deleted, conserving
@@ -342,27 +1047,233 @@ func (mgr Manager) SettleContract(ctx cosmos.Context, contract types.Contract, n
 		return contract, err
 	}
 
-	if err = mgr.EmitContractSettlementEvent(ctx, totalDebt, valIncome, &contract); err != nil {
+	if isFinal {
+		if err := mgr.keeper.ContractHooks().AfterContractSettled(ctx, contract); err != nil {
+			return contract, err
+		}
+	}
+
+	if err = mgr.EmitContractSettlementEvent(ctx, totalDebt, valIncome, refunded, isFinal, &contract); err != nil {
 		return contract, err
 	}
+	recordSettlementMetrics(contract, debt, valIncome, isFinal)
 
 	return contract, nil
 }
 
+// payAffiliateFee pays the affiliate that referred the client its fee out of
+// the provider's income from a settlement, returning the amount paid
+func (mgr Manager) payAffiliateFee(ctx cosmos.Context, contract types.Contract, income cosmos.Int) (cosmos.Int, error) {
+	fee := affiliateShare(contract.Affiliate, contract.AffiliateFee, income)
+	if fee.IsZero() {
+		return fee, nil
+	}
+	coin := cosmos.NewCoin(contract.Rate.Denom, fee)
+	if err := mgr.keeper.SendFromModuleToAccount(ctx, types.ContractName, contract.Affiliate, cosmos.NewCoins(coin)); err != nil {
+		return cosmos.ZeroInt(), err
+	}
+	return fee, mgr.EmitAffiliatePaymentEvent(ctx, coin, &contract)
+}
+
+// affiliateShare returns the affiliate's fee, in basis points, of the
+// provider's income
+func affiliateShare(affiliate cosmos.AccAddress, fee int64, income cosmos.Int) cosmos.Int {
+	if affiliate.Empty() || fee <= 0 {
+		return cosmos.ZeroInt()
+	}
+	return common.GetSafeShare(cosmos.NewInt(fee), cosmos.NewInt(configs.MaxBasisPoints), income)
+}
+
+// reserveTax returns the share of a settlement kept by the reserve
+func (mgr Manager) reserveTax(ctx cosmos.Context, debt cosmos.Int) cosmos.Int {
+	return common.GetSafeShare(cosmos.NewInt(mgr.FetchConfig(ctx, configs.ReserveTax)), cosmos.NewInt(configs.MaxBasisPoints), debt)
+}
+
+// payReserveTax moves the reserve tax of a settlement out of the contract
+// module, splitting it between the community pool (per CommunityTaxShare) and
+// the reserve
+func (mgr Manager) payReserveTax(ctx cosmos.Context, contract types.Contract, tax cosmos.Int) error {
+	denom := contract.Rate.Denom
+	community := common.GetSafeShare(cosmos.NewInt(mgr.FetchConfig(ctx, configs.CommunityTaxShare)), cosmos.NewInt(configs.MaxBasisPoints), tax)
+	reserve := tax.Sub(community)
+
+	if community.IsPositive() {
+		if err := mgr.keeper.FundCommunityPool(ctx, cosmos.NewCoins(cosmos.NewCoin(denom, community)), mgr.keeper.GetModuleAccAddress(types.ContractName)); err != nil {
+			return err
+		}
+	}
+	if err := mgr.keeper.SendFromModuleToModule(ctx, types.ContractName, types.ReserveName, cosmos.NewCoins(cosmos.NewCoin(denom, reserve))); err != nil {
+		return err
+	}
+
+	if community.IsZero() {
+		return nil
+	}
+	return mgr.EmitReserveTaxSplitEvent(ctx, denom, reserve, community, &contract)
+}
+
+// recordClientUsage adds what a client spent and the queries claimed against
+// it in a settlement to the client's history
+func (mgr Manager) recordClientUsage(ctx cosmos.Context, contract types.Contract, spent cosmos.Int, queries int64) error {
+	if !spent.IsPositive() && queries <= 0 {
+		return nil
+	}
+	stats, err := mgr.keeper.GetClientStats(ctx, contract.Client)
+	if err != nil {
+		return err
+	}
+	stats.TotalSpent = stats.TotalSpent.Add(cosmos.NewCoin(contract.Rate.Denom, spent))
+	stats.QueriesClaimed += queries
+	return mgr.keeper.SetClientStats(ctx, stats)
+}
+
+// recordProviderSettlement adds a settlement to the provider's history
+func (mgr Manager) recordProviderSettlement(ctx cosmos.Context, contract types.Contract, amount cosmos.Int, served bool) error {
+	if !amount.IsPositive() && !served {
+		return nil
+	}
+	stats, err := mgr.keeper.GetProviderStats(ctx, contract.Provider, contract.Service)
+	if err != nil {
+		return err
+	}
+	if amount.IsPositive() {
+		stats.SettledAmount = stats.SettledAmount.Add(amount)
+	}
+	if served {
+		stats.ContractsServed++
+	}
+	return mgr.keeper.SetProviderStats(ctx, stats)
+}
+
+// recordProviderEarning keeps a record of what the provider was paid, so they
+// can reconcile their income, and drops their records that have aged out
+func (mgr Manager) recordProviderEarning(ctx cosmos.Context, contract types.Contract, amount, tax cosmos.Int) error {
+	record, found, err := mgr.keeper.GetProviderEarning(ctx, contract.Provider, ctx.BlockHeight(), contract.Id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		record = types.NewProviderEarning(contract.Provider, contract.Service, contract.Id, ctx.BlockHeight(), contract.Rate.Denom)
+	}
+	record.Nonce = contract.Nonce
+	record.Amount = record.Amount.AddAmount(amount)
+	record.ReserveTax = record.ReserveTax.AddAmount(tax)
+	if err := mgr.keeper.SetProviderEarning(ctx, record); err != nil {
+		return err
+	}
+
+	if history := mgr.FetchConfig(ctx, configs.ProviderEarningsHistory); history > 0 {
+		mgr.keeper.PruneProviderEarnings(ctx, contract.Provider, ctx.BlockHeight()-history)
+	}
+	return nil
+}
+
+// recordProviderBreach counts a contract the client had to close before it
+// ran its full course against the provider
+func (mgr Manager) recordProviderBreach(ctx cosmos.Context, contract types.Contract) error {
+	stats, err := mgr.keeper.GetProviderStats(ctx, contract.Provider, contract.Service)
+	if err != nil {
+		return err
+	}
+	stats.Breaches++
+	return mgr.keeper.SetProviderStats(ctx, stats)
+}
+
 func (mgr Manager) contractDebt(ctx cosmos.Context, contract types.Contract) (cosmos.Int, error) {
-	var debt cosmos.Int
+	return calcContractDebt(contract, ctx.BlockHeight())
+}
+
+// calcContractTimeRemaining returns the blocks left before the contract
+// expires, or runs out of deposit, and for pay-as-you-go contracts the number
+// of queries the rest of the deposit pays for. A contract that has expired,
+// been closed or settled has nothing left.
+func calcContractTimeRemaining(contract types.Contract, height int64) (int64, int64, error) {
+	if contract.IsExpired(height) || contract.SettlementHeight > 0 {
+		return 0, 0, nil
+	}
+
+	accrued, err := calcContractAccrued(contract, height)
+	if err != nil {
+		return 0, 0, err
+	}
+	if contract.Paid.GT(accrued) {
+		accrued = contract.Paid
+	}
+	available := contract.Deposit.Sub(accrued)
+
+	blocks := contract.ExpirationAt(height) - height
+	if blocks < 0 {
+		blocks = 0
+	}
+	if !available.IsPositive() || !contract.Rate.Amount.IsPositive() {
+		if contract.IsSubscription() {
+			return 0, 0, nil
+		}
+		return blocks, 0, nil
+	}
+
+	funded := available.Quo(contract.Rate.Amount)
+	if contract.IsPayAsYouGo() {
+		if !funded.IsInt64() {
+			return blocks, math.MaxInt64, nil
+		}
+		return blocks, funded.Int64(), nil
+	}
+	if funded.IsInt64() && funded.Int64() < blocks {
+		blocks = funded.Int64()
+	}
+	return blocks, 0, nil
+}
+
+// calcContractAccrued returns the total amount the contract has accrued at
+// the given height, paid or not. Usage since the last rate change is charged
+// at the current rate, and its tiers, on top of what was accrued before the
+// change.
+func calcContractAccrued(contract types.Contract, height int64) (cosmos.Int, error) {
+	accrued := contract.AccruedAtRateChange
+	if accrued.IsNil() {
+		accrued = cosmos.ZeroInt()
+	}
 	switch contract.Type {
 	case types.ContractType_SUBSCRIPTION:
-		height := ctx.BlockHeight()
-		if height > contract.SettlementPeriodEnd() {
-			height = contract.SettlementPeriodEnd()
-		}
-		debt = contract.Rate.Amount.MulRaw(height - contract.Height).Sub(contract.Paid)
+		return accrued.Add(contract.TieredCost(subscriptionTieredBlocks(contract, height))), nil
 	case types.ContractType_PAY_AS_YOU_GO:
-		debt = contract.Rate.Amount.MulRaw(contract.Nonce).Sub(contract.Paid)
+		return accrued.Add(contract.TieredCost(contract.Nonce - contract.RateChangeNonce)), nil
 	default:
 		return cosmos.ZeroInt(), errors.Wrapf(types.ErrInvalidContractType, "%s", contract.Type.String())
 	}
+}
+
+// subscriptionTieredBlocks returns the blocks of the subscription charged at
+// its current rate and tiers by the given height, those since its last rate
+// change
+func subscriptionTieredBlocks(contract types.Contract, height int64) int64 {
+	if height > contract.SettlementPeriodEnd() {
+		height = contract.SettlementPeriodEnd()
+	}
+	start := contract.Height
+	if contract.RateChangeHeight > 0 {
+		start = contract.RateChangeHeight
+	}
+	// no debt accrues while the provider has the contract paused
+	blocks := height - start - (contract.PausedBlocks - contract.PausedBlocksAtRateChange)
+	if contract.IsPaused() && height > contract.PausedHeight {
+		blocks -= height - contract.PausedHeight
+	}
+	if blocks < 0 {
+		blocks = 0
+	}
+	return blocks
+}
+
+// calcContractDebt returns the amount owed to the provider of the contract at
+// the given height, that has not yet been paid
+func calcContractDebt(contract types.Contract, height int64) (cosmos.Int, error) {
+	accrued, err := calcContractAccrued(contract, height)
+	if err != nil {
+		return cosmos.ZeroInt(), err
+	}
+	debt := accrued.Sub(contract.Paid)
 
 	if debt.IsNegative() {
 		return cosmos.ZeroInt(), nil
@@ -370,6 +1281,9 @@ func (mgr Manager) contractDebt(ctx cosmos.Context, contract types.Contract) (co
 
 	// sanity check, ensure provider cannot take more than deposited into the contract
 	if contract.Paid.Add(debt).GT(contract.Deposit) {
+		if contract.Paid.GTE(contract.Deposit) {
+			return cosmos.ZeroInt(), nil
+		}
 		return contract.Deposit.Sub(contract.Paid), nil
 	}
 
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"math"
 	"testing"
 
 	"github.com/arkeonetwork/arkeo/common"
@@ -10,6 +11,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -78,7 +82,7 @@ func TestValidatorPayout(t *testing.T) {
 
 	// mint token1
 	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(common.Tokens(50000))))
-	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ReserveName, getCoins(common.Tokens(50000))))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ReserveName, getCoins(cosmos.NewInt(common.Tokens(50000)))))
 	// mint token2
 	coins := cosmos.NewCoins(cosmos.NewInt64Coin("tokkie", 50000*1e8))
 	require.NoError(t, k.MintToModule(ctx, types.ModuleName, coins[0]))
@@ -107,38 +111,181 @@ func TestValidatorPayout(t *testing.T) {
 	// check validator balances
 	totalBal := cosmos.ZeroInt()
 	bal := k.GetBalance(ctx, acc1)
-	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(18653))
+	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(18837))
 	totalBal = totalBal.Add(bal.AmountOf(configs.Denom))
-	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(18653))
+	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(18837))
 
 	bal = k.GetBalance(ctx, acc2)
-	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(37308))
+	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(38047))
 	totalBal = totalBal.Add(bal.AmountOf(configs.Denom))
-	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(37308))
+	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(38047))
 
 	bal = k.GetBalance(ctx, acc3)
-	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(93271))
+	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(95117))
 	totalBal = totalBal.Add(bal.AmountOf(configs.Denom))
-	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(93271))
+	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(95117))
 
 	// check delegate balances
 	bal = k.GetBalance(ctx, delAcc1)
-	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(1863))
+	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(1679))
 	totalBal = totalBal.Add(bal.AmountOf(configs.Denom))
-	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(1863))
+	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(1679))
 
 	bal = k.GetBalance(ctx, delAcc2)
-	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(3723))
+	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(2984))
 	totalBal = totalBal.Add(bal.AmountOf(configs.Denom))
-	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(3723))
+	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(2984))
 
 	bal = k.GetBalance(ctx, delAcc3)
-	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(3711))
+	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), int64(1865))
 	totalBal = totalBal.Add(bal.AmountOf(configs.Denom))
-	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(3711))
+	require.Equal(t, bal.AmountOf("tokkie").Int64(), int64(1865))
 
 	// ensure block reward is equal to total rewarded to validators and delegates
 	require.Equal(t, blockReward, totalBal.Int64())
+
+	// ensure the reserve was snapshotted
+	history, err := k.ReserveHistory(ctx, &types.QueryReserveHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, history.Snapshots, 1)
+	require.Equal(t, ctx.BlockHeight(), history.Snapshots[0].Height)
+	require.Equal(t, int64(5000000000000), cosmos.NewCoins(history.Snapshots[0].Reserve...).AmountOf(configs.Denom).Int64())
+	require.Equal(t, blockReward, cosmos.NewCoins(history.Snapshots[0].BlockReward...).AmountOf(configs.Denom).Int64())
+}
+
+func TestSnapshotReserve(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	mgr := NewManager(k, sk)
+
+	length := mgr.FetchConfig(ctx, configs.ReserveHistoryLength)
+	valCycle := int64(2)
+	reserve := getCoins(cosmos.NewInt(common.Tokens(100)))
+	rewards := getCoins(cosmos.NewInt(common.Tokens(1)))
+	for i := int64(1); i <= length+5; i++ {
+		ctx = ctx.WithBlockHeight(i * valCycle)
+		mgr.snapshotReserve(ctx, reserve, rewards)
+	}
+
+	// only the most recent cycles are kept
+	history, err := k.ReserveHistory(ctx, &types.QueryReserveHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, history.Snapshots, int(length))
+	require.Equal(t, 6*valCycle, history.Snapshots[0].Height)
+	require.Equal(t, ctx.BlockHeight(), history.Snapshots[len(history.Snapshots)-1].Height)
+
+	// a new payout cycle keeps the history in order, dropping the oldest
+	height := ctx.BlockHeight()
+	for i := int64(1); i <= 3; i++ {
+		ctx = ctx.WithBlockHeight(height + i*7)
+		mgr.snapshotReserve(ctx, reserve, rewards)
+	}
+	history, err = k.ReserveHistory(ctx, &types.QueryReserveHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, history.Snapshots, int(length))
+	require.Equal(t, 9*valCycle, history.Snapshots[0].Height)
+	for i := 1; i < len(history.Snapshots); i++ {
+		require.Less(t, history.Snapshots[i-1].Height, history.Snapshots[i].Height)
+	}
+	require.Equal(t, ctx.BlockHeight(), history.Snapshots[len(history.Snapshots)-1].Height)
+
+	// shortening the history prunes it on the next cycle
+	k.SetConfigOverride(ctx, configs.ReserveHistoryLength, 2)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 7)
+	mgr.snapshotReserve(ctx, reserve, rewards)
+	history, err = k.ReserveHistory(ctx, &types.QueryReserveHistoryRequest{})
+	require.NoError(t, err)
+	require.Len(t, history.Snapshots, 2)
+	require.Equal(t, ctx.BlockHeight()-7, history.Snapshots[0].Height)
+	iter := k.GetReserveSnapshotIterator(ctx)
+	count := 0
+	for ; iter.Valid(); iter.Next() {
+		count++
+	}
+	iter.Close()
+	require.Equal(t, 2, count)
+}
+
+func TestEmissionAPR(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	mgr := NewManager(k, sk)
+
+	// empty reserve
+	resp, err := k.EmissionAPR(ctx, &types.QueryEmissionAPRRequest{})
+	require.NoError(t, err)
+	require.True(t, resp.Apr.IsZero())
+	require.True(t, resp.BlockReward.IsZero())
+	require.True(t, resp.AnnualEmission.IsZero())
+
+	// 10 payout cycles a year, each paying a fifth of the reserve over the
+	// year
+	k.SetConfigOverride(ctx, configs.BlocksPerYear, 100)
+	k.SetConfigOverride(ctx, configs.EmissionCurve, 5)
+	k.SetConfigOverride(ctx, configs.ValidatorPayoutCycle, 10)
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(100_000)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ReserveName, getCoins(cosmos.NewInt(100_000))))
+	resp, err = k.EmissionAPR(ctx, &types.QueryEmissionAPRRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(100_000), resp.Reserve.Int64())
+	require.Equal(t, int64(10), resp.PayoutCycle)
+	require.Equal(t, int64(2000), resp.BlockReward.Int64())
+	require.Equal(t, int64(20_000), resp.AnnualEmission.Int64())
+	require.Equal(t, calcAPR(resp.BlockReward, 10, resp.BondedTokens), resp.Apr)
+
+	// 10 tokens per cycle, 100 cycles per year, 1000 bonded
+	apr := calcAPR(cosmos.NewInt(10), 100, cosmos.NewInt(1000))
+	require.Equal(t, cosmos.NewDec(1), apr)
+
+	// no bonded tokens
+	require.True(t, calcAPR(cosmos.NewInt(10), 100, cosmos.ZeroInt()).IsZero())
+
+	// no emission curve
+	blockReward := mgr.calcBlockReward(cosmos.NewInt(common.Tokens(1000)), 0, 100)
+	require.True(t, calcAPR(blockReward, 100, cosmos.NewInt(1000)).IsZero())
+
+	// consistent with payouts
+	blockReward = mgr.calcBlockReward(cosmos.NewInt(100_000), 5, 100)
+	require.Equal(t, int64(200), blockReward.Int64())
+	apr = calcAPR(blockReward, 100, cosmos.NewInt(200_000))
+	require.Equal(t, cosmos.NewDecWithPrec(1, 1), apr)
+
+	// a reserve beyond an int64 does not overflow
+	reserve := cosmos.NewInt(math.MaxInt64).MulRaw(100)
+	blockReward = mgr.calcBlockReward(reserve, 5, 20)
+	require.Equal(t, cosmos.NewInt(math.MaxInt64), blockReward)
+}
+
+func TestValidatorPayoutDust(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+
+	accs, _, votes := setupValidators(t, ctx, sk, []int64{3})
+	acc, valAddr := accs[0], cosmos.ValAddress(accs[0])
+
+	// split the stake into three equal delegations, a block reward of 100
+	// cannot be split evenly
+	delAcc1 := types.GetRandomBech32Addr()
+	delAcc2 := types.GetRandomBech32Addr()
+	sk.SetDelegation(ctx, stakingtypes.NewDelegation(acc, valAddr, cosmos.NewDec(1)))
+	sk.SetDelegation(ctx, stakingtypes.NewDelegation(delAcc1, valAddr, cosmos.NewDec(1)))
+	sk.SetDelegation(ctx, stakingtypes.NewDelegation(delAcc2, valAddr, cosmos.NewDec(1)))
+
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(common.Tokens(10))))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ReserveName, getCoins(cosmos.NewInt(common.Tokens(10)))))
+	reserve := k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom)
+
+	mgr := NewManager(k, sk)
+	blockReward := cosmos.NewInt(100)
+	paid := mgr.payValidators(ctx, votes, configs.Denom, blockReward, nil)
+	require.Equal(t, int64(99), paid.Int64())
+
+	distributed := k.GetBalance(ctx, acc).AmountOf(configs.Denom)
+	distributed = distributed.Add(k.GetBalance(ctx, delAcc1).AmountOf(configs.Denom))
+	distributed = distributed.Add(k.GetBalance(ctx, delAcc2).AmountOf(configs.Denom))
+	require.Equal(t, paid.Int64(), distributed.Int64())
+
+	// dust is never sent, and remains in the reserve
+	dust := blockReward.Sub(paid)
+	require.Equal(t, blockReward.Int64(), distributed.Add(dust).Int64())
+	require.Equal(t, reserve.Sub(paid).Int64(), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
 }
 
 func TestContractEndBlock(t *testing.T) {
@@ -334,6 +481,7 @@ func TestContractEndBlockWithSettlementDuration(t *testing.T) {
 	require.Equal(t, activeContract.SettlementHeight, int64(0))
 
 	// advance 10 more blocks and call end block
+	balance := k.GetBalance(ctx, user1Address).AmountOf(configs.Denom)
 	ctx = ctx.WithBlockHeight(activeContract.SettlementPeriodEnd())
 	err = mgr.ContractEndBlock(ctx)
 	require.NoError(t, err)
@@ -342,6 +490,11 @@ func TestContractEndBlockWithSettlementDuration(t *testing.T) {
 	activeContract, err = k.GetContract(ctx, activeContract.Id)
 	require.NoError(t, err)
 	require.Equal(t, activeContract.SettlementHeight, activeContract.SettlementPeriodEnd())
+
+	// the idle contract was never used, so the entire deposit is refunded
+	require.True(t, activeContract.Paid.IsZero())
+	require.True(t, activeContract.Deposit.IsZero())
+	require.Equal(t, balance.AddRaw(1500).Int64(), k.GetBalance(ctx, user1Address).AmountOf(configs.Denom).Int64())
 }
 
 func TestInvariantBondModule(t *testing.T) {
@@ -361,7 +514,7 @@ func TestInvariantBondModule(t *testing.T) {
 
 	// mint tokens into the provider module, and check that the invariant no longer fires
 	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(1000)))
-	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ProviderName, getCoins(1000)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ProviderName, getCoins(cosmos.NewInt(1000))))
 	require.NoError(t, mgr.invariantBondModule(ctx))
 }
 
@@ -385,8 +538,42 @@ func TestInvariantContractModule(t *testing.T) {
 
 	// mint tokens into the provider module, and check that the invariant no longer fires
 	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(1000)))
-	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(1000)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(cosmos.NewInt(1000))))
 	require.NoError(t, mgr.invariantContractModule(ctx))
+
+	// income held in escrow must be backed too
+	escrow := types.NewContractEscrow(contract, nil)
+	escrow.Amount = getCoin(800)
+	require.NoError(t, k.SetContractEscrow(ctx, escrow))
+	require.ErrorIs(t, mgr.invariantContractModule(ctx), types.ErrInvariantContractModule)
+
+	// a surplus does not break the invariant
+	escrow.Amount = getCoin(600)
+	require.NoError(t, k.SetContractEscrow(ctx, escrow))
+	require.NoError(t, mgr.invariantContractModule(ctx))
+
+	// a contract past its settlement period holds its unspent deposit until
+	// it is closed
+	ctx = ctx.WithBlockHeight(contract.SettlementPeriodEnd() + 1)
+	require.True(t, contract.IsSettled(ctx.BlockHeight()))
+	escrow.Amount = getCoin(750)
+	require.NoError(t, k.SetContractEscrow(ctx, escrow))
+	require.ErrorIs(t, mgr.invariantContractModule(ctx), types.ErrInvariantContractModule)
+	escrow.Amount = getCoin(600)
+	require.NoError(t, k.SetContractEscrow(ctx, escrow))
+
+	// closed, its deposit is what it paid
+	contract.Deposit = contract.Paid
+	require.NoError(t, k.SetContract(ctx, contract))
+	escrow.Amount = getCoin(1000)
+	require.NoError(t, k.SetContractEscrow(ctx, escrow))
+	require.NoError(t, mgr.invariantContractModule(ctx))
+	contract.Deposit = cosmos.NewInt(500)
+
+	// a contract that paid out more than its deposit
+	contract.Paid = cosmos.NewInt(600)
+	require.NoError(t, k.SetContract(ctx, contract))
+	require.ErrorIs(t, mgr.invariantContractModule(ctx), types.ErrInvariantContractModule)
 }
 
 func TestInvariantMaxSupply(t *testing.T) {
@@ -399,3 +586,1039 @@ func TestInvariantMaxSupply(t *testing.T) {
 	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(200_000_000*1e8)))
 	require.ErrorIs(t, mgr.invariantMaxSupply(ctx), types.ErrInvariantMaxSupply)
 }
+
+func TestContractEndBlockOrdering(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10).WithEventManager(sdk.NewEventManager())
+	mgr := NewManager(k, sk)
+
+	provA, provB := types.GetRandomPubKey(), types.GetRandomPubKey()
+	if provB.String() < provA.String() {
+		provA, provB = provB, provA
+	}
+	clientA, clientB := types.GetRandomPubKey(), types.GetRandomPubKey()
+	if clientB.String() < clientA.String() {
+		clientA, clientB = clientB, clientA
+	}
+
+	newContract := func(id uint64, provider common.PubKey, service common.Service, client common.PubKey) types.Contract {
+		contract := types.NewContract(provider, service, client)
+		contract.Id = id
+		contract.Type = types.ContractType_PAY_AS_YOU_GO
+		contract.Height = 5
+		contract.Duration = 5
+		contract.Rate = cosmos.NewInt64Coin(configs.Denom, 10)
+		return contract
+	}
+
+	// inserted in the opposite order they should be settled in
+	contracts := []types.Contract{
+		newContract(1, provB, common.BTCService, clientA),
+		newContract(2, provA, common.ETHService, clientA),
+		newContract(3, provA, common.BTCService, clientB),
+		newContract(4, provA, common.BTCService, clientA),
+	}
+	for _, contract := range contracts {
+		require.NoError(t, k.SetContract(ctx, contract))
+	}
+	require.NoError(t, k.SetUserContractSet(ctx, types.UserContractSet{
+		User:        clientA,
+		ContractSet: &types.ContractSet{ContractIds: []uint64{1, 2, 4}},
+	}))
+	require.NoError(t, k.SetUserContractSet(ctx, types.UserContractSet{
+		User:        clientB,
+		ContractSet: &types.ContractSet{ContractIds: []uint64{3}},
+	}))
+	require.NoError(t, k.SetContractExpirationSet(ctx, types.ContractExpirationSet{
+		Height:      ctx.BlockHeight(),
+		ContractSet: &types.ContractSet{ContractIds: []uint64{1, 2, 3, 4}},
+	}))
+
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+
+	settled := make([]uint64, 0)
+	for _, event := range ctx.EventManager().ABCIEvents() {
+		if event.Type != types.EventTypeSettleContract {
+			continue
+		}
+		msg, err := sdk.ParseTypedEvent(event)
+		require.NoError(t, err)
+		evt, ok := msg.(*types.EventSettleContract)
+		require.True(t, ok)
+		// contracts are closed when settled on expiration
+		require.True(t, evt.Closed)
+		settled = append(settled, evt.ContractId)
+	}
+	require.Equal(t, []uint64{4, 3, 2, 1}, settled)
+
+	// sorting is stable regardless of the input order
+	sorted := sortContracts([]types.Contract{contracts[2], contracts[0], contracts[3], contracts[1]})
+	ids := make([]uint64, len(sorted))
+	for i, contract := range sorted {
+		ids[i] = contract.Id
+	}
+	require.Equal(t, []uint64{4, 3, 2, 1}, ids)
+}
+
+func TestValidatorPayoutZeroTokens(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+
+	// the second validator is still bonded, but has been slashed down to
+	// nothing
+	accs, _, votes := setupValidators(t, ctx, sk, []int64{100, 0})
+	acc1, acc2 := accs[0], accs[1]
+	val2, found := sk.GetValidator(ctx, cosmos.ValAddress(acc2))
+	require.True(t, found)
+	val2.Commission = stakingtypes.NewCommission(cosmos.NewDecWithPrec(1, 1), cosmos.ZeroDec(), cosmos.ZeroDec())
+	sk.SetValidator(ctx, val2)
+
+	delAcc := types.GetRandomBech32Addr()
+	sk.SetDelegation(ctx, stakingtypes.NewDelegation(delAcc, cosmos.ValAddress(acc2), cosmos.ZeroDec()))
+
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(common.Tokens(10))))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ReserveName, getCoins(cosmos.NewInt(common.Tokens(10)))))
+
+	mgr := NewManager(k, sk)
+	blockReward := cosmos.NewInt(100)
+	paid := mgr.payValidators(ctx, votes, configs.Denom, blockReward, nil)
+	require.Equal(t, blockReward.Int64(), paid.Int64())
+	require.Equal(t, blockReward.Int64(), k.GetBalance(ctx, acc1).AmountOf(configs.Denom).Int64())
+
+	// nothing is sent to the zero token validator or its delegates
+	require.True(t, k.GetBalance(ctx, acc2).IsZero())
+	require.True(t, k.GetBalance(ctx, delAcc).IsZero())
+
+	payouts := 0
+	for _, evt := range ctx.EventManager().Events() {
+		if evt.Type == types.EventTypeValidatorPayout {
+			payouts++
+		}
+	}
+	require.Equal(t, 1, payouts)
+}
+
+func TestValidatorPayoutJailed(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+
+	accs, _, votes := setupValidators(t, ctx, sk, []int64{100, 300})
+	acc1, acc2 := accs[0], accs[1]
+
+	// jailed in this block, status has not transitioned yet
+	val2, found := sk.GetValidator(ctx, cosmos.ValAddress(acc2))
+	require.True(t, found)
+	val2.Jailed = true
+	sk.SetValidator(ctx, val2)
+
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(common.Tokens(10))))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ReserveName, getCoins(cosmos.NewInt(common.Tokens(10)))))
+
+	mgr := NewManager(k, sk)
+	blockReward := cosmos.NewInt(100)
+	paid := mgr.payValidators(ctx, votes, configs.Denom, blockReward, nil)
+
+	// the jailed validator's tokens are excluded from the total, so the
+	// remaining validator receives the whole block reward
+	require.Equal(t, blockReward.Int64(), paid.Int64())
+	require.Equal(t, blockReward.Int64(), k.GetBalance(ctx, acc1).AmountOf(configs.Denom).Int64())
+	require.True(t, k.GetBalance(ctx, acc2).IsZero())
+}
+
+func FuzzContractDebt(f *testing.F) {
+	f.Add(uint32(15), uint32(10), uint32(0), uint32(1500), uint32(10), uint16(100), uint16(10), uint32(50), true)
+	f.Add(uint32(15), uint32(200), uint32(100), uint32(1500), uint32(10), uint16(100), uint16(10), uint32(50), true)
+	f.Add(uint32(15), uint32(0), uint32(0), uint32(1500), uint32(10), uint16(100), uint16(0), uint32(50), false)
+	f.Add(uint32(15), uint32(0), uint32(300), uint32(1500), uint32(10), uint16(100), uint16(0), uint32(5), false)
+	f.Add(uint32(15), uint32(0), uint32(1500), uint32(1500), uint32(10), uint16(100), uint16(0), uint32(500), false)
+
+	f.Fuzz(func(t *testing.T, rate, nonce, paid, deposit, height uint32, duration, settlementDuration uint16, blockHeight uint32, payg bool) {
+		contract := types.Contract{
+			Type:               types.ContractType_SUBSCRIPTION,
+			Rate:               cosmos.NewInt64Coin(configs.Denom, int64(rate)),
+			Nonce:              int64(nonce),
+			Paid:               cosmos.NewInt(int64(paid)),
+			Deposit:            cosmos.NewInt(int64(deposit)),
+			Height:             int64(height),
+			Duration:           int64(duration),
+			SettlementDuration: int64(settlementDuration),
+		}
+		if payg {
+			contract.Type = types.ContractType_PAY_AS_YOU_GO
+		}
+
+		debt, err := calcContractDebt(contract, int64(blockHeight))
+		require.NoError(t, err)
+
+		// debt is never negative
+		require.False(t, debt.IsNegative())
+
+		// providers can never be paid more than the deposit
+		if contract.Paid.GTE(contract.Deposit) {
+			require.True(t, debt.IsZero())
+			return
+		}
+		require.True(t, contract.Paid.Add(debt).LTE(contract.Deposit))
+
+		// within bounds, debt is exactly what is owed
+		var owed cosmos.Int
+		if contract.IsPayAsYouGo() {
+			owed = contract.Rate.Amount.MulRaw(contract.Nonce)
+		} else {
+			end := int64(blockHeight)
+			if end > contract.SettlementPeriodEnd() {
+				end = contract.SettlementPeriodEnd()
+			}
+			owed = contract.Rate.Amount.MulRaw(end - contract.Height)
+		}
+		expected := owed.Sub(contract.Paid)
+		if !expected.IsNegative() && contract.Paid.Add(expected).LTE(contract.Deposit) {
+			require.Equal(t, expected.String(), debt.String())
+		}
+	})
+}
+
+func TestContractDebtInvalidType(t *testing.T) {
+	contract := types.Contract{
+		Type:    types.ContractType(99),
+		Rate:    cosmos.NewInt64Coin(configs.Denom, 1),
+		Paid:    cosmos.ZeroInt(),
+		Deposit: cosmos.NewInt(10),
+	}
+	_, err := calcContractDebt(contract, 10)
+	require.ErrorIs(t, err, types.ErrInvalidContractType)
+}
+
+func TestContractDebtOverflow(t *testing.T) {
+	// rate times blocks, or nonce, is well past the int64 max, debt must be
+	// computed without wrapping and still be capped at the deposit
+	rate := cosmos.NewInt64Coin(configs.Denom, math.MaxInt64)
+	deposit := cosmos.NewInt(math.MaxInt64).MulRaw(10)
+
+	contract := types.Contract{
+		Type:     types.ContractType_SUBSCRIPTION,
+		Rate:     rate,
+		Paid:     cosmos.ZeroInt(),
+		Deposit:  deposit,
+		Height:   10,
+		Duration: 100,
+	}
+	debt, err := calcContractDebt(contract, 15)
+	require.NoError(t, err)
+	require.Equal(t, cosmos.NewInt(math.MaxInt64).MulRaw(5).String(), debt.String())
+
+	debt, err = calcContractDebt(contract, 110)
+	require.NoError(t, err)
+	require.Equal(t, deposit.String(), debt.String())
+
+	contract.Paid = cosmos.NewInt(math.MaxInt64)
+	debt, err = calcContractDebt(contract, 110)
+	require.NoError(t, err)
+	require.Equal(t, deposit.Sub(contract.Paid).String(), debt.String())
+
+	contract = types.Contract{
+		Type:    types.ContractType_PAY_AS_YOU_GO,
+		Rate:    rate,
+		Nonce:   math.MaxInt64,
+		Paid:    cosmos.ZeroInt(),
+		Deposit: deposit,
+	}
+	debt, err = calcContractDebt(contract, 10)
+	require.NoError(t, err)
+	require.Equal(t, deposit.String(), debt.String())
+}
+
+func TestValidatorPayoutReserveFloor(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+
+	accs, _, votes := setupValidators(t, ctx, sk, []int64{100})
+	acc := accs[0]
+
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(common.Tokens(50000))))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ReserveName, getCoins(cosmos.NewInt(common.Tokens(50000)))))
+	reserve := k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom)
+
+	mgr := NewManager(k, sk)
+	ctx = ctx.WithBlockHeight(mgr.FetchConfig(ctx, configs.ValidatorPayoutCycle))
+
+	// reserve at the floor, nothing is emitted
+	k.SetConfigOverride(ctx, configs.MinReserveFloor, reserve.Int64())
+	require.NoError(t, mgr.ValidatorPayout(ctx, votes))
+	require.Equal(t, reserve.Int64(), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
+	require.True(t, k.GetBalance(ctx, acc).IsZero())
+	require.True(t, mgr.EmissionAPR(ctx).IsZero())
+
+	// reserve below the floor
+	k.SetConfigOverride(ctx, configs.MinReserveFloor, reserve.Int64()+1)
+	require.NoError(t, mgr.ValidatorPayout(ctx, votes))
+	require.Equal(t, reserve.Int64(), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
+	require.True(t, k.GetBalance(ctx, acc).IsZero())
+
+	// reserve above the floor, emission resumes
+	k.SetConfigOverride(ctx, configs.MinReserveFloor, reserve.Int64()-1)
+	require.NoError(t, mgr.ValidatorPayout(ctx, votes))
+	require.True(t, k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).LT(reserve))
+	require.False(t, k.GetBalance(ctx, acc).IsZero())
+}
+
+func TestContractEndBlockAutoRenew(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	provider := types.NewProvider(providerPubKey, common.BTCService)
+	provider.Bond = cosmos.NewInt(20000000000)
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+
+	modProviderMsg := types.MsgModProvider{
+		Provider:            provider.PubKey,
+		Service:             common.BTCService.String(),
+		MinContractDuration: 10,
+		MaxContractDuration: 500,
+		Status:              types.ProviderStatus_ONLINE,
+		PayAsYouGoRate:      rates,
+		SubscriptionRate:    rates,
+	}
+	require.NoError(t, s.ModProviderHandle(ctx, &modProviderMsg))
+
+	userPubKey := types.GetRandomPubKey()
+	userAddress, err := userPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, userAddress, getCoin(common.Tokens(10))))
+
+	msg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          common.BTCService.String(),
+		Creator:          userAddress,
+		Client:           userPubKey,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         100,
+		Rate:             rates[0],
+		Deposit:          cosmos.NewInt(1500),
+		QueriesPerMinute: 1,
+		AutoRenew:        true,
+	}
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+
+	contract, err := k.GetActiveContractForUser(ctx, userPubKey, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	require.True(t, contract.AutoRenew)
+	balance := k.GetBalance(ctx, userAddress).AmountOf(configs.Denom)
+
+	// at expiration, the client has the funds, so the contract renews
+	ctx = ctx.WithBlockHeight(110).WithEventManager(sdk.NewEventManager())
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeContractRenewal))
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, contract.Renewals)
+	require.EqualValues(t, 200, contract.Duration)
+	require.EqualValues(t, 210, contract.Expiration())
+	require.Equal(t, int64(3000), contract.Deposit.Int64())
+	require.Equal(t, int64(1500), contract.Paid.Int64())
+	require.Equal(t, balance.SubRaw(1500).Int64(), k.GetBalance(ctx, userAddress).AmountOf(configs.Denom).Int64())
+
+	activeContract, err := k.GetActiveContractForUser(ctx, userPubKey, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	require.Equal(t, contract.Id, activeContract.Id)
+
+	// drain the client, the next expiration falls back to a normal close
+	require.NoError(t, k.SendFromAccountToModule(ctx, userAddress, types.ReserveName, k.GetBalance(ctx, userAddress)))
+	ctx = ctx.WithBlockHeight(210).WithEventManager(sdk.NewEventManager())
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	require.Equal(t, 0, countEvents(ctx, types.EventTypeContractRenewal))
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, contract.Renewals)
+	require.True(t, contract.IsSettled(ctx.BlockHeight()))
+	activeContract, err = k.GetActiveContractForUser(ctx, userPubKey, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	require.True(t, activeContract.IsEmpty())
+}
+
+func TestContractEndBlockAutoRenewProviderOffline(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	provider := types.NewProvider(providerPubKey, common.BTCService)
+	provider.Bond = cosmos.NewInt(20000000000)
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+
+	modProviderMsg := types.MsgModProvider{
+		Provider:            provider.PubKey,
+		Service:             common.BTCService.String(),
+		MinContractDuration: 10,
+		MaxContractDuration: 500,
+		Status:              types.ProviderStatus_ONLINE,
+		PayAsYouGoRate:      rates,
+		SubscriptionRate:    rates,
+	}
+	require.NoError(t, s.ModProviderHandle(ctx, &modProviderMsg))
+
+	userPubKey := types.GetRandomPubKey()
+	userAddress, err := userPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, userAddress, getCoin(common.Tokens(10))))
+
+	msg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          common.BTCService.String(),
+		Creator:          userAddress,
+		Client:           userPubKey,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         100,
+		Rate:             rates[0],
+		Deposit:          cosmos.NewInt(1500),
+		QueriesPerMinute: 1,
+		AutoRenew:        true,
+	}
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+
+	// the provider goes offline before the contract expires
+	modProviderMsg.Status = types.ProviderStatus_OFFLINE
+	require.NoError(t, s.ModProviderHandle(ctx, &modProviderMsg))
+
+	ctx = ctx.WithBlockHeight(110).WithEventManager(sdk.NewEventManager())
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	require.Equal(t, 0, countEvents(ctx, types.EventTypeContractRenewal))
+
+	activeContract, err := k.GetActiveContractForUser(ctx, userPubKey, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	require.True(t, activeContract.IsEmpty())
+}
+
+func TestContractEndBlockAutoRenewCarriesDeposit(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	provider := types.NewProvider(providerPubKey, common.BTCService)
+	provider.Bond = cosmos.NewInt(20000000000)
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+	require.NoError(t, s.ModProviderHandle(ctx, &types.MsgModProvider{
+		Provider:            provider.PubKey,
+		Service:             common.BTCService.String(),
+		MinContractDuration: 10,
+		MaxContractDuration: 500,
+		Status:              types.ProviderStatus_ONLINE,
+		PayAsYouGoRate:      rates,
+		SubscriptionRate:    rates,
+	}))
+
+	userPubKey := types.GetRandomPubKey()
+	userAddress, err := userPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, userAddress, getCoin(common.Tokens(10))))
+
+	_, err = s.OpenContract(ctx, &types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          common.BTCService.String(),
+		Creator:          userAddress,
+		Client:           userPubKey,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         100,
+		Rate:             rates[0],
+		Deposit:          cosmos.NewInt(1500),
+		QueriesPerMinute: 1,
+		AutoRenew:        true,
+	})
+	require.NoError(t, err)
+
+	// leave 1000 of the deposit unspent at expiration
+	contract, err := k.GetActiveContractForUser(ctx, userPubKey, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	require.NoError(t, k.SendFromAccountToModule(ctx, userAddress, types.ContractName, cosmos.NewCoins(getCoin(1000))))
+	contract.Deposit = contract.Deposit.AddRaw(1000)
+	require.NoError(t, k.SetContract(ctx, contract))
+	balance := k.GetBalance(ctx, userAddress).AmountOf(configs.Denom)
+
+	// the client only tops up what the unspent deposit does not cover
+	ctx = ctx.WithBlockHeight(110).WithEventManager(sdk.NewEventManager())
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeContractRenewal))
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, contract.Renewals)
+	require.Equal(t, int64(3000), contract.Deposit.Int64())
+	require.Equal(t, int64(1500), contract.Paid.Int64())
+	require.Equal(t, balance.SubRaw(500).Int64(), k.GetBalance(ctx, userAddress).AmountOf(configs.Denom).Int64())
+}
+
+// setupValidators creates a bonded validator, without commission and with
+// only a self delegation, for each of the given stakes and returns their
+// operator accounts, the validators and their votes
+func setupValidators(t *testing.T, ctx cosmos.Context, sk stakingkeeper.Keeper, stakes []int64) ([]cosmos.AccAddress, []stakingtypes.Validator, []abci.VoteInfo) {
+	pks := simapp.CreateTestPubKeys(len(stakes))
+	accs := make([]cosmos.AccAddress, len(stakes))
+	vals := make([]stakingtypes.Validator, len(stakes))
+	votes := make([]abci.VoteInfo, len(stakes))
+	for i, stake := range stakes {
+		pk, err := common.NewPubKeyFromCrypto(pks[i])
+		require.NoError(t, err)
+		accs[i], err = pk.GetMyAddress()
+		require.NoError(t, err)
+
+		val, err := stakingtypes.NewValidator(cosmos.ValAddress(accs[i]), pks[i], stakingtypes.Description{})
+		require.NoError(t, err)
+		val.Tokens = cosmos.NewInt(stake)
+		val.DelegatorShares = cosmos.NewDec(stake)
+		val.Status = stakingtypes.Bonded
+		val.Commission = stakingtypes.NewCommission(cosmos.ZeroDec(), cosmos.ZeroDec(), cosmos.ZeroDec())
+		sk.SetValidator(ctx, val)
+		require.NoError(t, sk.SetValidatorByConsAddr(ctx, val))
+		sk.SetNewValidatorByPowerIndex(ctx, val)
+		sk.SetDelegation(ctx, stakingtypes.NewDelegation(accs[i], val.GetOperator(), cosmos.NewDec(stake)))
+		vals[i] = val
+
+		consAddr, err := val.GetConsAddr()
+		require.NoError(t, err)
+		votes[i] = abci.VoteInfo{
+			Validator: abci.Validator{
+				Address: consAddr.Bytes(),
+				Power:   stake,
+			},
+			SignedLastBlock: true,
+		}
+	}
+	return accs, vals, votes
+}
+
+func TestValidatorPayoutEmissionWeights(t *testing.T) {
+	stakes := []int64{100, 200, 700}
+	blockReward := cosmos.NewInt(100_000)
+
+	payout := func(weights func(accs []cosmos.AccAddress) map[string]int64) []int64 {
+		ctx, k, sk := SetupKeeperWithStaking(t)
+		accs, _, votes := setupValidators(t, ctx, sk, stakes)
+		require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(common.Tokens(10))))
+		require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ReserveName, getCoins(cosmos.NewInt(common.Tokens(10)))))
+
+		mgr := NewManager(k, sk)
+		paid := mgr.payValidators(ctx, votes, configs.Denom, blockReward, weights(accs))
+		require.True(t, paid.LTE(blockReward))
+
+		balances := make([]int64, len(accs))
+		for i, acc := range accs {
+			balances[i] = k.GetBalance(ctx, acc).AmountOf(configs.Denom).Int64()
+		}
+		return balances
+	}
+	uniform := func(weight int64) func(accs []cosmos.AccAddress) map[string]int64 {
+		return func(accs []cosmos.AccAddress) map[string]int64 {
+			weights := make(map[string]int64)
+			for _, acc := range accs {
+				weights[acc.String()] = weight
+			}
+			return weights
+		}
+	}
+
+	unweighted := payout(func([]cosmos.AccAddress) map[string]int64 { return nil })
+	require.Equal(t, []int64{10_000, 20_000, 70_000}, unweighted)
+
+	// uniform weights reproduce the stake proportional split
+	require.Equal(t, unweighted, payout(uniform(configs.MaxBasisPoints)))
+	require.Equal(t, unweighted, payout(uniform(25_000)))
+	// validators without a weight are neutral
+	require.Equal(t, unweighted, payout(func([]cosmos.AccAddress) map[string]int64 { return map[string]int64{} }))
+
+	// doubling the weight of the first validator shifts rewards towards it
+	weighted := payout(func(accs []cosmos.AccAddress) map[string]int64 {
+		return map[string]int64{accs[0].String(): 2 * configs.MaxBasisPoints}
+	})
+	require.Equal(t, []int64{18_182, 18_182, 63_636}, weighted)
+}
+
+func TestCalcEmissionWeights(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	mgr := NewManager(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAcc, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	openContract := func(id uint64, service common.Service, height int64) {
+		contract := types.NewContract(providerPubKey, service, types.GetRandomPubKey())
+		contract.Id = id
+		contract.Height = height
+		contract.Duration = 5
+		contract.Rate = getCoin(10)
+		contract.Deposit = cosmos.NewInt(1000)
+		require.NoError(t, k.SetContract(ctx, contract))
+	}
+	openContract(1, common.BTCService, ctx.BlockHeight())
+	openContract(2, common.BTCService, ctx.BlockHeight())
+	openContract(3, common.ETHService, ctx.BlockHeight())
+	// expired contracts are not counted
+	openContract(4, common.ETHService, 1)
+
+	chainWeights, err := parseChainEmissionWeights("btc-mainnet-fullnode:20000")
+	require.NoError(t, err)
+	weights := mgr.calcEmissionWeights(ctx, chainWeights)
+	// two contracts at 20000 and one at the neutral weight
+	require.Equal(t, map[string]int64{providerAcc.String(): (2*20_000 + configs.MaxBasisPoints) / 3}, weights)
+}
+
+func TestParseChainEmissionWeights(t *testing.T) {
+	weights, err := parseChainEmissionWeights("")
+	require.NoError(t, err)
+	require.Empty(t, weights)
+
+	weights, err = parseChainEmissionWeights("btc-mainnet-fullnode:20000, eth-mainnet-fullnode:5000")
+	require.NoError(t, err)
+	require.Equal(t, map[common.Service]int64{
+		common.BTCService: 20_000,
+		common.ETHService: 5_000,
+	}, weights)
+
+	_, err = parseChainEmissionWeights("btc-mainnet-fullnode")
+	require.ErrorIs(t, err, types.ErrInvalidConfig)
+	_, err = parseChainEmissionWeights("btc-mainnet-fullnode:-1")
+	require.ErrorIs(t, err, types.ErrInvalidConfig)
+	_, err = parseChainEmissionWeights("bogus:100")
+	require.Error(t, err)
+}
+
+func TestProviderStatsSettlement(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	provider := types.NewProvider(providerPubKey, common.BTCService)
+	provider.Bond = cosmos.NewInt(20000000000)
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+
+	modProviderMsg := types.MsgModProvider{
+		Provider:            provider.PubKey,
+		Service:             common.BTCService.String(),
+		MinContractDuration: 10,
+		MaxContractDuration: 500,
+		Status:              types.ProviderStatus_ONLINE,
+		PayAsYouGoRate:      rates,
+		SubscriptionRate:    rates,
+	}
+	require.NoError(t, s.ModProviderHandle(ctx, &modProviderMsg))
+
+	user1PubKey := types.GetRandomPubKey()
+	user1Address, err := user1PubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, user1Address, getCoin(common.Tokens(10))))
+
+	msg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          common.BTCService.String(),
+		Creator:          user1Address,
+		Client:           user1PubKey,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         100,
+		Rate:             rates[0],
+		Deposit:          cosmos.NewInt(1500),
+		QueriesPerMinute: 1,
+	}
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+
+	// a second client closes their contract early
+	user2PubKey := types.GetRandomPubKey()
+	user2Address, err := user2PubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, user2Address, getCoin(common.Tokens(10))))
+	msg.Creator = user2Address
+	msg.Client = user2PubKey
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+	contract, err := k.GetActiveContractForUser(ctx, user2PubKey, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+
+	ctx = ctx.WithBlockHeight(20)
+	_, err = s.CloseContract(ctx, &types.MsgCloseContract{Creator: user2Address, ContractId: contract.Id})
+	require.NoError(t, err)
+
+	stats, err := k.GetProviderStats(ctx, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	require.Zero(t, stats.ContractsServed)
+	require.EqualValues(t, 1, stats.Breaches)
+	require.Equal(t, int64(150), stats.SettledAmount.Int64())
+
+	// the first contract runs its full course
+	ctx = ctx.WithBlockHeight(110)
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+
+	stats, err = k.GetProviderStats(ctx, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, stats.ContractsServed)
+	require.EqualValues(t, 1, stats.Breaches)
+	require.Equal(t, int64(1650), stats.SettledAmount.Int64())
+
+	res, err := k.ProviderStats(ctx, &types.QueryProviderStatsRequest{
+		Pubkey:  providerPubKey.String(),
+		Service: common.BTCService.String(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, stats, res.Stats)
+	require.Equal(t, cosmos.NewDecWithPrec(5, 1), res.Score)
+
+	// settling again does not count the contract twice
+	contract, err = k.GetContract(ctx, contract.Id-1)
+	require.NoError(t, err)
+	require.True(t, contract.IsSettled(ctx.BlockHeight()))
+	_, err = mgr.SettleContract(ctx.WithBlockHeight(120), contract, 0, true)
+	require.NoError(t, err)
+	stats, err = k.GetProviderStats(ctx, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, stats.ContractsServed)
+	require.Equal(t, int64(1650), stats.SettledAmount.Int64())
+}
+
+func TestClientStatsSettlement(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	provider := types.NewProvider(providerPubKey, common.BTCService)
+	provider.Bond = cosmos.NewInt(20000000000)
+	provider.LastUpdate = ctx.BlockHeight()
+	provider.Status = types.ProviderStatus_ONLINE
+	provider.MinContractDuration = 10
+	provider.MaxContractDuration = 500
+	provider.PayAsYouGoRate = getCoins(cosmos.NewInt(15))
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAddress, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAddress, getCoin(common.Tokens(10))))
+
+	msg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          common.BTCService.String(),
+		Creator:          clientAddress,
+		Client:           clientPubKey,
+		ContractType:     types.ContractType_PAY_AS_YOU_GO,
+		Duration:         100,
+		Rate:             getCoin(15),
+		Deposit:          cosmos.NewInt(1500),
+		QueriesPerMinute: 10,
+	}
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+
+	stats, err := k.GetClientStats(ctx, clientPubKey)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, stats.ContractsOpened)
+	require.True(t, stats.TotalSpent.IsZero())
+
+	// each claim adds the queries made since the last and what they cost
+	contract, err := k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	contract, err = mgr.SettleContract(ctx, contract, 20, false)
+	require.NoError(t, err)
+	_, err = mgr.SettleContract(ctx, contract, 30, false)
+	require.NoError(t, err)
+
+	res, err := k.ClientStats(ctx, &types.QueryClientStatsRequest{Client: clientPubKey.String()})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, res.Stats.ContractsOpened)
+	require.EqualValues(t, 30, res.Stats.QueriesClaimed)
+	require.Equal(t, int64(450), res.Stats.TotalSpent.AmountOf(configs.Denom).Int64())
+
+	_, err = k.ClientStats(ctx, &types.QueryClientStatsRequest{Client: "bogus"})
+	require.Error(t, err)
+}
+
+func TestSettleContractCommunityTax(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(110)
+	mgr := NewManager(k, sk)
+
+	contract := types.NewContract(types.GetRandomPubKey(), common.BTCService, types.GetRandomPubKey())
+	contract.Id = 1
+	contract.Type = types.ContractType_SUBSCRIPTION
+	contract.Height = 10
+	contract.Duration = 100
+	contract.Rate = getCoin(10)
+	contract.Deposit = cosmos.NewInt(1000)
+	contract.QueriesPerMinute = 1
+	require.NoError(t, k.SetContract(ctx, contract))
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(1000)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(cosmos.NewInt(1000))))
+
+	// no community share by default, the whole tax goes to the reserve
+	_, err := mgr.SettleContract(ctx, contract, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
+	require.True(t, k.GetBalanceOfModule(ctx, distrtypes.ModuleName, configs.Denom).IsZero())
+	require.Equal(t, 0, countEvents(ctx, types.EventTypeReserveTaxSplit))
+
+	// a quarter of the tax funds the community pool
+	k.SetConfigOverride(ctx, configs.CommunityTaxShare, 2500)
+	contract.Id = 2
+	require.NoError(t, k.SetContract(ctx, contract))
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(1000)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(cosmos.NewInt(1000))))
+
+	_, err = mgr.SettleContract(ctx, contract, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, int64(175), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
+	require.Equal(t, int64(25), k.GetBalanceOfModule(ctx, distrtypes.ModuleName, configs.Denom).Int64())
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeReserveTaxSplit))
+}
+
+func TestSettleContractAffiliateFee(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(110)
+	mgr := NewManager(k, sk)
+
+	affiliate := types.GetRandomBech32Addr()
+	contract := types.NewContract(types.GetRandomPubKey(), common.BTCService, types.GetRandomPubKey())
+	contract.Id = 1
+	contract.Type = types.ContractType_SUBSCRIPTION
+	contract.Height = 10
+	contract.Duration = 100
+	contract.Rate = getCoin(10)
+	contract.Deposit = cosmos.NewInt(1000)
+	contract.QueriesPerMinute = 1
+	contract.Affiliate = affiliate
+	contract.AffiliateFee = 2000
+	require.NoError(t, k.SetContract(ctx, contract))
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(1000)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(cosmos.NewInt(1000))))
+
+	// the affiliate takes a fifth of the 900 left to the provider after the
+	// reserve tax
+	_, err := mgr.SettleContract(ctx, contract, 0, false)
+	require.NoError(t, err)
+	providerAddress, err := contract.Provider.GetMyAddress()
+	require.NoError(t, err)
+	require.Equal(t, int64(180), k.GetBalance(ctx, affiliate).AmountOf(configs.Denom).Int64())
+	require.Equal(t, int64(720), k.GetBalance(ctx, providerAddress).AmountOf(configs.Denom).Int64())
+	require.Equal(t, int64(100), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeAffiliatePayment))
+}
+
+func TestContractDebtRateTiers(t *testing.T) {
+	contract := types.Contract{
+		Type:     types.ContractType_SUBSCRIPTION,
+		Rate:     cosmos.NewInt64Coin(configs.Denom, 10),
+		Paid:     cosmos.ZeroInt(),
+		Deposit:  cosmos.NewInt(1000),
+		Height:   10,
+		Duration: 100,
+		RateTiers: []types.RateTier{
+			{Threshold: 20, Rate: cosmos.NewInt64Coin(configs.Denom, 5)},
+			{Threshold: 50, Rate: cosmos.NewInt64Coin(configs.Denom, 2)},
+		},
+	}
+
+	// the first 20 blocks are charged at the contract's rate
+	debt, err := calcContractDebt(contract, 25)
+	require.NoError(t, err)
+	require.Equal(t, int64(150), debt.Int64())
+
+	debt, err = calcContractDebt(contract, 40)
+	require.NoError(t, err)
+	require.Equal(t, int64(200+50), debt.Int64())
+
+	debt, err = calcContractDebt(contract, 110)
+	require.NoError(t, err)
+	require.Equal(t, int64(200+150+100), debt.Int64())
+
+	// what has been paid is taken off the tiered cost
+	contract.Paid = cosmos.NewInt(250)
+	debt, err = calcContractDebt(contract, 110)
+	require.NoError(t, err)
+	require.Equal(t, int64(200), debt.Int64())
+
+	contract = types.Contract{
+		Type:    types.ContractType_PAY_AS_YOU_GO,
+		Rate:    cosmos.NewInt64Coin(configs.Denom, 10),
+		Paid:    cosmos.ZeroInt(),
+		Deposit: cosmos.NewInt(1000),
+		Nonce:   30,
+		RateTiers: []types.RateTier{
+			{Threshold: 20, Rate: cosmos.NewInt64Coin(configs.Denom, 5)},
+		},
+	}
+	debt, err = calcContractDebt(contract, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(200+50), debt.Int64())
+}
+
+func TestContractEndBlockSettlementInterval(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+	k.SetConfigOverride(ctx, configs.MinSettlementInterval, 10)
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAddress, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	provider := types.NewProvider(providerPubKey, common.BTCService)
+	provider.Bond = cosmos.NewInt(20000000000)
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+
+	modProviderMsg := types.MsgModProvider{
+		Provider:            provider.PubKey,
+		Service:             common.BTCService.String(),
+		MinContractDuration: 10,
+		MaxContractDuration: 500,
+		Status:              types.ProviderStatus_ONLINE,
+		PayAsYouGoRate:      rates,
+		SubscriptionRate:    rates,
+	}
+	require.NoError(t, s.ModProviderHandle(ctx, &modProviderMsg))
+
+	userPubKey := types.GetRandomPubKey()
+	userAddress, err := userPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, userAddress, getCoin(common.Tokens(10))))
+
+	msg := types.MsgOpenContract{
+		Provider:           providerPubKey,
+		Service:            common.BTCService.String(),
+		Creator:            userAddress,
+		Client:             userPubKey,
+		ContractType:       types.ContractType_SUBSCRIPTION,
+		Duration:           100,
+		Rate:               rates[0],
+		Deposit:            cosmos.NewInt(1500),
+		QueriesPerMinute:   1,
+		SettlementInterval: 5,
+	}
+	_, err = s.OpenContract(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrInvalidSettlementInterval)
+
+	msg.SettlementInterval = 20
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+
+	contract, err := k.GetActiveContractForUser(ctx, userPubKey, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	require.EqualValues(t, 20, contract.SettlementInterval)
+
+	// nothing is due between settlements
+	ctx = ctx.WithBlockHeight(20).WithEventManager(sdk.NewEventManager())
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	require.Equal(t, 0, countEvents(ctx, types.EventTypeSettleContract))
+
+	// 20 blocks at 15 a block, less the 10% reserve tax
+	ctx = ctx.WithBlockHeight(30).WithEventManager(sdk.NewEventManager())
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeSettleContract))
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), contract.Paid.Int64())
+	require.EqualValues(t, 0, contract.SettlementHeight)
+	require.Equal(t, int64(270), k.GetBalance(ctx, providerAddress).AmountOf(configs.Denom).Int64())
+
+	ctx = ctx.WithBlockHeight(50).WithEventManager(sdk.NewEventManager())
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(600), contract.Paid.Int64())
+	require.Equal(t, int64(540), k.GetBalance(ctx, providerAddress).AmountOf(configs.Denom).Int64())
+
+	// the final settlement pays the rest and ends the schedule
+	for height := int64(70); height <= 110; height += 20 {
+		ctx = ctx.WithBlockHeight(height).WithEventManager(sdk.NewEventManager())
+		require.NoError(t, mgr.ContractEndBlock(ctx))
+	}
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1500), contract.Paid.Int64())
+	require.EqualValues(t, 110, contract.SettlementHeight)
+	require.Equal(t, int64(1350), k.GetBalance(ctx, providerAddress).AmountOf(configs.Denom).Int64())
+
+	set, err := k.GetContractSettlementSet(ctx, 130)
+	require.NoError(t, err)
+	require.Empty(t, set.ContractSet.ContractIds)
+}
+
+func TestContractPruneEndBlock(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	mgr := NewManager(k, sk)
+	k.SetConfigOverride(ctx, configs.ContractRetention, 100)
+
+	newClosed := func(id uint64, settled int64) types.Contract {
+		contract := types.NewContract(types.GetRandomPubKey(), common.BTCService, types.GetRandomPubKey())
+		contract.Id = id
+		contract.Rate = getCoin(1)
+		contract.SettlementHeight = settled
+		require.NoError(t, k.SetContract(ctx, contract))
+		return contract
+	}
+	old := newClosed(1, 10)
+	recent := newClosed(2, 50)
+	escrowed := newClosed(3, 20)
+	escrow := types.NewContractEscrow(escrowed, nil)
+	require.NoError(t, k.SetContractEscrow(ctx, escrow))
+	open := types.NewContract(types.GetRandomPubKey(), common.BTCService, types.GetRandomPubKey())
+	open.Id = 4
+	require.NoError(t, k.SetContract(ctx, open))
+
+	// nothing has been closed long enough yet
+	ctx = ctx.WithBlockHeight(110)
+	require.NoError(t, mgr.ContractPruneEndBlock(ctx))
+	require.True(t, k.ContractExists(ctx, old.Id))
+
+	ctx = ctx.WithBlockHeight(140)
+	require.NoError(t, mgr.ContractPruneEndBlock(ctx))
+	require.False(t, k.ContractExists(ctx, old.Id))
+	require.True(t, k.ContractExists(ctx, recent.Id))
+	require.True(t, k.ContractExists(ctx, escrowed.Id))
+	require.True(t, k.ContractExists(ctx, open.Id))
+
+	// the escrowed contract is pruned once its escrow is released
+	k.RemoveContractEscrow(ctx, escrowed.Id)
+	ctx = ctx.WithBlockHeight(200)
+	require.NoError(t, mgr.ContractPruneEndBlock(ctx))
+	require.False(t, k.ContractExists(ctx, escrowed.Id))
+	require.False(t, k.ContractExists(ctx, recent.Id))
+	require.True(t, k.ContractExists(ctx, open.Id))
+
+	iter := k.GetClosedContractIterator(ctx)
+	defer iter.Close()
+	require.False(t, iter.Valid())
+
+	// pruning is disabled with a zero retention
+	pruned := newClosed(5, 10)
+	k.SetConfigOverride(ctx, configs.ContractRetention, 0)
+	require.NoError(t, mgr.ContractPruneEndBlock(ctx))
+	require.True(t, k.ContractExists(ctx, pruned.Id))
+}
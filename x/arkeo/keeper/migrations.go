@@ -0,0 +1,121 @@
+package keeper
+
+import (
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// Migrator handles the in-place store migrations of the arkeo module
+type Migrator struct {
+	keeper Keeper
+}
+
+func NewMigrator(k Keeper) Migrator {
+	return Migrator{keeper: k}
+}
+
+// Migrate1to2 bumps the stored version to the version of this binary, so the
+// new config defaults are picked up. v1 contracts are left as they are, a
+// contract without a rate limit is treated as having the minimum one. It is
+// safe to run more than once.
+func (m Migrator) Migrate1to2(ctx cosmos.Context) error {
+	oldVersion := m.keeper.GetVersion(ctx)
+	newVersion := oldVersion
+	if configs.SWVersion > oldVersion {
+		newVersion = configs.SWVersion
+		m.keeper.SetVersion(ctx, newVersion)
+	}
+	ctx.Logger().Info("migrating arkeo store", "old version", oldVersion, "new version", newVersion)
+
+	return nil
+}
+
+// Migrate2to3 builds the client contract index for the contracts that were
+// saved before the index existed. It is safe to run more than once.
+func (m Migrator) Migrate2to3(ctx cosmos.Context) error {
+	// collect first, saving a contract writes under the prefix being iterated
+	var contracts []types.Contract
+	iter := m.keeper.GetContractIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var contract types.Contract
+		if err := m.keeper.Cdc().Unmarshal(iter.Value(), &contract); err != nil {
+			ctx.Logger().Error("fail to unmarshal contract", "error", err)
+			continue
+		}
+		contracts = append(contracts, contract)
+	}
+	iter.Close()
+
+	for _, contract := range contracts {
+		if err := m.keeper.SetContract(ctx, contract); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Migrate3to4 builds the provider contract index for the contracts that were
+// saved before the index existed. Saving a contract writes all of its indices,
+// so this re-saves every contract just as Migrate2to3 does.
+func (m Migrator) Migrate3to4(ctx cosmos.Context) error {
+	return m.Migrate2to3(ctx)
+}
+
+// Migrate4to5 assigns numeric ids to the providers bonded before providers
+// had them, in store order. It is safe to run more than once.
+func (m Migrator) Migrate4to5(ctx cosmos.Context) error {
+	// collect first, saving a provider writes under the prefix being iterated
+	var providers []types.Provider
+	iter := m.keeper.GetProviderIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var provider types.Provider
+		if err := m.keeper.Cdc().Unmarshal(iter.Value(), &provider); err != nil {
+			ctx.Logger().Error("fail to unmarshal provider", "error", err)
+			continue
+		}
+		if provider.Id == 0 {
+			providers = append(providers, provider)
+		}
+	}
+	iter.Close()
+
+	for _, provider := range providers {
+		if err := m.keeper.SetProvider(ctx, provider); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Migrate5to6 splits the contract expiration sets, saved as a single record
+// per height, into a key per contract. It is safe to run more than once.
+func (m Migrator) Migrate5to6(ctx cosmos.Context) error {
+	// collect first, the legacy sets are removed as they are migrated
+	var sets []types.ContractExpirationSet
+	iter := m.keeper.GetLegacyContractExpirationSetIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var set types.ContractExpirationSet
+		if err := m.keeper.Cdc().Unmarshal(iter.Value(), &set); err != nil {
+			ctx.Logger().Error("fail to unmarshal contract expiration set", "error", err)
+			continue
+		}
+		sets = append(sets, set)
+	}
+	iter.Close()
+
+	for _, set := range sets {
+		if set.ContractSet != nil {
+			for _, id := range set.ContractSet.ContractIds {
+				if err := m.keeper.AddContractExpiration(ctx, set.Height, id); err != nil {
+					return err
+				}
+			}
+		}
+		m.keeper.RemoveLegacyContractExpirationSet(ctx, set.Height)
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package keeper
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate1to2(t *testing.T) {
+	ctx, k := SetupKeeper(t)
+	k.SetVersion(ctx, 1)
+
+	// v1 contract, without a rate limit, must be left as it is
+	contract := types.NewContract(types.GetRandomPubKey(), common.BTCService, types.GetRandomPubKey())
+	contract.Id = 1
+	contract.Height = 10
+	contract.Duration = 100
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	// v2 contract, rate limit must be left alone too
+	contract2 := types.NewContract(types.GetRandomPubKey(), common.BTCService, types.GetRandomPubKey())
+	contract2.Id = 2
+	contract2.Height = 10
+	contract2.Duration = 100
+	contract2.QueriesPerMinute = 30
+	require.NoError(t, k.SetContract(ctx, contract2))
+
+	m := NewMigrator(k)
+	// migration is idempotent
+	for i := 0; i < 2; i++ {
+		require.NoError(t, m.Migrate1to2(ctx))
+
+		require.GreaterOrEqual(t, k.GetVersion(ctx), configs.SWVersion)
+
+		contract, err := k.GetContract(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), contract.QueriesPerMinute)
+		require.Equal(t, int64(1), contractQPM(contract))
+
+		contract, err = k.GetContract(ctx, 2)
+		require.NoError(t, err)
+		require.Equal(t, int64(30), contract.QueriesPerMinute)
+	}
+}
+
+func TestMigrate2to3(t *testing.T) {
+	ctx, k := SetupKeeper(t)
+	kvStore := k.(KVStore)
+
+	client := types.GetRandomPubKey()
+	contract := types.NewContract(types.GetRandomPubKey(), common.BTCService, client)
+	contract.Id = 1
+	contract.Height = 10
+	contract.Duration = 100
+	contract.Rate = cosmos.NewInt64Coin(configs.Denom, 1)
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	// v2 contracts were saved without the client index
+	kvStore.del(ctx, kvStore.getClientContractKey(ctx, client, contract.Id))
+	resp, err := k.ClientContracts(ctx, &types.QueryClientContractsRequest{Client: client.String()})
+	require.NoError(t, err)
+	require.Empty(t, resp.Contracts)
+
+	m := NewMigrator(k)
+	// migration is idempotent
+	for i := 0; i < 2; i++ {
+		require.NoError(t, m.Migrate2to3(ctx))
+
+		resp, err = k.ClientContracts(ctx, &types.QueryClientContractsRequest{Client: client.String()})
+		require.NoError(t, err)
+		require.Len(t, resp.Contracts, 1)
+		require.Equal(t, contract.Id, resp.Contracts[0].Contract.Id)
+	}
+}
+
+func TestMigrate3to4(t *testing.T) {
+	ctx, k := SetupKeeper(t)
+	ctx = ctx.WithBlockHeight(20)
+	kvStore := k.(KVStore)
+
+	provider := types.GetRandomPubKey()
+	contract := types.NewContract(provider, common.BTCService, types.GetRandomPubKey())
+	contract.Id = 1
+	contract.Height = 10
+	contract.Duration = 100
+	contract.Rate = cosmos.NewInt64Coin(configs.Denom, 1)
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	// v3 contracts were saved without the provider index
+	kvStore.del(ctx, kvStore.getProviderContractKey(ctx, provider, common.BTCService, contract.Id))
+	count, err := k.CountOpenProviderContracts(ctx, provider, common.BTCService)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	m := NewMigrator(k)
+	// migration is idempotent
+	for i := 0; i < 2; i++ {
+		require.NoError(t, m.Migrate3to4(ctx))
+
+		count, err = k.CountOpenProviderContracts(ctx, provider, common.BTCService)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+	}
+}
+
+func TestMigrate4to5(t *testing.T) {
+	ctx, k := SetupKeeper(t)
+	kvStore := k.(KVStore)
+
+	// v4 providers were saved without an id
+	var pubkeys []common.PubKey
+	for i := 0; i < 2; i++ {
+		provider := types.NewProvider(types.GetRandomPubKey(), common.BTCService)
+		provider.Bond = cosmos.NewInt(100)
+		kvStore.setProvider(ctx, kvStore.GetKey(ctx, prefixProvider, provider.Key()), provider)
+		pubkeys = append(pubkeys, provider.PubKey)
+	}
+
+	m := NewMigrator(k)
+	// migration is idempotent
+	for i := 0; i < 2; i++ {
+		require.NoError(t, m.Migrate4to5(ctx))
+
+		seen := make(map[uint64]bool)
+		for _, pubkey := range pubkeys {
+			provider, err := k.GetProvider(ctx, pubkey, common.BTCService)
+			require.NoError(t, err)
+			require.NotZero(t, provider.Id)
+			require.False(t, seen[provider.Id])
+			seen[provider.Id] = true
+
+			byId, err := k.GetProviderById(ctx, provider.Id)
+			require.NoError(t, err)
+			require.True(t, pubkey.Equals(byId.PubKey))
+		}
+		require.Equal(t, uint64(3), k.GetNextProviderId(ctx))
+	}
+}
+
+func TestMigrate5to6(t *testing.T) {
+	ctx, k := SetupKeeper(t)
+	kvStore := k.(KVStore)
+
+	// v5 expirations were saved as a single set per height
+	for _, set := range []types.ContractExpirationSet{
+		{Height: 10, ContractSet: &types.ContractSet{ContractIds: []uint64{3, 1}}},
+		{Height: 20, ContractSet: &types.ContractSet{ContractIds: []uint64{2}}},
+	} {
+		kvStore.setContractExpirationSet(ctx, kvStore.GetKey(ctx, prefixContractExpirationSet, strconv.FormatInt(set.Height, 10)), set)
+	}
+
+	m := NewMigrator(k)
+	// migration is idempotent
+	for i := 0; i < 2; i++ {
+		require.NoError(t, m.Migrate5to6(ctx))
+
+		set, err := k.GetContractExpirationSet(ctx, 10)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{1, 3}, set.ContractSet.ContractIds)
+		set, err = k.GetContractExpirationSet(ctx, 20)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{2}, set.ContractSet.ContractIds)
+
+		iter := k.GetLegacyContractExpirationSetIterator(ctx)
+		require.False(t, iter.Valid())
+		iter.Close()
+	}
+}
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"github.com/arkeonetwork/arkeo/common"
 	"github.com/arkeonetwork/arkeo/common/cosmos"
 	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
@@ -29,15 +30,27 @@ func NewMsgServerImpl(keeper Keeper, sk stakingkeeper.Keeper) types.MsgServer {
 var _ types.MsgServer = msgServer{}
 
 func (k msgServer) FetchConfig(ctx cosmos.Context, name configs.ConfigName) int64 {
-	// TODO: use ctx to fetch config overrides from the chain state
-	return k.mgr.Configs(ctx).GetInt64Value(name)
+	return k.mgr.FetchConfig(ctx, name)
 }
 
-// convert int64s into coins asset
-func getCoins(vals ...int64) cosmos.Coins {
+func (k msgServer) isSupportedService(ctx cosmos.Context, service common.Service) bool {
+	return k.mgr.isSupportedService(ctx, service)
+}
+
+func (k msgServer) isProviderAllowed(ctx cosmos.Context, provider common.PubKey) bool {
+	return k.mgr.isProviderAllowed(ctx, provider)
+}
+
+func (k msgServer) isDenomAllowed(ctx cosmos.Context, denom string) bool {
+	return k.mgr.isDenomAllowed(ctx, denom)
+}
+
+// convert amounts into coins asset, amounts are kept as cosmos.Int to avoid
+// truncating large values
+func getCoins(vals ...cosmos.Int) cosmos.Coins {
 	coins := make(cosmos.Coins, len(vals))
 	for i, val := range vals {
-		coins[i] = getCoin(val)
+		coins[i] = cosmos.NewCoin(configs.Denom, val)
 	}
 	return coins
 }
@@ -43,6 +43,24 @@ func (k msgServer) BondProviderValidate(ctx cosmos.Context, msg *types.MsgBondPr
 		return errors.Wrapf(types.ErrDisabledHandler, "bond provider")
 	}
 
+	// in permissioned mode, only allow listed providers can register.
+	// Unbonding is always allowed, so removed providers can withdraw
+	if msg.Bond.IsPositive() && !k.isProviderAllowed(ctx, msg.Provider) {
+		return errors.Wrapf(types.ErrProviderNotAllowed, "%s", msg.Provider)
+	}
+
+	// new bond is only accepted for supported services, while bond of a
+	// service that has since been dropped can still be withdrawn
+	if msg.Bond.IsPositive() {
+		service, err := common.NewService(msg.Service)
+		if err != nil {
+			return errors.Wrapf(types.ErrInvalidService, "%s", err)
+		}
+		if !k.isSupportedService(ctx, service) {
+			return errors.Wrapf(types.ErrUnsupportedService, "%s", service)
+		}
+	}
+
 	// We allow providers to unbond WHILE active contracts are underway. This
 	// is because A) users can cancel their owned contracts at any time, and B)
 	// this is the way the provider signals to the service that they don't want
@@ -66,7 +84,7 @@ func (k msgServer) BondProviderHandle(ctx cosmos.Context, msg *types.MsgBondProv
 		return err
 	}
 
-	coins := getCoins(msg.Bond.Abs().Int64())
+	coins := getCoins(msg.Bond.Abs())
 
 	switch {
 	case msg.Bond.IsPositive():
@@ -78,7 +96,7 @@ func (k msgServer) BondProviderHandle(ctx cosmos.Context, msg *types.MsgBondProv
 		// provider is withdrawing their bond
 		// ensure we provider bond is never negative
 		if provider.Bond.LT(coins[0].Amount) {
-			return errors.Wrapf(types.ErrInsufficientFunds, "not enough bond to satisfy bond request: %d/%d", coins[0].Amount.Int64(), provider.Bond.Int64())
+			return errors.Wrapf(types.ErrInsufficientFunds, "not enough bond to satisfy bond request: %s/%s", coins[0].Amount, provider.Bond)
 		}
 		if err := k.SendFromModuleToAccount(ctx, types.ProviderName, addr, coins); err != nil {
 			return err
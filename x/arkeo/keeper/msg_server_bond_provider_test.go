@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"math"
 	"testing"
 
 	"github.com/arkeonetwork/arkeo/common"
@@ -32,6 +33,8 @@ func TestHandle(t *testing.T) {
 	// check balance as drawn down by two
 	bal := k.GetBalance(ctx, acct)
 	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), common.Tokens(2))
+	// check bond is held by the provider module
+	require.Equal(t, k.GetBalanceOfModule(ctx, types.ProviderName, configs.Denom).Int64(), common.Tokens(8))
 	// check that provider now exists
 	require.True(t, k.ProviderExists(ctx, msg.Provider, common.BTCService))
 	provider, err := k.GetProvider(ctx, msg.Provider, common.BTCService)
@@ -59,5 +62,44 @@ func TestHandle(t *testing.T) {
 
 	bal = k.GetBalance(ctx, acct) // check balance
 	require.Equal(t, bal.AmountOf(configs.Denom).Int64(), common.Tokens(10))
+	require.True(t, k.GetBalanceOfModule(ctx, types.ProviderName, configs.Denom).IsZero())
 	require.False(t, k.ProviderExists(ctx, msg.Provider, common.BTCService)) // should be removed
 }
+
+func TestGetCoins(t *testing.T) {
+	// amounts beyond the int64 range must not be truncated
+	large := cosmos.NewInt(math.MaxInt64).MulRaw(1000).AddRaw(7)
+	coins := getCoins(large, cosmos.NewInt(math.MaxInt64))
+	require.Len(t, coins, 2)
+	require.Equal(t, configs.Denom, coins[0].Denom)
+	require.True(t, coins[0].Amount.Equal(large))
+	require.Equal(t, configs.Denom, coins[1].Denom)
+	require.Equal(t, int64(math.MaxInt64), coins[1].Amount.Int64())
+}
+
+func TestHandleLargeBond(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	acct, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	bond := cosmos.NewInt(math.MaxInt64).MulRaw(2)
+	require.NoError(t, k.MintAndSendToAccount(ctx, acct, cosmos.NewCoin(configs.Denom, bond)))
+
+	msg := types.MsgBondProvider{
+		Creator:  acct,
+		Provider: providerPubKey,
+		Service:  common.BTCService.String(),
+		Bond:     bond,
+	}
+	require.NoError(t, s.BondProviderHandle(ctx, &msg))
+	require.True(t, k.GetBalanceOfModule(ctx, types.ProviderName, configs.Denom).Equal(bond))
+	require.True(t, k.GetBalance(ctx, acct).AmountOf(configs.Denom).IsZero())
+
+	provider, err := k.GetProvider(ctx, msg.Provider, common.BTCService)
+	require.NoError(t, err)
+	require.True(t, provider.Bond.Equal(bond))
+}
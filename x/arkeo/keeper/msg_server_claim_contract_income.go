@@ -3,6 +3,7 @@ package keeper
 import (
 	"context"
 
+	"github.com/arkeonetwork/arkeo/common"
 	"github.com/arkeonetwork/arkeo/common/cosmos"
 	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
@@ -45,6 +46,21 @@ func (k msgServer) ClaimContractIncomeValidate(ctx cosmos.Context, msg *types.Ms
 		return err
 	}
 
+	// the provider and service are given for authz grants restricted to
+	// them, they must be those of the contract
+	if !msg.Provider.IsEmpty() && !msg.Provider.Equals(contract.Provider) {
+		return errors.Wrapf(types.ErrClaimContractIncomeMismatch, "contract provider is %s, claim is for %s", contract.Provider, msg.Provider)
+	}
+	if msg.Service != "" {
+		service, err := common.NewService(msg.Service)
+		if err != nil {
+			return errors.Wrapf(types.ErrInvalidService, "%s", err)
+		}
+		if service != contract.Service {
+			return errors.Wrapf(types.ErrClaimContractIncomeMismatch, "contract service is %s, claim is for %s", contract.Service, service)
+		}
+	}
+
 	if contract.Nonce >= msg.Nonce {
 		return errors.Wrapf(types.ErrClaimContractIncomeBadNonce, "contract nonce (%d) is greater than msg nonce (%d)", contract.Nonce, msg.Nonce)
 	}
@@ -53,6 +69,24 @@ func (k msgServer) ClaimContractIncomeValidate(ctx cosmos.Context, msg *types.Ms
 		return errors.Wrapf(types.ErrClaimContractIncomeClosed, "settled on block: %d", contract.SettlementPeriodEnd())
 	}
 
+	// a provider cannot claim more pay-as-you-go queries than the client
+	// could have made at the contract's queries per minute
+	if contract.IsPayAsYouGo() {
+		allowed := contract.AllowedQueries(ctx.BlockHeight(), k.FetchConfig(ctx, configs.AvgBlockTime))
+		if !allowed.IsNegative() {
+			leeway := k.FetchConfig(ctx, configs.ClaimRateLeeway)
+			allowed = allowed.Add(common.GetSafeShare(cosmos.NewInt(leeway), cosmos.NewInt(configs.MaxBasisPoints), allowed))
+			if cosmos.NewInt(msg.Nonce - contract.Nonce).GT(allowed) {
+				return errors.Wrapf(types.ErrClaimContractIncomeRateExceeded, "%d queries claimed, %s allowed", msg.Nonce-contract.Nonce, allowed)
+			}
+		}
+	}
+
+	// free tier contracts are capped at the queries the provider gives away
+	if contract.MaxQueries > 0 && msg.Nonce > contract.MaxQueries {
+		return errors.Wrapf(types.ErrClaimContractIncomeRateExceeded, "nonce %d is over the free tier limit of %d queries", msg.Nonce, contract.MaxQueries)
+	}
+
 	// open subscription contracts do NOT need to verify the signature
 	if contract.IsSubscription() && contract.IsOpenAuthorization() {
 		return nil
@@ -75,6 +109,9 @@ func (k msgServer) ClaimContractIncomeHandle(ctx cosmos.Context, msg *types.MsgC
 		return err
 	}
 
+	if msg.Nonce > contract.Nonce {
+		contract.NonceHeight = ctx.BlockHeight()
+	}
 	_, err = k.mgr.SettleContract(ctx, contract, msg.Nonce, false)
 	return err
 }
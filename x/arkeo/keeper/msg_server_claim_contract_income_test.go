@@ -66,6 +66,19 @@ func TestValidate(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, s.ClaimContractIncomeValidate(ctx, &msg))
 
+	// the provider and service, when given, must be those of the contract
+	msg.Provider = pubkey
+	msg.Service = service.String()
+	require.NoError(t, s.ClaimContractIncomeValidate(ctx, &msg))
+	msg.Service = common.ETHService.String()
+	err = s.ClaimContractIncomeValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrClaimContractIncomeMismatch)
+	msg.Service = service.String()
+	msg.Provider = types.GetRandomPubKey()
+	err = s.ClaimContractIncomeValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrClaimContractIncomeMismatch)
+	msg.Provider = pubkey
+
 	// check closed contract
 	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + contract.Duration)
 	err = s.ClaimContractIncomeValidate(ctx, &msg)
@@ -84,7 +97,7 @@ func TestHandlePayAsYouGo(t *testing.T) {
 	service := common.BTCService
 	client := types.GetRandomPubKey()
 	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(common.Tokens(10*100*2))))
-	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(10*100)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(cosmos.NewInt(10*100))))
 	rate, err := cosmos.ParseCoin("10uarkeo")
 	require.NoError(t, err)
 
@@ -150,7 +163,7 @@ func TestHandleSubscription(t *testing.T) {
 	service := common.BTCService
 	client := types.GetRandomPubKey()
 	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(common.Tokens(10*100*2))))
-	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(10*100)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(cosmos.NewInt(10*100))))
 	rate, err := cosmos.ParseCoin("10uarkeo")
 	require.NoError(t, err)
 
@@ -203,3 +216,82 @@ func TestHandleSubscription(t *testing.T) {
 	require.Equal(t, rname, int64(100))
 	require.Equal(t, rname+cname+acct, contract.Rate.Amount.Int64()*contract.Duration)
 }
+
+func TestValidateQueryRate(t *testing.T) {
+	var err error
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(20)
+
+	s := newMsgServer(k, sk)
+
+	// setup
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	module.NewBasicManager().RegisterInterfaces(interfaceRegistry)
+	types.RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	pubkey := types.GetRandomPubKey()
+	acc, err := pubkey.GetMyAddress()
+	require.NoError(t, err)
+	kb := cKeys.NewInMemory(cdc)
+	info, _, err := kb.NewMnemonic("whatever", cKeys.English, `m/44'/931'/0'/0/0`, "", hd.Secp256k1)
+	require.NoError(t, err)
+	pk, err := info.GetPubKey()
+	require.NoError(t, err)
+	client, err := common.NewPubKeyFromCrypto(pk)
+	require.NoError(t, err)
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(common.Tokens(10*100*2))))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(cosmos.NewInt(10*100))))
+
+	contract := types.NewContract(pubkey, common.BTCService, client)
+	contract.Duration = 100
+	contract.Rate = getCoin(1)
+	contract.Height = 10
+	contract.Type = types.ContractType_PAY_AS_YOU_GO
+	contract.Deposit = cosmos.NewInt(1000)
+	contract.QueriesPerMinute = 10
+	contract.Id = 1
+	require.NoError(t, k.SetContract(ctx, contract))
+	k.SetConfigOverride(ctx, configs.AvgBlockTime, 6000)
+	k.SetConfigOverride(ctx, configs.ClaimRateLeeway, 0)
+
+	sign := func(nonce int64) *types.MsgClaimContractIncome {
+		msg := types.MsgClaimContractIncome{
+			ContractId: contract.Id,
+			Creator:    acc,
+			Nonce:      nonce,
+		}
+		msg.Signature, _, err = kb.Sign("whatever", msg.GetBytesToSign())
+		require.NoError(t, err)
+		return &msg
+	}
+
+	// 11 blocks of 6 seconds at 10 queries per minute
+	err = s.ClaimContractIncomeValidate(ctx, sign(12))
+	require.ErrorIs(t, err, types.ErrClaimContractIncomeRateExceeded)
+	require.NoError(t, s.ClaimContractIncomeValidate(ctx, sign(11)))
+
+	// the leeway allows for block time drift
+	k.SetConfigOverride(ctx, configs.ClaimRateLeeway, 1000)
+	require.NoError(t, s.ClaimContractIncomeValidate(ctx, sign(12)))
+
+	_, err = s.ClaimContractIncome(ctx, sign(12))
+	require.NoError(t, err)
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, contract.Nonce)
+	require.EqualValues(t, 20, contract.NonceHeight)
+
+	// only the blocks since the last claim count
+	k.SetConfigOverride(ctx, configs.ClaimRateLeeway, 0)
+	ctx = ctx.WithBlockHeight(22)
+	err = s.ClaimContractIncomeValidate(ctx, sign(16))
+	require.ErrorIs(t, err, types.ErrClaimContractIncomeRateExceeded)
+	require.NoError(t, s.ClaimContractIncomeValidate(ctx, sign(15)))
+
+	// no queries can be made once the contract expires
+	ctx = ctx.WithBlockHeight(contract.Expiration() + 5)
+	allowed := contract.AllowedQueries(ctx.BlockHeight(), 6000)
+	require.EqualValues(t, 91, allowed.Int64())
+}
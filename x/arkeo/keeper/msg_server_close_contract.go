@@ -81,21 +81,24 @@ func (k msgServer) CloseContractHandle(ctx cosmos.Context, msg *types.MsgCloseCo
 	if contract.IsPayAsYouGo() {
 		// add a new expiration return deposit to user
 		newHeight := ctx.BlockHeight() + contract.SettlementDuration
-		expirationSet, err := k.GetContractExpirationSet(ctx, newHeight)
-		if err != nil {
-			return err
-		}
-		expirationSet.Append(contract.Id)
-		err = k.SetContractExpirationSet(ctx, expirationSet)
-		if err != nil {
+		if err := k.AddContractExpiration(ctx, newHeight, contract.Id); err != nil {
 			return err
 		}
 	}
 
-	_, err = k.mgr.SettleContract(ctx, contract, 0, contract.IsSubscription())
+	settled, err := k.mgr.SettleContract(ctx, contract, 0, contract.IsSubscription())
 	if err != nil {
 		return err
 	}
 
+	// closing a contract early counts against the provider's reputation
+	if err := k.mgr.recordProviderBreach(ctx, contract); err != nil {
+		return err
+	}
+
+	if err := k.ContractHooks().AfterContractClosed(ctx, settled); err != nil {
+		return err
+	}
+
 	return k.EmitCloseContractEvent(ctx, &contract)
 }
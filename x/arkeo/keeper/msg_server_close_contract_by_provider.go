@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) CloseContractByProvider(goCtx context.Context, msg *types.MsgCloseContractByProvider) (*types.MsgCloseContractByProviderResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgCloseContractByProvider",
+		"contract_id", msg.ContractId,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.CloseContractByProviderValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed close contract by provider validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.CloseContractByProviderHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed close contract by provider handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgCloseContractByProviderResponse{}, nil
+}
+
+func (k msgServer) CloseContractByProviderValidate(ctx cosmos.Context, msg *types.MsgCloseContractByProvider) error {
+	if k.FetchConfig(ctx, configs.HandlerCloseContractByProvider) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "close contract by provider")
+	}
+
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	if contract.IsEmpty() {
+		return errors.Wrapf(types.ErrContractNotFound, "id: %d", msg.ContractId)
+	}
+
+	providerAccountAddress, err := contract.Provider.GetMyAddress()
+	if err != nil {
+		return err
+	}
+
+	if !msg.MustGetSigner().Equals(providerAccountAddress) {
+		return errors.Wrapf(types.ErrProviderCloseContractUnauthorized, "id: %d", msg.ContractId)
+	}
+
+	if contract.IsExpired(ctx.BlockHeight()) {
+		return errors.Wrapf(types.ErrCloseContractAlreadyClosed, "closed %d", contract.Expiration())
+	}
+
+	return nil
+}
+
+func (k msgServer) CloseContractByProviderHandle(ctx cosmos.Context, msg *types.MsgCloseContractByProvider) error {
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	// the contract is settled now, so it no longer needs to be picked up when
+	// it would have expired
+	k.RemoveContractExpiration(ctx, contract.SettlementPeriodEnd(), contract.Id)
+
+	// unlike a client close, there is no settlement period for pay-as-you-go
+	// contracts, the provider forfeits any queries they have not yet claimed
+	settled, err := k.mgr.SettleContract(ctx, contract, 0, true)
+	if err != nil {
+		return err
+	}
+
+	if err := k.mgr.recordProviderBreach(ctx, contract); err != nil {
+		return err
+	}
+
+	penalty, err := k.slashProviderBond(ctx, contract.Provider, contract.Service)
+	if err != nil {
+		return err
+	}
+
+	if err := k.ContractHooks().AfterContractClosed(ctx, settled); err != nil {
+		return err
+	}
+
+	if err := k.EmitCloseContractEvent(ctx, &contract); err != nil {
+		return err
+	}
+	return k.EmitCloseContractByProviderEvent(ctx, penalty, &contract)
+}
+
+// slashProviderBond takes the cancellation penalty from the provider's bond
+// and moves it to the reserve
+func (k msgServer) slashProviderBond(ctx cosmos.Context, pubkey common.PubKey, service common.Service) (cosmos.Int, error) {
+	bps := k.FetchConfig(ctx, configs.ProviderCancelPenalty)
+	if bps <= 0 {
+		return cosmos.ZeroInt(), nil
+	}
+	return k.mgr.SlashProvider(ctx, pubkey, service, bps, false, nil, "contract closed by provider")
+}
@@ -0,0 +1,123 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseContractByProviderValidate(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(14)
+
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAcct, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	contract := types.NewContract(providerPubKey, common.BTCService, clientPubKey)
+	contract.Duration = 100
+	contract.Height = 10
+	contract.Id = 1
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	// only the provider may close the contract this way
+	msg := types.MsgCloseContractByProvider{
+		Creator:    clientAcct,
+		ContractId: contract.Id,
+	}
+	err = s.CloseContractByProviderValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrProviderCloseContractUnauthorized)
+
+	msg.Creator = providerAcct
+	require.NoError(t, s.CloseContractByProviderValidate(ctx, &msg))
+
+	contract.Duration = 3
+	require.NoError(t, k.SetContract(ctx, contract))
+	err = s.CloseContractByProviderValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrCloseContractAlreadyClosed)
+}
+
+func TestCloseContractByProviderHandle(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAcct, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	service := common.BTCService
+
+	require.NoError(t, k.MintAndSendToAccount(ctx, providerAcct, getCoin(common.Tokens(10))))
+	require.NoError(t, s.BondProviderHandle(ctx, &types.MsgBondProvider{
+		Creator:  providerAcct,
+		Provider: providerPubKey,
+		Service:  service.String(),
+		Bond:     cosmos.NewInt(common.Tokens(10)),
+	}))
+
+	rate, err := cosmos.ParseCoin("5uarkeo")
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAcct, getCoin(common.Tokens(10))))
+	require.NoError(t, s.OpenContractHandle(ctx, &types.MsgOpenContract{
+		Creator:      clientAcct,
+		Client:       clientPubKey,
+		Service:      service.String(),
+		Provider:     providerPubKey,
+		Deposit:      cosmos.NewInt(500),
+		Rate:         rate,
+		Duration:     100,
+		ContractType: types.ContractType_SUBSCRIPTION,
+	}))
+	contract, err := k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, service)
+	require.NoError(t, err)
+	clientBalance := k.GetBalance(ctx, clientAcct).AmountOf(configs.Denom)
+	reserve := k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom)
+
+	ctx = ctx.WithBlockHeight(14).WithEventManager(cosmos.NewEventManager())
+	msg := types.MsgCloseContractByProvider{
+		Creator:    providerAcct,
+		ContractId: contract.Id,
+	}
+	require.NoError(t, s.CloseContractByProviderHandle(ctx, &msg))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeCloseContract))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeCloseContractByProvider))
+
+	// the owed debt is paid and the client refunded the remainder
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), contract.Paid.Int64())
+	require.Equal(t, ctx.BlockHeight(), contract.SettlementHeight)
+	require.True(t, k.GetBalanceOfModule(ctx, types.ContractName, configs.Denom).IsZero())
+	require.Equal(t, int64(18), k.GetBalance(ctx, providerAcct).AmountOf(configs.Denom).Int64())
+	require.Equal(t, clientBalance.AddRaw(480).Int64(), k.GetBalance(ctx, clientAcct).AmountOf(configs.Denom).Int64())
+
+	// 5% of the bond is taken and sent to the reserve
+	penalty := common.Tokens(10) / 20
+	provider, err := k.GetProvider(ctx, providerPubKey, service)
+	require.NoError(t, err)
+	require.Equal(t, common.Tokens(10)-penalty, provider.Bond.Int64())
+	require.Equal(t, common.Tokens(10)-penalty, k.GetBalanceOfModule(ctx, types.ProviderName, configs.Denom).Int64())
+	require.Equal(t, reserve.AddRaw(2+penalty).Int64(), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeSlashProvider))
+
+	stats, err := k.GetProviderStats(ctx, providerPubKey, service)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, stats.Breaches)
+
+	// the contract is no longer picked up when it would have expired
+	expirationSet, err := k.GetContractExpirationSet(ctx, contract.Expiration())
+	require.NoError(t, err)
+	require.Empty(t, expirationSet.ContractSet.ContractIds)
+}
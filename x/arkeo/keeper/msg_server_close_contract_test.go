@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/arkeonetwork/arkeo/common"
@@ -99,12 +100,61 @@ func TestCloseContractHandle(t *testing.T) {
 
 	bal = k.GetBalanceOfModule(ctx, types.ContractName, configs.Denom)
 	require.Equal(t, bal.Int64(), int64(0))
-	require.True(t, k.HasCoins(ctx, provider, getCoins(18)))
-	require.True(t, k.HasCoins(ctx, contract.ClientAddress(), getCoins(480)))
+	require.True(t, k.HasCoins(ctx, provider, getCoins(cosmos.NewInt(18))))
+	require.True(t, k.HasCoins(ctx, contract.ClientAddress(), getCoins(cosmos.NewInt(480))))
 	bal = k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom)
 	require.Equal(t, bal.Int64(), int64(100000002)) // open cost + fee
 }
 
+func TestCloseContractRefundAddress(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	provider, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	clientPubKey := types.GetRandomPubKey()
+	clientAccount, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	treasury, err := types.GetRandomPubKey().GetMyAddress()
+	require.NoError(t, err)
+
+	rate, err := cosmos.ParseCoin("5uarkeo")
+	require.NoError(t, err)
+
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAccount, getCoin(common.Tokens(10))))
+	err = s.OpenContractHandle(ctx, &types.MsgOpenContract{
+		Creator:       clientAccount,
+		Client:        clientPubKey,
+		Service:       common.BTCService.String(),
+		Provider:      providerPubKey,
+		Deposit:       cosmos.NewInt(500),
+		Rate:          rate,
+		Duration:      100,
+		ContractType:  types.ContractType_SUBSCRIPTION,
+		RefundAddress: treasury,
+	})
+	require.NoError(t, err)
+
+	contract, err := k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	require.Equal(t, treasury, contract.RefundAddress)
+	clientBalance := k.GetBalance(ctx, clientAccount)
+
+	ctx = ctx.WithBlockHeight(14)
+	require.NoError(t, s.CloseContractHandle(ctx, &types.MsgCloseContract{
+		Creator:    clientAccount,
+		ContractId: contract.Id,
+	}))
+
+	// the rest of the deposit goes to the refund address, not the client
+	require.True(t, k.HasCoins(ctx, provider, getCoins(cosmos.NewInt(18))))
+	require.True(t, k.HasCoins(ctx, treasury, getCoins(cosmos.NewInt(480))))
+	require.Equal(t, clientBalance, k.GetBalance(ctx, clientAccount))
+	require.True(t, k.GetBalanceOfModule(ctx, types.ContractName, configs.Denom).IsZero())
+}
+
 func TestCloseSubscriptionContract(t *testing.T) {
 	ctx, k, sk := SetupKeeperWithStaking(t)
 	ctx = ctx.WithBlockHeight(10)
@@ -294,3 +344,56 @@ func TestClosePayAsYouGoContract(t *testing.T) {
 	_, err = s.CloseContract(ctx, &closeContractMsg)
 	require.NoError(t, err)
 }
+
+// recordingHooks records the contract hooks called, in order
+type recordingHooks struct {
+	calls []string
+}
+
+func (h *recordingHooks) AfterContractOpened(ctx cosmos.Context, contract types.Contract) error {
+	h.calls = append(h.calls, fmt.Sprintf("opened %d", contract.Id))
+	return nil
+}
+
+func (h *recordingHooks) AfterContractSettled(ctx cosmos.Context, contract types.Contract) error {
+	h.calls = append(h.calls, fmt.Sprintf("settled %d at %d", contract.Id, contract.SettlementHeight))
+	return nil
+}
+
+func (h *recordingHooks) AfterContractClosed(ctx cosmos.Context, contract types.Contract) error {
+	h.calls = append(h.calls, fmt.Sprintf("closed %d at %d", contract.Id, contract.SettlementHeight))
+	return nil
+}
+
+func TestCloseContractHooks(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	hooks := &recordingHooks{}
+	kvStore := k.(KVStore)
+	kvStore.SetContractHooks(types.NewMultiContractHooks(hooks))
+	require.Panics(t, func() { kvStore.SetContractHooks(hooks) })
+	s := newMsgServer(kvStore, sk)
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAccount, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAccount, getCoin(common.Tokens(10))))
+
+	openContractMessage := types.MsgOpenContract{
+		Creator:      clientAccount,
+		Client:       clientPubKey,
+		Service:      common.BTCService.String(),
+		Provider:     types.GetRandomPubKey(),
+		Deposit:      cosmos.NewInt(500),
+		Rate:         cosmos.NewInt64Coin(configs.Denom, 5),
+		Duration:     100,
+		ContractType: types.ContractType_SUBSCRIPTION,
+	}
+	require.NoError(t, s.OpenContractHandle(ctx, &openContractMessage))
+	require.Equal(t, []string{"opened 1"}, hooks.calls)
+
+	// closing a subscription settles it right away
+	ctx = ctx.WithBlockHeight(14)
+	require.NoError(t, s.CloseContractHandle(ctx, &types.MsgCloseContract{Creator: clientAccount, ContractId: 1}))
+	require.Equal(t, []string{"opened 1", "settled 1 at 14", "closed 1 at 14"}, hooks.calls)
+}
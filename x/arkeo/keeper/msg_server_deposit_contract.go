@@ -0,0 +1,168 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) DepositContract(goCtx context.Context, msg *types.MsgDepositContract) (*types.MsgDepositContractResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgDepositContract",
+		"contract_id", msg.ContractId,
+		"deposit", msg.Deposit,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.DepositContractValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed deposit contract validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.DepositContractHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed deposit contract handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgDepositContractResponse{}, nil
+}
+
+func (k msgServer) DepositContractValidate(ctx cosmos.Context, msg *types.MsgDepositContract) error {
+	if k.FetchConfig(ctx, configs.HandlerDepositContract) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "deposit contract")
+	}
+
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	if contract.IsEmpty() {
+		return errors.Wrapf(types.ErrContractNotFound, "id: %d", msg.ContractId)
+	}
+
+	if !msg.MustGetSigner().Equals(contract.ClientAddress()) {
+		return errors.Wrapf(types.ErrDepositContract, "only the client can top up the contract")
+	}
+
+	if contract.Rate.Amount.IsZero() {
+		return errors.Wrapf(types.ErrDepositContract, "free tier contracts take no deposit")
+	}
+
+	if contract.IsPaused() {
+		return errors.Wrapf(types.ErrContractPaused, "paused since %d", contract.PausedHeight)
+	}
+
+	// an expired subscription in its grace period is renewed instead
+	if contract.SettlementHeight > 0 || contract.GraceEnd > 0 || contract.IsExpired(ctx.BlockHeight()) {
+		return errors.Wrapf(types.ErrCloseContractAlreadyClosed, "closed %d", contract.Expiration())
+	}
+
+	remaining := contract.Expiration() - ctx.BlockHeight()
+	switch contract.Type {
+	case types.ContractType_SUBSCRIPTION:
+		blocks, err := subscriptionDepositBlocks(contract, msg.Deposit)
+		if err != nil {
+			return err
+		}
+		provider, err := k.GetProvider(ctx, contract.Provider, contract.Service)
+		if err != nil {
+			return err
+		}
+		if remaining+blocks > provider.MaxContractDuration {
+			return errors.Wrapf(types.ErrOpenContractDuration, "contract would run for more than the provider's maximum duration of %d blocks", provider.MaxContractDuration)
+		}
+	case types.ContractType_PAY_AS_YOU_GO:
+		if multiple := k.FetchConfig(ctx, configs.MaxDepositMultiple); multiple > 0 {
+			queries := types.MaxQueries(remaining, k.FetchConfig(ctx, configs.AvgBlockTime), contract.QueriesPerMinute)
+			unspent := contract.Deposit.Sub(contract.Paid).Add(msg.Deposit)
+			if maxDeposit := contract.Rate.Amount.Mul(queries).MulRaw(multiple); unspent.GT(maxDeposit) {
+				return errors.Wrapf(types.ErrExcessiveDeposit, "pay-as-you-go deposit %s would exceed %d times the most it can spend (%s queries at rate %s)", unspent, multiple, queries, contract.Rate.Amount)
+			}
+		}
+	default:
+		return errors.Wrapf(types.ErrInvalidContractType, "%s", contract.Type.String())
+	}
+
+	maxTotalDeposit := k.FetchConfig(ctx, configs.MaxTotalDeposit)
+	if maxTotalDeposit > 0 {
+		deposits, err := k.SumClientDeposits(ctx, contract.Client)
+		if err != nil {
+			return err
+		}
+		total := deposits.AmountOf(contract.Rate.Denom).Add(msg.Deposit)
+		if total.GT(cosmos.NewInt(maxTotalDeposit)) {
+			return errors.Wrapf(types.ErrOpenContractMaxTotalDeposit, "total deposits would be %s, max is %d", total.String(), maxTotalDeposit)
+		}
+	}
+
+	return nil
+}
+
+func (k msgServer) DepositContractHandle(ctx cosmos.Context, msg *types.MsgDepositContract) error {
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	coins := cosmos.NewCoins(cosmos.NewCoin(contract.Rate.Denom, msg.Deposit))
+	if err := k.SendFromAccountToModule(ctx, msg.MustGetSigner(), types.ContractName, coins); err != nil {
+		return errors.Wrapf(err, "failed to send deposit=%s", msg.Deposit)
+	}
+	contract.Deposit = contract.Deposit.Add(msg.Deposit)
+
+	if contract.IsSubscription() {
+		// the subscription runs for the blocks the deposit pays for, and so
+		// moves to its new expiration set
+		k.RemoveContractExpiration(ctx, contract.ClosingHeight(), contract.Id)
+
+		blocks, err := subscriptionDepositBlocks(contract, msg.Deposit)
+		if err != nil {
+			return err
+		}
+		contract.Duration += blocks
+		contract.DepositBlocks += blocks
+
+		if err := k.AddContractExpiration(ctx, contract.SettlementPeriodEnd(), contract.Id); err != nil {
+			return err
+		}
+	}
+
+	if err := k.SetContract(ctx, contract); err != nil {
+		return err
+	}
+
+	return k.EmitDepositContractEvent(ctx, msg.Deposit, &contract)
+}
+
+// subscriptionDepositBlocks returns the blocks a top up extends the
+// subscription by. The blocks follow those already paid for and are priced
+// at the contract's rate and tiers, as they are charged when the contract
+// accrues. The deposit must pay for whole blocks, so the subscription is
+// funded to its new expiration exactly.
+func subscriptionDepositBlocks(contract types.Contract, deposit cosmos.Int) (int64, error) {
+	qpm := contractQPM(contract)
+	paid := subscriptionTieredBlocks(contract, contract.SettlementPeriodEnd())
+	blocks, cost := contract.TieredUnits(paid, deposit.QuoRaw(qpm))
+	if !cost.MulRaw(qpm).Equal(deposit) {
+		return 0, errors.Wrapf(types.ErrDepositContract, "deposit %s is not a whole number of blocks at rate %s and %d queries per minute", deposit, contract.Rate.Amount, qpm)
+	}
+	return blocks, nil
+}
+
+// contractQPM returns the queries per minute a subscription is priced at,
+// contracts opened before queries per minute were set are priced at one
+func contractQPM(contract types.Contract) int64 {
+	if contract.QueriesPerMinute < 1 {
+		return 1
+	}
+	return contract.QueriesPerMinute
+}
@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositContractSubscription(t *testing.T) {
+	ctx, k, s, _, contract := setupRenewContract(t)
+	userAddress := contract.ClientAddress()
+	ctx = ctx.WithBlockHeight(50)
+
+	// only the client may top up
+	err := s.DepositContractValidate(ctx, types.NewMsgDepositContract(types.GetRandomBech32Addr(), contract.Id, cosmos.NewInt(300)))
+	require.ErrorIs(t, err, types.ErrDepositContract)
+
+	// the deposit must pay for whole blocks
+	err = s.DepositContractValidate(ctx, types.NewMsgDepositContract(userAddress, contract.Id, cosmos.NewInt(301)))
+	require.ErrorIs(t, err, types.ErrDepositContract)
+
+	// and not run the contract past the provider's maximum duration
+	err = s.DepositContractValidate(ctx, types.NewMsgDepositContract(userAddress, contract.Id, cosmos.NewInt(15*441)))
+	require.ErrorIs(t, err, types.ErrOpenContractDuration)
+
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	_, err = s.DepositContract(ctx, types.NewMsgDepositContract(userAddress, contract.Id, cosmos.NewInt(300)))
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeDepositContract))
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1800), contract.Deposit.Int64())
+	require.Equal(t, int64(120), contract.Duration)
+	require.Equal(t, int64(20), contract.DepositBlocks)
+	require.Equal(t, int64(130), contract.Expiration())
+	require.Equal(t, int64(100), contract.RenewalPeriod())
+
+	// the contract moves to its new expiration set
+	set, err := k.GetContractExpirationSet(ctx, 110)
+	require.NoError(t, err)
+	require.Len(t, set.ContractSet.ContractIds, 0)
+	set, err = k.GetContractExpirationSet(ctx, 130)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{contract.Id}, set.ContractSet.ContractIds)
+
+	// an expired contract cannot be topped up
+	ctx = ctx.WithBlockHeight(131)
+	err = s.DepositContractValidate(ctx, types.NewMsgDepositContract(userAddress, contract.Id, cosmos.NewInt(300)))
+	require.ErrorIs(t, err, types.ErrCloseContractAlreadyClosed)
+
+	k.SetConfigOverride(ctx, configs.HandlerDepositContract, 1)
+	err = s.DepositContractValidate(ctx, types.NewMsgDepositContract(userAddress, contract.Id, cosmos.NewInt(300)))
+	require.ErrorIs(t, err, types.ErrDisabledHandler)
+}
+
+func TestDepositContractRateTiers(t *testing.T) {
+	ctx, k, s, _, contract := setupRenewContract(t)
+	userAddress := contract.ClientAddress()
+	ctx = ctx.WithBlockHeight(50)
+
+	// blocks past the first 110 are charged 10 instead of 15
+	contract.RateTiers = []types.RateTier{{Threshold: 110, Rate: cosmos.NewInt64Coin("uarkeo", 10)}}
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	// 10 blocks at 15 and 9 and a half at 10
+	err := s.DepositContractValidate(ctx, types.NewMsgDepositContract(userAddress, contract.Id, cosmos.NewInt(245)))
+	require.ErrorIs(t, err, types.ErrDepositContract)
+
+	// 10 blocks at 15 and 10 at 10
+	_, err = s.DepositContract(ctx, types.NewMsgDepositContract(userAddress, contract.Id, cosmos.NewInt(250)))
+	require.NoError(t, err)
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1750), contract.Deposit.Int64())
+	require.Equal(t, int64(20), contract.DepositBlocks)
+	require.Equal(t, int64(130), contract.Expiration())
+
+	// the deposit pays for exactly what the contract accrues by expiration
+	accrued, err := calcContractAccrued(contract, contract.Expiration())
+	require.NoError(t, err)
+	require.Equal(t, contract.Deposit, accrued)
+}
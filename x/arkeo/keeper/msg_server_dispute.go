@@ -0,0 +1,140 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) DisputeContract(goCtx context.Context, msg *types.MsgDisputeContract) (*types.MsgDisputeContractResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgDisputeContract",
+		"contract_id", msg.ContractId,
+		"reason", msg.Reason,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.DisputeContractValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed dispute contract validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.DisputeContractHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed dispute contract handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgDisputeContractResponse{}, nil
+}
+
+func (k msgServer) DisputeContractValidate(ctx cosmos.Context, msg *types.MsgDisputeContract) error {
+	if k.FetchConfig(ctx, configs.HandlerDisputeContract) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "dispute contract")
+	}
+
+	escrow, err := k.GetContractEscrow(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+	if escrow.IsEmpty() {
+		return errors.Wrapf(types.ErrInvalidDispute, "contract %d has no escrowed income", msg.ContractId)
+	}
+
+	clientAccountAddress, err := escrow.Client.GetMyAddress()
+	if err != nil {
+		return err
+	}
+	if !msg.MustGetSigner().Equals(clientAccountAddress) {
+		return errors.Wrapf(types.ErrDisputeUnauthorized, "id: %d", msg.ContractId)
+	}
+
+	if escrow.Disputed {
+		return errors.Wrapf(types.ErrInvalidDispute, "contract %d is already disputed", msg.ContractId)
+	}
+	if ctx.BlockHeight() >= escrow.ReleaseHeight {
+		return errors.Wrapf(types.ErrInvalidDispute, "dispute window closed at %d", escrow.ReleaseHeight)
+	}
+
+	return nil
+}
+
+func (k msgServer) DisputeContractHandle(ctx cosmos.Context, msg *types.MsgDisputeContract) error {
+	escrow, err := k.GetContractEscrow(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	escrow.Disputed = true
+	escrow.DisputeHeight = ctx.BlockHeight()
+	escrow.Reason = msg.Reason
+	// without a timeout the escrow stays frozen until an arbiter steps in
+	escrow.ReleaseHeight = 0
+	if timeout := k.FetchConfig(ctx, configs.DisputeTimeout); timeout > 0 {
+		escrow.ReleaseHeight = ctx.BlockHeight() + timeout
+	}
+	if err := k.SetContractEscrow(ctx, escrow); err != nil {
+		return err
+	}
+
+	return k.EmitDisputeContractEvent(ctx, escrow)
+}
+
+func (k msgServer) ResolveDispute(goCtx context.Context, msg *types.MsgResolveDispute) (*types.MsgResolveDisputeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgResolveDispute",
+		"authority", msg.Authority,
+		"contract_id", msg.ContractId,
+		"provider_basis_points", msg.ProviderBasisPoints,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.ResolveDisputeValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed resolve dispute validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.ResolveDisputeHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed resolve dispute handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgResolveDisputeResponse{}, nil
+}
+
+func (k msgServer) ResolveDisputeValidate(ctx cosmos.Context, msg *types.MsgResolveDispute) error {
+	if msg.Authority != k.GetAuthority() {
+		arbiter, err := sdk.AccAddressFromBech32(msg.Authority)
+		if err != nil || !k.IsArbiter(ctx, arbiter) {
+			return errors.Wrapf(types.ErrResolveDisputeUnauthorized, "%s", msg.Authority)
+		}
+	}
+
+	escrow, err := k.GetContractEscrow(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+	if escrow.IsEmpty() || !escrow.Disputed {
+		return errors.Wrapf(types.ErrInvalidDispute, "contract %d is not disputed", msg.ContractId)
+	}
+
+	return nil
+}
+
+func (k msgServer) ResolveDisputeHandle(ctx cosmos.Context, msg *types.MsgResolveDispute) error {
+	escrow, err := k.GetContractEscrow(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+	return k.mgr.resolveDispute(ctx, escrow, msg.ProviderBasisPoints, msg.Authority)
+}
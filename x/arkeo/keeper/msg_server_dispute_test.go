@@ -0,0 +1,261 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+// setupEscrowedContract opens a subscription at height 10 with the dispute
+// window set, returning the contract along with the provider and client
+// accounts
+func setupEscrowedContract(t *testing.T, ctx cosmos.Context, k Keeper, s *msgServer) (types.Contract, cosmos.AccAddress, cosmos.AccAddress) {
+	k.SetConfigOverride(ctx, configs.DisputeWindow, 10)
+	k.SetConfigOverride(ctx, configs.DisputeTimeout, 20)
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAcct, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	service := common.BTCService
+
+	require.NoError(t, k.MintAndSendToAccount(ctx, providerAcct, getCoin(common.Tokens(10))))
+	require.NoError(t, s.BondProviderHandle(ctx, &types.MsgBondProvider{
+		Creator:  providerAcct,
+		Provider: providerPubKey,
+		Service:  service.String(),
+		Bond:     cosmos.NewInt(common.Tokens(10)),
+	}))
+
+	rate, err := cosmos.ParseCoin("5uarkeo")
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAcct, getCoin(common.Tokens(10))))
+	require.NoError(t, s.OpenContractHandle(ctx, &types.MsgOpenContract{
+		Creator:      clientAcct,
+		Client:       clientPubKey,
+		Service:      service.String(),
+		Provider:     providerPubKey,
+		Deposit:      cosmos.NewInt(500),
+		Rate:         rate,
+		Duration:     100,
+		ContractType: types.ContractType_SUBSCRIPTION,
+	}))
+	contract, err := k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, service)
+	require.NoError(t, err)
+	return contract, providerAcct, clientAcct
+}
+
+func TestDisputeContract(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	contract, providerAcct, clientAcct := setupEscrowedContract(t, ctx, k, s)
+	clientBalance := k.GetBalance(ctx, clientAcct).AmountOf(configs.Denom)
+
+	// nothing to dispute before the contract is settled
+	msg := types.NewMsgDisputeContract(clientAcct, contract.Id, "provider served stale blocks")
+	require.ErrorIs(t, s.DisputeContractValidate(ctx, msg), types.ErrInvalidDispute)
+
+	// the provider's income is held back rather than paid out
+	ctx = ctx.WithBlockHeight(14)
+	contract, err := s.mgr.SettleContract(ctx, contract, 0, false)
+	require.NoError(t, err)
+	require.True(t, k.GetBalance(ctx, providerAcct).AmountOf(configs.Denom).IsZero())
+	escrow, err := k.GetContractEscrow(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(18), escrow.Amount.Amount.Int64())
+	require.Equal(t, int64(24), escrow.ReleaseHeight)
+
+	// only the client may dispute
+	require.ErrorIs(t, s.DisputeContractValidate(ctx, types.NewMsgDisputeContract(providerAcct, contract.Id, "bogus")), types.ErrDisputeUnauthorized)
+	require.NoError(t, s.DisputeContractValidate(ctx, msg))
+
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	require.NoError(t, s.DisputeContractHandle(ctx, msg))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeDisputeContract))
+	escrow, err = k.GetContractEscrow(ctx, contract.Id)
+	require.NoError(t, err)
+	require.True(t, escrow.Disputed)
+	require.Equal(t, int64(14), escrow.DisputeHeight)
+	require.Equal(t, int64(34), escrow.ReleaseHeight)
+	require.ErrorIs(t, s.DisputeContractValidate(ctx, msg), types.ErrInvalidDispute)
+
+	// later income is frozen along with the disputed escrow
+	ctx = ctx.WithBlockHeight(20)
+	_, err = s.mgr.SettleContract(ctx, contract, 0, false)
+	require.NoError(t, err)
+	escrow, err = k.GetContractEscrow(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(45), escrow.Amount.Amount.Int64())
+	require.Equal(t, int64(34), escrow.ReleaseHeight)
+
+	// resolved by gov or an arbiter only
+	arbiter := types.GetRandomBech32Addr()
+	resolve := types.NewMsgResolveDispute(arbiter.String(), contract.Id, 4_000)
+	require.ErrorIs(t, s.ResolveDisputeValidate(ctx, resolve), types.ErrResolveDisputeUnauthorized)
+	require.NoError(t, s.SetArbiterHandle(ctx, types.NewMsgSetArbiter(k.GetAuthority(), arbiter, true)))
+	require.NoError(t, s.ResolveDisputeValidate(ctx, resolve))
+	require.NoError(t, s.ResolveDisputeValidate(ctx, types.NewMsgResolveDispute(k.GetAuthority(), contract.Id, 0)))
+
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	require.NoError(t, s.ResolveDisputeHandle(ctx, resolve))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeResolveDispute))
+	require.Equal(t, int64(18), k.GetBalance(ctx, providerAcct).AmountOf(configs.Denom).Int64())
+	require.Equal(t, clientBalance.AddRaw(27).Int64(), k.GetBalance(ctx, clientAcct).AmountOf(configs.Denom).Int64())
+	escrow, err = k.GetContractEscrow(ctx, contract.Id)
+	require.NoError(t, err)
+	require.True(t, escrow.IsEmpty())
+	require.ErrorIs(t, s.ResolveDisputeValidate(ctx, resolve), types.ErrInvalidDispute)
+
+	// the escrowed income is backed by the contract module
+	require.NoError(t, s.mgr.invariantContractModule(ctx))
+}
+
+func TestEscrowEndBlock(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	released, releasedProvider, _ := setupEscrowedContract(t, ctx, k, s)
+	disputed, disputedProvider, disputedClient := setupEscrowedContract(t, ctx, k, s)
+
+	ctx = ctx.WithBlockHeight(14)
+	_, err := s.mgr.SettleContract(ctx, released, 0, false)
+	require.NoError(t, err)
+	_, err = s.mgr.SettleContract(ctx, disputed, 0, false)
+	require.NoError(t, err)
+	require.NoError(t, s.DisputeContractHandle(ctx, types.NewMsgDisputeContract(disputedClient, disputed.Id, "provider was offline")))
+
+	// the window is still open
+	ctx = ctx.WithBlockHeight(23)
+	require.NoError(t, s.mgr.EscrowEndBlock(ctx))
+	require.True(t, k.GetBalance(ctx, releasedProvider).AmountOf(configs.Denom).IsZero())
+
+	// the undisputed escrow goes to the provider once the window closes
+	ctx = ctx.WithBlockHeight(24).WithEventManager(cosmos.NewEventManager())
+	require.NoError(t, s.mgr.EscrowEndBlock(ctx))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeReleaseEscrow))
+	require.Equal(t, int64(18), k.GetBalance(ctx, releasedProvider).AmountOf(configs.Denom).Int64())
+	require.True(t, k.GetBalance(ctx, disputedProvider).AmountOf(configs.Denom).IsZero())
+	escrow, err := k.GetContractEscrow(ctx, released.Id)
+	require.NoError(t, err)
+	require.True(t, escrow.IsEmpty())
+
+	// a dispute nobody resolved times out in the provider's favour
+	ctx = ctx.WithBlockHeight(34).WithEventManager(cosmos.NewEventManager())
+	require.NoError(t, s.mgr.EscrowEndBlock(ctx))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeResolveDispute))
+	require.Equal(t, int64(18), k.GetBalance(ctx, disputedProvider).AmountOf(configs.Denom).Int64())
+	escrow, err = k.GetContractEscrow(ctx, disputed.Id)
+	require.NoError(t, err)
+	require.True(t, escrow.IsEmpty())
+}
+
+func TestEscrowReleasedBetweenSettlements(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	contract, providerAcct, _ := setupEscrowedContract(t, ctx, k, s)
+	providerBalance := func() int64 {
+		return k.GetBalance(ctx, providerAcct).AmountOf(configs.Denom).Int64()
+	}
+
+	ctx = ctx.WithBlockHeight(14)
+	contract, err := s.mgr.SettleContract(ctx, contract, 0, false)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(20)
+	contract, err = s.mgr.SettleContract(ctx, contract, 0, false)
+	require.NoError(t, err)
+	escrow, err := k.GetContractEscrow(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Len(t, escrow.Tranches, 2)
+	require.Equal(t, int64(45), escrow.Amount.Amount.Int64())
+	// a later settlement does not push back the release of the earlier one
+	require.Equal(t, int64(24), escrow.ReleaseHeight)
+
+	ctx = ctx.WithBlockHeight(24).WithEventManager(cosmos.NewEventManager())
+	require.NoError(t, s.mgr.EscrowEndBlock(ctx))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeReleaseEscrow))
+	require.Equal(t, int64(18), providerBalance())
+	escrow, err = k.GetContractEscrow(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(27), escrow.Amount.Amount.Int64())
+	require.Equal(t, int64(30), escrow.ReleaseHeight)
+
+	// settlements keep arriving while the earlier ones are released
+	ctx = ctx.WithBlockHeight(28)
+	contract, err = s.mgr.SettleContract(ctx, contract, 0, false)
+	require.NoError(t, err)
+	escrow, err = k.GetContractEscrow(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(63), escrow.Amount.Amount.Int64())
+	require.Equal(t, int64(30), escrow.ReleaseHeight)
+
+	ctx = ctx.WithBlockHeight(30)
+	require.NoError(t, s.mgr.EscrowEndBlock(ctx))
+	require.Equal(t, int64(45), providerBalance())
+	escrow, err = k.GetContractEscrow(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(36), escrow.Amount.Amount.Int64())
+	require.Equal(t, int64(38), escrow.ReleaseHeight)
+
+	ctx = ctx.WithBlockHeight(38)
+	require.NoError(t, s.mgr.EscrowEndBlock(ctx))
+	require.Equal(t, int64(81), providerBalance())
+	escrow, err = k.GetContractEscrow(ctx, contract.Id)
+	require.NoError(t, err)
+	require.True(t, escrow.IsEmpty())
+
+	// the escrowed income is backed by the contract module throughout
+	require.NoError(t, s.mgr.invariantContractModule(ctx))
+}
+
+func TestEscrowAffiliateFee(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+	k.SetConfigOverride(ctx, configs.ReserveTax, 0)
+
+	affiliate := types.GetRandomBech32Addr()
+	released, releasedProvider, _ := setupEscrowedContract(t, ctx, k, s)
+	disputed, disputedProvider, disputedClient := setupEscrowedContract(t, ctx, k, s)
+	for _, contract := range []*types.Contract{&released, &disputed} {
+		contract.Affiliate = affiliate
+		contract.AffiliateFee = 2000
+		require.NoError(t, k.SetContract(ctx, *contract))
+	}
+	clientBalance := k.GetBalance(ctx, disputedClient).AmountOf(configs.Denom)
+
+	// the affiliate is not paid while the income is escrowed
+	ctx = ctx.WithBlockHeight(14)
+	_, err := s.mgr.SettleContract(ctx, released, 0, false)
+	require.NoError(t, err)
+	_, err = s.mgr.SettleContract(ctx, disputed, 0, false)
+	require.NoError(t, err)
+	require.True(t, k.GetBalance(ctx, affiliate).AmountOf(configs.Denom).IsZero())
+	require.NoError(t, s.DisputeContractHandle(ctx, types.NewMsgDisputeContract(disputedClient, disputed.Id, "provider was offline")))
+
+	// the affiliate takes a fifth of the income released to the provider
+	ctx = ctx.WithBlockHeight(24).WithEventManager(cosmos.NewEventManager())
+	require.NoError(t, s.mgr.EscrowEndBlock(ctx))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeAffiliatePayment))
+	require.Equal(t, int64(4), k.GetBalance(ctx, affiliate).AmountOf(configs.Denom).Int64())
+	require.Equal(t, int64(16), k.GetBalance(ctx, releasedProvider).AmountOf(configs.Denom).Int64())
+
+	// a dispute resolved wholly for the client refunds all it was charged,
+	// the affiliate's fee included
+	require.NoError(t, s.ResolveDisputeHandle(ctx, types.NewMsgResolveDispute(k.GetAuthority(), disputed.Id, 0)))
+	require.Equal(t, clientBalance.AddRaw(20).Int64(), k.GetBalance(ctx, disputedClient).AmountOf(configs.Denom).Int64())
+	require.True(t, k.GetBalance(ctx, disputedProvider).AmountOf(configs.Denom).IsZero())
+	require.Equal(t, int64(4), k.GetBalance(ctx, affiliate).AmountOf(configs.Denom).Int64())
+
+	require.NoError(t, s.mgr.invariantContractModule(ctx))
+}
@@ -58,6 +58,9 @@ func (k msgServer) ModProviderValidate(ctx cosmos.Context, msg *types.MsgModProv
 			return errors.Wrapf(types.ErrInvalidModProviderMinContractDuration, "min contract duration is too long (%d/%d)", msg.MaxContractDuration, maxContractDuration)
 		}
 	}
+	if maxOpen := k.FetchConfig(ctx, configs.MaxOpenContracts); maxOpen > 0 && msg.MaxOpenContracts > maxOpen {
+		return errors.Wrapf(types.ErrInvalidModProviderMaxOpenContracts, "max open contracts is too high (%d/%d)", msg.MaxOpenContracts, maxOpen)
+	}
 
 	service, err := common.NewService(msg.Service)
 	if err != nil {
@@ -84,9 +87,10 @@ func (k msgServer) ModProviderHandle(ctx cosmos.Context, msg *types.MsgModProvid
 		return err
 	}
 
-	// update metadata URI
-	if len(msg.MetadataUri) > 0 {
+	// update metadata URI, the hash was of the old document
+	if len(msg.MetadataUri) > 0 && msg.MetadataUri != provider.MetadataUri {
 		provider.MetadataUri = msg.MetadataUri
+		provider.MetadataHash = nil
 	}
 
 	// update metadata nonce
@@ -104,6 +108,11 @@ func (k msgServer) ModProviderHandle(ctx cosmos.Context, msg *types.MsgModProvid
 	// update contract rates
 	provider.SubscriptionRate = msg.SubscriptionRate
 	provider.PayAsYouGoRate = msg.PayAsYouGoRate
+	provider.SubscriptionRateTiers = msg.SubscriptionRateTiers
+	provider.SubscriptionRatePerDay = k.FetchConfig(ctx, configs.SubscriptionRatePerDay) > 0
+	provider.PayAsYouGoRateTiers = msg.PayAsYouGoRateTiers
+	provider.FreeTier = msg.FreeTier
+	provider.MaxOpenContracts = msg.MaxOpenContracts
 	provider.SettlementDuration = msg.SettlementDuration
 
 	provider.LastUpdate = ctx.BlockHeight()
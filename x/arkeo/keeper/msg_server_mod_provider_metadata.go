@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"context"
+	"encoding/hex"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+func (k msgServer) ModProviderMetadata(goCtx context.Context, msg *types.MsgModProviderMetadata) (*types.MsgModProviderMetadataResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgModProviderMetadata",
+		"provider", msg.Provider,
+		"service", msg.Service,
+		"metadata uri", msg.MetadataUri,
+		"metadata hash", hex.EncodeToString(msg.MetadataHash),
+		"metadata nonce", msg.MetadataNonce,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.ModProviderMetadataValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed mod provider metadata validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.ModProviderMetadataHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed mod provider metadata handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgModProviderMetadataResponse{}, nil
+}
+
+func (k msgServer) ModProviderMetadataValidate(ctx cosmos.Context, msg *types.MsgModProviderMetadata) error {
+	if k.FetchConfig(ctx, configs.HandlerModProviderMetadata) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "mod provider metadata")
+	}
+
+	service, err := common.NewService(msg.Service)
+	if err != nil {
+		return errors.Wrapf(types.ErrInvalidService, "%s", err)
+	}
+	provider, err := k.GetProvider(ctx, msg.Provider, service)
+	if err != nil {
+		return err
+	}
+	if provider.Bond.IsZero() {
+		return errors.Wrapf(types.ErrInvalidModProviderNoBond, "bond cannot be zero")
+	}
+
+	// every version of the document gets a new nonce, so a replayed or
+	// reordered update cannot roll the metadata back
+	if msg.MetadataNonce <= provider.MetadataNonce {
+		return errors.Wrapf(types.ErrInvalidMetadataNonce, "nonce must be greater than %d, got %d", provider.MetadataNonce, msg.MetadataNonce)
+	}
+
+	return nil
+}
+
+func (k msgServer) ModProviderMetadataHandle(ctx cosmos.Context, msg *types.MsgModProviderMetadata) error {
+	service, err := common.NewService(msg.Service)
+	if err != nil {
+		return err
+	}
+	provider, err := k.GetProvider(ctx, msg.Provider, service)
+	if err != nil {
+		return err
+	}
+
+	provider.MetadataUri = msg.MetadataUri
+	provider.MetadataHash = msg.MetadataHash
+	provider.MetadataNonce = msg.MetadataNonce
+
+	if err := k.SetProvider(ctx, provider); err != nil {
+		return err
+	}
+	return k.EmitModProviderMetadataEvent(ctx, &provider)
+}
@@ -0,0 +1,82 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModProviderMetadata(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	s := newMsgServer(k, sk)
+
+	pubkey := types.GetRandomPubKey()
+	acct, err := pubkey.GetMyAddress()
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte(`{"version":"1.0"}`))
+
+	msg := types.NewMsgModProviderMetadata(acct, pubkey, common.BTCService.String(), "https://mad.hatter.net/metadata.json", hash[:], 2)
+
+	// provider must be bonded
+	_, err = s.ModProviderMetadata(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidModProviderNoBond)
+
+	provider := types.NewProvider(pubkey, common.BTCService)
+	provider.Bond = cosmos.NewInt(500)
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	// no metadata yet
+	req := &types.QueryProviderMetadataRequest{Pubkey: pubkey.String(), Service: common.BTCService.String()}
+	_, err = k.ProviderMetadata(ctx, req)
+	require.Error(t, err)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	_, err = s.ModProviderMetadata(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeModProviderMetadata))
+
+	res, err := k.ProviderMetadata(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "https://mad.hatter.net/metadata.json", res.MetadataUri)
+	require.Equal(t, hash[:], res.MetadataHash)
+	require.EqualValues(t, 2, res.MetadataNonce)
+
+	// the nonce must increase
+	hash = sha256.Sum256([]byte(`{"version":"1.1"}`))
+	msg.MetadataHash = hash[:]
+	_, err = s.ModProviderMetadata(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidMetadataNonce)
+
+	msg.MetadataNonce = 3
+	_, err = s.ModProviderMetadata(ctx, msg)
+	require.NoError(t, err)
+	res, err = k.ProviderMetadata(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, hash[:], res.MetadataHash)
+	require.EqualValues(t, 3, res.MetadataNonce)
+
+	// pointing mod provider at another document drops the stale hash
+	sRates, err := cosmos.ParseCoins("11uarkeo")
+	require.NoError(t, err)
+	modMsg := types.MsgModProvider{
+		Creator:             acct,
+		Provider:            pubkey,
+		Service:             common.BTCService.String(),
+		MetadataUri:         "https://mad.hatter.net/other.json",
+		MinContractDuration: 10,
+		MaxContractDuration: 500,
+		Status:              types.ProviderStatus_ONLINE,
+		SubscriptionRate:    sRates,
+		PayAsYouGoRate:      sRates,
+	}
+	require.NoError(t, s.ModProviderHandle(ctx, &modMsg))
+	res, err = k.ProviderMetadata(ctx, req)
+	require.NoError(t, err)
+	require.Empty(t, res.MetadataHash)
+	require.EqualValues(t, 3, res.MetadataNonce)
+}
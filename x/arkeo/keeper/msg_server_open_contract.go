@@ -48,11 +48,41 @@ func (k msgServer) OpenContractValidate(ctx cosmos.Context, msg *types.MsgOpenCo
 	if k.FetchConfig(ctx, configs.HandlerOpenContract) > 0 {
 		return errors.Wrapf(types.ErrDisabledHandler, "open contract")
 	}
+	if k.FetchConfig(ctx, configs.HaltContractOpening) > 0 {
+		return errors.Wrapf(types.ErrContractOpeningHalted, "new contracts cannot be opened")
+	}
+	if !k.isProviderAllowed(ctx, msg.Provider) {
+		return errors.Wrapf(types.ErrProviderNotAllowed, "%s", msg.Provider)
+	}
+	if !k.isDenomAllowed(ctx, msg.Rate.Denom) {
+		return errors.Wrapf(types.ErrDenomNotAllowed, "%s", msg.Rate.Denom)
+	}
+	if openCost := k.FetchConfig(ctx, configs.OpenContractCost); !msg.MaxOpenCost.IsNil() && msg.MaxOpenCost.LT(cosmos.NewInt(openCost)) {
+		return errors.Wrapf(types.ErrOpenContractMaxCost, "open cost %d is above the max open cost %s", openCost, msg.MaxOpenCost)
+	}
 
-	service, err := common.NewService(msg.Service)
+	// compare addresses rather than pubkeys, to catch the same key encoded
+	// differently
+	providerAddress, err := msg.Provider.GetMyAddress()
 	if err != nil {
 		return err
 	}
+	clientAddress, err := msg.Client.GetMyAddress()
+	if err != nil {
+		return err
+	}
+	if providerAddress.Equals(clientAddress) {
+		return errors.Wrapf(types.ErrSelfContract, "provider and client resolve to the same address %s", clientAddress)
+	}
+
+	service, err := common.NewService(msg.Service)
+	if err != nil {
+		return errors.Wrapf(types.ErrInvalidService, "%s", err)
+	}
+	if !k.isSupportedService(ctx, service) {
+		return errors.Wrapf(types.ErrUnsupportedService, "%s", service)
+	}
+
 	provider, err := k.GetProvider(ctx, msg.Provider, service)
 	if err != nil {
 		return err
@@ -71,6 +101,21 @@ func (k msgServer) OpenContractValidate(ctx cosmos.Context, msg *types.MsgOpenCo
 		return errors.Wrapf(types.ErrOpenContractBadProviderStatus, "has status %s", provider.Status.String())
 	}
 
+	// the provider's own limit applies, up to the MaxOpenContracts cap
+	limit := provider.MaxOpenContracts
+	if maxOpen := k.FetchConfig(ctx, configs.MaxOpenContracts); maxOpen > 0 && (limit == 0 || limit > maxOpen) {
+		limit = maxOpen
+	}
+	if limit > 0 {
+		open, err := k.CountOpenProviderContracts(ctx, msg.Provider, service)
+		if err != nil {
+			return err
+		}
+		if open >= limit {
+			return errors.Wrapf(types.ErrOpenContractCapacity, "provider has %d of %d contracts open", open, limit)
+		}
+	}
+
 	if msg.Duration > provider.MaxContractDuration {
 		return errors.Wrapf(types.ErrOpenContractDuration, "duration exceeds allowed maximum duration from provider")
 	}
@@ -87,10 +132,34 @@ func (k msgServer) OpenContractValidate(ctx cosmos.Context, msg *types.MsgOpenCo
 		if !msg.Rate.Amount.Equal(cosmos.NewCoins(provider.SubscriptionRate...).AmountOf(msg.Rate.Denom)) {
 			return errors.Wrapf(types.ErrOpenContractMismatchRate, "provider rates is %d, client sent %d", cosmos.NewCoins(provider.SubscriptionRate...).AmountOf(msg.Rate.Denom).Int64(), msg.Rate.Amount.Int64())
 		}
-		if !cosmos.NewInt(msg.Rate.Amount.Int64() * msg.Duration * msg.QueriesPerMinute).Equal(msg.Deposit) {
-			return errors.Wrapf(types.ErrOpenContractMismatchRate, "mismatch of rate*duration and deposit: %d * %d * %d != %d", msg.Rate.Amount.Int64(), msg.Duration, msg.QueriesPerMinute, msg.Deposit.Int64())
+		rate := msg.Rate
+		if provider.SubscriptionRatePerDay {
+			rate, err = k.perBlockRate(ctx, msg.Rate)
+			if err != nil {
+				return err
+			}
+		}
+		// the deposit must fund the contract for a meaningful number of blocks
+		minPeriods := k.FetchConfig(ctx, configs.MinPeriodsFunded)
+		if minDeposit := rate.Amount.MulRaw(minPeriods); msg.Deposit.LT(minDeposit) {
+			return errors.Wrapf(types.ErrInsufficientDeposit, "deposit %s does not cover %d blocks at rate %s", msg.Deposit, minPeriods, rate.Amount)
+		}
+		// the deposit pays for exactly the whole subscription, anything less
+		// underpays the provider and anything more is never spent
+		cost := rate.Amount.MulRaw(msg.Duration).MulRaw(msg.QueriesPerMinute)
+		if msg.Deposit.LT(cost) {
+			return errors.Wrapf(types.ErrInsufficientDeposit, "deposit %s does not cover rate*duration*qpm: %s * %d * %d = %s", msg.Deposit, rate.Amount, msg.Duration, msg.QueriesPerMinute, cost)
+		}
+		if msg.Deposit.GT(cost) {
+			return errors.Wrapf(types.ErrExcessiveDeposit, "deposit %s exceeds rate*duration*qpm: %s * %d * %d = %s", msg.Deposit, rate.Amount, msg.Duration, msg.QueriesPerMinute, cost)
 		}
 	case types.ContractType_PAY_AS_YOU_GO:
+		if msg.Rate.Amount.IsZero() {
+			if err := validateFreeTier(msg, provider); err != nil {
+				return err
+			}
+			break
+		}
 		if cosmos.NewCoins(provider.PayAsYouGoRate...).AmountOf(msg.Rate.Denom).IsZero() {
 			return errors.Wrapf(types.ErrOpenContractMismatchRate, "provider rates is 0, client sent %d", msg.Rate.Amount.Int64())
 		}
@@ -100,10 +169,43 @@ func (k msgServer) OpenContractValidate(ctx cosmos.Context, msg *types.MsgOpenCo
 		if msg.SettlementDuration != provider.SettlementDuration {
 			return errors.Wrapf(types.ErrOpenContractMismatchSettlementDuration, "pay-as-you-go provider settlement duration is %d, client sent %d", provider.SettlementDuration, msg.SettlementDuration)
 		}
+		if minDeposit := k.FetchConfig(ctx, configs.MinPayAsYouGoDeposit); msg.Deposit.LT(cosmos.NewInt(minDeposit)) {
+			return errors.Wrapf(types.ErrInsufficientDeposit, "pay-as-you-go deposit %s is below the minimum of %d", msg.Deposit, minDeposit)
+		}
+		// a deposit many times more than the queries per minute allow over the
+		// duration can never be spent
+		if multiple := k.FetchConfig(ctx, configs.MaxDepositMultiple); multiple > 0 {
+			queries := types.MaxQueries(msg.Duration, k.FetchConfig(ctx, configs.AvgBlockTime), msg.QueriesPerMinute)
+			if maxDeposit := msg.Rate.Amount.Mul(queries).MulRaw(multiple); msg.Deposit.GT(maxDeposit) {
+				return errors.Wrapf(types.ErrExcessiveDeposit, "pay-as-you-go deposit %s exceeds %d times the most it can spend (%s queries at rate %s)", msg.Deposit, multiple, queries, msg.Rate.Amount)
+			}
+		}
 	default:
 		return errors.Wrapf(types.ErrInvalidContractType, "%s", msg.ContractType.String())
 	}
 
+	if msg.SettlementInterval > 0 {
+		if minInterval := k.FetchConfig(ctx, configs.MinSettlementInterval); msg.SettlementInterval < minInterval {
+			return errors.Wrapf(types.ErrInvalidSettlementInterval, "settlement interval %d is below the minimum of %d blocks", msg.SettlementInterval, minInterval)
+		}
+	}
+
+	if maxFee := k.FetchConfig(ctx, configs.MaxAffiliateFee); msg.AffiliateFee > maxFee {
+		return errors.Wrapf(types.ErrInvalidAffiliate, "affiliate fee %d is over the maximum of %d basis points", msg.AffiliateFee, maxFee)
+	}
+
+	maxTotalDeposit := k.FetchConfig(ctx, configs.MaxTotalDeposit)
+	if maxTotalDeposit > 0 {
+		deposits, err := k.SumClientDeposits(ctx, msg.Client)
+		if err != nil {
+			return err
+		}
+		total := deposits.AmountOf(msg.Rate.Denom).Add(msg.Deposit)
+		if total.GT(cosmos.NewInt(maxTotalDeposit)) {
+			return errors.Wrapf(types.ErrOpenContractMaxTotalDeposit, "total deposits would be %s, max is %d", total.String(), maxTotalDeposit)
+		}
+	}
+
 	activeContract, err := k.GetActiveContractForUser(ctx, msg.GetSpender(), msg.Provider, service)
 	if err != nil {
 		return err
@@ -116,16 +218,36 @@ func (k msgServer) OpenContractValidate(ctx cosmos.Context, msg *types.MsgOpenCo
 	return nil
 }
 
+// validateFreeTier checks a zero rate contract against the provider's free
+// tier, which is free to the client so takes no deposit
+func validateFreeTier(msg *types.MsgOpenContract, provider types.Provider) error {
+	if provider.FreeTier.MaxDuration <= 0 {
+		return errors.Wrapf(types.ErrOpenContractMismatchRate, "provider does not offer a free tier")
+	}
+	if msg.Duration > provider.FreeTier.MaxDuration {
+		return errors.Wrapf(types.ErrOpenContractDuration, "duration exceeds the provider's free tier maximum of %d blocks", provider.FreeTier.MaxDuration)
+	}
+	if !msg.Deposit.IsZero() {
+		return errors.Wrapf(types.ErrExcessiveDeposit, "free tier contracts take no deposit, client sent %s", msg.Deposit)
+	}
+	if msg.SettlementDuration != provider.SettlementDuration {
+		return errors.Wrapf(types.ErrOpenContractMismatchSettlementDuration, "pay-as-you-go provider settlement duration is %d, client sent %d", provider.SettlementDuration, msg.SettlementDuration)
+	}
+	return nil
+}
+
 func (k msgServer) OpenContractHandle(ctx cosmos.Context, msg *types.MsgOpenContract) error {
 	openCost := k.FetchConfig(ctx, configs.OpenContractCost)
 	if openCost > 0 {
-		if err := k.SendFromAccountToModule(ctx, msg.MustGetSigner(), types.ReserveName, getCoins(openCost)); err != nil {
+		if err := k.SendFromAccountToModule(ctx, msg.MustGetSigner(), types.ReserveName, getCoins(cosmos.NewInt(openCost))); err != nil {
 			return errors.Wrapf(err, "failed to send open contract costs openCost=%d", openCost)
 		}
 	}
 
-	if err := k.SendFromAccountToModule(ctx, msg.MustGetSigner(), types.ContractName, cosmos.NewCoins(cosmos.NewCoin(msg.Rate.Denom, msg.Deposit))); err != nil {
-		return errors.Wrapf(err, "failed to send deposit=%d", msg.Deposit.Int64())
+	if msg.Deposit.IsPositive() {
+		if err := k.SendFromAccountToModule(ctx, msg.MustGetSigner(), types.ContractName, cosmos.NewCoins(cosmos.NewCoin(msg.Rate.Denom, msg.Deposit))); err != nil {
+			return errors.Wrapf(err, "failed to send deposit=%d", msg.Deposit.Int64())
+		}
 	}
 
 	service, err := common.NewService(msg.Service)
@@ -133,34 +255,74 @@ func (k msgServer) OpenContractHandle(ctx cosmos.Context, msg *types.MsgOpenCont
 		return err
 	}
 
+	provider, err := k.GetProvider(ctx, msg.Provider, service)
+	if err != nil {
+		return err
+	}
+
+	// subscription rates are stored per block, but are also kept per day for
+	// readability
+	rate := msg.Rate
+	ratePerDay := cosmos.NewCoin(msg.Rate.Denom, cosmos.ZeroInt())
+	if msg.ContractType == types.ContractType_SUBSCRIPTION {
+		if provider.SubscriptionRatePerDay {
+			ratePerDay = msg.Rate
+			rate, err = k.perBlockRate(ctx, msg.Rate)
+			if err != nil {
+				return err
+			}
+		} else {
+			ratePerDay = cosmos.NewCoin(msg.Rate.Denom, msg.Rate.Amount.MulRaw(k.blocksPerDay(ctx)))
+		}
+	}
+
+	rateTiers, err := k.contractRateTiers(ctx, provider, msg.ContractType, msg.Rate.Denom)
+	if err != nil {
+		return err
+	}
+
 	contract := types.Contract{
-		Provider:           msg.Provider,
-		Id:                 k.Keeper.GetAndIncrementNextContractId(ctx),
-		Service:            service,
-		Type:               msg.ContractType,
-		Client:             msg.Client,
-		Delegate:           msg.Delegate,
-		Duration:           msg.Duration,
-		Rate:               msg.Rate,
-		Deposit:            msg.Deposit,
-		Paid:               cosmos.ZeroInt(),
-		Height:             ctx.BlockHeight(),
-		SettlementDuration: msg.SettlementDuration,
-		Authorization:      msg.Authorization,
-		QueriesPerMinute:   msg.QueriesPerMinute,
+		Provider:            msg.Provider,
+		Id:                  k.Keeper.GetAndIncrementNextContractId(ctx),
+		Service:             service,
+		Type:                msg.ContractType,
+		Client:              msg.Client,
+		Delegate:            msg.Delegate,
+		Duration:            msg.Duration,
+		Rate:                rate,
+		RatePerDay:          ratePerDay,
+		Deposit:             msg.Deposit,
+		Paid:                cosmos.ZeroInt(),
+		Height:              ctx.BlockHeight(),
+		SettlementDuration:  msg.SettlementDuration,
+		Authorization:       msg.Authorization,
+		QueriesPerMinute:    msg.QueriesPerMinute,
+		Memo:                msg.Memo,
+		AutoRenew:           msg.AutoRenew,
+		AccruedAtRateChange: cosmos.ZeroInt(),
+		RefundAddress:       msg.RefundAddress,
+		RateTiers:           rateTiers,
+		SettlementInterval:  msg.SettlementInterval,
+		Affiliate:           msg.Affiliate,
+		AffiliateFee:        msg.AffiliateFee,
+	}
+	// free tier contracts are limited to the queries the provider gives away
+	if msg.Rate.Amount.IsZero() {
+		provider, err := k.GetProvider(ctx, msg.Provider, service)
+		if err != nil {
+			return err
+		}
+		contract.MaxQueries = provider.FreeTier.MaxQueries
 	}
 
 	// create expiration set
 	// these are used by the end blocker to settle contracts. We need to
 	// use the additional settlement period for pay as you go contracts.
-	expirationSet, err := k.GetContractExpirationSet(ctx, contract.SettlementPeriodEnd())
-	if err != nil {
+	if err := k.AddContractExpiration(ctx, contract.SettlementPeriodEnd(), contract.Id); err != nil {
 		return err
 	}
 
-	expirationSet.Append(contract.Id)
-	err = k.SetContractExpirationSet(ctx, expirationSet)
-	if err != nil {
+	if err := k.mgr.scheduleContractSettlement(ctx, contract); err != nil {
 		return err
 	}
 
@@ -180,10 +342,72 @@ func (k msgServer) OpenContractHandle(ctx cosmos.Context, msg *types.MsgOpenCont
 		return err
 	}
 
+	stats, err := k.GetClientStats(ctx, contract.Client)
+	if err != nil {
+		return err
+	}
+	stats.ContractsOpened++
+	if err := k.SetClientStats(ctx, stats); err != nil {
+		return err
+	}
+
 	err = k.SetContract(ctx, contract)
 	if err != nil {
 		return err
 	}
 
+	if err := k.ContractHooks().AfterContractOpened(ctx, contract); err != nil {
+		return err
+	}
+
 	return k.EmitOpenContractEvent(ctx, openCost, &contract)
 }
+
+func (k msgServer) blocksPerDay(ctx cosmos.Context) int64 {
+	return k.FetchConfig(ctx, configs.BlocksPerYear) / 365
+}
+
+// perBlockRate converts a subscription rate expressed per day into the per
+// block rate that is stored on the contract
+func (k msgServer) perBlockRate(ctx cosmos.Context, ratePerDay cosmos.Coin) (cosmos.Coin, error) {
+	blocksPerDay := k.blocksPerDay(ctx)
+	if blocksPerDay <= 0 {
+		return ratePerDay, nil
+	}
+	rate := ratePerDay.Amount.QuoRaw(blocksPerDay)
+	if rate.IsZero() {
+		return ratePerDay, errors.Wrapf(types.ErrOpenContractRate, "rate per day (%s) is less than one per block (%d blocks per day)", ratePerDay.String(), blocksPerDay)
+	}
+	return cosmos.NewCoin(ratePerDay.Denom, rate), nil
+}
+
+// providerRatePerDay returns whether the provider set its subscription rates
+// per day, in which case the rates it agrees to on its contracts are per day
+// as well
+func (k msgServer) providerRatePerDay(ctx cosmos.Context, pubkey common.PubKey, service common.Service) (bool, error) {
+	provider, err := k.GetProvider(ctx, pubkey, service)
+	if err != nil {
+		return false, err
+	}
+	return provider.SubscriptionRatePerDay, nil
+}
+
+// contractRateTiers returns the provider's rate tiers for the contract type in
+// the given denom. Like the rate, subscription tiers are stored per block.
+func (k msgServer) contractRateTiers(ctx cosmos.Context, provider types.Provider, contractType types.ContractType, denom string) ([]types.RateTier, error) {
+	var err error
+	if contractType == types.ContractType_PAY_AS_YOU_GO {
+		return types.RateTiersOf(provider.PayAsYouGoRateTiers, denom), nil
+	}
+
+	tiers := types.RateTiersOf(provider.SubscriptionRateTiers, denom)
+	if provider.SubscriptionRatePerDay {
+		for i := range tiers {
+			tiers[i].Rate, err = k.perBlockRate(ctx, tiers[i].Rate)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return tiers, nil
+}
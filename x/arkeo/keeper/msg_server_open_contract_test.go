@@ -60,6 +60,20 @@ func TestOpenContractValidate(t *testing.T) {
 	require.NoError(t, k.MintAndSendToAccount(ctx, acc, getCoin(common.Tokens(100*25))))
 	require.NoError(t, s.OpenContractValidate(ctx, &msg))
 
+	// check service
+	msg.Service = "bogus-mainnet-fullnode"
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrInvalidService)
+	msg.Service = service.String()
+	require.True(t, s.isSupportedService(ctx, service))
+
+	// once governance registers services, only those can be contracted
+	require.NoError(t, k.SetServiceInfo(ctx, types.ServiceInfo{Service: common.ETHService}))
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrUnsupportedService)
+	require.NoError(t, k.SetServiceInfo(ctx, types.ServiceInfo{Service: service}))
+	require.NoError(t, s.OpenContractValidate(ctx, &msg))
+
 	// check duration
 	msg.Duration = 10000000000000
 	err = s.OpenContractValidate(ctx, &msg)
@@ -85,6 +99,15 @@ func TestOpenContractValidate(t *testing.T) {
 	msg.Rate = cosmos.NewInt64Coin("uarkeo", 15)
 	msg.ContractType = types.ContractType_SUBSCRIPTION
 
+	// check max open cost
+	k.SetConfigOverride(ctx, configs.OpenContractCost, 10)
+	msg.MaxOpenCost = cosmos.NewInt(9)
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrOpenContractMaxCost)
+	msg.MaxOpenCost = cosmos.NewInt(10)
+	require.NoError(t, s.OpenContractValidate(ctx, &msg))
+	msg.MaxOpenCost = cosmos.Int{}
+
 	provider.Bond = cosmos.NewInt(1)
 	require.NoError(t, k.SetProvider(ctx, provider))
 	err = s.OpenContractValidate(ctx, &msg)
@@ -199,14 +222,9 @@ func TestOpenContract(t *testing.T) {
 		Rate:         cosmos.NewInt64Coin("uarkeo", 15),
 		Deposit:      cosmos.NewInt(1500),
 	}
+	// provider cannot open a contract with itself
 	_, err = s.OpenContract(ctx, &msg)
-	require.NoError(t, err)
-
-	contract, err := k.GetActiveContractForUser(ctx, providerPubKey, providerPubKey, service)
-	require.NoError(t, err)
-
-	require.False(t, contract.IsEmpty())
-	require.Equal(t, contract.Id, uint64(1))
+	require.ErrorIs(t, err, types.ErrSelfContract)
 
 	clientPubKey := types.GetRandomPubKey()
 	clientAddress, err := clientPubKey.GetMyAddress()
@@ -225,11 +243,11 @@ func TestOpenContract(t *testing.T) {
 	require.NoError(t, k.MintAndSendToAccount(ctx, clientAddress, getCoin(common.Tokens(10))))
 	require.NoError(t, s.OpenContractHandle(ctx, &msg))
 
-	contract, err = k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, service)
+	contract, err := k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, service)
 	require.NoError(t, err)
 
 	require.False(t, contract.IsEmpty())
-	require.Equal(t, contract.Id, uint64(2))
+	require.Equal(t, contract.Id, uint64(1))
 
 	_, err = s.OpenContract(ctx, &msg)
 	require.ErrorIs(t, err, types.ErrOpenContractAlreadyOpen)
@@ -244,7 +262,7 @@ func TestOpenContract(t *testing.T) {
 	require.NoError(t, err)
 
 	require.False(t, contract.IsEmpty())
-	require.Equal(t, contract.Id, uint64(3))
+	require.Equal(t, contract.Id, uint64(2))
 }
 
 func TestOpenContractWithSettlementPeriod(t *testing.T) {
@@ -355,3 +373,493 @@ func TestOpenContractWithSettlementPeriod(t *testing.T) {
 	_, err = s.ClaimContractIncome(ctx, &claimMsg)
 	require.ErrorIs(t, err, types.ErrClaimContractIncomeClosed)
 }
+
+func TestOpenContractPerBlockRate(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	s := newMsgServer(k, sk)
+
+	blocksPerDay := s.blocksPerDay(ctx)
+	require.Equal(t, s.FetchConfig(ctx, configs.BlocksPerYear)/365, blocksPerDay)
+
+	rate, err := s.perBlockRate(ctx, cosmos.NewInt64Coin("uarkeo", blocksPerDay*3+1))
+	require.NoError(t, err)
+	require.Equal(t, cosmos.NewInt64Coin("uarkeo", 3), rate)
+
+	// rates that would truncate to zero per block are rejected
+	_, err = s.perBlockRate(ctx, cosmos.NewInt64Coin("uarkeo", blocksPerDay-1))
+	require.ErrorIs(t, err, types.ErrOpenContractRate)
+}
+
+func TestOpenContractRatePerDay(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+	blocksPerDay := s.blocksPerDay(ctx)
+
+	providerPubKey := types.GetRandomPubKey()
+	service := common.BTCService
+	provider := types.NewProvider(providerPubKey, service)
+	provider.Bond = cosmos.NewInt(10000000000)
+	provider.Status = types.ProviderStatus_ONLINE
+	provider.MinContractDuration = 10
+	provider.MaxContractDuration = 500
+	provider.SubscriptionRate = cosmos.NewCoins(cosmos.NewInt64Coin("uarkeo", 15))
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAddress, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAddress, getCoin(common.Tokens(10))))
+	msg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          service.String(),
+		Creator:          clientAddress,
+		Client:           clientPubKey,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         100,
+		Rate:             cosmos.NewInt64Coin("uarkeo", 15),
+		Deposit:          cosmos.NewInt(100 * 15),
+		QueriesPerMinute: 1,
+	}
+
+	// rates are per block while disabled, the per day rate is derived
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+	contract, err := k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, service)
+	require.NoError(t, err)
+	require.Equal(t, cosmos.NewInt64Coin("uarkeo", 15), contract.Rate)
+	require.Equal(t, cosmos.NewInt64Coin("uarkeo", 15*blocksPerDay), contract.RatePerDay)
+
+	// enabling it leaves the rates the provider already set per block
+	k.SetConfigOverride(ctx, configs.SubscriptionRatePerDay, 1)
+	clientPubKey = types.GetRandomPubKey()
+	clientAddress, err = clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAddress, getCoin(common.Tokens(10))))
+	msg.Creator = clientAddress
+	msg.Client = clientPubKey
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+	contract, err = k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, service)
+	require.NoError(t, err)
+	require.Equal(t, cosmos.NewInt64Coin("uarkeo", 15), contract.Rate)
+	require.Equal(t, cosmos.NewInt64Coin("uarkeo", 15*blocksPerDay), contract.RatePerDay)
+
+	// once the provider sets its rates again, they and the client rates are
+	// per day and the deposit covers the per block rate
+	ratePerDay := cosmos.NewInt64Coin("uarkeo", 3*blocksPerDay)
+	require.NoError(t, s.ModProviderHandle(ctx, &types.MsgModProvider{
+		Provider:            providerPubKey,
+		Service:             service.String(),
+		MinContractDuration: provider.MinContractDuration,
+		MaxContractDuration: provider.MaxContractDuration,
+		Status:              types.ProviderStatus_ONLINE,
+		SubscriptionRate:    cosmos.NewCoins(ratePerDay),
+	}))
+	provider, err = k.GetProvider(ctx, providerPubKey, service)
+	require.NoError(t, err)
+	require.True(t, provider.SubscriptionRatePerDay)
+
+	clientPubKey = types.GetRandomPubKey()
+	clientAddress, err = clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAddress, getCoin(common.Tokens(10))))
+	msg.Creator = clientAddress
+	msg.Client = clientPubKey
+	msg.Rate = ratePerDay
+
+	// a deposit of the per day rate for every block is rejected
+	msg.Deposit = ratePerDay.Amount.MulRaw(100)
+	_, err = s.OpenContract(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrExcessiveDeposit)
+
+	msg.Deposit = cosmos.NewInt(100 * 3)
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+	contract, err = k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, service)
+	require.NoError(t, err)
+	require.Equal(t, cosmos.NewInt64Coin("uarkeo", 3), contract.Rate)
+	require.Equal(t, ratePerDay, contract.RatePerDay)
+	require.Equal(t, cosmos.NewInt(100*3), contract.Deposit)
+
+	// a per day rate below one per block is rejected
+	tooLow := cosmos.NewInt64Coin("uarkeo", blocksPerDay-1)
+	provider.SubscriptionRate = cosmos.NewCoins(tooLow)
+	require.NoError(t, k.SetProvider(ctx, provider))
+	clientPubKey = types.GetRandomPubKey()
+	clientAddress, err = clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAddress, getCoin(common.Tokens(10))))
+	msg.Creator = clientAddress
+	msg.Client = clientPubKey
+	msg.Rate = tooLow
+	_, err = s.OpenContract(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrOpenContractRate)
+}
+
+func TestOpenContractHalted(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	service := common.BTCService
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+	provider := types.NewProvider(providerPubKey, service)
+	provider.Bond = cosmos.NewInt(10000000000)
+	provider.Status = types.ProviderStatus_ONLINE
+	provider.MinContractDuration = 10
+	provider.MaxContractDuration = 500
+	provider.SubscriptionRate = rates
+	provider.PayAsYouGoRate = rates
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	newOpenMsg := func() types.MsgOpenContract {
+		clientPubKey := types.GetRandomPubKey()
+		clientAddress, err := clientPubKey.GetMyAddress()
+		require.NoError(t, err)
+		require.NoError(t, k.MintAndSendToAccount(ctx, clientAddress, getCoin(common.Tokens(10))))
+		return types.MsgOpenContract{
+			Provider:         providerPubKey,
+			Service:          service.String(),
+			Creator:          clientAddress,
+			Client:           clientPubKey,
+			ContractType:     types.ContractType_SUBSCRIPTION,
+			Duration:         100,
+			Rate:             rates[0],
+			Deposit:          cosmos.NewInt(100 * 15),
+			QueriesPerMinute: 1,
+		}
+	}
+
+	// open two contracts before the halt
+	msg1 := newOpenMsg()
+	_, err = s.OpenContract(ctx, &msg1)
+	require.NoError(t, err)
+	msg2 := newOpenMsg()
+	_, err = s.OpenContract(ctx, &msg2)
+	require.NoError(t, err)
+
+	// halt
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	_, err = s.SetConfig(ctx, types.NewMsgSetConfig(k.GetAuthority(), configs.HaltContractOpening.String(), 1))
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeContractHalt))
+
+	msg3 := newOpenMsg()
+	_, err = s.OpenContract(ctx, &msg3)
+	require.ErrorIs(t, err, types.ErrContractOpeningHalted)
+
+	// existing contracts can still be closed and refunded
+	ctx = ctx.WithBlockHeight(20)
+	contract1, err := k.GetActiveContractForUser(ctx, msg1.Client, providerPubKey, service)
+	require.NoError(t, err)
+	_, err = s.CloseContract(ctx, &types.MsgCloseContract{Creator: msg1.Creator, ContractId: contract1.Id})
+	require.NoError(t, err)
+	contract1, err = k.GetContract(ctx, contract1.Id)
+	require.NoError(t, err)
+	require.Equal(t, ctx.BlockHeight(), contract1.SettlementHeight)
+
+	// and settle on expiration
+	contract2, err := k.GetActiveContractForUser(ctx, msg2.Client, providerPubKey, service)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(contract2.SettlementPeriodEnd())
+	require.NoError(t, NewManager(k, sk).ContractEndBlock(ctx))
+	contract2, err = k.GetContract(ctx, contract2.Id)
+	require.NoError(t, err)
+	require.Equal(t, ctx.BlockHeight(), contract2.SettlementHeight)
+
+	// setting the same value again does not toggle
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	_, err = s.SetConfig(ctx, types.NewMsgSetConfig(k.GetAuthority(), configs.HaltContractOpening.String(), 1))
+	require.NoError(t, err)
+	require.Equal(t, 0, countEvents(ctx, types.EventTypeContractHalt))
+
+	// resume
+	_, err = s.SetConfig(ctx, types.NewMsgSetConfig(k.GetAuthority(), configs.HaltContractOpening.String(), 0))
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeContractHalt))
+	_, err = s.OpenContract(ctx, &msg3)
+	require.NoError(t, err)
+}
+
+func countEvents(ctx cosmos.Context, eventType string) int {
+	count := 0
+	for _, evt := range ctx.EventManager().Events() {
+		if evt.Type == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+func TestOpenContractMinDeposit(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	service := common.BTCService
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+	provider := types.NewProvider(providerPubKey, service)
+	provider.Bond = cosmos.NewInt(10000000000)
+	provider.Status = types.ProviderStatus_ONLINE
+	provider.MinContractDuration = 1
+	provider.MaxContractDuration = 500
+	provider.SubscriptionRate = rates
+	provider.PayAsYouGoRate = rates
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAddress, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAddress, getCoin(common.Tokens(10))))
+
+	// subscriptions must fund at least the configured number of blocks
+	k.SetConfigOverride(ctx, configs.MinPeriodsFunded, 10)
+	msg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          service.String(),
+		Creator:          clientAddress,
+		Client:           clientPubKey,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         9,
+		Rate:             rates[0],
+		Deposit:          cosmos.NewInt(9 * 15),
+		QueriesPerMinute: 1,
+	}
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrInsufficientDeposit)
+	msg.Duration = 10
+	msg.Deposit = cosmos.NewInt(10*15 - 1)
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrInsufficientDeposit)
+	msg.Deposit = cosmos.NewInt(10*15 + 1)
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrExcessiveDeposit)
+	msg.Deposit = cosmos.NewInt(10 * 15)
+	require.NoError(t, s.OpenContractValidate(ctx, &msg))
+
+	// pay-as-you-go deposits are checked against their own minimum
+	msg.ContractType = types.ContractType_PAY_AS_YOU_GO
+	msg.Deposit = cosmos.NewInt(99)
+	require.NoError(t, s.OpenContractValidate(ctx, &msg))
+	k.SetConfigOverride(ctx, configs.MinPayAsYouGoDeposit, 100)
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrInsufficientDeposit)
+	msg.Deposit = cosmos.NewInt(100)
+	require.NoError(t, s.OpenContractValidate(ctx, &msg))
+
+	// and may be capped at a multiple of the most they can spend, 10 blocks
+	// of 6 seconds at 1 query per minute is 1 query
+	k.SetConfigOverride(ctx, configs.MinPayAsYouGoDeposit, 0)
+	k.SetConfigOverride(ctx, configs.MaxDepositMultiple, 5)
+	msg.Deposit = cosmos.NewInt(5*15 + 1)
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrExcessiveDeposit)
+	msg.Deposit = cosmos.NewInt(5 * 15)
+	require.NoError(t, s.OpenContractValidate(ctx, &msg))
+}
+
+func TestOpenContractRateTiers(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	service := common.BTCService
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAcct, getCoin(common.Tokens(10))))
+
+	// the provider offers tiers in two denoms, the contract only takes those
+	// in its own
+	provider := types.NewProvider(providerPubKey, service)
+	provider.PayAsYouGoRateTiers = []types.RateTier{
+		{Threshold: 100, Rate: cosmos.NewInt64Coin(configs.Denom, 10)},
+		{Threshold: 100, Rate: cosmos.NewInt64Coin(testIBCDenom, 4)},
+		{Threshold: 500, Rate: cosmos.NewInt64Coin(configs.Denom, 5)},
+	}
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	msg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          service.String(),
+		Creator:          clientAcct,
+		Client:           clientPubKey,
+		ContractType:     types.ContractType_PAY_AS_YOU_GO,
+		Duration:         100,
+		Rate:             cosmos.NewInt64Coin(configs.Denom, 15),
+		Deposit:          cosmos.NewInt(10000),
+		QueriesPerMinute: 1,
+	}
+	require.NoError(t, s.OpenContractHandle(ctx, &msg))
+
+	contract, err := k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, service)
+	require.NoError(t, err)
+	require.Len(t, contract.RateTiers, 2)
+	require.EqualValues(t, 100, contract.RateTiers[0].Threshold)
+	require.EqualValues(t, 500, contract.RateTiers[1].Threshold)
+
+	// 100 queries at 15, 400 at 10 and 100 at 5
+	contract.Nonce = 600
+	debt, err := calcContractDebt(contract, ctx.BlockHeight())
+	require.NoError(t, err)
+	require.Equal(t, int64(1500+4000+500), debt.Int64())
+}
+
+func TestOpenContractFreeTier(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	service := common.BTCService
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+	provider := types.NewProvider(providerPubKey, service)
+	provider.Bond = cosmos.NewInt(10000000000)
+	provider.Status = types.ProviderStatus_ONLINE
+	provider.MinContractDuration = 1
+	provider.MaxContractDuration = 500
+	provider.SubscriptionRate = rates
+	provider.PayAsYouGoRate = rates
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAddress, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	msg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          service.String(),
+		Creator:          clientAddress,
+		Client:           clientPubKey,
+		ContractType:     types.ContractType_PAY_AS_YOU_GO,
+		Duration:         101,
+		Rate:             cosmos.NewInt64Coin(configs.Denom, 0),
+		Deposit:          cosmos.NewInt(1),
+		QueriesPerMinute: 1000,
+	}
+
+	// the provider does not offer a free tier
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrOpenContractMismatchRate)
+
+	provider.FreeTier = types.FreeTier{MaxDuration: 100, MaxQueries: 50}
+	require.NoError(t, k.SetProvider(ctx, provider))
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrOpenContractDuration)
+
+	msg.Duration = 100
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrExcessiveDeposit)
+
+	// the client only pays the open cost
+	msg.Deposit = cosmos.ZeroInt()
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAddress, getCoin(common.Tokens(1))))
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeOpenContract))
+
+	contract, err := k.GetActiveContractForUser(ctx, clientPubKey, providerPubKey, service)
+	require.NoError(t, err)
+	require.Equal(t, int64(50), contract.MaxQueries)
+	require.True(t, contract.Deposit.IsZero())
+	require.True(t, k.GetBalance(ctx, clientAddress).IsZero())
+
+	// claims cannot go past the free queries
+	ctx = ctx.WithBlockHeight(60)
+	err = s.ClaimContractIncomeValidate(ctx, types.NewMsgClaimContractIncome(types.GetRandomBech32Addr(), contract.Id, 51, nil))
+	require.ErrorIs(t, err, types.ErrClaimContractIncomeRateExceeded)
+
+	// nor can the contract be topped up
+	err = s.DepositContractValidate(ctx, types.NewMsgDepositContract(clientAddress, contract.Id, cosmos.NewInt(15)))
+	require.ErrorIs(t, err, types.ErrDepositContract)
+}
+
+func TestOpenContractCapacity(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	service := common.BTCService
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+	provider := types.NewProvider(providerPubKey, service)
+	provider.Bond = cosmos.NewInt(500_00000000)
+	provider.Status = types.ProviderStatus_ONLINE
+	provider.MinContractDuration = 10
+	provider.MaxContractDuration = 1000
+	provider.SubscriptionRate = rates
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAddress, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	msg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          service.String(),
+		Client:           clientPubKey,
+		Creator:          clientAddress,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         100,
+		Rate:             rates[0],
+		Deposit:          cosmos.NewInt(100 * 15),
+		QueriesPerMinute: 1,
+	}
+
+	// two contracts open against the provider, and one already settled
+	for i := uint64(1); i <= 3; i++ {
+		contract := types.NewContract(providerPubKey, service, types.GetRandomPubKey())
+		contract.Id = i
+		contract.Height = 5
+		contract.Duration = 100
+		contract.Rate = rates[0]
+		if i == 3 {
+			contract.SettlementHeight = 8
+		}
+		require.NoError(t, k.SetContract(ctx, contract))
+	}
+	count, err := k.CountOpenProviderContracts(ctx, providerPubKey, service)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	// no limit by default
+	require.NoError(t, s.OpenContractValidate(ctx, &msg))
+
+	// the provider's limit
+	provider.MaxOpenContracts = 2
+	require.NoError(t, k.SetProvider(ctx, provider))
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrOpenContractCapacity)
+
+	provider.MaxOpenContracts = 3
+	require.NoError(t, k.SetProvider(ctx, provider))
+	require.NoError(t, s.OpenContractValidate(ctx, &msg))
+
+	// the network cap applies over the provider's limit
+	k.SetConfigOverride(ctx, configs.MaxOpenContracts, 2)
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrOpenContractCapacity)
+
+	// and to providers without a limit of their own
+	provider.MaxOpenContracts = 0
+	require.NoError(t, k.SetProvider(ctx, provider))
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrOpenContractCapacity)
+
+	// expired contracts no longer count
+	ctx = ctx.WithBlockHeight(106)
+	require.NoError(t, s.OpenContractValidate(ctx, &msg))
+}
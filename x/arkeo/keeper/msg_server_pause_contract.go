@@ -0,0 +1,174 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) PauseContract(goCtx context.Context, msg *types.MsgPauseContract) (*types.MsgPauseContractResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgPauseContract",
+		"contract_id", msg.ContractId,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.PauseContractValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed pause contract validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.PauseContractHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed pause contract handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgPauseContractResponse{}, nil
+}
+
+func (k msgServer) PauseContractValidate(ctx cosmos.Context, msg *types.MsgPauseContract) error {
+	if k.FetchConfig(ctx, configs.HandlerPauseContract) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "pause contract")
+	}
+
+	contract, _, err := k.validatePausableContract(ctx, msg.ContractId, msg.MustGetSigner())
+	if err != nil {
+		return err
+	}
+
+	if contract.IsPaused() {
+		return errors.Wrapf(types.ErrContractPaused, "paused since %d", contract.PausedHeight)
+	}
+
+	return nil
+}
+
+func (k msgServer) PauseContractHandle(ctx cosmos.Context, msg *types.MsgPauseContract) error {
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	// a paused contract does not expire, resuming it queues it again at its
+	// new expiration
+	k.RemoveContractExpiration(ctx, contract.SettlementPeriodEnd(), contract.Id)
+
+	contract.PausedHeight = ctx.BlockHeight()
+	contract.PausedByClient = msg.MustGetSigner().Equals(contract.ClientAddress())
+	if err := k.SetContract(ctx, contract); err != nil {
+		return err
+	}
+
+	return k.EmitPauseContractEvent(ctx, &contract)
+}
+
+func (k msgServer) ResumeContract(goCtx context.Context, msg *types.MsgResumeContract) (*types.MsgResumeContractResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgResumeContract",
+		"contract_id", msg.ContractId,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.ResumeContractValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed resume contract validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.ResumeContractHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed resume contract handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgResumeContractResponse{}, nil
+}
+
+func (k msgServer) ResumeContractValidate(ctx cosmos.Context, msg *types.MsgResumeContract) error {
+	if k.FetchConfig(ctx, configs.HandlerPauseContract) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "resume contract")
+	}
+
+	contract, byClient, err := k.validatePausableContract(ctx, msg.ContractId, msg.MustGetSigner())
+	if err != nil {
+		return err
+	}
+
+	if !contract.IsPaused() {
+		return errors.Wrapf(types.ErrContractNotPaused, "id: %d", msg.ContractId)
+	}
+
+	if contract.PausedByClient != byClient {
+		return errors.Wrapf(types.ErrPauseContractUnauthorized, "only the party that paused the contract can resume it")
+	}
+
+	return nil
+}
+
+func (k msgServer) ResumeContractHandle(ctx cosmos.Context, msg *types.MsgResumeContract) error {
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	// the contract is pushed back by the blocks it spent paused, so it must
+	// move to its new expiration set
+	k.RemoveContractExpiration(ctx, contract.SettlementPeriodEnd(), contract.Id)
+
+	contract.PausedBlocks += ctx.BlockHeight() - contract.PausedHeight
+	contract.PausedHeight = 0
+	contract.PausedByClient = false
+	if err := k.SetContract(ctx, contract); err != nil {
+		return err
+	}
+
+	if err := k.AddContractExpiration(ctx, contract.SettlementPeriodEnd(), contract.Id); err != nil {
+		return err
+	}
+
+	return k.EmitResumeContractEvent(ctx, &contract)
+}
+
+// validatePausableContract checks the contract is an open subscription and the
+// signer is its provider or client, returning whether it is the client.
+// Pay-as-you-go contracts are charged per query, not per block, so there is
+// nothing to pause.
+func (k msgServer) validatePausableContract(ctx cosmos.Context, contractId uint64, signer cosmos.AccAddress) (types.Contract, bool, error) {
+	contract, err := k.GetContract(ctx, contractId)
+	if err != nil {
+		return contract, false, err
+	}
+
+	if contract.IsEmpty() {
+		return contract, false, errors.Wrapf(types.ErrContractNotFound, "id: %d", contractId)
+	}
+
+	providerAccountAddress, err := contract.Provider.GetMyAddress()
+	if err != nil {
+		return contract, false, err
+	}
+
+	byClient := signer.Equals(contract.ClientAddress())
+	if !byClient && !signer.Equals(providerAccountAddress) {
+		return contract, false, errors.Wrapf(types.ErrPauseContractUnauthorized, "only the provider or client can pause or resume the contract")
+	}
+
+	if !contract.IsSubscription() {
+		return contract, byClient, errors.Wrapf(types.ErrPauseContractType, "id: %d", contractId)
+	}
+
+	if contract.IsExpired(ctx.BlockHeight()) {
+		return contract, byClient, errors.Wrapf(types.ErrCloseContractAlreadyClosed, "closed %d", contract.Expiration())
+	}
+
+	return contract, byClient, nil
+}
@@ -0,0 +1,332 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseContract(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(30)
+
+	s := newMsgServer(k, sk)
+
+	// setup
+	providerPubkey := types.GetRandomPubKey()
+	providerAcct, err := providerPubkey.GetMyAddress()
+	require.NoError(t, err)
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	contract := types.NewContract(providerPubkey, common.BTCService, clientPubKey)
+	contract.Type = types.ContractType_SUBSCRIPTION
+	contract.Duration = 100
+	contract.Height = 10
+	contract.Id = 1
+	contract.QueriesPerMinute = 1
+	contract.Rate = getCoin(10)
+	contract.Deposit = cosmos.NewInt(1000)
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	expirationSet, err := k.GetContractExpirationSet(ctx, contract.Expiration())
+	require.NoError(t, err)
+	expirationSet.Append(contract.Id)
+	require.NoError(t, k.SetContractExpirationSet(ctx, expirationSet))
+
+	// only the provider or client may pause the contract
+	pause := types.MsgPauseContract{
+		Creator:    types.GetRandomBech32Addr(),
+		ContractId: contract.Id,
+	}
+	err = s.PauseContractValidate(ctx, &pause)
+	require.ErrorIs(t, err, types.ErrPauseContractUnauthorized)
+
+	// cannot resume a contract that is not paused
+	resume := types.MsgResumeContract{
+		Creator:    providerAcct,
+		ContractId: contract.Id,
+	}
+	err = s.ResumeContractValidate(ctx, &resume)
+	require.ErrorIs(t, err, types.ErrContractNotPaused)
+
+	pause.Creator = providerAcct
+	_, err = s.PauseContract(ctx, &pause)
+	require.NoError(t, err)
+
+	// cannot pause twice
+	err = s.PauseContractValidate(ctx, &pause)
+	require.ErrorIs(t, err, types.ErrContractPaused)
+
+	// no debt accrues while paused
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.True(t, contract.IsPaused())
+	for _, height := range []int64{30, 40, 50} {
+		debt, err := calcContractDebt(contract, height)
+		require.NoError(t, err)
+		require.Equal(t, int64(200), debt.Int64())
+	}
+
+	// a rate change cannot take effect while paused
+	contract.ProposedRate = getCoin(15)
+	require.NoError(t, k.SetContract(ctx, contract))
+	err = s.AcceptRateChangeValidate(ctx, &types.MsgAcceptRateChange{
+		Creator:    clientAcct,
+		ContractId: contract.Id,
+		Rate:       getCoin(15),
+	})
+	require.ErrorIs(t, err, types.ErrContractPaused)
+
+	// resume after 20 blocks, the expiration moves back by as much
+	ctx = ctx.WithBlockHeight(50)
+	_, err = s.ResumeContract(ctx, &resume)
+	require.NoError(t, err)
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.False(t, contract.IsPaused())
+	require.EqualValues(t, 20, contract.PausedBlocks)
+	require.EqualValues(t, 130, contract.Expiration())
+
+	expirationSet, err = k.GetContractExpirationSet(ctx, 110)
+	require.NoError(t, err)
+	require.Empty(t, expirationSet.ContractSet.ContractIds)
+	expirationSet, err = k.GetContractExpirationSet(ctx, 130)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{contract.Id}, expirationSet.ContractSet.ContractIds)
+
+	// debt accrues again once resumed, excluding the paused span
+	debt, err := calcContractDebt(contract, 60)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), debt.Int64())
+
+	// the contract runs its full 100 blocks
+	debt, err = calcContractDebt(contract, 130)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), debt.Int64())
+	require.True(t, contract.IsOpen(120))
+
+	// a second pause adds to the paused blocks
+	ctx = ctx.WithBlockHeight(60)
+	_, err = s.PauseContract(ctx, &pause)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(65)
+	_, err = s.ResumeContract(ctx, &resume)
+	require.NoError(t, err)
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 25, contract.PausedBlocks)
+	require.EqualValues(t, 135, contract.Expiration())
+	debt, err = calcContractDebt(contract, 70)
+	require.NoError(t, err)
+	require.Equal(t, int64(350), debt.Int64())
+}
+
+func TestPauseContractByClient(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(30)
+
+	s := newMsgServer(k, sk)
+
+	providerPubkey := types.GetRandomPubKey()
+	providerAcct, err := providerPubkey.GetMyAddress()
+	require.NoError(t, err)
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	contract := types.NewContract(providerPubkey, common.BTCService, clientPubKey)
+	contract.Type = types.ContractType_SUBSCRIPTION
+	contract.Duration = 100
+	contract.Height = 10
+	contract.Id = 1
+	contract.QueriesPerMinute = 1
+	contract.Rate = getCoin(10)
+	contract.Deposit = cosmos.NewInt(1000)
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	// the client pauses for planned downtime
+	_, err = s.PauseContract(ctx, &types.MsgPauseContract{
+		Creator:    clientAcct,
+		ContractId: contract.Id,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypePauseContract))
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.True(t, contract.IsPaused())
+	require.True(t, contract.PausedByClient)
+
+	// the provider cannot resume a contract the client paused
+	err = s.ResumeContractValidate(ctx, &types.MsgResumeContract{
+		Creator:    providerAcct,
+		ContractId: contract.Id,
+	})
+	require.ErrorIs(t, err, types.ErrPauseContractUnauthorized)
+
+	// no debt accrues while paused
+	debt, err := calcContractDebt(contract, 50)
+	require.NoError(t, err)
+	require.Equal(t, int64(200), debt.Int64())
+
+	ctx = ctx.WithBlockHeight(50)
+	_, err = s.ResumeContract(ctx, &types.MsgResumeContract{
+		Creator:    clientAcct,
+		ContractId: contract.Id,
+	})
+	require.NoError(t, err)
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.False(t, contract.IsPaused())
+	require.False(t, contract.PausedByClient)
+	require.EqualValues(t, 20, contract.PausedBlocks)
+	debt, err = calcContractDebt(contract, 60)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), debt.Int64())
+
+	// nor can the client resume a contract the provider paused
+	pause := types.MsgPauseContract{
+		Creator:    providerAcct,
+		ContractId: contract.Id,
+	}
+	_, err = s.PauseContract(ctx, &pause)
+	require.NoError(t, err)
+	err = s.ResumeContractValidate(ctx, &types.MsgResumeContract{
+		Creator:    clientAcct,
+		ContractId: contract.Id,
+	})
+	require.ErrorIs(t, err, types.ErrPauseContractUnauthorized)
+}
+
+func TestPauseContractPayAsYouGo(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(30)
+
+	s := newMsgServer(k, sk)
+
+	providerPubkey := types.GetRandomPubKey()
+	providerAcct, err := providerPubkey.GetMyAddress()
+	require.NoError(t, err)
+
+	contract := types.NewContract(providerPubkey, common.BTCService, types.GetRandomPubKey())
+	contract.Type = types.ContractType_PAY_AS_YOU_GO
+	contract.Duration = 100
+	contract.Height = 10
+	contract.Id = 1
+	contract.Rate = getCoin(10)
+	contract.Deposit = cosmos.NewInt(1000)
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	// pay-as-you-go contracts are charged per query, they cannot be paused
+	err = s.PauseContractValidate(ctx, &types.MsgPauseContract{
+		Creator:    providerAcct,
+		ContractId: contract.Id,
+	})
+	require.ErrorIs(t, err, types.ErrPauseContractType)
+}
+
+func TestPauseContractAcrossExpiration(t *testing.T) {
+	testPauseAcrossExpiration(t, false)
+}
+
+func TestPauseContractByClientAcrossExpiration(t *testing.T) {
+	testPauseAcrossExpiration(t, true)
+}
+
+// testPauseAcrossExpiration pauses a subscription over the height it was due
+// to expire at, the contract must neither be renewed nor settled until it is
+// resumed and runs out the rest of its term
+func testPauseAcrossExpiration(t *testing.T, byClient bool) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+
+	providerPubkey := types.GetRandomPubKey()
+	providerAcct, err := providerPubkey.GetMyAddress()
+	require.NoError(t, err)
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	provider := types.NewProvider(providerPubkey, common.BTCService)
+	provider.Bond = cosmos.NewInt(500_00000000)
+	provider.Status = types.ProviderStatus_ONLINE
+	provider.MinContractDuration = 10
+	provider.MaxContractDuration = 1000
+	provider.SubscriptionRate = cosmos.NewCoins(getCoin(10))
+	provider.LastUpdate = 1
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAcct, getCoin(common.Tokens(10))))
+	_, err = s.OpenContract(ctx, &types.MsgOpenContract{
+		Creator:          clientAcct,
+		Provider:         providerPubkey,
+		Service:          common.BTCService.String(),
+		Client:           clientPubKey,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         100,
+		Rate:             getCoin(10),
+		Deposit:          cosmos.NewInt(1000),
+		QueriesPerMinute: 1,
+		AutoRenew:        true,
+	})
+	require.NoError(t, err)
+	contract, err := k.GetActiveContractForUser(ctx, clientPubKey, providerPubkey, common.BTCService)
+	require.NoError(t, err)
+	require.EqualValues(t, 110, contract.Expiration())
+
+	signer := providerAcct
+	if byClient {
+		signer = clientAcct
+	}
+	ctx = ctx.WithBlockHeight(100)
+	_, err = s.PauseContract(ctx, &types.MsgPauseContract{Creator: signer, ContractId: contract.Id})
+	require.NoError(t, err)
+
+	// the height the contract was due to expire at passes while it is paused
+	for _, height := range []int64{110, 120} {
+		ctx = ctx.WithBlockHeight(height)
+		require.NoError(t, mgr.ContractEndBlock(ctx))
+		contract, err = k.GetContract(ctx, contract.Id)
+		require.NoError(t, err)
+		require.True(t, contract.IsPaused())
+		require.True(t, contract.IsOpen(height))
+		require.EqualValues(t, 0, contract.Renewals)
+		require.EqualValues(t, 0, contract.GraceEnd)
+		require.EqualValues(t, 0, contract.SettlementHeight)
+		require.True(t, contract.Paid.IsZero())
+	}
+
+	// resuming pushes the expiration back by the 30 blocks it was paused
+	ctx = ctx.WithBlockHeight(130)
+	_, err = s.ResumeContract(ctx, &types.MsgResumeContract{Creator: signer, ContractId: contract.Id})
+	require.NoError(t, err)
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.False(t, contract.IsPaused())
+	require.EqualValues(t, 140, contract.Expiration())
+	expirationSet, err := k.GetContractExpirationSet(ctx, 140)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{contract.Id}, expirationSet.ContractSet.ContractIds)
+
+	// the contract is renewed at its new expiration, the client paid for the
+	// full 100 blocks of the first term
+	ctx = ctx.WithBlockHeight(140)
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, contract.Renewals)
+	require.Equal(t, int64(1000), contract.Paid.Int64())
+}
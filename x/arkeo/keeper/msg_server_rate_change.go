@@ -0,0 +1,359 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) ProposeRateChange(goCtx context.Context, msg *types.MsgProposeRateChange) (*types.MsgProposeRateChangeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgProposeRateChange",
+		"contract_id", msg.ContractId,
+		"rate", msg.Rate,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.ProposeRateChangeValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed propose rate change validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.ProposeRateChangeHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed propose rate change handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgProposeRateChangeResponse{}, nil
+}
+
+func (k msgServer) ProposeRateChangeValidate(ctx cosmos.Context, msg *types.MsgProposeRateChange) error {
+	if k.FetchConfig(ctx, configs.HandlerRateChange) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "propose rate change")
+	}
+
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	if contract.IsEmpty() {
+		return errors.Wrapf(types.ErrContractNotFound, "id: %d", msg.ContractId)
+	}
+
+	providerAccountAddress, err := contract.Provider.GetMyAddress()
+	if err != nil {
+		return err
+	}
+
+	if !msg.MustGetSigner().Equals(providerAccountAddress) {
+		return errors.Wrapf(types.ErrRateChangeUnauthorized, "only the provider can propose a rate change")
+	}
+
+	if contract.IsExpired(ctx.BlockHeight()) {
+		return errors.Wrapf(types.ErrCloseContractAlreadyClosed, "closed %d", contract.Expiration())
+	}
+
+	if msg.Rate.Denom != contract.Rate.Denom {
+		return errors.Wrapf(types.ErrRateChangeMismatch, "contract rate is in %s, proposed rate is in %s", contract.Rate.Denom, msg.Rate.Denom)
+	}
+
+	if contract.IsSubscription() {
+		perDay, err := k.providerRatePerDay(ctx, contract.Provider, contract.Service)
+		if err != nil {
+			return err
+		}
+		if perDay {
+			if _, err := k.perBlockRate(ctx, msg.Rate); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (k msgServer) ProposeRateChangeHandle(ctx cosmos.Context, msg *types.MsgProposeRateChange) error {
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	// a new proposal replaces any pending one
+	contract.ProposedRate = msg.Rate
+	if err := k.SetContract(ctx, contract); err != nil {
+		return err
+	}
+
+	return k.EmitRateChangeProposalEvent(ctx, &contract)
+}
+
+func (k msgServer) AcceptRateChange(goCtx context.Context, msg *types.MsgAcceptRateChange) (*types.MsgAcceptRateChangeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgAcceptRateChange",
+		"contract_id", msg.ContractId,
+		"rate", msg.Rate,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.AcceptRateChangeValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed accept rate change validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.AcceptRateChangeHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed accept rate change handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgAcceptRateChangeResponse{}, nil
+}
+
+func (k msgServer) AcceptRateChangeValidate(ctx cosmos.Context, msg *types.MsgAcceptRateChange) error {
+	if k.FetchConfig(ctx, configs.HandlerRateChange) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "accept rate change")
+	}
+
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	if contract.IsEmpty() {
+		return errors.Wrapf(types.ErrContractNotFound, "id: %d", msg.ContractId)
+	}
+
+	clientAccountAddress, err := contract.Client.GetMyAddress()
+	if err != nil {
+		return err
+	}
+
+	if !msg.MustGetSigner().Equals(clientAccountAddress) {
+		return errors.Wrapf(types.ErrRateChangeUnauthorized, "only the client can accept a rate change")
+	}
+
+	if contract.IsExpired(ctx.BlockHeight()) {
+		return errors.Wrapf(types.ErrCloseContractAlreadyClosed, "closed %d", contract.Expiration())
+	}
+
+	if !contract.HasProposedRate() {
+		return errors.Wrapf(types.ErrNoRateChangeProposal, "id: %d", msg.ContractId)
+	}
+
+	if contract.IsPaused() {
+		return errors.Wrapf(types.ErrContractPaused, "id: %d", msg.ContractId)
+	}
+
+	// the client accepts a specific rate, so the provider cannot swap the
+	// proposal from under them
+	if !msg.Rate.IsEqual(contract.ProposedRate) {
+		return errors.Wrapf(types.ErrRateChangeMismatch, "proposed rate is %s, client accepted %s", contract.ProposedRate, msg.Rate)
+	}
+
+	return nil
+}
+
+func (k msgServer) AcceptRateChangeHandle(ctx cosmos.Context, msg *types.MsgAcceptRateChange) error {
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	return k.changeContractRate(ctx, contract, contract.ProposedRate)
+}
+
+func (k msgServer) RenegotiateRate(goCtx context.Context, msg *types.MsgRenegotiateRate) (*types.MsgRenegotiateRateResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgRenegotiateRate",
+		"contract_id", msg.ContractId,
+		"rate", msg.Rate,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.RenegotiateRateValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed renegotiate rate validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.RenegotiateRateHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed renegotiate rate handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgRenegotiateRateResponse{}, nil
+}
+
+func (k msgServer) RenegotiateRateValidate(ctx cosmos.Context, msg *types.MsgRenegotiateRate) error {
+	if k.FetchConfig(ctx, configs.HandlerRateChange) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "renegotiate rate")
+	}
+
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	if contract.IsEmpty() {
+		return errors.Wrapf(types.ErrContractNotFound, "id: %d", msg.ContractId)
+	}
+
+	if ctx.BlockHeight() > msg.Deadline {
+		return errors.Wrapf(types.ErrRateRenegotiation, "deadline %d has passed", msg.Deadline)
+	}
+
+	if contract.SettlementHeight > 0 || contract.IsExpired(ctx.BlockHeight()) {
+		return errors.Wrapf(types.ErrCloseContractAlreadyClosed, "closed %d", contract.Expiration())
+	}
+
+	if contract.IsPaused() {
+		return errors.Wrapf(types.ErrContractPaused, "id: %d", msg.ContractId)
+	}
+
+	if msg.Rate.Denom != contract.Rate.Denom {
+		return errors.Wrapf(types.ErrRateChangeMismatch, "contract rate is in %s, new rate is in %s", contract.Rate.Denom, msg.Rate.Denom)
+	}
+
+	if contract.IsSubscription() {
+		perDay, err := k.providerRatePerDay(ctx, contract.Provider, contract.Service)
+		if err != nil {
+			return err
+		}
+		if perDay {
+			if _, err := k.perBlockRate(ctx, msg.Rate); err != nil {
+				return err
+			}
+		}
+	}
+
+	// both parties must have signed the new rate, the client rather than
+	// its delegate as the client pays for it
+	signBytes := msg.GetBytesToSign(contract.RateChangeHeight)
+	for _, signer := range []struct {
+		name      string
+		pubkey    common.PubKey
+		signature []byte
+	}{
+		{"client", contract.Client, msg.ClientSignature},
+		{"provider", contract.Provider, msg.ProviderSignature},
+	} {
+		pk, err := cosmos.GetPubKeyFromBech32(cosmos.Bech32PubKeyTypeAccPub, signer.pubkey.String())
+		if err != nil {
+			return err
+		}
+		if !pk.VerifySignature(signBytes, signer.signature) {
+			return errors.Wrapf(types.ErrRateRenegotiation, "invalid %s signature", signer.name)
+		}
+	}
+
+	return nil
+}
+
+func (k msgServer) RenegotiateRateHandle(ctx cosmos.Context, msg *types.MsgRenegotiateRate) error {
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	// the provider is paid what is owed at the old rate up to the change
+	contract, err = k.mgr.SettleContract(ctx, contract, 0, false)
+	if err != nil {
+		return err
+	}
+
+	return k.changeContractRate(ctx, contract, msg.Rate)
+}
+
+// changeContractRate moves the contract to the new rate from this block
+// onwards. A subscription's deposit is topped up or refunded by the
+// difference over its remaining blocks.
+func (k msgServer) changeContractRate(ctx cosmos.Context, contract types.Contract, rate cosmos.Coin) error {
+	// snapshot what was accrued at the old rate, the new rate only applies
+	// from this block onwards
+	accrued, err := calcContractAccrued(contract, ctx.BlockHeight())
+	if err != nil {
+		return err
+	}
+
+	if contract.IsSubscription() {
+		perDay, err := k.providerRatePerDay(ctx, contract.Provider, contract.Service)
+		if err != nil {
+			return err
+		}
+		ratePerDay := rate
+		if perDay {
+			rate, err = k.perBlockRate(ctx, ratePerDay)
+			if err != nil {
+				return err
+			}
+		} else {
+			ratePerDay = cosmos.NewCoin(rate.Denom, rate.Amount.MulRaw(k.blocksPerDay(ctx)))
+		}
+
+		// the deposit of a subscription covers the rest of the contract, so
+		// it is topped up or refunded by the difference for the remaining
+		// blocks
+		qpm := contract.QueriesPerMinute
+		if qpm < 1 {
+			qpm = 1
+		}
+		remaining := contract.Expiration() - ctx.BlockHeight()
+		diff := rate.Amount.Sub(contract.Rate.Amount).MulRaw(remaining).MulRaw(qpm)
+		if diff.IsPositive() {
+			if err := k.SendFromAccountToModule(ctx, contract.ClientAddress(), types.ContractName, cosmos.NewCoins(cosmos.NewCoin(rate.Denom, diff))); err != nil {
+				return errors.Wrapf(err, "failed to top up deposit=%s", diff)
+			}
+			contract.Deposit = contract.Deposit.Add(diff)
+		} else if diff.IsNegative() {
+			refund := diff.Neg()
+			// never refund what has already been accrued
+			committed := accrued
+			if contract.Paid.GT(committed) {
+				committed = contract.Paid
+			}
+			if available := contract.Deposit.Sub(committed); refund.GT(available) {
+				refund = available
+			}
+			if refund.IsPositive() {
+				refundTo, err := contract.RefundTo()
+				if err != nil {
+					return err
+				}
+				if err := k.SendFromModuleToAccount(ctx, types.ContractName, refundTo, cosmos.NewCoins(cosmos.NewCoin(rate.Denom, refund))); err != nil {
+					return errors.Wrapf(err, "failed to refund deposit=%s", refund)
+				}
+				contract.Deposit = contract.Deposit.Sub(refund)
+			}
+		}
+		contract.RatePerDay = ratePerDay
+	}
+
+	contract.PriorRate = contract.Rate
+	contract.Rate = rate
+	contract.RateChangeHeight = ctx.BlockHeight()
+	contract.RateChangeNonce = contract.Nonce
+	contract.AccruedAtRateChange = accrued
+	contract.PausedBlocksAtRateChange = contract.PausedBlocks
+	contract.ProposedRate = cosmos.Coin{}
+	// tiers count from the start of the contract, a new rate is flat
+	contract.RateTiers = nil
+	if err := k.SetContract(ctx, contract); err != nil {
+		return err
+	}
+
+	return k.EmitRateChangeEvent(ctx, &contract)
+}
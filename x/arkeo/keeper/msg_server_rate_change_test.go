@@ -0,0 +1,298 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	cKeys "github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/std"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateChange(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(30)
+
+	s := newMsgServer(k, sk)
+
+	// setup
+	providerPubkey := types.GetRandomPubKey()
+	providerAcct, err := providerPubkey.GetMyAddress()
+	require.NoError(t, err)
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	contract := types.NewContract(providerPubkey, common.BTCService, clientPubKey)
+	contract.Type = types.ContractType_SUBSCRIPTION
+	contract.Duration = 100
+	contract.Height = 10
+	contract.Id = 1
+	contract.QueriesPerMinute = 1
+	contract.Rate = getCoin(10)
+	contract.Deposit = cosmos.NewInt(1000)
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	debt, err := calcContractDebt(contract, ctx.BlockHeight())
+	require.NoError(t, err)
+	require.Equal(t, int64(200), debt.Int64())
+
+	// only the provider may propose a new rate
+	propose := types.MsgProposeRateChange{
+		Creator:    clientAcct,
+		ContractId: contract.Id,
+		Rate:       getCoin(15),
+	}
+	err = s.ProposeRateChangeValidate(ctx, &propose)
+	require.ErrorIs(t, err, types.ErrRateChangeUnauthorized)
+
+	// nothing to accept yet
+	accept := types.MsgAcceptRateChange{
+		Creator:    clientAcct,
+		ContractId: contract.Id,
+		Rate:       getCoin(15),
+	}
+	err = s.AcceptRateChangeValidate(ctx, &accept)
+	require.ErrorIs(t, err, types.ErrNoRateChangeProposal)
+
+	propose.Creator = providerAcct
+	_, err = s.ProposeRateChange(ctx, &propose)
+	require.NoError(t, err)
+
+	// the rate does not change until the client accepts it
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), contract.Rate.Amount.Int64())
+	require.True(t, contract.HasProposedRate())
+
+	// only the client may accept, and only the proposed rate
+	accept.Creator = providerAcct
+	err = s.AcceptRateChangeValidate(ctx, &accept)
+	require.ErrorIs(t, err, types.ErrRateChangeUnauthorized)
+	accept.Creator = clientAcct
+	accept.Rate = getCoin(12)
+	err = s.AcceptRateChangeValidate(ctx, &accept)
+	require.ErrorIs(t, err, types.ErrRateChangeMismatch)
+	accept.Rate = getCoin(15)
+
+	// the deposit is topped up for the remaining 80 blocks at the new rate
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAcct, getCoin(400)))
+	_, err = s.AcceptRateChange(ctx, &accept)
+	require.NoError(t, err)
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(15), contract.Rate.Amount.Int64())
+	require.Equal(t, int64(10), contract.PriorRate.Amount.Int64())
+	require.Equal(t, int64(30), contract.RateChangeHeight)
+	require.Equal(t, int64(200), contract.AccruedAtRateChange.Int64())
+	require.Equal(t, int64(1400), contract.Deposit.Int64())
+	require.False(t, contract.HasProposedRate())
+	require.True(t, k.GetBalance(ctx, clientAcct).IsZero())
+
+	// usage before the change is charged at the old rate, after it at the new
+	debt, err = calcContractDebt(contract, 50)
+	require.NoError(t, err)
+	require.Equal(t, int64(200+15*20), debt.Int64())
+
+	// the full deposit is consumed by the end of the contract
+	debt, err = calcContractDebt(contract, contract.Expiration())
+	require.NoError(t, err)
+	require.Equal(t, contract.Deposit.Int64(), debt.Int64())
+}
+
+func TestRateChangePayAsYouGo(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(30)
+
+	s := newMsgServer(k, sk)
+
+	// setup
+	providerPubkey := types.GetRandomPubKey()
+	providerAcct, err := providerPubkey.GetMyAddress()
+	require.NoError(t, err)
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	contract := types.NewContract(providerPubkey, common.BTCService, clientPubKey)
+	contract.Type = types.ContractType_PAY_AS_YOU_GO
+	contract.Duration = 100
+	contract.Height = 10
+	contract.Id = 1
+	contract.Rate = getCoin(10)
+	contract.Nonce = 20
+	contract.Deposit = cosmos.NewInt(1000)
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	_, err = s.ProposeRateChange(ctx, &types.MsgProposeRateChange{
+		Creator:    providerAcct,
+		ContractId: contract.Id,
+		Rate:       getCoin(5),
+	})
+	require.NoError(t, err)
+	_, err = s.AcceptRateChange(ctx, &types.MsgAcceptRateChange{
+		Creator:    clientAcct,
+		ContractId: contract.Id,
+		Rate:       getCoin(5),
+	})
+	require.NoError(t, err)
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), contract.RateChangeNonce)
+	// the deposit of a pay-as-you-go contract is left as is
+	require.Equal(t, int64(1000), contract.Deposit.Int64())
+
+	contract.Nonce = 30
+	debt, err := calcContractDebt(contract, ctx.BlockHeight())
+	require.NoError(t, err)
+	require.Equal(t, int64(20*10+10*5), debt.Int64())
+}
+
+func TestRenegotiateRate(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(30)
+
+	s := newMsgServer(k, sk)
+
+	// setup
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	types.RegisterInterfaces(interfaceRegistry)
+	kb := cKeys.NewInMemory(codec.NewProtoCodec(interfaceRegistry))
+	newKey := func(name string) common.PubKey {
+		info, _, err := kb.NewMnemonic(name, cKeys.English, `m/44'/931'/0'/0/0`, "", hd.Secp256k1)
+		require.NoError(t, err)
+		pk, err := info.GetPubKey()
+		require.NoError(t, err)
+		pubkey, err := common.NewPubKeyFromCrypto(pk)
+		require.NoError(t, err)
+		return pubkey
+	}
+	clientPubKey := newKey("client")
+	providerPubKey := newKey("provider")
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	providerAcct, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	contract := types.NewContract(providerPubKey, common.BTCService, clientPubKey)
+	contract.Type = types.ContractType_SUBSCRIPTION
+	contract.Duration = 100
+	contract.Height = 10
+	contract.Id = 1
+	contract.QueriesPerMinute = 1
+	contract.Rate = getCoin(10)
+	contract.Deposit = cosmos.NewInt(1000)
+	require.NoError(t, k.SetContract(ctx, contract))
+	require.NoError(t, k.MintToModule(ctx, types.ContractName, getCoin(1000)))
+
+	sign := func(msg *types.MsgRenegotiateRate, rateChangeHeight int64) {
+		msg.ClientSignature, _, err = kb.Sign("client", msg.GetBytesToSign(rateChangeHeight))
+		require.NoError(t, err)
+		msg.ProviderSignature, _, err = kb.Sign("provider", msg.GetBytesToSign(rateChangeHeight))
+		require.NoError(t, err)
+	}
+	msg := types.NewMsgRenegotiateRate(types.GetRandomBech32Addr(), contract.Id, getCoin(5), 40)
+	sign(msg, 0)
+
+	// both parties must sign the same terms
+	bad := *msg
+	bad.Rate = getCoin(4)
+	err = s.RenegotiateRateValidate(ctx, &bad)
+	require.ErrorIs(t, err, types.ErrRateRenegotiation)
+	bad = *msg
+	bad.ProviderSignature = msg.ClientSignature
+	err = s.RenegotiateRateValidate(ctx, &bad)
+	require.ErrorIs(t, err, types.ErrRateRenegotiation)
+
+	// the signatures expire at the deadline
+	err = s.RenegotiateRateValidate(ctx.WithBlockHeight(41), msg)
+	require.ErrorIs(t, err, types.ErrRateRenegotiation)
+
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	_, err = s.RenegotiateRate(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeRateChange))
+
+	// the provider was paid at the old rate up to the change, less the
+	// reserve tax, and the client refunded the difference for the remaining
+	// 80 blocks
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), contract.Rate.Amount.Int64())
+	require.Equal(t, int64(30), contract.RateChangeHeight)
+	require.Equal(t, int64(200), contract.Paid.Int64())
+	require.Equal(t, int64(600), contract.Deposit.Int64())
+	require.Equal(t, int64(180), k.GetBalance(ctx, providerAcct).AmountOf(configs.Denom).Int64())
+	require.Equal(t, int64(400), k.GetBalance(ctx, clientAcct).AmountOf(configs.Denom).Int64())
+
+	debt, err := calcContractDebt(contract, contract.Expiration())
+	require.NoError(t, err)
+	require.Equal(t, int64(5*80), debt.Int64())
+
+	// the signatures cannot be replayed
+	err = s.RenegotiateRateValidate(ctx, msg)
+	require.ErrorIs(t, err, types.ErrRateRenegotiation)
+}
+
+func TestRenegotiateRateRefundAddress(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(30)
+
+	s := newMsgServer(k, sk)
+
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	types.RegisterInterfaces(interfaceRegistry)
+	kb := cKeys.NewInMemory(codec.NewProtoCodec(interfaceRegistry))
+	newKey := func(name string) common.PubKey {
+		info, _, err := kb.NewMnemonic(name, cKeys.English, `m/44'/931'/0'/0/0`, "", hd.Secp256k1)
+		require.NoError(t, err)
+		pk, err := info.GetPubKey()
+		require.NoError(t, err)
+		pubkey, err := common.NewPubKeyFromCrypto(pk)
+		require.NoError(t, err)
+		return pubkey
+	}
+	clientPubKey := newKey("client")
+	providerPubKey := newKey("provider")
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	treasury := types.GetRandomBech32Addr()
+
+	contract := types.NewContract(providerPubKey, common.BTCService, clientPubKey)
+	contract.Type = types.ContractType_SUBSCRIPTION
+	contract.Duration = 100
+	contract.Height = 10
+	contract.Id = 1
+	contract.QueriesPerMinute = 1
+	contract.Rate = getCoin(10)
+	contract.Deposit = cosmos.NewInt(1000)
+	contract.RefundAddress = treasury
+	require.NoError(t, k.SetContract(ctx, contract))
+	require.NoError(t, k.MintToModule(ctx, types.ContractName, getCoin(1000)))
+
+	msg := types.NewMsgRenegotiateRate(types.GetRandomBech32Addr(), contract.Id, getCoin(5), 40)
+	msg.ClientSignature, _, err = kb.Sign("client", msg.GetBytesToSign(0))
+	require.NoError(t, err)
+	msg.ProviderSignature, _, err = kb.Sign("provider", msg.GetBytesToSign(0))
+	require.NoError(t, err)
+
+	_, err = s.RenegotiateRate(ctx, msg)
+	require.NoError(t, err)
+
+	// the lowered rate refunds the refund address, as closing the contract
+	// would, not the client
+	require.Equal(t, int64(400), k.GetBalance(ctx, treasury).AmountOf(configs.Denom).Int64())
+	require.True(t, k.GetBalance(ctx, clientAcct).AmountOf(configs.Denom).IsZero())
+}
@@ -0,0 +1,122 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) RenewContract(goCtx context.Context, msg *types.MsgRenewContract) (*types.MsgRenewContractResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgRenewContract",
+		"contract_id", msg.ContractId,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.RenewContractValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed renew contract validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.RenewContractHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed renew contract handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgRenewContractResponse{}, nil
+}
+
+func (k msgServer) RenewContractValidate(ctx cosmos.Context, msg *types.MsgRenewContract) error {
+	if k.FetchConfig(ctx, configs.HandlerRenewContract) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "renew contract")
+	}
+
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	if contract.IsEmpty() {
+		return errors.Wrapf(types.ErrContractNotFound, "id: %d", msg.ContractId)
+	}
+
+	if !msg.MustGetSigner().Equals(contract.ClientAddress()) {
+		return errors.Wrapf(types.ErrRenewContract, "only the client can renew the contract")
+	}
+
+	if !contract.IsSubscription() {
+		return errors.Wrapf(types.ErrRenewContract, "only subscriptions can be renewed")
+	}
+
+	if contract.IsPaused() {
+		return errors.Wrapf(types.ErrContractPaused, "paused since %d", contract.PausedHeight)
+	}
+
+	// a contract can be renewed while it is open, or after it expires until
+	// its grace period ends
+	if contract.SettlementHeight > 0 {
+		return errors.Wrapf(types.ErrCloseContractAlreadyClosed, "closed %d", contract.SettlementHeight)
+	}
+	if contract.GraceEnd > 0 {
+		if ctx.BlockHeight() >= contract.GraceEnd {
+			return errors.Wrapf(types.ErrCloseContractAlreadyClosed, "grace period ended %d", contract.GraceEnd)
+		}
+	} else if contract.IsExpired(ctx.BlockHeight()) {
+		return errors.Wrapf(types.ErrCloseContractAlreadyClosed, "closed %d", contract.Expiration())
+	}
+
+	// the client may have opened another contract with the provider since
+	activeContract, err := k.GetActiveContractForUser(ctx, contract.GetSpender(), contract.Provider, contract.Service)
+	if err != nil {
+		return err
+	}
+	if !activeContract.IsEmpty() && activeContract.Id != contract.Id && !activeContract.IsExpired(ctx.BlockHeight()) {
+		return errors.Wrapf(types.ErrOpenContractAlreadyOpen, "contract %d is open", activeContract.Id)
+	}
+
+	return nil
+}
+
+func (k msgServer) RenewContractHandle(ctx cosmos.Context, msg *types.MsgRenewContract) error {
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	// the contract moves to its new expiration set once renewed
+	inGrace := contract.GraceEnd > 0
+	k.RemoveContractExpiration(ctx, contract.ClosingHeight(), contract.Id)
+
+	if inGrace {
+		// the blocks spent in the grace period were not served, they are
+		// skipped like paused blocks so the new period starts now
+		contract.PausedBlocks += ctx.BlockHeight() - contract.Expiration()
+		contract.GraceEnd = 0
+	}
+
+	renewed, err := k.mgr.renewContract(ctx, contract)
+	if err != nil {
+		return err
+	}
+	if !renewed {
+		return errors.Wrapf(types.ErrRenewContract, "the provider no longer offers the contract's terms, or the client cannot afford them")
+	}
+
+	if inGrace {
+		// periodic settlements stop once a contract expires
+		contract, err = k.GetContract(ctx, contract.Id)
+		if err != nil {
+			return err
+		}
+		return k.mgr.scheduleContractSettlement(ctx, contract)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRenewContract(t *testing.T) (cosmos.Context, Keeper, *msgServer, Manager, types.Contract) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	provider := types.NewProvider(providerPubKey, common.BTCService)
+	provider.Bond = cosmos.NewInt(20000000000)
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+	require.NoError(t, s.ModProviderHandle(ctx, &types.MsgModProvider{
+		Provider:            provider.PubKey,
+		Service:             common.BTCService.String(),
+		MinContractDuration: 10,
+		MaxContractDuration: 500,
+		Status:              types.ProviderStatus_ONLINE,
+		PayAsYouGoRate:      rates,
+		SubscriptionRate:    rates,
+	}))
+
+	userPubKey := types.GetRandomPubKey()
+	userAddress, err := userPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, userAddress, getCoin(common.Tokens(10))))
+
+	_, err = s.OpenContract(ctx, &types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          common.BTCService.String(),
+		Creator:          userAddress,
+		Client:           userPubKey,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         100,
+		Rate:             rates[0],
+		Deposit:          cosmos.NewInt(1500),
+		QueriesPerMinute: 1,
+	})
+	require.NoError(t, err)
+
+	contract, err := k.GetActiveContractForUser(ctx, userPubKey, providerPubKey, common.BTCService)
+	require.NoError(t, err)
+	return ctx, k, s, mgr, contract
+}
+
+func TestRenewContractOpen(t *testing.T) {
+	ctx, k, s, mgr, contract := setupRenewContract(t)
+	userAddress := contract.ClientAddress()
+
+	// only the client may renew
+	err := s.RenewContractValidate(ctx, &types.MsgRenewContract{
+		Creator:    types.GetRandomBech32Addr(),
+		ContractId: contract.Id,
+	})
+	require.ErrorIs(t, err, types.ErrRenewContract)
+
+	// renewing before expiration extends the contract without a gap
+	ctx = ctx.WithBlockHeight(50)
+	_, err = s.RenewContract(ctx, &types.MsgRenewContract{
+		Creator:    userAddress,
+		ContractId: contract.Id,
+	})
+	require.NoError(t, err)
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 210, contract.Expiration())
+	// 40 blocks were paid for, the client tops up what the next period needs
+	require.Equal(t, int64(600), contract.Paid.Int64())
+	require.Equal(t, int64(2100), contract.Deposit.Int64())
+
+	expirationSet, err := k.GetContractExpirationSet(ctx, 110)
+	require.NoError(t, err)
+	require.Empty(t, expirationSet.ContractSet.ContractIds)
+
+	// the old expiration passes without settling the contract
+	ctx = ctx.WithBlockHeight(110)
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.True(t, contract.IsOpen(110))
+	require.EqualValues(t, 0, contract.SettlementHeight)
+}
+
+func TestRenewContractGracePeriod(t *testing.T) {
+	ctx, k, s, mgr, contract := setupRenewContract(t)
+	userAddress := contract.ClientAddress()
+	k.SetConfigOverride(ctx, configs.ContractGracePeriod, 20)
+
+	// at expiration the provider is paid, but the contract is held open for
+	// renewal until the grace period ends
+	ctx = ctx.WithBlockHeight(110).WithEventManager(sdk.NewEventManager())
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeContractGracePeriod))
+
+	contract, err := k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 130, contract.GraceEnd)
+	require.EqualValues(t, 0, contract.SettlementHeight)
+	require.Equal(t, int64(1500), contract.Paid.Int64())
+	require.True(t, contract.IsExpired(120))
+
+	// renewing in the grace period starts the new period straight away, the
+	// client does not pay for the blocks they went without
+	balance := k.GetBalance(ctx, userAddress).AmountOf(configs.Denom)
+	ctx = ctx.WithBlockHeight(120)
+	_, err = s.RenewContract(ctx, &types.MsgRenewContract{
+		Creator:    userAddress,
+		ContractId: contract.Id,
+	})
+	require.NoError(t, err)
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, contract.GraceEnd)
+	require.EqualValues(t, 10, contract.PausedBlocks)
+	require.EqualValues(t, 220, contract.Expiration())
+	require.Equal(t, balance.SubRaw(1500).Int64(), k.GetBalance(ctx, userAddress).AmountOf(configs.Denom).Int64())
+
+	debt, err := calcContractDebt(contract, 120)
+	require.NoError(t, err)
+	require.True(t, debt.IsZero())
+	debt, err = calcContractDebt(contract, 220)
+	require.NoError(t, err)
+	require.Equal(t, int64(1500), debt.Int64())
+
+	// the end of the grace period no longer settles the contract
+	ctx = ctx.WithBlockHeight(130)
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.True(t, contract.IsOpen(130))
+
+	// left to expire again, the contract is settled once its grace ends
+	ctx = ctx.WithBlockHeight(220)
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 240, contract.GraceEnd)
+
+	ctx = ctx.WithBlockHeight(240)
+	err = s.RenewContractValidate(ctx, &types.MsgRenewContract{
+		Creator:    userAddress,
+		ContractId: contract.Id,
+	})
+	require.ErrorIs(t, err, types.ErrCloseContractAlreadyClosed)
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, 240, contract.SettlementHeight)
+	require.Equal(t, contract.Deposit, contract.Paid)
+}
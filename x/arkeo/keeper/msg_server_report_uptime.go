@@ -0,0 +1,98 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+func (k msgServer) ReportUptime(goCtx context.Context, msg *types.MsgReportUptime) (*types.MsgReportUptimeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgReportUptime",
+		"creator", msg.Creator,
+		"observations", len(msg.Observations),
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.ReportUptimeValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed report uptime validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.ReportUptimeHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed report uptime handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgReportUptimeResponse{}, nil
+}
+
+func (k msgServer) ReportUptimeValidate(ctx cosmos.Context, msg *types.MsgReportUptime) error {
+	if k.FetchConfig(ctx, configs.HandlerReportUptime) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "report uptime")
+	}
+
+	// only the operators of active validators observe providers
+	valAddr := cosmos.ValAddress(msg.Creator)
+	active := false
+	for _, val := range k.GetActiveValidators(ctx) {
+		if val.GetOperator().Equals(valAddr) {
+			active = true
+			break
+		}
+	}
+	if !active {
+		return errors.Wrapf(types.ErrInvalidUptimeReport, "%s is not an active validator", valAddr)
+	}
+
+	for _, obs := range msg.Observations {
+		service, err := common.NewService(obs.Service)
+		if err != nil {
+			return errors.Wrapf(types.ErrInvalidService, "%s", err)
+		}
+		if !k.ProviderExists(ctx, obs.Provider, service) {
+			return errors.Wrapf(types.ErrProviderNotFound, "provider %s for service %s not found", obs.Provider, service)
+		}
+		uptime, err := k.GetProviderUptime(ctx, obs.Provider, service)
+		if err != nil {
+			return err
+		}
+		if uptime.HasVoted(valAddr) {
+			return errors.Wrapf(types.ErrInvalidUptimeReport, "already reported on %s this cycle", uptime.Key())
+		}
+	}
+
+	return nil
+}
+
+func (k msgServer) ReportUptimeHandle(ctx cosmos.Context, msg *types.MsgReportUptime) error {
+	valAddr := cosmos.ValAddress(msg.Creator)
+	for _, obs := range msg.Observations {
+		service, err := common.NewService(obs.Service)
+		if err != nil {
+			return err
+		}
+		uptime, err := k.GetProviderUptime(ctx, obs.Provider, service)
+		if err != nil {
+			return err
+		}
+		if obs.Online {
+			uptime.OnlineVotes = append(uptime.OnlineVotes, valAddr)
+		} else {
+			uptime.OfflineVotes = append(uptime.OfflineVotes, valAddr)
+		}
+		if err := k.SetProviderUptime(ctx, uptime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
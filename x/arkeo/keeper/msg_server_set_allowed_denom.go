@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) SetAllowedDenom(goCtx context.Context, msg *types.MsgSetAllowedDenom) (*types.MsgSetAllowedDenomResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgSetAllowedDenom",
+		"denom", msg.Denom,
+		"allowed", msg.Allowed,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.SetAllowedDenomValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set allowed denom validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.SetAllowedDenomHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set allowed denom handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgSetAllowedDenomResponse{}, nil
+}
+
+func (k msgServer) SetAllowedDenomValidate(ctx cosmos.Context, msg *types.MsgSetAllowedDenom) error {
+	if msg.Authority != k.GetAuthority() {
+		return errors.Wrapf(types.ErrInvalidAuthority, "expected %s, got %s", k.GetAuthority(), msg.Authority)
+	}
+	return nil
+}
+
+func (k msgServer) SetAllowedDenomHandle(ctx cosmos.Context, msg *types.MsgSetAllowedDenom) error {
+	if k.IsAllowedDenom(ctx, msg.Denom) == msg.Allowed {
+		// nothing changed
+		return nil
+	}
+
+	if msg.Allowed {
+		if err := k.AddAllowedDenom(ctx, msg.Denom); err != nil {
+			return err
+		}
+	} else {
+		// existing contracts are untouched and settle in the denom they
+		// were opened in, they are only no longer renewed
+		k.RemoveAllowedDenom(ctx, msg.Denom)
+	}
+
+	return k.EmitAllowedDenomEvent(ctx, msg.Denom, msg.Allowed)
+}
@@ -0,0 +1,105 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testIBCDenom = "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+
+func TestSetAllowedDenom(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	s := newMsgServer(k, sk)
+
+	// only the gov module account may change the allow list
+	msg := types.NewMsgSetAllowedDenom(types.GetRandomBech32Addr().String(), testIBCDenom, true)
+	_, err := s.SetAllowedDenom(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidAuthority)
+	require.False(t, k.IsAllowedDenom(ctx, testIBCDenom))
+
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	msg.Authority = k.GetAuthority()
+	_, err = s.SetAllowedDenom(ctx, msg)
+	require.NoError(t, err)
+	require.True(t, k.IsAllowedDenom(ctx, testIBCDenom))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeAllowedDenom))
+
+	// denoms are case sensitive
+	require.False(t, k.IsAllowedDenom(ctx, "IBC/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"))
+
+	// no change, no event
+	_, err = s.SetAllowedDenom(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeAllowedDenom))
+
+	msg.Allowed = false
+	_, err = s.SetAllowedDenom(ctx, msg)
+	require.NoError(t, err)
+	require.False(t, k.IsAllowedDenom(ctx, testIBCDenom))
+	require.Equal(t, 2, countEvents(ctx, types.EventTypeAllowedDenom))
+}
+
+func TestOpenContractAllowedDenom(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAddress, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	provider := types.NewProvider(providerPubKey, common.BTCService)
+	provider.Bond = cosmos.NewInt(20000000000)
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	rate := cosmos.NewInt64Coin(testIBCDenom, 15)
+	require.NoError(t, s.ModProviderHandle(ctx, &types.MsgModProvider{
+		Provider:            provider.PubKey,
+		Service:             common.BTCService.String(),
+		MinContractDuration: 10,
+		MaxContractDuration: 500,
+		Status:              types.ProviderStatus_ONLINE,
+		PayAsYouGoRate:      cosmos.NewCoins(rate),
+		SubscriptionRate:    cosmos.NewCoins(rate),
+	}))
+
+	userPubKey := types.GetRandomPubKey()
+	userAddress, err := userPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, userAddress, getCoin(common.Tokens(10))))
+	require.NoError(t, k.MintAndSendToAccount(ctx, userAddress, cosmos.NewInt64Coin(testIBCDenom, 10000)))
+
+	msg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          common.BTCService.String(),
+		Creator:          userAddress,
+		Client:           userPubKey,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         100,
+		Rate:             rate,
+		Deposit:          cosmos.NewInt(1500),
+		QueriesPerMinute: 1,
+	}
+
+	// the denom must be on the allow list
+	err = s.OpenContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrDenomNotAllowed)
+
+	require.NoError(t, k.AddAllowedDenom(ctx, testIBCDenom))
+	_, err = s.OpenContract(ctx, &msg)
+	require.NoError(t, err)
+	require.Equal(t, int64(8500), k.GetBalance(ctx, userAddress).AmountOf(testIBCDenom).Int64())
+	require.Equal(t, int64(1500), k.GetBalanceOfModule(ctx, types.ContractName, testIBCDenom).Int64())
+
+	// the provider is paid in the denom the contract was opened in
+	ctx = ctx.WithBlockHeight(110)
+	require.NoError(t, mgr.ContractEndBlock(ctx))
+	tax := mgr.reserveTax(ctx, cosmos.NewInt(1500))
+	require.Equal(t, cosmos.NewInt(1500).Sub(tax).Int64(), k.GetBalance(ctx, providerAddress).AmountOf(testIBCDenom).Int64())
+	require.True(t, k.GetBalanceOfModule(ctx, types.ContractName, testIBCDenom).IsZero())
+}
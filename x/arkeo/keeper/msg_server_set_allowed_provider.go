@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) SetAllowedProvider(goCtx context.Context, msg *types.MsgSetAllowedProvider) (*types.MsgSetAllowedProviderResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgSetAllowedProvider",
+		"provider", msg.Provider,
+		"allowed", msg.Allowed,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.SetAllowedProviderValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set allowed provider validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.SetAllowedProviderHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set allowed provider handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgSetAllowedProviderResponse{}, nil
+}
+
+func (k msgServer) SetAllowedProviderValidate(ctx cosmos.Context, msg *types.MsgSetAllowedProvider) error {
+	if msg.Authority != k.GetAuthority() {
+		return errors.Wrapf(types.ErrInvalidAuthority, "expected %s, got %s", k.GetAuthority(), msg.Authority)
+	}
+	return nil
+}
+
+func (k msgServer) SetAllowedProviderHandle(ctx cosmos.Context, msg *types.MsgSetAllowedProvider) error {
+	if k.IsAllowedProvider(ctx, msg.Provider) == msg.Allowed {
+		// nothing changed
+		return nil
+	}
+
+	if msg.Allowed {
+		if err := k.AddAllowedProvider(ctx, msg.Provider); err != nil {
+			return err
+		}
+	} else {
+		// existing bonds and contracts are untouched, the provider can no
+		// longer add to their bond or have new contracts opened
+		k.RemoveAllowedProvider(ctx, msg.Provider)
+	}
+
+	return k.EmitAllowedProviderEvent(ctx, msg.Provider, msg.Allowed)
+}
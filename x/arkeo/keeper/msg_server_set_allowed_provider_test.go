@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAllowedProvider(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+
+	// only the gov module account may change the allow list
+	msg := types.NewMsgSetAllowedProvider(types.GetRandomBech32Addr().String(), providerPubKey, true)
+	_, err := s.SetAllowedProvider(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidAuthority)
+	require.False(t, k.IsAllowedProvider(ctx, providerPubKey))
+
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	msg.Authority = k.GetAuthority()
+	_, err = s.SetAllowedProvider(ctx, msg)
+	require.NoError(t, err)
+	require.True(t, k.IsAllowedProvider(ctx, providerPubKey))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeAllowedProvider))
+
+	// no change, no event
+	_, err = s.SetAllowedProvider(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeAllowedProvider))
+
+	msg.Allowed = false
+	_, err = s.SetAllowedProvider(ctx, msg)
+	require.NoError(t, err)
+	require.False(t, k.IsAllowedProvider(ctx, providerPubKey))
+	require.Equal(t, 2, countEvents(ctx, types.EventTypeAllowedProvider))
+}
+
+func TestPermissionedProviders(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAddress, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	service := common.BTCService
+
+	bondMsg := types.MsgBondProvider{
+		Creator:  providerAddress,
+		Provider: providerPubKey,
+		Service:  service.String(),
+		Bond:     cosmos.NewInt(500),
+	}
+
+	rates, err := cosmos.ParseCoins("15uarkeo")
+	require.NoError(t, err)
+	provider := types.NewProvider(providerPubKey, service)
+	provider.Bond = cosmos.NewInt(10000000000)
+	provider.Status = types.ProviderStatus_ONLINE
+	provider.MinContractDuration = 10
+	provider.MaxContractDuration = 500
+	provider.SubscriptionRate = rates
+	provider.PayAsYouGoRate = rates
+	provider.LastUpdate = ctx.BlockHeight()
+	require.NoError(t, k.SetProvider(ctx, provider))
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAddress, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAddress, getCoin(common.Tokens(10))))
+	openMsg := types.MsgOpenContract{
+		Provider:         providerPubKey,
+		Service:          service.String(),
+		Creator:          clientAddress,
+		Client:           clientPubKey,
+		ContractType:     types.ContractType_SUBSCRIPTION,
+		Duration:         100,
+		Rate:             rates[0],
+		Deposit:          cosmos.NewInt(100 * 15),
+		QueriesPerMinute: 1,
+	}
+
+	// permissionless by default
+	require.NoError(t, s.BondProviderValidate(ctx, &bondMsg))
+	require.NoError(t, s.OpenContractValidate(ctx, &openMsg))
+
+	_, err = s.SetConfig(ctx, types.NewMsgSetConfig(k.GetAuthority(), configs.PermissionedProviders.String(), 1))
+	require.NoError(t, err)
+
+	err = s.BondProviderValidate(ctx, &bondMsg)
+	require.ErrorIs(t, err, types.ErrProviderNotAllowed)
+	err = s.OpenContractValidate(ctx, &openMsg)
+	require.ErrorIs(t, err, types.ErrProviderNotAllowed)
+
+	// providers that are not allowed can still unbond
+	bondMsg.Bond = cosmos.NewInt(-500)
+	require.NoError(t, s.BondProviderValidate(ctx, &bondMsg))
+	bondMsg.Bond = cosmos.NewInt(500)
+
+	require.NoError(t, k.AddAllowedProvider(ctx, providerPubKey))
+	require.NoError(t, s.BondProviderValidate(ctx, &bondMsg))
+	require.NoError(t, s.OpenContractValidate(ctx, &openMsg))
+
+	k.RemoveAllowedProvider(ctx, providerPubKey)
+	err = s.OpenContractValidate(ctx, &openMsg)
+	require.ErrorIs(t, err, types.ErrProviderNotAllowed)
+}
@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) SetArbiter(goCtx context.Context, msg *types.MsgSetArbiter) (*types.MsgSetArbiterResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgSetArbiter",
+		"arbiter", msg.Arbiter,
+		"allowed", msg.Allowed,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.SetArbiterValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set arbiter validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.SetArbiterHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set arbiter handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgSetArbiterResponse{}, nil
+}
+
+func (k msgServer) SetArbiterValidate(ctx cosmos.Context, msg *types.MsgSetArbiter) error {
+	if msg.Authority != k.GetAuthority() {
+		return errors.Wrapf(types.ErrInvalidAuthority, "expected %s, got %s", k.GetAuthority(), msg.Authority)
+	}
+	return nil
+}
+
+func (k msgServer) SetArbiterHandle(ctx cosmos.Context, msg *types.MsgSetArbiter) error {
+	if k.IsArbiter(ctx, msg.Arbiter) == msg.Allowed {
+		// nothing changed
+		return nil
+	}
+
+	if msg.Allowed {
+		if err := k.AddArbiter(ctx, msg.Arbiter); err != nil {
+			return err
+		}
+	} else {
+		// disputes are not assigned to an arbiter, so there is nothing to
+		// hand over
+		k.RemoveArbiter(ctx, msg.Arbiter)
+	}
+
+	return k.EmitArbiterEvent(ctx, msg.Arbiter, msg.Allowed)
+}
@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) SetConfig(goCtx context.Context, msg *types.MsgSetConfig) (*types.MsgSetConfigResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgSetConfig",
+		"name", msg.Name,
+		"value", msg.Value,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.SetConfigValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set config validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.SetConfigHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set config handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgSetConfigResponse{}, nil
+}
+
+func (k msgServer) SetConfigValidate(ctx cosmos.Context, msg *types.MsgSetConfig) error {
+	if msg.Authority != k.GetAuthority() {
+		return errors.Wrapf(types.ErrInvalidAuthority, "expected %s, got %s", k.GetAuthority(), msg.Authority)
+	}
+
+	name, ok := configs.GetConfigName(msg.Name)
+	if !ok {
+		return errors.Wrapf(types.ErrInvalidConfig, "unknown config (%s)", msg.Name)
+	}
+
+	return types.ValidateConfigOverride(name, msg.Value)
+}
+
+func (k msgServer) SetConfigHandle(ctx cosmos.Context, msg *types.MsgSetConfig) error {
+	name, _ := configs.GetConfigName(msg.Name)
+	oldValue := k.FetchConfig(ctx, name)
+	k.SetConfigOverride(ctx, name, msg.Value)
+	if err := k.EmitConfigChangeEvent(ctx, name.String(), oldValue, msg.Value); err != nil {
+		return err
+	}
+
+	halted := msg.Value > 0
+	if name == configs.HaltContractOpening && halted != (oldValue > 0) {
+		return k.EmitContractOpeningHaltEvent(ctx, halted)
+	}
+	return nil
+}
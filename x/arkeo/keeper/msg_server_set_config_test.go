@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetConfig(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	s := newMsgServer(k, sk)
+
+	defaultTax := s.FetchConfig(ctx, configs.ReserveTax)
+
+	// only the gov module account may change configs
+	msg := types.NewMsgSetConfig(types.GetRandomBech32Addr().String(), configs.ReserveTax.String(), 500)
+	_, err := s.SetConfig(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidAuthority)
+
+	// tax out of range
+	msg.Authority = k.GetAuthority()
+	msg.Value = configs.MaxBasisPoints + 1
+	_, err = s.SetConfig(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidConfig)
+	require.Equal(t, defaultTax, s.FetchConfig(ctx, configs.ReserveTax))
+
+	// config that cannot be changed by governance
+	_, err = s.SetConfig(ctx, types.NewMsgSetConfig(k.GetAuthority(), configs.MaxSupply.String(), 1))
+	require.ErrorIs(t, err, types.ErrInvalidConfig)
+
+	// happy path
+	msg.Value = 500
+	_, err = s.SetConfig(ctx, msg)
+	require.NoError(t, err)
+	require.EqualValues(t, 500, s.FetchConfig(ctx, configs.ReserveTax))
+	require.EqualValues(t, 500, NewManager(k, sk).FetchConfig(ctx, configs.ReserveTax))
+
+	value, ok := k.GetConfigOverride(ctx, configs.ReserveTax)
+	require.True(t, ok)
+	require.EqualValues(t, 500, value)
+	_, ok = k.GetConfigOverride(ctx, configs.EmissionCurve)
+	require.False(t, ok)
+
+	found := false
+	for _, evt := range ctx.EventManager().Events() {
+		if evt.Type != types.EventTypeConfigChange {
+			continue
+		}
+		found = true
+		for _, attr := range evt.Attributes {
+			switch string(attr.Key) {
+			case "name":
+				require.Equal(t, "\"ReserveTax\"", string(attr.Value))
+			case "old_value":
+				require.Equal(t, "\"1000\"", string(attr.Value))
+			case "new_value":
+				require.Equal(t, "\"500\"", string(attr.Value))
+			}
+		}
+	}
+	require.True(t, found)
+	require.EqualValues(t, 1000, defaultTax)
+}
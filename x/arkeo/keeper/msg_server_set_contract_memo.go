@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) SetContractMemo(goCtx context.Context, msg *types.MsgSetContractMemo) (*types.MsgSetContractMemoResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgSetContractMemo",
+		"contract_id", msg.ContractId,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.SetContractMemoValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set contract memo validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.SetContractMemoHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set contract memo handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgSetContractMemoResponse{}, nil
+}
+
+func (k msgServer) SetContractMemoValidate(ctx cosmos.Context, msg *types.MsgSetContractMemo) error {
+	if k.FetchConfig(ctx, configs.HandlerSetContractMemo) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "set contract memo")
+	}
+
+	if err := types.ValidateContractMemo(msg.Memo); err != nil {
+		return err
+	}
+
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	if contract.IsEmpty() {
+		return errors.Wrapf(types.ErrContractNotFound, "id: %d", msg.ContractId)
+	}
+
+	clientAccountAddress, err := contract.Client.GetMyAddress()
+	if err != nil {
+		return err
+	}
+
+	if !msg.MustGetSigner().Equals(clientAccountAddress) {
+		return errors.Wrapf(types.ErrSetContractMemoUnauthorized, "only the client can set the contract memo")
+	}
+
+	return nil
+}
+
+func (k msgServer) SetContractMemoHandle(ctx cosmos.Context, msg *types.MsgSetContractMemo) error {
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	// the memo is client side bookkeeping only, it has no effect on settlement
+	contract.Memo = msg.Memo
+	return k.SetContract(ctx, contract)
+}
@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetContractMemo(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(14)
+
+	s := newMsgServer(k, sk)
+
+	// setup
+	providerPubkey := types.GetRandomPubKey()
+	providerAcct, err := providerPubkey.GetMyAddress()
+	require.NoError(t, err)
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	contract := types.NewContract(providerPubkey, common.BTCService, clientPubKey)
+	contract.Duration = 100
+	contract.Height = 10
+	contract.Id = 1
+	contract.Deposit = cosmos.NewInt(500)
+	contract.Memo = "initial"
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	msg := types.MsgSetContractMemo{
+		Creator:    clientAcct,
+		ContractId: contract.Id,
+		Memo:       "staging cluster",
+	}
+	_, err = s.SetContractMemo(ctx, &msg)
+	require.NoError(t, err)
+
+	contract, err = k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.Equal(t, "staging cluster", contract.Memo)
+	// settlement fields are untouched
+	require.Equal(t, int64(500), contract.Deposit.Int64())
+	require.True(t, contract.Paid.IsZero())
+
+	// only the client may set the memo
+	msg.Creator = providerAcct
+	err = s.SetContractMemoValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrSetContractMemoUnauthorized)
+
+	// oversized memo
+	msg.Creator = clientAcct
+	msg.Memo = strings.Repeat("a", types.MaxContractMemoLength+1)
+	err = s.SetContractMemoValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrInvalidContractMemo)
+
+	// unknown contract
+	msg.Memo = ""
+	msg.ContractId = 2
+	err = s.SetContractMemoValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrContractNotFound)
+}
@@ -0,0 +1,47 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) SetEmissionSchedule(goCtx context.Context, msg *types.MsgSetEmissionSchedule) (*types.MsgSetEmissionScheduleResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgSetEmissionSchedule",
+		"epochs", len(msg.Epochs),
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.SetEmissionScheduleValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set emission schedule validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.SetEmissionScheduleHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set emission schedule handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgSetEmissionScheduleResponse{}, nil
+}
+
+func (k msgServer) SetEmissionScheduleValidate(ctx cosmos.Context, msg *types.MsgSetEmissionSchedule) error {
+	if msg.Authority != k.GetAuthority() {
+		return errors.Wrapf(types.ErrInvalidAuthority, "expected %s, got %s", k.GetAuthority(), msg.Authority)
+	}
+	return types.EmissionSchedule{Epochs: msg.Epochs}.Validate()
+}
+
+func (k msgServer) SetEmissionScheduleHandle(ctx cosmos.Context, msg *types.MsgSetEmissionSchedule) error {
+	schedule := types.EmissionSchedule{Epochs: msg.Epochs}
+	k.SetEmissionSchedule(ctx, schedule)
+	return k.EmitEmissionScheduleEvent(ctx, schedule)
+}
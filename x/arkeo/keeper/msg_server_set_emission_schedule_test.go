@@ -0,0 +1,73 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetEmissionSchedule(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(250)
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+
+	// a reserve paying 100 per block on the default emission curve of 6
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(5256666*600)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ReserveName, getCoins(cosmos.NewInt(5256666*600))))
+	require.Equal(t, int64(100), mgr.NextBlockReward(ctx).Int64())
+
+	epochs := []types.EmissionEpoch{
+		{StartHeight: 0, EmissionCurve: 6, HalvingInterval: 100},
+		{StartHeight: 1000, EmissionCurve: 12},
+	}
+
+	// only the gov module account may set the schedule
+	msg := types.NewMsgSetEmissionSchedule(types.GetRandomBech32Addr().String(), epochs)
+	_, err := s.SetEmissionSchedule(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidAuthority)
+
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	msg.Authority = k.GetAuthority()
+	_, err = s.SetEmissionSchedule(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeEmissionSchedule))
+
+	// halved twice by height 250
+	res, err := k.EmissionSchedule(ctx, &types.QueryEmissionScheduleRequest{})
+	require.NoError(t, err)
+	require.Equal(t, epochs, res.Epochs)
+	require.Equal(t, int64(6), res.EmissionCurve)
+	require.Equal(t, int64(2), res.Halvings)
+	require.Equal(t, int64(25), res.BlockReward.Int64())
+
+	// the next epoch does not halve
+	ctx = ctx.WithBlockHeight(5000)
+	require.Equal(t, int64(50), mgr.NextBlockReward(ctx).Int64())
+
+	// epochs must start one after the other
+	msg.Epochs = []types.EmissionEpoch{
+		{StartHeight: 100, EmissionCurve: 6},
+		{StartHeight: 100, EmissionCurve: 12},
+	}
+	_, err = s.SetEmissionSchedule(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidEmissionSchedule)
+
+	// clearing the schedule falls back to the emission curve config
+	msg.Epochs = nil
+	_, err = s.SetEmissionSchedule(ctx, msg)
+	require.NoError(t, err)
+	schedule, err := k.GetEmissionSchedule(ctx)
+	require.NoError(t, err)
+	require.Empty(t, schedule.Epochs)
+	require.Equal(t, int64(100), mgr.NextBlockReward(ctx).Int64())
+}
+
+func TestHalveReward(t *testing.T) {
+	require.Equal(t, int64(100), halveReward(cosmos.NewInt(100), 0).Int64())
+	require.Equal(t, int64(12), halveReward(cosmos.NewInt(100), 3).Int64())
+	require.True(t, halveReward(cosmos.NewInt(100), 7).IsZero())
+	require.True(t, halveReward(cosmos.NewInt(100), 1000).IsZero())
+}
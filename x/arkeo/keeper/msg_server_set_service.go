@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) SetService(goCtx context.Context, msg *types.MsgSetService) (*types.MsgSetServiceResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgSetService",
+		"service", msg.Service,
+		"default_port", msg.DefaultPort,
+		"query_schema", msg.QuerySchema,
+		"supported", msg.Supported,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.SetServiceValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set service validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.SetServiceHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed set service handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgSetServiceResponse{}, nil
+}
+
+func (k msgServer) SetServiceValidate(ctx cosmos.Context, msg *types.MsgSetService) error {
+	if msg.Authority != k.GetAuthority() {
+		return errors.Wrapf(types.ErrInvalidAuthority, "expected %s, got %s", k.GetAuthority(), msg.Authority)
+	}
+	if _, err := common.NewService(msg.Service); err != nil {
+		return errors.Wrapf(types.ErrInvalidService, "%s", err)
+	}
+	return nil
+}
+
+func (k msgServer) SetServiceHandle(ctx cosmos.Context, msg *types.MsgSetService) error {
+	service, err := common.NewService(msg.Service)
+	if err != nil {
+		return err
+	}
+
+	if msg.Supported {
+		info := types.ServiceInfo{
+			Service:     service,
+			Description: msg.Description,
+			DefaultPort: msg.DefaultPort,
+			QuerySchema: msg.QuerySchema,
+		}
+		if err := k.SetServiceInfo(ctx, info); err != nil {
+			return err
+		}
+	} else {
+		if !k.ServiceInfoExists(ctx, service) {
+			// nothing changed
+			return nil
+		}
+		// bonded providers and open contracts are untouched, the service
+		// only takes no new bond or contracts
+		k.RemoveServiceInfo(ctx, service)
+	}
+
+	return k.EmitServiceEvent(ctx, service.String(), msg.Supported)
+}
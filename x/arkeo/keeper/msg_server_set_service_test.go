@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetService(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	s := newMsgServer(k, sk)
+
+	// only the gov module account may change the registry
+	msg := types.NewMsgSetService(types.GetRandomBech32Addr().String(), common.BTCService.String(), "bitcoin full node", 8332, "https://example.com/btc.json", true)
+	_, err := s.SetService(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidAuthority)
+	require.False(t, k.ServiceInfoExists(ctx, common.BTCService))
+
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	msg.Authority = k.GetAuthority()
+	_, err = s.SetService(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeService))
+	info, err := k.GetServiceInfo(ctx, common.BTCService)
+	require.NoError(t, err)
+	require.Equal(t, uint32(8332), info.DefaultPort)
+	require.Equal(t, "https://example.com/btc.json", info.QuerySchema)
+
+	res, err := k.Services(ctx, &types.QueryServicesRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Services, 1)
+	require.Equal(t, common.BTCService, res.Services[0].Service)
+
+	// once a service is registered, unregistered services are not supported
+	providerPubKey := types.GetRandomPubKey()
+	acct, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, acct, getCoin(common.Tokens(10))))
+	bond := types.MsgBondProvider{
+		Creator:  acct,
+		Provider: providerPubKey,
+		Service:  common.ETHService.String(),
+		Bond:     cosmos.NewInt(common.Tokens(1)),
+	}
+	require.ErrorIs(t, s.BondProviderValidate(ctx, &bond), types.ErrUnsupportedService)
+	bond.Service = common.BTCService.String()
+	require.NoError(t, s.BondProviderValidate(ctx, &bond))
+	require.NoError(t, s.BondProviderHandle(ctx, &bond))
+
+	// removing a service still lets its providers withdraw their bond
+	msg.Supported = false
+	_, err = s.SetService(ctx, msg)
+	require.NoError(t, err)
+	require.False(t, k.ServiceInfoExists(ctx, common.BTCService))
+	require.Equal(t, 2, countEvents(ctx, types.EventTypeService))
+
+	bond.Bond = cosmos.NewInt(common.Tokens(-1))
+	require.NoError(t, s.BondProviderValidate(ctx, &bond))
+
+	// no change, no event
+	_, err = s.SetService(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, 2, countEvents(ctx, types.EventTypeService))
+}
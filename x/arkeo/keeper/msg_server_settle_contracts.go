@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) SettleContracts(goCtx context.Context, msg *types.MsgSettleContracts) (*types.MsgSettleContractsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgSettleContracts",
+		"claims", len(msg.Claims),
+	)
+
+	if err := k.SettleContractsValidate(ctx, msg); err != nil {
+		ctx.Logger().Error("failed settle contracts validation", "err", err)
+		return nil, err
+	}
+
+	return &types.MsgSettleContractsResponse{
+		Results: k.SettleContractsHandle(ctx, msg),
+	}, nil
+}
+
+func (k msgServer) SettleContractsValidate(ctx cosmos.Context, msg *types.MsgSettleContracts) error {
+	if k.FetchConfig(ctx, configs.HandlerSettleContracts) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "settle contracts")
+	}
+
+	max := k.FetchConfig(ctx, configs.MaxSettleBatchSize)
+	if int64(len(msg.Claims)) > max {
+		return errors.Wrapf(types.ErrInvalidSettleContractsBatch, "too many claims (%d/%d)", len(msg.Claims), max)
+	}
+
+	return nil
+}
+
+// SettleContractsHandle settles each claim of the batch on its own, a claim
+// that fails is skipped (and its state changes discarded) without aborting
+// the rest of the batch
+func (k msgServer) SettleContractsHandle(ctx cosmos.Context, msg *types.MsgSettleContracts) []types.SettleContractResult {
+	results := make([]types.SettleContractResult, 0, len(msg.Claims))
+	for _, claim := range msg.Claims {
+		result := types.SettleContractResult{ContractId: claim.ContractId}
+		if err := k.settleContractClaim(ctx, msg.ClaimContractIncomeMsg(claim)); err != nil {
+			ctx.Logger().Error("failed to settle contract claim", "contract_id", claim.ContractId, "err", err)
+			result.Error = err.Error()
+		} else {
+			result.Success = true
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (k msgServer) settleContractClaim(ctx cosmos.Context, claimMsg *types.MsgClaimContractIncome) error {
+	if err := claimMsg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.ClaimContractIncomeValidate(cacheCtx, claimMsg); err != nil {
+		return err
+	}
+	if err := k.ClaimContractIncomeHandle(cacheCtx, claimMsg); err != nil {
+		return err
+	}
+	commit()
+	return nil
+}
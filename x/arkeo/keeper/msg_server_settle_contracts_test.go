@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	cKeys "github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettleContracts(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(20).WithEventManager(sdk.NewEventManager())
+
+	s := newMsgServer(k, sk)
+
+	// setup
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	module.NewBasicManager().RegisterInterfaces(interfaceRegistry)
+	types.RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+	kb := cKeys.NewInMemory(cdc)
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAcc, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(common.Tokens(10*100*2))))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ContractName, getCoins(cosmos.NewInt(10*100*2))))
+
+	rate, err := cosmos.ParseCoin("10uarkeo")
+	require.NoError(t, err)
+
+	newClient := func(name string) common.PubKey {
+		info, _, err := kb.NewMnemonic(name, cKeys.English, `m/44'/931'/0'/0/0`, "", hd.Secp256k1)
+		require.NoError(t, err)
+		pk, err := info.GetPubKey()
+		require.NoError(t, err)
+		client, err := common.NewPubKeyFromCrypto(pk)
+		require.NoError(t, err)
+		return client
+	}
+
+	for i, name := range []string{"client1", "client2"} {
+		contract := types.NewContract(providerPubKey, common.BTCService, newClient(name))
+		contract.Duration = 100
+		contract.Rate = rate
+		contract.Height = 10
+		contract.Type = types.ContractType_PAY_AS_YOU_GO
+		contract.Deposit = cosmos.NewInt(contract.Duration * contract.Rate.Amount.Int64())
+		contract.Id = uint64(i + 1)
+		require.NoError(t, k.SetContract(ctx, contract))
+	}
+
+	sig1, _, err := kb.Sign("client1", types.GetBytesToSign(1, 20))
+	require.NoError(t, err)
+	// signed by the wrong client
+	sig2, _, err := kb.Sign("client1", types.GetBytesToSign(2, 20))
+	require.NoError(t, err)
+
+	msg := types.NewMsgSettleContracts(providerAcc, []types.ContractClaim{
+		{ContractId: 1, Nonce: 20, Signature: sig1},
+		{ContractId: 2, Nonce: 20, Signature: sig2},
+		{ContractId: 3, Nonce: 20, Signature: sig1},
+	})
+	resp, err := s.SettleContracts(ctx, msg)
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 3)
+	require.True(t, resp.Results[0].Success)
+	require.False(t, resp.Results[1].Success)
+	require.Contains(t, resp.Results[1].Error, types.ErrClaimContractIncomeInvalidSignature.Error())
+	require.False(t, resp.Results[2].Success)
+	require.NotEmpty(t, resp.Results[2].Error)
+
+	// only the valid claim was paid out
+	require.Equal(t, int64(180), k.GetBalance(ctx, providerAcc).AmountOf(configs.Denom).Int64())
+	contract, err := k.GetContract(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), contract.Nonce)
+	contract, err = k.GetContract(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), contract.Nonce)
+
+	settlements := 0
+	for _, event := range ctx.EventManager().ABCIEvents() {
+		if event.Type == types.EventTypeSettleContract {
+			settlements++
+		}
+	}
+	require.Equal(t, 1, settlements)
+
+	// batch size is capped
+	max := s.FetchConfig(ctx, configs.MaxSettleBatchSize)
+	msg.Claims = make([]types.ContractClaim, max+1)
+	_, err = s.SettleContracts(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidSettleContractsBatch)
+}
@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) SlashProvider(goCtx context.Context, msg *types.MsgSlashProvider) (*types.MsgSlashProviderResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgSlashProvider",
+		"provider", msg.Provider,
+		"service", msg.Service,
+		"basis_points", msg.BasisPoints,
+		"burn", msg.Burn,
+		"reason", msg.Reason,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.SlashProviderValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed slash provider validation", "err", err)
+		return nil, err
+	}
+
+	amount, err := k.SlashProviderHandle(cacheCtx, msg)
+	if err != nil {
+		ctx.Logger().Error("failed slash provider handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgSlashProviderResponse{Amount: amount}, nil
+}
+
+func (k msgServer) SlashProviderValidate(ctx cosmos.Context, msg *types.MsgSlashProvider) error {
+	if msg.Authority != k.GetAuthority() {
+		return errors.Wrapf(types.ErrInvalidAuthority, "expected %s, got %s", k.GetAuthority(), msg.Authority)
+	}
+
+	service, err := common.NewService(msg.Service)
+	if err != nil {
+		return errors.Wrapf(types.ErrInvalidService, "invalid service (%s): %s", msg.Service, err)
+	}
+
+	provider, err := k.GetProvider(ctx, msg.Provider, service)
+	if err != nil {
+		return err
+	}
+	if provider.Bond.IsZero() {
+		return errors.Wrapf(types.ErrProviderNotFound, "%s has no bond for %s", msg.Provider, msg.Service)
+	}
+
+	return nil
+}
+
+func (k msgServer) SlashProviderHandle(ctx cosmos.Context, msg *types.MsgSlashProvider) (cosmos.Int, error) {
+	service, err := common.NewService(msg.Service)
+	if err != nil {
+		return cosmos.ZeroInt(), err
+	}
+	return k.mgr.SlashProvider(ctx, msg.Provider, service, msg.BasisPoints, msg.Burn, msg.Recipient, msg.Reason)
+}
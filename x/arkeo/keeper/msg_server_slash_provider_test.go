@@ -0,0 +1,105 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashProvider(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAcct, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	service := common.BTCService
+
+	require.NoError(t, k.MintAndSendToAccount(ctx, providerAcct, getCoin(common.Tokens(10))))
+	require.NoError(t, s.BondProviderHandle(ctx, &types.MsgBondProvider{
+		Creator:  providerAcct,
+		Provider: providerPubKey,
+		Service:  service.String(),
+		Bond:     cosmos.NewInt(common.Tokens(10)),
+	}))
+
+	// only the gov module account may slash
+	msg := types.NewMsgSlashProvider(types.GetRandomBech32Addr().String(), providerPubKey, service.String(), 1000, false, nil, "served stale blocks")
+	_, err = s.SlashProvider(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidAuthority)
+
+	// the provider must be bonded for the service
+	msg.Authority = k.GetAuthority()
+	msg.Service = common.ETHService.String()
+	_, err = s.SlashProvider(ctx, msg)
+	require.ErrorIs(t, err, types.ErrProviderNotFound)
+
+	// 10% of the bond goes to the reserve by default
+	reserve := k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom)
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	msg.Service = service.String()
+	resp, err := s.SlashProvider(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, common.Tokens(1), resp.Amount.Int64())
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeSlashProvider))
+	require.Equal(t, reserve.AddRaw(common.Tokens(1)).Int64(), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
+	provider, err := k.GetProvider(ctx, providerPubKey, service)
+	require.NoError(t, err)
+	require.Equal(t, common.Tokens(9), provider.Bond.Int64())
+
+	// or to a recipient, such as the affected clients
+	recipient := types.GetRandomBech32Addr()
+	msg.Recipient = recipient
+	_, err = s.SlashProvider(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, common.Tokens(9)/10, k.GetBalance(ctx, recipient).AmountOf(configs.Denom).Int64())
+
+	// a burned slash leaves the supply
+	ctx = ctx.WithBlockHeight(20)
+	supply := k.GetSupply(ctx, configs.Denom).Amount
+	bond := common.Tokens(9) - common.Tokens(9)/10
+	msg.Recipient = nil
+	msg.Burn = true
+	msg.BasisPoints = 5000
+	resp, err = s.SlashProvider(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, bond/2, resp.Amount.Int64())
+	require.Equal(t, supply.SubRaw(bond/2).Int64(), k.GetSupply(ctx, configs.Denom).Amount.Int64())
+	require.Equal(t, bond-bond/2, k.GetBalanceOfModule(ctx, types.ProviderName, configs.Denom).Int64())
+
+	// the history is kept in order, slashes in the same block are kept apart
+	query, err := k.ProviderSlashes(ctx, &types.QueryProviderSlashesRequest{Pubkey: providerPubKey.String()})
+	require.NoError(t, err)
+	require.Len(t, query.Slashes, 3)
+	require.EqualValues(t, 10, query.Slashes[0].Height)
+	require.EqualValues(t, 0, query.Slashes[0].Sequence)
+	require.Equal(t, k.GetModuleAccAddress(types.ReserveName), query.Slashes[0].Recipient)
+	require.Equal(t, "served stale blocks", query.Slashes[0].Reason)
+	require.EqualValues(t, 1, query.Slashes[1].Sequence)
+	require.Equal(t, recipient, query.Slashes[1].Recipient)
+	require.EqualValues(t, 20, query.Slashes[2].Height)
+	require.True(t, query.Slashes[2].Burned)
+	require.Empty(t, query.Slashes[2].Recipient)
+
+	query, err = k.ProviderSlashes(ctx, &types.QueryProviderSlashesRequest{
+		Pubkey:  providerPubKey.String(),
+		Service: common.ETHService.String(),
+	})
+	require.NoError(t, err)
+	require.Empty(t, query.Slashes)
+
+	// slashing the whole bond removes the provider
+	msg.BasisPoints = configs.MaxBasisPoints
+	_, err = s.SlashProvider(ctx, msg)
+	require.NoError(t, err)
+	provider, err = k.GetProvider(ctx, providerPubKey, service)
+	require.NoError(t, err)
+	require.True(t, provider.Bond.IsZero())
+	_, err = s.SlashProvider(ctx, msg)
+	require.ErrorIs(t, err, types.ErrProviderNotFound)
+}
@@ -0,0 +1,137 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k msgServer) TransferContract(goCtx context.Context, msg *types.MsgTransferContract) (*types.MsgTransferContractResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ctx.Logger().Info(
+		"receive MsgTransferContract",
+		"contract_id", msg.ContractId,
+		"new_client", msg.NewClient,
+	)
+
+	cacheCtx, commit := ctx.CacheContext()
+	if err := k.TransferContractValidate(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed transfer contract validation", "err", err)
+		return nil, err
+	}
+
+	if err := k.TransferContractHandle(cacheCtx, msg); err != nil {
+		ctx.Logger().Error("failed transfer contract handler", "err", err)
+		return nil, err
+	}
+
+	commit()
+	return &types.MsgTransferContractResponse{}, nil
+}
+
+func (k msgServer) TransferContractValidate(ctx cosmos.Context, msg *types.MsgTransferContract) error {
+	if k.FetchConfig(ctx, configs.HandlerTransferContract) > 0 {
+		return errors.Wrapf(types.ErrDisabledHandler, "transfer contract")
+	}
+
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	if contract.IsEmpty() {
+		return errors.Wrapf(types.ErrContractNotFound, "id: %d", msg.ContractId)
+	}
+
+	clientAccountAddress, err := contract.Client.GetMyAddress()
+	if err != nil {
+		return err
+	}
+
+	if !msg.MustGetSigner().Equals(clientAccountAddress) {
+		return errors.Wrapf(types.ErrTransferContractUnauthorized, "id: %d", msg.ContractId)
+	}
+
+	if contract.IsExpired(ctx.BlockHeight()) {
+		return errors.Wrapf(types.ErrCloseContractAlreadyClosed, "closed %d", contract.Expiration())
+	}
+
+	// pay-as-you-go income is claimed with queries signed by the spender, so
+	// the provider would be unable to claim what was used before the transfer
+	if contract.IsPayAsYouGo() {
+		return errors.Wrapf(types.ErrTransferContractType, "id: %d", msg.ContractId)
+	}
+
+	// compare addresses rather than pubkeys, to catch the same key encoded
+	// differently
+	newClientAddress, err := msg.NewClient.GetMyAddress()
+	if err != nil {
+		return err
+	}
+	if newClientAddress.Equals(clientAccountAddress) {
+		return errors.Wrapf(types.ErrTransferContractSameClient, "%s", msg.NewClient)
+	}
+	providerAddress, err := contract.Provider.GetMyAddress()
+	if err != nil {
+		return err
+	}
+	if providerAddress.Equals(newClientAddress) {
+		return errors.Wrapf(types.ErrSelfContract, "provider and client resolve to the same address %s", newClientAddress)
+	}
+
+	activeContract, err := k.GetActiveContractForUser(ctx, msg.NewClient, contract.Provider, contract.Service)
+	if err != nil {
+		return err
+	}
+	if !activeContract.IsEmpty() && !activeContract.IsExpired(ctx.BlockHeight()) {
+		return errors.Wrapf(types.ErrOpenContractAlreadyOpen, "expires in %d blocks", activeContract.Expiration()-ctx.BlockHeight())
+	}
+
+	return nil
+}
+
+func (k msgServer) TransferContractHandle(ctx cosmos.Context, msg *types.MsgTransferContract) error {
+	contract, err := k.GetContract(ctx, msg.ContractId)
+	if err != nil {
+		return err
+	}
+
+	if err := k.RemoveFromUserContractSet(ctx, contract.GetSpender(), contract.Id); err != nil {
+		return err
+	}
+	// drop the contract from the old client's index before it is re-indexed
+	// under the new client
+	k.RemoveContract(ctx, contract.Id)
+
+	// the delegate and refund address were chosen by the old client, the new
+	// client spends and is refunded themselves until they open another
+	// contract. The deposit, nonce and expiration carry over.
+	oldClient := contract.Client
+	contract.Client = msg.NewClient
+	contract.Delegate = common.EmptyPubKey
+	contract.RefundAddress = nil
+	if err := k.SetContract(ctx, contract); err != nil {
+		return err
+	}
+
+	userSet, err := k.GetUserContractSet(ctx, contract.GetSpender())
+	if err != nil {
+		return err
+	}
+	if userSet.ContractSet == nil {
+		userSet.ContractSet = &types.ContractSet{}
+	}
+	userSet.ContractSet.ContractIds = append(userSet.ContractSet.ContractIds, contract.Id)
+	if err := k.SetUserContractSet(ctx, userSet); err != nil {
+		return err
+	}
+
+	return k.EmitTransferContractEvent(ctx, oldClient, &contract)
+}
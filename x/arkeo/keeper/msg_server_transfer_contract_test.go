@@ -0,0 +1,134 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferContract(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAcct, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+	newClientPubKey := types.GetRandomPubKey()
+	delegatePubKey := types.GetRandomPubKey()
+	service := common.BTCService
+
+	require.NoError(t, k.MintAndSendToAccount(ctx, providerAcct, getCoin(common.Tokens(10))))
+	require.NoError(t, s.BondProviderHandle(ctx, &types.MsgBondProvider{
+		Creator:  providerAcct,
+		Provider: providerPubKey,
+		Service:  service.String(),
+		Bond:     cosmos.NewInt(common.Tokens(10)),
+	}))
+
+	rate, err := cosmos.ParseCoin("5uarkeo")
+	require.NoError(t, err)
+	require.NoError(t, k.MintAndSendToAccount(ctx, clientAcct, getCoin(common.Tokens(10))))
+	require.NoError(t, s.OpenContractHandle(ctx, &types.MsgOpenContract{
+		Creator:      clientAcct,
+		Client:       clientPubKey,
+		Delegate:     delegatePubKey,
+		Service:      service.String(),
+		Provider:     providerPubKey,
+		Deposit:      cosmos.NewInt(500),
+		Rate:         rate,
+		Duration:     100,
+		ContractType: types.ContractType_SUBSCRIPTION,
+	}))
+	contract, err := k.GetActiveContractForUser(ctx, delegatePubKey, providerPubKey, service)
+	require.NoError(t, err)
+	contract.Nonce = 7
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	// only the client may transfer the contract
+	msg := types.MsgTransferContract{
+		Creator:    providerAcct,
+		ContractId: contract.Id,
+		NewClient:  newClientPubKey,
+	}
+	err = s.TransferContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrTransferContractUnauthorized)
+
+	msg.Creator = clientAcct
+	msg.NewClient = clientPubKey
+	err = s.TransferContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrTransferContractSameClient)
+
+	msg.NewClient = providerPubKey
+	err = s.TransferContractValidate(ctx, &msg)
+	require.ErrorIs(t, err, types.ErrSelfContract)
+
+	msg.NewClient = newClientPubKey
+	ctx = ctx.WithBlockHeight(20).WithEventManager(cosmos.NewEventManager())
+	_, err = s.TransferContract(ctx, &msg)
+	require.NoError(t, err)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeTransferContract))
+
+	// the deposit, nonce and term carry over, the delegate does not
+	transferred, err := k.GetContract(ctx, contract.Id)
+	require.NoError(t, err)
+	require.True(t, transferred.Client.Equals(newClientPubKey))
+	require.True(t, transferred.Delegate.IsEmpty())
+	require.Equal(t, contract.Deposit, transferred.Deposit)
+	require.Equal(t, contract.Nonce, transferred.Nonce)
+	require.Equal(t, contract.Expiration(), transferred.Expiration())
+
+	// the contract moved between the clients' indexes
+	active, err := k.GetActiveContractForUser(ctx, newClientPubKey, providerPubKey, service)
+	require.NoError(t, err)
+	require.Equal(t, contract.Id, active.Id)
+	active, err = k.GetActiveContractForUser(ctx, delegatePubKey, providerPubKey, service)
+	require.NoError(t, err)
+	require.True(t, active.IsEmpty())
+
+	resp, err := k.ClientContracts(ctx, &types.QueryClientContractsRequest{Client: clientPubKey.String()})
+	require.NoError(t, err)
+	require.Empty(t, resp.Contracts)
+	resp, err = k.ClientContracts(ctx, &types.QueryClientContractsRequest{Client: newClientPubKey.String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Contracts, 1)
+
+	// the old client can no longer transfer it
+	err = s.TransferContractValidate(ctx, &types.MsgTransferContract{
+		Creator:    clientAcct,
+		ContractId: contract.Id,
+		NewClient:  types.GetRandomPubKey(),
+	})
+	require.ErrorIs(t, err, types.ErrTransferContractUnauthorized)
+}
+
+func TestTransferContractPayAsYouGo(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(14)
+	s := newMsgServer(k, sk)
+
+	clientPubKey := types.GetRandomPubKey()
+	clientAcct, err := clientPubKey.GetMyAddress()
+	require.NoError(t, err)
+
+	contract := types.NewContract(types.GetRandomPubKey(), common.BTCService, clientPubKey)
+	contract.Type = types.ContractType_PAY_AS_YOU_GO
+	contract.Duration = 100
+	contract.Height = 10
+	contract.Id = 1
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	// queries signed by the old client could no longer be claimed
+	err = s.TransferContractValidate(ctx, &types.MsgTransferContract{
+		Creator:    clientAcct,
+		ContractId: contract.Id,
+		NewClient:  types.GetRandomPubKey(),
+	})
+	require.ErrorIs(t, err, types.ErrTransferContractType)
+}
@@ -0,0 +1,123 @@
+package keeper
+
+import (
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// ProviderIncentiveEndBlock pays the ProviderIncentive out of the reserve to
+// the active providers, every ProviderIncentiveCycle blocks, until the
+// ProviderIncentiveEnd height. This bootstraps the network while contract
+// income is still low. Each provider's share is pro-rated by its bond times
+// one more than the number of contracts open against it, so bonded providers
+// without contracts yet still earn, times its uptime score. Every contract
+// counted cost its client the OpenContractCost, so contracts a provider opens
+// against itself from other accounts are not free. The incentive does not
+// draw the reserve below the MinReserveFloor.
+func (mgr Manager) ProviderIncentiveEndBlock(ctx cosmos.Context) error {
+	incentive := mgr.FetchConfig(ctx, configs.ProviderIncentive)
+	cycle := mgr.FetchConfig(ctx, configs.ProviderIncentiveCycle)
+	if incentive <= 0 || cycle <= 0 || ctx.BlockHeight()%cycle != 0 {
+		return nil
+	}
+	if end := mgr.FetchConfig(ctx, configs.ProviderIncentiveEnd); end > 0 && ctx.BlockHeight() > end {
+		return nil
+	}
+
+	reserve := mgr.keeper.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom)
+	available := reserve.Sub(cosmos.NewInt(mgr.FetchConfig(ctx, configs.MinReserveFloor)))
+	if !available.IsPositive() {
+		return nil
+	}
+	pool := cosmos.NewInt(incentive)
+	if pool.GT(available) {
+		pool = available
+	}
+
+	providers, err := mgr.incentiveProviders(ctx)
+	if err != nil {
+		return err
+	}
+
+	openContracts := make([]int64, len(providers))
+	weights := make([]cosmos.Int, len(providers))
+	total := cosmos.ZeroInt()
+	for i, provider := range providers {
+		score, _, err := mgr.uptimeScore(ctx, provider.PubKey, provider.Service)
+		if err != nil {
+			return err
+		}
+		openContracts[i], err = mgr.keeper.CountOpenProviderContracts(ctx, provider.PubKey, provider.Service)
+		if err != nil {
+			return err
+		}
+		weight := provider.Bond.MulRaw(1 + openContracts[i])
+		weights[i] = cosmos.NewDecFromInt(weight).Mul(score).TruncateInt()
+		total = total.Add(weights[i])
+	}
+	if total.IsZero() {
+		return nil
+	}
+
+	for i := range providers {
+		provider := providers[i]
+		reward := common.GetSafeShare(weights[i], total, pool)
+		if reward.IsZero() {
+			continue
+		}
+		addr, err := provider.PubKey.GetMyAddress()
+		if err != nil {
+			ctx.Logger().Error("unable to get provider address", "provider", provider.PubKey, "error", err)
+			continue
+		}
+		coin := cosmos.NewCoin(configs.Denom, reward)
+		if err := mgr.keeper.SendFromModuleToAccount(ctx, types.ReserveName, addr, cosmos.NewCoins(coin)); err != nil {
+			ctx.Logger().Error("unable to pay provider incentive", "provider", provider.PubKey, "error", err)
+			continue
+		}
+		if err := mgr.EmitProviderIncentiveEvent(ctx, &provider, openContracts[i], coin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// incentiveProviders returns the providers eligible for the incentive, those
+// online, allowed, bonded with at least the MinProviderBond and meeting the
+// MinProviderUptime
+func (mgr Manager) incentiveProviders(ctx cosmos.Context) ([]types.Provider, error) {
+	minBond := cosmos.NewInt(mgr.FetchConfig(ctx, configs.MinProviderBond))
+	var providers []types.Provider
+	iter := mgr.keeper.GetProviderIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var provider types.Provider
+		if err := mgr.keeper.Cdc().Unmarshal(iter.Value(), &provider); err != nil {
+			ctx.Logger().Error("fail to unmarshal provider", "error", err)
+			continue
+		}
+		if provider.LastUpdate == 0 || provider.Status != types.ProviderStatus_ONLINE {
+			continue
+		}
+		if !provider.Bond.IsPositive() || provider.Bond.LT(minBond) {
+			continue
+		}
+		if !mgr.isProviderAllowed(ctx, provider.PubKey) {
+			continue
+		}
+		_, meetsUptime, err := mgr.uptimeScore(ctx, provider.PubKey, provider.Service)
+		if err != nil {
+			return nil, err
+		}
+		if !meetsUptime {
+			continue
+		}
+		if !mgr.isSupportedService(ctx, provider.Service) {
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderIncentiveEndBlock(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(100)
+	mgr := NewManager(k, sk)
+
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(1000)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ReserveName, getCoins(cosmos.NewInt(1000))))
+	k.SetConfigOverride(ctx, configs.MinProviderBond, 10)
+	k.SetConfigOverride(ctx, configs.ProviderIncentiveCycle, 10)
+
+	newProvider := func(bond int64, status types.ProviderStatus) types.Provider {
+		provider := types.NewProvider(types.GetRandomPubKey(), common.BTCService)
+		provider.Bond = cosmos.NewInt(bond)
+		provider.Status = status
+		provider.LastUpdate = 1
+		require.NoError(t, k.SetProvider(ctx, provider))
+		return provider
+	}
+	idle := newProvider(100, types.ProviderStatus_ONLINE)
+	busy := newProvider(100, types.ProviderStatus_ONLINE)
+	bonded := newProvider(200, types.ProviderStatus_ONLINE)
+	offline := newProvider(100, types.ProviderStatus_OFFLINE)
+	underBonded := newProvider(5, types.ProviderStatus_ONLINE)
+
+	contract := types.NewContract(busy.PubKey, busy.Service, types.GetRandomPubKey())
+	contract.Id = 1
+	contract.Height = 90
+	contract.Duration = 100
+	contract.Rate = getCoin(1)
+	require.NoError(t, k.SetContract(ctx, contract))
+
+	balanceOf := func(pubkey common.PubKey) int64 {
+		addr, err := pubkey.GetMyAddress()
+		require.NoError(t, err)
+		return k.GetBalance(ctx, addr).AmountOf(configs.Denom).Int64()
+	}
+
+	// the program is disabled by default
+	require.NoError(t, mgr.ProviderIncentiveEndBlock(ctx))
+	require.Zero(t, balanceOf(idle.PubKey))
+
+	// the busy provider earns twice the idle one, with one open contract, as
+	// does the one with twice the bond
+	k.SetConfigOverride(ctx, configs.ProviderIncentive, 300)
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	require.NoError(t, mgr.ProviderIncentiveEndBlock(ctx))
+	require.Equal(t, 3, countEvents(ctx, types.EventTypeProviderIncentive))
+	require.Equal(t, int64(60), balanceOf(idle.PubKey))
+	require.Equal(t, int64(120), balanceOf(busy.PubKey))
+	require.Equal(t, int64(120), balanceOf(bonded.PubKey))
+	require.Zero(t, balanceOf(offline.PubKey))
+	require.Zero(t, balanceOf(underBonded.PubKey))
+	require.Equal(t, int64(700), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
+
+	// only paid once each cycle
+	ctx = ctx.WithBlockHeight(105)
+	require.NoError(t, mgr.ProviderIncentiveEndBlock(ctx))
+	require.Equal(t, int64(60), balanceOf(idle.PubKey))
+
+	// the incentive does not draw the reserve below the floor
+	k.SetConfigOverride(ctx, configs.MinReserveFloor, 550)
+	ctx = ctx.WithBlockHeight(110)
+	require.NoError(t, mgr.ProviderIncentiveEndBlock(ctx))
+	require.Equal(t, int64(550), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
+
+	// nothing is paid after the program ends
+	k.SetConfigOverride(ctx, configs.MinReserveFloor, 0)
+	k.SetConfigOverride(ctx, configs.ProviderIncentiveEnd, 115)
+	ctx = ctx.WithBlockHeight(120)
+	require.NoError(t, mgr.ProviderIncentiveEndBlock(ctx))
+	require.Equal(t, int64(550), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
+}
@@ -2,10 +2,13 @@ package keeper
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
 
 	"github.com/arkeonetwork/arkeo/common"
 	"github.com/arkeonetwork/arkeo/common/cosmos"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	gogotypes "github.com/gogo/protobuf/types"
 )
 
 func (k KVStore) setProvider(ctx cosmos.Context, key string, record types.Provider) {
@@ -13,11 +16,23 @@ func (k KVStore) setProvider(ctx cosmos.Context, key string, record types.Provid
 	buf := k.cdc.MustMarshal(&record)
 	if buf == nil || record.Bond.IsZero() {
 		store.Delete([]byte(key))
+		if record.Id > 0 {
+			store.Delete([]byte(k.getProviderIdKey(ctx, record.Id)))
+		}
 	} else {
 		store.Set([]byte(key), buf)
+		if record.Id > 0 {
+			store.Set([]byte(k.getProviderIdKey(ctx, record.Id)), []byte(key))
+		}
 	}
 }
 
+// getProviderIdKey index the provider by its numeric id, the index holds the
+// key the provider is saved under
+func (k KVStore) getProviderIdKey(ctx cosmos.Context, id uint64) string {
+	return k.GetKey(ctx, prefixProviderId, strconv.FormatUint(id, 10))
+}
+
 func (k KVStore) getProvider(ctx cosmos.Context, key string, record *types.Provider) (bool, error) {
 	store := ctx.KVStore(k.storeKey)
 	if !store.Has([]byte(key)) {
@@ -44,15 +59,71 @@ func (k KVStore) GetProvider(ctx cosmos.Context, pubkey common.PubKey, service c
 	return record, err
 }
 
-// SetProvider save the entire Provider metadata struct to key value store
+// GetProviderById get the provider with the given numeric id, the provider is
+// empty when no provider has the id
+func (k KVStore) GetProviderById(ctx cosmos.Context, id uint64) (types.Provider, error) {
+	var record types.Provider
+	store := ctx.KVStore(k.storeKey)
+	key := store.Get([]byte(k.getProviderIdKey(ctx, id)))
+	if key == nil {
+		return record, nil
+	}
+	_, err := k.getProvider(ctx, string(key), &record)
+	return record, err
+}
+
+// SetProvider save the entire Provider metadata struct to key value store. A
+// bonded provider is assigned the next numeric id the first time it is saved,
+// and keeps it until its bond is gone.
 func (k KVStore) SetProvider(ctx cosmos.Context, provider types.Provider) error {
 	if provider.PubKey.IsEmpty() || provider.Service.IsEmpty() {
 		return errors.New("cannot save a provider with an empty pubkey or service")
 	}
-	k.setProvider(ctx, k.GetKey(ctx, prefixProvider, provider.Key()), provider)
+	key := k.GetKey(ctx, prefixProvider, provider.Key())
+	if provider.Id == 0 {
+		// keep the id of the saved provider, so a provider built from scratch
+		// is not given a second one
+		var saved types.Provider
+		if _, err := k.getProvider(ctx, key, &saved); err != nil {
+			return err
+		}
+		provider.Id = saved.Id
+	}
+	if provider.Id == 0 && !provider.Bond.IsZero() {
+		provider.Id = k.GetAndIncrementNextProviderId(ctx)
+	}
+	k.setProvider(ctx, key, provider)
 	return nil
 }
 
+func (k KVStore) GetAndIncrementNextProviderId(ctx cosmos.Context) uint64 {
+	providerId := k.GetNextProviderId(ctx)
+	k.SetNextProviderId(ctx, providerId+1)
+	return providerId
+}
+
+// GetNextProviderId returns the id the next new provider is assigned, ids
+// start at one so zero marks a provider without an id
+func (k KVStore) GetNextProviderId(ctx cosmos.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get([]byte(prefixProviderNextId))
+	if bz == nil {
+		return 1
+	}
+	val := gogotypes.UInt64Value{}
+	k.cdc.MustUnmarshal(bz, &val)
+	if val.GetValue() == 0 {
+		return 1
+	}
+	return val.GetValue()
+}
+
+func (k KVStore) SetNextProviderId(ctx cosmos.Context, providerId uint64) {
+	bz := k.cdc.MustMarshal(&gogotypes.UInt64Value{Value: providerId})
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(prefixProviderNextId), bz)
+}
+
 // ProviderExists check whether the given provider exist in the data store
 func (k KVStore) ProviderExists(ctx cosmos.Context, pubkey common.PubKey, service common.Service) bool {
 	record := types.NewProvider(pubkey, service)
@@ -61,5 +132,190 @@ func (k KVStore) ProviderExists(ctx cosmos.Context, pubkey common.PubKey, servic
 
 func (k KVStore) RemoveProvider(ctx cosmos.Context, pubkey common.PubKey, service common.Service) {
 	record := types.NewProvider(pubkey, service)
-	k.del(ctx, k.GetKey(ctx, prefixProvider, record.Key()))
+	key := k.GetKey(ctx, prefixProvider, record.Key())
+	if _, err := k.getProvider(ctx, key, &record); err == nil && record.Id > 0 {
+		k.del(ctx, k.getProviderIdKey(ctx, record.Id))
+	}
+	k.del(ctx, key)
+}
+
+// GetAllowedProviderIterator iterate the providers on the allow list
+func (k KVStore) GetAllowedProviderIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixAllowedProvider)
+}
+
+// IsAllowedProvider check whether the given provider is on the allow list
+// used in permissioned mode
+func (k KVStore) IsAllowedProvider(ctx cosmos.Context, pubkey common.PubKey) bool {
+	return k.has(ctx, k.GetKey(ctx, prefixAllowedProvider, pubkey.String()))
+}
+
+// AddAllowedProvider add the given provider to the allow list
+func (k KVStore) AddAllowedProvider(ctx cosmos.Context, pubkey common.PubKey) error {
+	if pubkey.IsEmpty() {
+		return errors.New("cannot allow a provider with an empty pubkey")
+	}
+	store := ctx.KVStore(k.storeKey)
+	record := types.AllowedProvider{PubKey: pubkey}
+	store.Set([]byte(k.GetKey(ctx, prefixAllowedProvider, pubkey.String())), k.cdc.MustMarshal(&record))
+	return nil
+}
+
+// RemoveAllowedProvider remove the given provider from the allow list
+func (k KVStore) RemoveAllowedProvider(ctx cosmos.Context, pubkey common.PubKey) {
+	k.del(ctx, k.GetKey(ctx, prefixAllowedProvider, pubkey.String()))
+}
+
+// GetProviderStatsIterator iterate provider stats
+func (k KVStore) GetProviderStatsIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixProviderStats)
+}
+
+// GetProviderStats get the settlement history of the given provider
+func (k KVStore) GetProviderStats(ctx cosmos.Context, pubkey common.PubKey, service common.Service) (types.ProviderStats, error) {
+	record := types.NewProviderStats(pubkey, service)
+	key := k.GetKey(ctx, prefixProviderStats, record.Key())
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has([]byte(key)) {
+		return record, nil
+	}
+	err := k.cdc.Unmarshal(store.Get([]byte(key)), &record)
+	return record, err
+}
+
+// SetProviderStats save the settlement history of a provider
+func (k KVStore) SetProviderStats(ctx cosmos.Context, stats types.ProviderStats) error {
+	if stats.PubKey.IsEmpty() || stats.Service.IsEmpty() {
+		return errors.New("cannot save provider stats with an empty pubkey or service")
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(k.GetKey(ctx, prefixProviderStats, stats.Key())), k.cdc.MustMarshal(&stats))
+	return nil
+}
+
+// GetProviderUptimeIterator iterate provider uptimes
+func (k KVStore) GetProviderUptimeIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixProviderUptime)
+}
+
+// GetProviderUptime get the validator observed uptime of the given provider
+func (k KVStore) GetProviderUptime(ctx cosmos.Context, pubkey common.PubKey, service common.Service) (types.ProviderUptime, error) {
+	record := types.NewProviderUptime(pubkey, service)
+	key := k.GetKey(ctx, prefixProviderUptime, record.Key())
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has([]byte(key)) {
+		return record, nil
+	}
+	err := k.cdc.Unmarshal(store.Get([]byte(key)), &record)
+	return record, err
+}
+
+// SetProviderUptime save the validator observed uptime of a provider
+func (k KVStore) SetProviderUptime(ctx cosmos.Context, uptime types.ProviderUptime) error {
+	if uptime.PubKey.IsEmpty() || uptime.Service.IsEmpty() {
+		return errors.New("cannot save provider uptime with an empty pubkey or service")
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(k.GetKey(ctx, prefixProviderUptime, uptime.Key())), k.cdc.MustMarshal(&uptime))
+	return nil
+}
+
+// GetProviderEarningIterator iterate the earnings of every provider
+func (k KVStore) GetProviderEarningIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixProviderEarning)
+}
+
+// GetProviderEarningPrefix returns the prefix of the earnings of the given
+// provider. Heights and ids are zero padded, so earnings are ordered by height
+func (k KVStore) GetProviderEarningPrefix(ctx cosmos.Context, pubkey common.PubKey) string {
+	return k.GetKey(ctx, prefixProviderEarning, pubkey.String()) + "/"
+}
+
+func (k KVStore) getProviderEarningKey(ctx cosmos.Context, pubkey common.PubKey, height int64, contractId uint64) string {
+	return fmt.Sprintf("%s%020d/%020d", k.GetProviderEarningPrefix(ctx, pubkey), height, contractId)
+}
+
+// GetProviderEarning get what a provider was paid for a contract at the given
+// height, and whether it was found
+func (k KVStore) GetProviderEarning(ctx cosmos.Context, pubkey common.PubKey, height int64, contractId uint64) (types.ProviderEarning, bool, error) {
+	var record types.ProviderEarning
+	store := ctx.KVStore(k.storeKey)
+	buf := store.Get([]byte(k.getProviderEarningKey(ctx, pubkey, height, contractId)))
+	if buf == nil {
+		return record, false, nil
+	}
+	err := k.cdc.Unmarshal(buf, &record)
+	return record, true, err
+}
+
+// SetProviderEarning save a provider earning to the data store
+func (k KVStore) SetProviderEarning(ctx cosmos.Context, record types.ProviderEarning) error {
+	if record.PubKey.IsEmpty() || record.Height <= 0 {
+		return errors.New("cannot save provider earning with an empty pubkey or invalid height")
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(k.getProviderEarningKey(ctx, record.PubKey, record.Height, record.ContractId)), k.cdc.MustMarshal(&record))
+	return nil
+}
+
+// PruneProviderEarnings remove the earnings of the given provider from before
+// the given height
+func (k KVStore) PruneProviderEarnings(ctx cosmos.Context, pubkey common.PubKey, before int64) {
+	if before <= 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	start := []byte(k.GetProviderEarningPrefix(ctx, pubkey))
+	end := []byte(fmt.Sprintf("%s%020d", k.GetProviderEarningPrefix(ctx, pubkey), before))
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// GetProviderSlashIterator iterate the slashes of every provider
+func (k KVStore) GetProviderSlashIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixProviderSlash)
+}
+
+// GetProviderSlashPrefix returns the prefix of the slashes of the given
+// provider. Heights and sequences are zero padded, so slashes are ordered by
+// height
+func (k KVStore) GetProviderSlashPrefix(ctx cosmos.Context, pubkey common.PubKey) string {
+	return k.GetKey(ctx, prefixProviderSlash, pubkey.String()) + "/"
+}
+
+func (k KVStore) getProviderSlashHeightPrefix(ctx cosmos.Context, pubkey common.PubKey, height int64) string {
+	return fmt.Sprintf("%s%020d/", k.GetProviderSlashPrefix(ctx, pubkey), height)
+}
+
+// NextProviderSlashSequence returns the sequence of the next slash of the
+// given provider at the given height
+func (k KVStore) NextProviderSlashSequence(ctx cosmos.Context, pubkey common.PubKey, height int64) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	iter := cosmos.KVStoreReversePrefixIterator(store, []byte(k.getProviderSlashHeightPrefix(ctx, pubkey, height)))
+	defer iter.Close()
+	if !iter.Valid() {
+		return 0
+	}
+	var record types.ProviderSlash
+	k.cdc.MustUnmarshal(iter.Value(), &record)
+	return record.Sequence + 1
+}
+
+// SetProviderSlash save a provider slash to the data store
+func (k KVStore) SetProviderSlash(ctx cosmos.Context, record types.ProviderSlash) error {
+	if record.PubKey.IsEmpty() || record.Height <= 0 {
+		return errors.New("cannot save provider slash with an empty pubkey or invalid height")
+	}
+	store := ctx.KVStore(k.storeKey)
+	key := fmt.Sprintf("%s%020d", k.getProviderSlashHeightPrefix(ctx, record.PubKey, record.Height), record.Sequence)
+	store.Set([]byte(key), k.cdc.MustMarshal(&record))
+	return nil
 }
@@ -25,6 +25,54 @@ func TestProvider(t *testing.T) {
 	require.True(t, k.ProviderExists(ctx, provider.PubKey, provider.Service))
 	require.False(t, k.ProviderExists(ctx, provider.PubKey, common.ETHService))
 
+	// bonded providers are assigned the next id, once
+	require.Equal(t, uint64(1), provider.Id)
+	rebuilt := types.NewProvider(provider.PubKey, provider.Service)
+	rebuilt.Bond = cosmos.NewInt(200)
+	require.NoError(t, k.SetProvider(ctx, rebuilt))
+	byId, err := k.GetProviderById(ctx, 1)
+	require.NoError(t, err)
+	require.True(t, byId.PubKey.Equals(provider.PubKey))
+	require.Equal(t, int64(200), byId.Bond.Int64())
+	require.Equal(t, uint64(2), k.GetNextProviderId(ctx))
+
+	// unbonded providers are not assigned one
+	unbonded := types.NewProvider(types.GetRandomPubKey(), common.BTCService)
+	require.NoError(t, k.SetProvider(ctx, unbonded))
+	require.Equal(t, uint64(2), k.GetNextProviderId(ctx))
+
 	k.RemoveProvider(ctx, provider.PubKey, provider.Service)
 	require.False(t, k.ProviderExists(ctx, provider.PubKey, provider.Service))
+	byId, err = k.GetProviderById(ctx, 1)
+	require.NoError(t, err)
+	require.True(t, byId.PubKey.IsEmpty())
+}
+
+func TestProviderStats(t *testing.T) {
+	ctx, k := SetupKeeper(t)
+
+	require.Error(t, k.SetProviderStats(ctx, types.ProviderStats{}))
+
+	pubkey := types.GetRandomPubKey()
+	stats, err := k.GetProviderStats(ctx, pubkey, common.BTCService)
+	require.NoError(t, err)
+	require.True(t, stats.SettledAmount.IsZero())
+	require.True(t, stats.Score().IsZero())
+
+	stats.ContractsServed = 3
+	stats.Breaches = 1
+	stats.SettledAmount = cosmos.NewInt(500)
+	require.NoError(t, k.SetProviderStats(ctx, stats))
+
+	stats, err = k.GetProviderStats(ctx, pubkey, common.BTCService)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, stats.ContractsServed)
+	require.EqualValues(t, 1, stats.Breaches)
+	require.Equal(t, int64(500), stats.SettledAmount.Int64())
+	require.Equal(t, cosmos.NewDecWithPrec(75, 2), stats.Score())
+
+	// stats are kept per service
+	stats, err = k.GetProviderStats(ctx, pubkey, common.ETHService)
+	require.NoError(t, err)
+	require.Zero(t, stats.ContractsServed)
 }
@@ -0,0 +1,34 @@
+package keeper
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// GetReserveSnapshotIterator iterate reserve snapshots, heights are zero
+// padded so the history is ordered oldest first
+func (k KVStore) GetReserveSnapshotIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixReserveSnapshot)
+}
+
+func (k KVStore) getReserveSnapshotKey(ctx cosmos.Context, height int64) string {
+	return k.GetKey(ctx, prefixReserveSnapshot, fmt.Sprintf("%020d", height))
+}
+
+// SetReserveSnapshot save a reserve snapshot, keyed by its height
+func (k KVStore) SetReserveSnapshot(ctx cosmos.Context, snapshot types.ReserveSnapshot) error {
+	if snapshot.Height <= 0 {
+		return errors.New("cannot save a reserve snapshot with an invalid height (less than or equal to zero)")
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(k.getReserveSnapshotKey(ctx, snapshot.Height)), k.cdc.MustMarshal(&snapshot))
+	return nil
+}
+
+// RemoveReserveSnapshot remove the reserve snapshot taken at the given height
+func (k KVStore) RemoveReserveSnapshot(ctx cosmos.Context, height int64) {
+	k.del(ctx, k.getReserveSnapshotKey(ctx, height))
+}
@@ -0,0 +1,38 @@
+package keeper
+
+import (
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// ReserveFeesEndBlock moves the FeeReserveShare of the tx fees collected in
+// the block from the fee collector into the reserve, before the distribution
+// module allocates them at the start of the next block. This keeps the
+// validator rewards emitted out of the reserve going as it depletes.
+func (mgr Manager) ReserveFeesEndBlock(ctx cosmos.Context) error {
+	share := mgr.FetchConfig(ctx, configs.FeeReserveShare)
+	if share <= 0 {
+		return nil
+	}
+
+	fees := mgr.keeper.GetBalance(ctx, mgr.keeper.GetModuleAccAddress(authtypes.FeeCollectorName))
+	toReserve := cosmos.NewCoins()
+	for _, fee := range fees {
+		amt := common.GetSafeShare(cosmos.NewInt(share), cosmos.NewInt(configs.MaxBasisPoints), fee.Amount)
+		if amt.IsPositive() {
+			toReserve = toReserve.Add(cosmos.NewCoin(fee.Denom, amt))
+		}
+	}
+	if toReserve.IsZero() {
+		return nil
+	}
+
+	if err := mgr.keeper.SendFromModuleToModule(ctx, authtypes.FeeCollectorName, types.ReserveName, toReserve); err != nil {
+		return err
+	}
+	return mgr.EmitReserveFeesEvent(ctx, toReserve)
+}
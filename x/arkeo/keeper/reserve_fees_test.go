@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReserveFeesEndBlock(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	mgr := NewManager(k, sk)
+
+	fees := cosmos.NewCoins(getCoin(1000), cosmos.NewInt64Coin("uatom", 3))
+	for _, fee := range fees {
+		require.NoError(t, k.MintToModule(ctx, types.ModuleName, fee))
+	}
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, authtypes.FeeCollectorName, fees))
+	feeCollector := k.GetModuleAccAddress(authtypes.FeeCollectorName)
+
+	// the fees are left to the validators by default
+	require.NoError(t, mgr.ReserveFeesEndBlock(ctx))
+	require.Equal(t, fees, k.GetBalance(ctx, feeCollector))
+	require.True(t, k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).IsZero())
+
+	// a quarter goes to the reserve, too little of the uatom to move any
+	k.SetConfigOverride(ctx, configs.FeeReserveShare, 2500)
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	require.NoError(t, mgr.ReserveFeesEndBlock(ctx))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeReserveFees))
+	require.Equal(t, int64(250), k.GetBalanceOfModule(ctx, types.ReserveName, configs.Denom).Int64())
+	require.True(t, k.GetBalanceOfModule(ctx, types.ReserveName, "uatom").IsZero())
+	require.Equal(t, cosmos.NewCoins(getCoin(750), cosmos.NewInt64Coin("uatom", 3)), k.GetBalance(ctx, feeCollector))
+
+	require.ErrorIs(t, types.ValidateConfigOverride(configs.FeeReserveShare, configs.MaxBasisPoints+1), types.ErrInvalidConfig)
+}
@@ -0,0 +1,126 @@
+package keeper
+
+import (
+	"errors"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// GetRewardVestingIterator iterate the accounts with rewards still vesting
+func (k KVStore) GetRewardVestingIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixRewardVesting)
+}
+
+// GetRewardVesting get the rewards of the given account still vesting, there
+// are none when the record is empty
+func (k KVStore) GetRewardVesting(ctx cosmos.Context, addr cosmos.AccAddress) (types.RewardVesting, error) {
+	record := types.NewRewardVesting(addr)
+	store := ctx.KVStore(k.storeKey)
+	key := k.GetKey(ctx, prefixRewardVesting, addr.String())
+	if !store.Has([]byte(key)) {
+		return record, nil
+	}
+	err := k.cdc.Unmarshal(store.Get([]byte(key)), &record)
+	return record, err
+}
+
+// SetRewardVesting save the rewards of an account still vesting, accounts
+// without unvested rewards are removed
+func (k KVStore) SetRewardVesting(ctx cosmos.Context, record types.RewardVesting) error {
+	if record.Address.Empty() {
+		return errors.New("cannot save reward vesting with an empty address")
+	}
+	key := k.GetKey(ctx, prefixRewardVesting, record.Address.String())
+	if record.Unvested.IsZero() {
+		k.del(ctx, key)
+		return nil
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(key), k.cdc.MustMarshal(&record))
+	return nil
+}
+
+// payReward pays a validator or delegator reward out of the reserve. With a
+// RewardVestingPeriod the reward is moved to the vesting module instead, and
+// vests continuously over the period. The rewards already vesting are
+// released up to now, and what is left of them vests over the new period
+// along with the new reward.
+func (mgr Manager) payReward(ctx cosmos.Context, addr cosmos.AccAddress, coins cosmos.Coins) error {
+	period := mgr.FetchConfig(ctx, configs.RewardVestingPeriod)
+	if period <= 0 {
+		return mgr.keeper.SendFromModuleToAccount(ctx, types.ReserveName, addr, coins)
+	}
+
+	// the release and the new reward are all or nothing
+	cacheCtx, commit := ctx.CacheContext()
+	if err := mgr.vestReward(cacheCtx, addr, coins, period); err != nil {
+		return err
+	}
+	commit()
+	return nil
+}
+
+func (mgr Manager) vestReward(ctx cosmos.Context, addr cosmos.AccAddress, coins cosmos.Coins, period int64) error {
+	vesting, err := mgr.keeper.GetRewardVesting(ctx, addr)
+	if err != nil {
+		return err
+	}
+	vesting, err = mgr.releaseVestedRewards(ctx, vesting)
+	if err != nil {
+		return err
+	}
+	if err := mgr.keeper.SendFromModuleToModule(ctx, types.ReserveName, types.VestingName, coins); err != nil {
+		return err
+	}
+	vesting.Unvested = vesting.Unvested.Add(coins...)
+	vesting.LastHeight = ctx.BlockHeight()
+	vesting.EndHeight = ctx.BlockHeight() + period
+	return mgr.keeper.SetRewardVesting(ctx, vesting)
+}
+
+// releaseVestedRewards pays out the rewards that vested since they were last
+// released, the returned record is not saved
+func (mgr Manager) releaseVestedRewards(ctx cosmos.Context, vesting types.RewardVesting) (types.RewardVesting, error) {
+	vested := vesting.Vested(ctx.BlockHeight())
+	if vested.IsZero() {
+		return vesting, nil
+	}
+	if err := mgr.keeper.SendFromModuleToAccount(ctx, types.VestingName, vesting.Address, vested); err != nil {
+		return vesting, err
+	}
+	vesting.Unvested = vesting.Unvested.Sub(vested...)
+	vesting.LastHeight = ctx.BlockHeight()
+	return vesting, mgr.EmitReleaseVestedRewardsEvent(ctx, vesting.Address, vested)
+}
+
+// releaseAllVestedRewards pays out the vested rewards of every account, so
+// rewards keep vesting for accounts that are no longer rewarded
+func (mgr Manager) releaseAllVestedRewards(ctx cosmos.Context) {
+	var vestings []types.RewardVesting
+	iter := mgr.keeper.GetRewardVestingIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var vesting types.RewardVesting
+		if err := mgr.keeper.Cdc().Unmarshal(iter.Value(), &vesting); err != nil {
+			ctx.Logger().Error("fail to unmarshal reward vesting", "error", err)
+			continue
+		}
+		vestings = append(vestings, vesting)
+	}
+	iter.Close()
+
+	for _, vesting := range vestings {
+		// a failed release must not leave the rewards half paid out
+		cacheCtx, commit := ctx.CacheContext()
+		released, err := mgr.releaseVestedRewards(cacheCtx, vesting)
+		if err == nil {
+			err = mgr.keeper.SetRewardVesting(cacheCtx, released)
+		}
+		if err != nil {
+			ctx.Logger().Error("unable to release vested rewards", "address", vesting.Address.String(), "error", err)
+			continue
+		}
+		commit()
+	}
+}
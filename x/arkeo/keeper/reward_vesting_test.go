@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewardVesting(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(100)
+	mgr := NewManager(k, sk)
+
+	require.NoError(t, k.MintToModule(ctx, types.ModuleName, getCoin(1000)))
+	require.NoError(t, k.SendFromModuleToModule(ctx, types.ModuleName, types.ReserveName, getCoins(cosmos.NewInt(1000))))
+	addr := types.GetRandomBech32Addr()
+	reward := getCoins(cosmos.NewInt(100))
+
+	// without a vesting period rewards are liquid
+	require.NoError(t, mgr.payReward(ctx, addr, reward))
+	require.Equal(t, int64(100), k.GetBalance(ctx, addr).AmountOf(configs.Denom).Int64())
+
+	k.SetConfigOverride(ctx, configs.RewardVestingPeriod, 100)
+	require.NoError(t, mgr.payReward(ctx, addr, reward))
+	require.Equal(t, int64(100), k.GetBalance(ctx, addr).AmountOf(configs.Denom).Int64())
+	require.Equal(t, int64(100), k.GetBalanceOfModule(ctx, types.VestingName, configs.Denom).Int64())
+	require.NoError(t, mgr.invariantVestingModule(ctx))
+
+	// half way through the period, half has vested
+	ctx = ctx.WithBlockHeight(150)
+	res, err := k.RewardVesting(ctx, &types.QueryRewardVestingRequest{Address: addr.String()})
+	require.NoError(t, err)
+	require.Equal(t, int64(50), res.Vested.AmountOf(configs.Denom).Int64())
+	require.Equal(t, int64(50), res.Pending.AmountOf(configs.Denom).Int64())
+	require.Equal(t, int64(200), res.Vesting.EndHeight)
+
+	ctx = ctx.WithEventManager(cosmos.NewEventManager())
+	mgr.releaseAllVestedRewards(ctx)
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeReleaseVestedRewards))
+	require.Equal(t, int64(150), k.GetBalance(ctx, addr).AmountOf(configs.Denom).Int64())
+
+	// a new reward vests along with what is left over a new period
+	require.NoError(t, mgr.payReward(ctx, addr, reward))
+	vesting, err := k.GetRewardVesting(ctx, addr)
+	require.NoError(t, err)
+	require.Equal(t, int64(150), vesting.Unvested.AmountOf(configs.Denom).Int64())
+	require.Equal(t, int64(150), vesting.LastHeight)
+	require.Equal(t, int64(250), vesting.EndHeight)
+	require.NoError(t, mgr.invariantVestingModule(ctx))
+
+	// everything is released at the end of the period
+	ctx = ctx.WithBlockHeight(300)
+	mgr.releaseAllVestedRewards(ctx)
+	require.Equal(t, int64(300), k.GetBalance(ctx, addr).AmountOf(configs.Denom).Int64())
+	require.True(t, k.GetBalanceOfModule(ctx, types.VestingName, configs.Denom).IsZero())
+	iter := k.GetRewardVestingIterator(ctx)
+	defer iter.Close()
+	require.False(t, iter.Valid())
+}
@@ -0,0 +1,46 @@
+package keeper
+
+import (
+	"errors"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// GetServiceInfoIterator iterate the services in the registry
+func (k KVStore) GetServiceInfoIterator(ctx cosmos.Context) cosmos.Iterator {
+	return k.getIterator(ctx, prefixServiceInfo)
+}
+
+// GetServiceInfo get the registry entry of the given service
+func (k KVStore) GetServiceInfo(ctx cosmos.Context, service common.Service) (types.ServiceInfo, error) {
+	record := types.ServiceInfo{Service: service}
+	store := ctx.KVStore(k.storeKey)
+	key := k.GetKey(ctx, prefixServiceInfo, service.String())
+	if !store.Has([]byte(key)) {
+		return record, nil
+	}
+	err := k.cdc.Unmarshal(store.Get([]byte(key)), &record)
+	return record, err
+}
+
+// SetServiceInfo add or update the given service in the registry
+func (k KVStore) SetServiceInfo(ctx cosmos.Context, info types.ServiceInfo) error {
+	if info.Service.IsEmpty() {
+		return errors.New("cannot save a service with an empty service")
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(k.GetKey(ctx, prefixServiceInfo, info.Service.String())), k.cdc.MustMarshal(&info))
+	return nil
+}
+
+// ServiceInfoExists check whether the given service is in the registry
+func (k KVStore) ServiceInfoExists(ctx cosmos.Context, service common.Service) bool {
+	return k.has(ctx, k.GetKey(ctx, prefixServiceInfo, service.String()))
+}
+
+// RemoveServiceInfo remove the given service from the registry
+func (k KVStore) RemoveServiceInfo(ctx cosmos.Context, service common.Service) {
+	k.del(ctx, k.GetKey(ctx, prefixServiceInfo, service.String()))
+}
@@ -0,0 +1,80 @@
+package keeper
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// SlashProvider takes the given share of a provider's bond for a service
+// fault. The slashed bond is burned when burn is set, otherwise it is sent to
+// the recipient, or to the reserve when there is none. A provider left without
+// a bond is removed. Every slash is kept in the provider's slash history.
+//
+// This is the entry point for anything that penalises a provider, gov
+// through MsgSlashProvider as well as fault proofs, which should call it
+// rather than move the bond themselves.
+func (mgr Manager) SlashProvider(ctx cosmos.Context, pubkey common.PubKey, service common.Service, basisPoints int64, burn bool, recipient cosmos.AccAddress, reason string) (cosmos.Int, error) {
+	if basisPoints <= 0 || basisPoints > configs.MaxBasisPoints {
+		return cosmos.ZeroInt(), errors.Wrapf(types.ErrInvalidSlash, "basis points must be between 1 and %d, got %d", configs.MaxBasisPoints, basisPoints)
+	}
+	if burn && !recipient.Empty() {
+		return cosmos.ZeroInt(), errors.Wrapf(types.ErrInvalidSlash, "a burned slash cannot have a recipient")
+	}
+
+	provider, err := mgr.keeper.GetProvider(ctx, pubkey, service)
+	if err != nil {
+		return cosmos.ZeroInt(), err
+	}
+
+	amount := common.GetSafeShare(cosmos.NewInt(basisPoints), cosmos.NewInt(configs.MaxBasisPoints), provider.Bond)
+	if !amount.IsPositive() {
+		return cosmos.ZeroInt(), nil
+	}
+
+	switch {
+	case burn:
+		// the bond module account cannot burn, so the slash goes through
+		// the arkeo module account
+		if err := mgr.keeper.SendFromModuleToModule(ctx, types.ProviderName, types.ModuleName, getCoins(amount)); err != nil {
+			return cosmos.ZeroInt(), err
+		}
+		if err := mgr.keeper.BurnFromModule(ctx, types.ModuleName, cosmos.NewCoin(configs.Denom, amount)); err != nil {
+			return cosmos.ZeroInt(), err
+		}
+	case recipient.Empty():
+		if err := mgr.keeper.SendFromModuleToModule(ctx, types.ProviderName, types.ReserveName, getCoins(amount)); err != nil {
+			return cosmos.ZeroInt(), err
+		}
+	default:
+		if err := mgr.keeper.SendFromModuleToAccount(ctx, types.ProviderName, recipient, getCoins(amount)); err != nil {
+			return cosmos.ZeroInt(), err
+		}
+	}
+
+	provider.Bond = provider.Bond.Sub(amount)
+	if provider.Bond.IsZero() {
+		mgr.keeper.RemoveProvider(ctx, provider.PubKey, provider.Service)
+	} else if err := mgr.keeper.SetProvider(ctx, provider); err != nil {
+		return cosmos.ZeroInt(), err
+	}
+
+	slash := types.NewProviderSlash(pubkey, service, ctx.BlockHeight(), reason)
+	slash.Sequence = mgr.keeper.NextProviderSlashSequence(ctx, pubkey, ctx.BlockHeight())
+	slash.Amount = amount
+	slash.Burned = burn
+	if !burn {
+		slash.Recipient = recipient
+		if recipient.Empty() {
+			slash.Recipient = mgr.keeper.GetModuleAccAddress(types.ReserveName)
+		}
+	}
+	if err := mgr.keeper.SetProviderSlash(ctx, slash); err != nil {
+		return cosmos.ZeroInt(), err
+	}
+
+	return amount, mgr.EmitSlashProviderEvent(ctx, slash)
+}
@@ -0,0 +1,56 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// metrics are exposed through the node's prometheus endpoint, prefixed with
+// the module name
+
+// toFloat32 convert an amount to the float telemetry reports, which loses
+// precision on large amounts
+func toFloat32(amount cosmos.Int) float32 {
+	f, _ := new(big.Float).SetInt(amount.BigInt()).Float32()
+	return f
+}
+
+// recordSettlementMetrics counts a settlement along with the debt it paid out
+func recordSettlementMetrics(contract types.Contract, debt, tax cosmos.Int, final bool) {
+	labels := []telemetry.Label{
+		telemetry.NewLabel("service", contract.Service.String()),
+		telemetry.NewLabel("type", contract.Type.String()),
+	}
+	telemetry.IncrCounterWithLabels([]string{types.ModuleName, "contract", "settlements"}, 1, labels)
+	if final {
+		telemetry.IncrCounterWithLabels([]string{types.ModuleName, "contract", "closed"}, 1, labels)
+	}
+	if debt.IsPositive() {
+		labels = append(labels, telemetry.NewLabel("denom", contract.Rate.Denom))
+		telemetry.IncrCounterWithLabels([]string{types.ModuleName, "contract", "debt", "paid"}, toFloat32(debt), labels)
+		telemetry.IncrCounterWithLabels([]string{types.ModuleName, "contract", "reserve", "tax"}, toFloat32(tax), labels)
+	}
+}
+
+// recordContractEndBlockMetrics reports the contracts finally settled in the
+// block
+func recordContractEndBlockMetrics(settled int) {
+	telemetry.SetGauge(float32(settled), types.ModuleName, "endblock", "contracts", "settled")
+}
+
+// recordValidatorPayoutMetrics reports the reserve balance ahead of a payout
+// and the rewards emitted out of it
+func recordValidatorPayoutMetrics(reserve, emitted cosmos.Coins) {
+	for _, coin := range reserve {
+		labels := []telemetry.Label{telemetry.NewLabel("denom", coin.Denom)}
+		telemetry.SetGaugeWithLabels([]string{types.ModuleName, "reserve", "balance"}, toFloat32(coin.Amount), labels)
+	}
+	for _, coin := range emitted {
+		labels := []telemetry.Label{telemetry.NewLabel("denom", coin.Denom)}
+		telemetry.IncrCounterWithLabels([]string{types.ModuleName, "validator", "rewards", "emitted"}, toFloat32(coin.Amount), labels)
+	}
+}
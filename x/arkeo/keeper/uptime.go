@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+)
+
+// UptimeEndBlock folds the liveness votes validators reported over the
+// UptimeCycle into each provider's uptime, counting the provider online for
+// the cycle when most of the validators that reported on it found it online.
+// A provider found offline while its uptime score is below the
+// MinProviderUptime has UptimeSlash basis points of its bond slashed to the
+// reserve.
+func (mgr Manager) UptimeEndBlock(ctx cosmos.Context) error {
+	cycle := mgr.FetchConfig(ctx, configs.UptimeCycle)
+	if cycle <= 0 || ctx.BlockHeight()%cycle != 0 {
+		return nil
+	}
+
+	var uptimes []types.ProviderUptime
+	iter := mgr.keeper.GetProviderUptimeIterator(ctx)
+	for ; iter.Valid(); iter.Next() {
+		var uptime types.ProviderUptime
+		if err := mgr.keeper.Cdc().Unmarshal(iter.Value(), &uptime); err != nil {
+			ctx.Logger().Error("fail to unmarshal provider uptime", "error", err)
+			continue
+		}
+		if len(uptime.OnlineVotes)+len(uptime.OfflineVotes) == 0 {
+			continue
+		}
+		uptimes = append(uptimes, uptime)
+	}
+	iter.Close()
+
+	minObservations := mgr.FetchConfig(ctx, configs.MinUptimeObservations)
+	minUptime := cosmos.NewDec(mgr.FetchConfig(ctx, configs.MinProviderUptime)).QuoInt64(configs.MaxBasisPoints)
+	slash := mgr.FetchConfig(ctx, configs.UptimeSlash)
+	for i := range uptimes {
+		uptime := uptimes[i]
+		online := len(uptime.OnlineVotes) > len(uptime.OfflineVotes)
+		uptime.CyclesObserved++
+		if online {
+			uptime.CyclesOnline++
+		}
+		uptime.OnlineVotes = nil
+		uptime.OfflineVotes = nil
+		if err := mgr.keeper.SetProviderUptime(ctx, uptime); err != nil {
+			return err
+		}
+		if err := mgr.EmitProviderUptimeEvent(ctx, online, &uptime); err != nil {
+			return err
+		}
+
+		if online || slash <= 0 || !uptime.Score(minObservations).LT(minUptime) {
+			continue
+		}
+		if !mgr.keeper.ProviderExists(ctx, uptime.PubKey, uptime.Service) {
+			continue
+		}
+		if _, err := mgr.SlashProvider(ctx, uptime.PubKey, uptime.Service, slash, false, nil, "uptime"); err != nil {
+			ctx.Logger().Error("unable to slash provider for uptime", "provider", uptime.PubKey, "service", uptime.Service, "error", err)
+		}
+	}
+	return nil
+}
+
+// uptimeScore returns the uptime score of a provider, and whether it meets
+// the MinProviderUptime
+func (mgr Manager) uptimeScore(ctx cosmos.Context, pubkey common.PubKey, service common.Service) (cosmos.Dec, bool, error) {
+	uptime, err := mgr.keeper.GetProviderUptime(ctx, pubkey, service)
+	if err != nil {
+		return cosmos.ZeroDec(), false, err
+	}
+	score := uptime.Score(mgr.FetchConfig(ctx, configs.MinUptimeObservations))
+	minUptime := cosmos.NewDec(mgr.FetchConfig(ctx, configs.MinProviderUptime)).QuoInt64(configs.MaxBasisPoints)
+	return score, !score.LT(minUptime), nil
+}
@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/stretchr/testify/require"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+func TestReportUptime(t *testing.T) {
+	ctx, k, sk := SetupKeeperWithStaking(t)
+	ctx = ctx.WithBlockHeight(10)
+	s := newMsgServer(k, sk)
+	mgr := NewManager(k, sk)
+
+	// three active validators observe the provider
+	operators, _, _ := setupValidators(t, ctx, sk, []int64{100, 100, 100})
+
+	providerPubKey := types.GetRandomPubKey()
+	providerAcct, err := providerPubKey.GetMyAddress()
+	require.NoError(t, err)
+	service := common.BTCService
+	require.NoError(t, k.MintAndSendToAccount(ctx, providerAcct, getCoin(common.Tokens(10))))
+	require.NoError(t, s.BondProviderHandle(ctx, &types.MsgBondProvider{
+		Creator:  providerAcct,
+		Provider: providerPubKey,
+		Service:  service.String(),
+		Bond:     cosmos.NewInt(common.Tokens(10)),
+	}))
+
+	report := func(operator cosmos.AccAddress, online bool) *types.MsgReportUptime {
+		return types.NewMsgReportUptime(operator, []types.UptimeObservation{
+			{Provider: providerPubKey, Service: service.String(), Online: online},
+		})
+	}
+
+	// only active validators may report
+	_, err = s.ReportUptime(ctx, report(types.GetRandomBech32Addr(), true))
+	require.ErrorIs(t, err, types.ErrInvalidUptimeReport)
+
+	// on bonded providers
+	msg := report(operators[0], true)
+	msg.Observations[0].Service = common.ETHService.String()
+	_, err = s.ReportUptime(ctx, msg)
+	require.ErrorIs(t, err, types.ErrProviderNotFound)
+
+	// and once each cycle
+	_, err = s.ReportUptime(ctx, report(operators[0], true))
+	require.NoError(t, err)
+	_, err = s.ReportUptime(ctx, report(operators[0], false))
+	require.ErrorIs(t, err, types.ErrInvalidUptimeReport)
+	_, err = s.ReportUptime(ctx, report(operators[1], false))
+	require.NoError(t, err)
+	_, err = s.ReportUptime(ctx, report(operators[2], false))
+	require.NoError(t, err)
+
+	// most validators found the provider offline, and with too low a score it
+	// is slashed
+	k.SetConfigOverride(ctx, configs.UptimeCycle, 100)
+	k.SetConfigOverride(ctx, configs.MinUptimeObservations, 1)
+	k.SetConfigOverride(ctx, configs.MinProviderUptime, 5000)
+	k.SetConfigOverride(ctx, configs.UptimeSlash, 100)
+	ctx = ctx.WithBlockHeight(100).WithEventManager(cosmos.NewEventManager())
+	require.NoError(t, mgr.UptimeEndBlock(ctx))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeProviderUptime))
+	require.Equal(t, 1, countEvents(ctx, types.EventTypeSlashProvider))
+
+	res, err := k.ProviderUptime(ctx, &types.QueryProviderUptimeRequest{
+		Pubkey:  providerPubKey.String(),
+		Service: service.String(),
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, res.Uptime.CyclesObserved)
+	require.Zero(t, res.Uptime.CyclesOnline)
+	require.Empty(t, res.Uptime.OnlineVotes)
+	require.Empty(t, res.Uptime.OfflineVotes)
+	require.True(t, res.Score.IsZero())
+
+	provider, err := k.GetProvider(ctx, providerPubKey, service)
+	require.NoError(t, err)
+	require.Equal(t, common.Tokens(10)-common.Tokens(10)/100, provider.Bond.Int64())
+
+	// validators may report again in the next cycle, a provider found online
+	// is not slashed
+	_, err = s.ReportUptime(ctx, report(operators[0], true))
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(200).WithEventManager(cosmos.NewEventManager())
+	require.NoError(t, mgr.UptimeEndBlock(ctx))
+	require.Equal(t, 0, countEvents(ctx, types.EventTypeSlashProvider))
+
+	uptime, err := k.GetProviderUptime(ctx, providerPubKey, service)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, uptime.CyclesObserved)
+	require.EqualValues(t, 1, uptime.CyclesOnline)
+	require.Equal(t, cosmos.NewDecWithPrec(5, 1), uptime.Score(1))
+	// new providers are not scored until observed for long enough
+	require.Equal(t, cosmos.NewDec(1), uptime.Score(3))
+}
@@ -3,6 +3,8 @@ package simulation
 import (
 	"math/rand"
 
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
 	"github.com/arkeonetwork/arkeo/x/arkeo/keeper"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
 
@@ -23,8 +25,47 @@ func SimulateMsgBondProvider(
 			Creator: simAccount.Address,
 		}
 
-		// TODO: Handling the BondProvider simulation
+		if fetchConfig(ctx, k, configs.HandlerBondProvider) > 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "handler disabled"), nil, nil
+		}
+
+		pubkey, err := common.NewPubKeyFromCrypto(simAccount.PubKey)
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "unable to convert pubkey"), nil, err
+		}
+		service := randomService(r)
+		msg.Provider = pubkey
+		msg.Service = service.String()
+
+		provider, err := k.GetProvider(ctx, pubkey, service)
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "unable to get provider"), nil, err
+		}
+
+		// occasionally withdraw some of an existing bond
+		if provider.Bond.IsPositive() && r.Intn(4) == 0 {
+			amt, err := simtypes.RandPositiveInt(r, provider.Bond)
+			if err != nil {
+				return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "unable to generate unbond amount"), nil, nil
+			}
+			msg.Bond = amt.Neg()
+			return deliver(r, app, ctx, ak, bk, simAccount, msg, nil)
+		}
+
+		if fetchConfig(ctx, k, configs.PermissionedProviders) > 0 && !k.IsAllowedProvider(ctx, pubkey) {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "provider not allowed"), nil, nil
+		}
+
+		spendable := bk.SpendableCoins(ctx, simAccount.Address).AmountOf(configs.Denom)
+		if !spendable.IsPositive() {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "insufficient funds"), nil, nil
+		}
+		amt, err := simtypes.RandPositiveInt(r, spendable.QuoRaw(2).AddRaw(1))
+		if err != nil || amt.GT(spendable) {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "unable to generate bond amount"), nil, nil
+		}
+		msg.Bond = amt
 
-		return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "BondProvider simulation not implemented"), nil, nil
+		return deliver(r, app, ctx, ak, bk, simAccount, msg, sdk.NewCoins(sdk.NewCoin(configs.Denom, amt)))
 	}
 }
@@ -3,6 +3,7 @@ package simulation
 import (
 	"math/rand"
 
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
 	"github.com/arkeonetwork/arkeo/x/arkeo/keeper"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
 
@@ -18,13 +19,37 @@ func SimulateMsgClaimContractIncome(
 ) simtypes.Operation {
 	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, serviceID string,
 	) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		// anyone can post a claim, the income is always paid to the provider
 		simAccount, _ := simtypes.RandomAcc(r, accs)
 		msg := &types.MsgClaimContractIncome{
 			Creator: simAccount.Address,
 		}
 
-		// TODO: Handling the ClaimContractIncome simulation
+		if fetchConfig(ctx, k, configs.HandlerClaimContractIncome) > 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "handler disabled"), nil, nil
+		}
+
+		contracts := getOpenContracts(ctx, k, accs)
+		if len(contracts) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "no open contracts"), nil, nil
+		}
+		contract := contracts[r.Intn(len(contracts))]
+
+		msg.ContractId = contract.Id
+		msg.Nonce = contract.Nonce + int64(simtypes.RandIntBetween(r, 1, 100))
+
+		// the spender signs off on the nonce, so the provider cannot claim
+		// more than was actually consumed
+		spender, err := contract.GetSpender().GetMyAddress()
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "invalid spender pubkey"), nil, err
+		}
+		spenderAccount, _ := simtypes.FindAccount(accs, spender)
+		msg.Signature, err = spenderAccount.PrivKey.Sign(msg.GetBytesToSign())
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "unable to sign claim"), nil, err
+		}
 
-		return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "ClaimContractIncome simulation not implemented"), nil, nil
+		return deliver(r, app, ctx, ak, bk, simAccount, msg, nil)
 	}
 }
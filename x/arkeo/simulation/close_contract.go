@@ -3,6 +3,7 @@ package simulation
 import (
 	"math/rand"
 
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
 	"github.com/arkeonetwork/arkeo/x/arkeo/keeper"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
 
@@ -18,13 +19,32 @@ func SimulateMsgCloseContract(
 ) simtypes.Operation {
 	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, serviceID string,
 	) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
-		simAccount, _ := simtypes.RandomAcc(r, accs)
-		msg := &types.MsgCloseContract{
-			Creator: simAccount.Address,
+		msg := &types.MsgCloseContract{}
+
+		if fetchConfig(ctx, k, configs.HandlerCloseContract) > 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "handler disabled"), nil, nil
+		}
+
+		// only the client can close a contract
+		contracts := make([]types.Contract, 0)
+		for _, contract := range getOpenContracts(ctx, k, accs) {
+			addr, err := contract.Client.GetMyAddress()
+			if err != nil {
+				continue
+			}
+			if _, found := simtypes.FindAccount(accs, addr); found {
+				contracts = append(contracts, contract)
+			}
+		}
+		if len(contracts) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "no open contracts"), nil, nil
 		}
+		contract := contracts[r.Intn(len(contracts))]
+		simAccount, _ := simtypes.FindAccount(accs, contract.ClientAddress())
 
-		// TODO: Handling the CloseContract simulation
+		msg.Creator = simAccount.Address
+		msg.ContractId = contract.Id
 
-		return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "CloseContract simulation not implemented"), nil, nil
+		return deliver(r, app, ctx, ak, bk, simAccount, msg, nil)
 	}
 }
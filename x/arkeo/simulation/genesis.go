@@ -0,0 +1,56 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+// genConfigs are the configs randomized in the simulated genesis, each with a
+// generator of its value. The economics and the contract lifecycle are
+// randomized, the handlers are left enabled so every operation is exercised.
+var genConfigs = []struct {
+	name configs.ConfigName
+	gen  func(r *rand.Rand) int64
+}{
+	{configs.OpenContractCost, func(r *rand.Rand) int64 { return simtypes.RandomAmount(r, sdk.NewInt(common.Tokens(1))).Int64() }},
+	{configs.MinProviderBond, func(r *rand.Rand) int64 { return 1 + r.Int63n(common.Tokens(1)) }},
+	{configs.ReserveTax, func(r *rand.Rand) int64 { return int64(r.Intn(2001)) }},
+	{configs.CommunityTaxShare, func(r *rand.Rand) int64 { return r.Int63n(configs.MaxBasisPoints + 1) }},
+	{configs.ProviderCancelPenalty, func(r *rand.Rand) int64 { return int64(r.Intn(1001)) }},
+	{configs.DisputeWindow, func(r *rand.Rand) int64 { return randomBlocks(r, 100) }},
+	{configs.ContractGracePeriod, func(r *rand.Rand) int64 { return randomBlocks(r, 50) }},
+	{configs.ContractRetention, func(r *rand.Rand) int64 { return randomBlocks(r, 200) }},
+}
+
+// randomBlocks returns zero half of the time, disabling the feature the config
+// controls, otherwise a number of blocks up to max
+func randomBlocks(r *rand.Rand, max int) int64 {
+	if r.Intn(2) == 0 {
+		return 0
+	}
+	return int64(simtypes.RandIntBetween(r, 1, max+1))
+}
+
+// RandomizedGenState generates a random genesis state for the arkeo module,
+// overriding a set of configs with random values
+func RandomizedGenState(simState *module.SimulationState) {
+	genesis := types.DefaultGenesis()
+	for _, cfg := range genConfigs {
+		var value int64
+		simState.AppParams.GetOrGenerate(simState.Cdc, cfg.name.String(), &value, simState.Rand,
+			func(r *rand.Rand) { value = cfg.gen(r) },
+		)
+		genesis.ConfigOverrides = append(genesis.ConfigOverrides, types.ConfigOverride{
+			Name:  cfg.name.String(),
+			Value: value,
+		})
+	}
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(genesis)
+}
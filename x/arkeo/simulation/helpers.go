@@ -1,10 +1,24 @@
 package simulation
 
 import (
+	"math/rand"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/arkeonetwork/arkeo/x/arkeo/keeper"
+	"github.com/arkeonetwork/arkeo/x/arkeo/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	"github.com/cosmos/cosmos-sdk/x/auth/migrations/legacytx"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
 )
 
+// simServices are the services providers and contracts are simulated against
+var simServices = []common.Service{common.MockService, common.BTCService, common.ETHService}
+
 // FindAccount find a specific address from an account list
 func FindAccount(accs []simtypes.Account, address string) (simtypes.Account, bool) {
 	creator, err := sdk.AccAddressFromBech32(address)
@@ -13,3 +27,105 @@ func FindAccount(accs []simtypes.Account, address string) (simtypes.Account, boo
 	}
 	return simtypes.FindAccount(accs, creator)
 }
+
+// randomService returns one of the simulated services
+func randomService(r *rand.Rand) common.Service {
+	return simServices[r.Intn(len(simServices))]
+}
+
+// fetchConfig returns the config value in effect, configs changed by
+// governance take precedence
+func fetchConfig(ctx sdk.Context, k keeper.Keeper, name configs.ConfigName) int64 {
+	if value, ok := k.GetConfigOverride(ctx, name); ok {
+		return value
+	}
+	return k.GetConfigValues(ctx).GetInt64Value(name)
+}
+
+// isSupportedService check the service against the service registry, all
+// known services are supported while the registry is empty
+func isSupportedService(ctx sdk.Context, k keeper.Keeper, service common.Service) bool {
+	iter := k.GetServiceInfoIterator(ctx)
+	registered := iter.Valid()
+	iter.Close()
+	if registered {
+		return k.ServiceInfoExists(ctx, service)
+	}
+	return true
+}
+
+// getProviders returns all providers whose key belongs to one of the
+// simulation accounts
+func getProviders(ctx sdk.Context, k keeper.Keeper, accs []simtypes.Account) []types.Provider {
+	providers := make([]types.Provider, 0)
+	iter := k.GetProviderIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var provider types.Provider
+		if err := k.Cdc().Unmarshal(iter.Value(), &provider); err != nil {
+			continue
+		}
+		addr, err := provider.PubKey.GetMyAddress()
+		if err != nil {
+			continue
+		}
+		if _, found := simtypes.FindAccount(accs, addr); found {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+// getOpenContracts returns all contracts that have not yet expired, and
+// whose spender is one of the simulation accounts
+func getOpenContracts(ctx sdk.Context, k keeper.Keeper, accs []simtypes.Account) []types.Contract {
+	contracts := make([]types.Contract, 0)
+	iter := k.GetContractIterator(ctx)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var contract types.Contract
+		if err := k.Cdc().Unmarshal(iter.Value(), &contract); err != nil {
+			continue
+		}
+		if contract.IsExpired(ctx.BlockHeight()) {
+			continue
+		}
+		addr, err := contract.GetSpender().GetMyAddress()
+		if err != nil {
+			continue
+		}
+		if _, found := simtypes.FindAccount(accs, addr); found {
+			contracts = append(contracts, contract)
+		}
+	}
+	return contracts
+}
+
+// deliver signs the msg with the given account and delivers it, paying
+// random fees out of what is left after the coins spent in the msg
+func deliver(
+	r *rand.Rand,
+	app *baseapp.BaseApp,
+	ctx sdk.Context,
+	ak types.AccountKeeper,
+	bk types.BankKeeper,
+	simAccount simtypes.Account,
+	msg legacytx.LegacyMsg,
+	spent sdk.Coins,
+) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+	txCtx := simulation.OperationInput{
+		R:               r,
+		App:             app,
+		TxGen:           simappparams.MakeTestEncodingConfig().TxConfig,
+		Cdc:             nil,
+		Msg:             msg,
+		MsgType:         msg.Type(),
+		Context:         ctx,
+		SimAccount:      simAccount,
+		AccountKeeper:   ak,
+		Bankkeeper:      bk,
+		ModuleName:      types.ModuleName,
+		CoinsSpentInMsg: spent,
+	}
+	return simulation.GenAndDeliverTxWithRandFees(txCtx)
+}
@@ -3,6 +3,7 @@ package simulation
 import (
 	"math/rand"
 
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
 	"github.com/arkeonetwork/arkeo/x/arkeo/keeper"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
 
@@ -18,13 +19,55 @@ func SimulateMsgModProvider(
 ) simtypes.Operation {
 	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, serviceID string,
 	) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
-		simAccount, _ := simtypes.RandomAcc(r, accs)
-		msg := &types.MsgModProvider{
-			Creator: simAccount.Address,
+		msg := &types.MsgModProvider{}
+
+		if fetchConfig(ctx, k, configs.HandlerModProvider) > 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "handler disabled"), nil, nil
+		}
+
+		providers := make([]types.Provider, 0)
+		for _, provider := range getProviders(ctx, k, accs) {
+			if provider.Bond.IsPositive() {
+				providers = append(providers, provider)
+			}
+		}
+		if len(providers) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "no bonded providers"), nil, nil
+		}
+		provider := providers[r.Intn(len(providers))]
+		addr, err := provider.PubKey.GetMyAddress()
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "invalid provider pubkey"), nil, err
 		}
+		simAccount, _ := simtypes.FindAccount(accs, addr)
 
-		// TODO: Handling the ModProvider simulation
+		maxDuration := int64(simtypes.RandIntBetween(r, 10, 1000))
+		if maxLength := fetchConfig(ctx, k, configs.MaxContractLength); maxLength > 0 && maxDuration > maxLength {
+			maxDuration = maxLength
+		}
+
+		// per day subscription rates must cover at least one token per block
+		minSubRate := int64(1)
+		if fetchConfig(ctx, k, configs.SubscriptionRatePerDay) > 0 {
+			minSubRate = fetchConfig(ctx, k, configs.BlocksPerYear) / 365
+		}
+
+		msg.Creator = simAccount.Address
+		msg.Provider = provider.PubKey
+		msg.Service = provider.Service.String()
+		msg.MetadataUri = simtypes.RandStringOfLength(r, 20)
+		msg.MetadataNonce = provider.MetadataNonce + 1
+		msg.Status = types.ProviderStatus(r.Intn(2))
+		// skew towards online providers so contracts get opened
+		if r.Intn(4) > 0 {
+			msg.Status = types.ProviderStatus_ONLINE
+		}
+		msg.MinContractDuration = int64(simtypes.RandIntBetween(r, 1, int(maxDuration)+1))
+		msg.MaxContractDuration = maxDuration
+		msg.SubscriptionRate = sdk.NewCoins(sdk.NewInt64Coin(configs.Denom, minSubRate*int64(simtypes.RandIntBetween(r, 1, 100))))
+		msg.PayAsYouGoRate = sdk.NewCoins(sdk.NewInt64Coin(configs.Denom, int64(simtypes.RandIntBetween(r, 1, 100))))
+		msg.SettlementDuration = int64(r.Intn(50))
 
-		return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "ModProvider simulation not implemented"), nil, nil
+		return deliver(r, app, ctx, ak, bk, simAccount, msg, nil)
 	}
 }
@@ -3,6 +3,8 @@ package simulation
 import (
 	"math/rand"
 
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
 	"github.com/arkeonetwork/arkeo/x/arkeo/keeper"
 	"github.com/arkeonetwork/arkeo/x/arkeo/types"
 
@@ -23,8 +25,100 @@ func SimulateMsgOpenContract(
 			Creator: simAccount.Address,
 		}
 
-		// TODO: Handling the OpenContract simulation
+		if fetchConfig(ctx, k, configs.HandlerOpenContract) > 0 || fetchConfig(ctx, k, configs.HaltContractOpening) > 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "contract opening disabled"), nil, nil
+		}
+
+		// find a provider that is able to take on new contracts
+		minBond := sdk.NewInt(fetchConfig(ctx, k, configs.MinProviderBond))
+		permissioned := fetchConfig(ctx, k, configs.PermissionedProviders) > 0
+		providers := make([]types.Provider, 0)
+		for _, provider := range getProviders(ctx, k, accs) {
+			if provider.LastUpdate == 0 || provider.Status != types.ProviderStatus_ONLINE || provider.Bond.LT(minBond) {
+				continue
+			}
+			if permissioned && !k.IsAllowedProvider(ctx, provider.PubKey) {
+				continue
+			}
+			if !isSupportedService(ctx, k, provider.Service) {
+				continue
+			}
+			providers = append(providers, provider)
+		}
+		if len(providers) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "no available providers"), nil, nil
+		}
+		provider := providers[r.Intn(len(providers))]
+
+		providerAddress, err := provider.PubKey.GetMyAddress()
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "invalid provider pubkey"), nil, err
+		}
+		if providerAddress.Equals(simAccount.Address) {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "provider cannot contract with itself"), nil, nil
+		}
+
+		client, err := common.NewPubKeyFromCrypto(simAccount.PubKey)
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "unable to convert pubkey"), nil, err
+		}
+
+		activeContract, err := k.GetActiveContractForUser(ctx, client, provider.PubKey, provider.Service)
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "unable to get active contract"), nil, err
+		}
+		if !activeContract.IsEmpty() && !activeContract.IsExpired(ctx.BlockHeight()) {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "contract already open"), nil, nil
+		}
+
+		msg.Provider = provider.PubKey
+		msg.Service = provider.Service.String()
+		msg.Client = client
+		msg.Duration = int64(simtypes.RandIntBetween(r, int(provider.MinContractDuration), int(provider.MaxContractDuration)+1))
+		msg.QueriesPerMinute = int64(simtypes.RandIntBetween(r, 1, 10))
+
+		if r.Intn(2) == 0 {
+			msg.ContractType = types.ContractType_SUBSCRIPTION
+			msg.Rate = sdk.NewCoin(configs.Denom, sdk.NewCoins(provider.SubscriptionRate...).AmountOf(configs.Denom))
+			msg.Authorization = types.ContractAuthorization(r.Intn(2))
+			msg.AutoRenew = r.Intn(2) == 0
+
+			// the deposit must cover the per block rate for the whole duration
+			rate := msg.Rate.Amount
+			if provider.SubscriptionRatePerDay {
+				blocksPerDay := fetchConfig(ctx, k, configs.BlocksPerYear) / 365
+				if blocksPerDay <= 0 {
+					return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "invalid blocks per day"), nil, nil
+				}
+				rate = rate.QuoRaw(blocksPerDay)
+			}
+			msg.Deposit = rate.MulRaw(msg.Duration).MulRaw(msg.QueriesPerMinute)
+		} else {
+			msg.ContractType = types.ContractType_PAY_AS_YOU_GO
+			msg.Rate = sdk.NewCoin(configs.Denom, sdk.NewCoins(provider.PayAsYouGoRate...).AmountOf(configs.Denom))
+			msg.Authorization = types.ContractAuthorization_STRICT
+			msg.SettlementDuration = provider.SettlementDuration
+			msg.Deposit = msg.Rate.Amount.MulRaw(int64(simtypes.RandIntBetween(r, 1, 1000)))
+		}
+		if !msg.Rate.Amount.IsPositive() || !msg.Deposit.IsPositive() {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "provider has no rate"), nil, nil
+		}
+
+		if maxTotalDeposit := fetchConfig(ctx, k, configs.MaxTotalDeposit); maxTotalDeposit > 0 {
+			deposits, err := k.SumClientDeposits(ctx, client)
+			if err != nil {
+				return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "unable to sum client deposits"), nil, err
+			}
+			if deposits.AmountOf(configs.Denom).Add(msg.Deposit).GT(sdk.NewInt(maxTotalDeposit)) {
+				return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "max total deposit reached"), nil, nil
+			}
+		}
+
+		spent := sdk.NewCoins(sdk.NewCoin(configs.Denom, msg.Deposit.AddRaw(fetchConfig(ctx, k, configs.OpenContractCost))))
+		if !bk.SpendableCoins(ctx, simAccount.Address).IsAllGTE(spent) {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "insufficient funds"), nil, nil
+		}
 
-		return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "OpenContract simulation not implemented"), nil, nil
+		return deliver(r, app, ctx, ak, bk, simAccount, msg, spent)
 	}
 }
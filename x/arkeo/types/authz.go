@@ -0,0 +1,205 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+)
+
+var (
+	_ authz.Authorization = &ClaimContractIncomeAuthorization{}
+	_ authz.Authorization = &ModProviderAuthorization{}
+	_ authz.Authorization = &OpenContractAuthorization{}
+)
+
+// NewClaimContractIncomeAuthorization returns an authorization to claim
+// contract income, restricted to contracts of the given providers and
+// services when any are given
+func NewClaimContractIncomeAuthorization(providers []common.PubKey, services []common.Service) *ClaimContractIncomeAuthorization {
+	return &ClaimContractIncomeAuthorization{
+		Providers: pubKeyStrings(providers),
+		Services:  serviceStrings(services),
+	}
+}
+
+func (a ClaimContractIncomeAuthorization) MsgTypeURL() string {
+	return sdk.MsgTypeURL(&MsgClaimContractIncome{})
+}
+
+func (a ClaimContractIncomeAuthorization) Accept(_ sdk.Context, msg sdk.Msg) (authz.AcceptResponse, error) {
+	claim, ok := msg.(*MsgClaimContractIncome)
+	if !ok {
+		return authz.AcceptResponse{}, errors.Wrap(sdkerrors.ErrInvalidType, "type mismatch")
+	}
+	// the claim handler checks the provider and service given in the claim
+	// against the contract, so a restricted grant requires them
+	if len(a.Providers) > 0 && claim.Provider.IsEmpty() {
+		return authz.AcceptResponse{}, errors.Wrapf(sdkerrors.ErrUnauthorized, "claim of contract %d does not give its provider", claim.ContractId)
+	}
+	if len(a.Services) > 0 && claim.Service == "" {
+		return authz.AcceptResponse{}, errors.Wrapf(sdkerrors.ErrUnauthorized, "claim of contract %d does not give its service", claim.ContractId)
+	}
+	if err := acceptProviderService(a.Providers, a.Services, claim.Provider, claim.Service); err != nil {
+		return authz.AcceptResponse{}, err
+	}
+	return authz.AcceptResponse{Accept: true}, nil
+}
+
+func (a ClaimContractIncomeAuthorization) ValidateBasic() error {
+	return validateProviderServices(a.Providers, a.Services)
+}
+
+// NewModProviderAuthorization returns an authorization to modify the
+// granter's provider bonds, restricted to the given providers and services
+// when any are given
+func NewModProviderAuthorization(providers []common.PubKey, services []common.Service) *ModProviderAuthorization {
+	return &ModProviderAuthorization{
+		Providers: pubKeyStrings(providers),
+		Services:  serviceStrings(services),
+	}
+}
+
+func (a ModProviderAuthorization) MsgTypeURL() string {
+	return sdk.MsgTypeURL(&MsgModProvider{})
+}
+
+func (a ModProviderAuthorization) Accept(_ sdk.Context, msg sdk.Msg) (authz.AcceptResponse, error) {
+	mod, ok := msg.(*MsgModProvider)
+	if !ok {
+		return authz.AcceptResponse{}, errors.Wrap(sdkerrors.ErrInvalidType, "type mismatch")
+	}
+	if err := acceptProviderService(a.Providers, a.Services, mod.Provider, mod.Service); err != nil {
+		return authz.AcceptResponse{}, err
+	}
+	return authz.AcceptResponse{Accept: true}, nil
+}
+
+func (a ModProviderAuthorization) ValidateBasic() error {
+	return validateProviderServices(a.Providers, a.Services)
+}
+
+// NewOpenContractAuthorization returns an authorization to open contracts
+// for the granter with deposits and open costs totalling up to the spend
+// limit, restricted
+// to the given providers and services when any are given
+func NewOpenContractAuthorization(spendLimit sdk.Coins, providers []common.PubKey, services []common.Service) *OpenContractAuthorization {
+	return &OpenContractAuthorization{
+		SpendLimit: spendLimit,
+		Providers:  pubKeyStrings(providers),
+		Services:   serviceStrings(services),
+	}
+}
+
+func (a OpenContractAuthorization) MsgTypeURL() string {
+	return sdk.MsgTypeURL(&MsgOpenContract{})
+}
+
+// Accept takes the deposit and the max open cost of the contract, both paid
+// by the granter, from the spend limit. The open contract handler charges no
+// more than the max open cost, so it is required. The authorization is
+// removed once the limit is spent.
+func (a OpenContractAuthorization) Accept(_ sdk.Context, msg sdk.Msg) (authz.AcceptResponse, error) {
+	open, ok := msg.(*MsgOpenContract)
+	if !ok {
+		return authz.AcceptResponse{}, errors.Wrap(sdkerrors.ErrInvalidType, "type mismatch")
+	}
+	if err := acceptProviderService(a.Providers, a.Services, open.Provider, open.Service); err != nil {
+		return authz.AcceptResponse{}, err
+	}
+
+	if open.Deposit.IsNil() || open.Deposit.IsNegative() {
+		return authz.AcceptResponse{}, errors.Wrap(sdkerrors.ErrInvalidCoins, "invalid deposit")
+	}
+	if open.MaxOpenCost.IsNil() || open.MaxOpenCost.IsNegative() {
+		return authz.AcceptResponse{}, errors.Wrap(sdkerrors.ErrUnauthorized, "contract opened under an authorization must give its max open cost")
+	}
+	spend := sdk.NewCoins(sdk.NewCoin(open.Rate.Denom, open.Deposit), sdk.NewCoin(configs.Denom, open.MaxOpenCost))
+	limitLeft, isNegative := a.SpendLimit.SafeSub(spend...)
+	if isNegative {
+		return authz.AcceptResponse{}, errors.Wrapf(sdkerrors.ErrInsufficientFunds, "deposit and open cost %s is above the spend limit %s", spend, a.SpendLimit)
+	}
+	if limitLeft.IsZero() {
+		return authz.AcceptResponse{Accept: true, Delete: true}, nil
+	}
+	return authz.AcceptResponse{Accept: true, Updated: &OpenContractAuthorization{
+		SpendLimit: limitLeft,
+		Providers:  a.Providers,
+		Services:   a.Services,
+	}}, nil
+}
+
+func (a OpenContractAuthorization) ValidateBasic() error {
+	if a.SpendLimit == nil {
+		return errors.Wrap(sdkerrors.ErrInvalidCoins, "spend limit cannot be nil")
+	}
+	if !a.SpendLimit.IsAllPositive() {
+		return errors.Wrap(sdkerrors.ErrInvalidCoins, "spend limit must be positive")
+	}
+	return validateProviderServices(a.Providers, a.Services)
+}
+
+// acceptProviderService checks the provider and service of a message are
+// among those allowed, an empty list allows any
+func acceptProviderService(providers, services []string, provider common.PubKey, service string) error {
+	if len(providers) > 0 && !containsString(providers, provider.String()) {
+		return errors.Wrapf(sdkerrors.ErrUnauthorized, "provider %s is not authorized", provider)
+	}
+	if len(services) == 0 {
+		return nil
+	}
+	svc, err := common.NewService(service)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidService, "invalid service (%s): %s", service, err)
+	}
+	if !containsString(services, svc.String()) {
+		return errors.Wrapf(sdkerrors.ErrUnauthorized, "service %s is not authorized", service)
+	}
+	return nil
+}
+
+func validateProviderServices(providers, services []string) error {
+	for _, provider := range providers {
+		if _, err := common.NewPubKey(provider); err != nil || provider == "" {
+			return errors.Wrapf(ErrInvalidPubKey, "invalid provider pubkey (%s)", provider)
+		}
+	}
+	for _, service := range services {
+		svc, err := common.NewService(service)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidService, "invalid service (%s): %s", service, err)
+		}
+		if svc.String() != service {
+			return errors.Wrapf(ErrInvalidService, "service %s must be written as %s", service, svc)
+		}
+	}
+	return nil
+}
+
+func pubKeyStrings(pubkeys []common.PubKey) []string {
+	result := make([]string, len(pubkeys))
+	for i, pk := range pubkeys {
+		result[i] = pk.String()
+	}
+	return result
+}
+
+func serviceStrings(services []common.Service) []string {
+	result := make([]string, len(services))
+	for i, service := range services {
+		result[i] = service.String()
+	}
+	return result
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
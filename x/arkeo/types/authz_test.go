@@ -0,0 +1,115 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimContractIncomeAuthorization(t *testing.T) {
+	ctx := sdk.Context{}
+	provider := GetRandomPubKey()
+	auth := NewClaimContractIncomeAuthorization([]common.PubKey{provider}, []common.Service{common.BTCService})
+	require.NoError(t, auth.ValidateBasic())
+	require.Equal(t, sdk.MsgTypeURL(&MsgClaimContractIncome{}), auth.MsgTypeURL())
+
+	res, err := auth.Accept(ctx, &MsgClaimContractIncome{ContractId: 2, Provider: provider, Service: common.BTCService.String()})
+	require.NoError(t, err)
+	require.True(t, res.Accept)
+	require.False(t, res.Delete)
+
+	_, err = auth.Accept(ctx, &MsgClaimContractIncome{ContractId: 2, Provider: GetRandomPubKey(), Service: common.BTCService.String()})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	_, err = auth.Accept(ctx, &MsgClaimContractIncome{ContractId: 2, Provider: provider, Service: common.ETHService.String()})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	// claims that leave out the restricted provider or service are refused
+	_, err = auth.Accept(ctx, &MsgClaimContractIncome{ContractId: 2, Service: common.BTCService.String()})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	_, err = auth.Accept(ctx, &MsgClaimContractIncome{ContractId: 2, Provider: provider})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	_, err = auth.Accept(ctx, &MsgCloseContract{ContractId: 1})
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidType)
+
+	// any contract
+	res, err = NewClaimContractIncomeAuthorization(nil, nil).Accept(ctx, &MsgClaimContractIncome{ContractId: 3})
+	require.NoError(t, err)
+	require.True(t, res.Accept)
+
+	auth.Providers = []string{"bogus"}
+	require.ErrorIs(t, auth.ValidateBasic(), ErrInvalidPubKey)
+}
+
+func TestModProviderAuthorization(t *testing.T) {
+	ctx := sdk.Context{}
+	provider := GetRandomPubKey()
+	auth := NewModProviderAuthorization([]common.PubKey{provider}, []common.Service{common.BTCService})
+	require.NoError(t, auth.ValidateBasic())
+
+	res, err := auth.Accept(ctx, &MsgModProvider{Provider: provider, Service: common.BTCService.String()})
+	require.NoError(t, err)
+	require.True(t, res.Accept)
+
+	_, err = auth.Accept(ctx, &MsgModProvider{Provider: GetRandomPubKey(), Service: common.BTCService.String()})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	_, err = auth.Accept(ctx, &MsgModProvider{Provider: provider, Service: common.ETHService.String()})
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+
+	auth.Services = []string{"bogus"}
+	require.ErrorIs(t, auth.ValidateBasic(), ErrInvalidService)
+	auth.Services = nil
+	auth.Providers = []string{"bogus"}
+	require.ErrorIs(t, auth.ValidateBasic(), ErrInvalidPubKey)
+}
+
+func TestOpenContractAuthorization(t *testing.T) {
+	ctx := sdk.Context{}
+	provider := GetRandomPubKey()
+
+	auth := NewOpenContractAuthorization(sdk.NewCoins(sdk.NewInt64Coin("uarkeo", 100)), nil, []common.Service{common.BTCService})
+	require.NoError(t, auth.ValidateBasic())
+
+	msg := &MsgOpenContract{
+		Provider: provider,
+		Service:  common.BTCService.String(),
+		Rate:     sdk.NewInt64Coin("uarkeo", 1),
+		Deposit:  sdk.NewInt(60),
+	}
+	// the grant cannot be charged an open cost it does not know
+	_, err := auth.Accept(ctx, msg)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+
+	msg.MaxOpenCost = sdk.NewInt(10)
+	res, err := auth.Accept(ctx, msg)
+	require.NoError(t, err)
+	require.True(t, res.Accept)
+	require.False(t, res.Delete)
+	updated, ok := res.Updated.(*OpenContractAuthorization)
+	require.True(t, ok)
+	// the max open cost is spent along with the deposit
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("uarkeo", 30)), updated.SpendLimit)
+
+	// above what is left of the limit
+	_, err = updated.Accept(ctx, msg)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFunds)
+	// the deposit alone is covered, but not with the open cost
+	msg.Deposit = sdk.NewInt(30)
+	_, err = updated.Accept(ctx, msg)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFunds)
+
+	// spending the rest removes the grant
+	msg.Deposit = sdk.NewInt(20)
+	res, err = updated.Accept(ctx, msg)
+	require.NoError(t, err)
+	require.True(t, res.Accept)
+	require.True(t, res.Delete)
+
+	msg.Service = common.ETHService.String()
+	_, err = auth.Accept(ctx, msg)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+
+	auth.SpendLimit = sdk.NewCoins()
+	require.ErrorIs(t, auth.ValidateBasic(), sdkerrors.ErrInvalidCoins)
+}
@@ -5,6 +5,7 @@ import (
 	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/msgservice"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 )
 
 func RegisterCodec(cdc *codec.LegacyAmino) {
@@ -12,8 +13,33 @@ func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgModProvider{}, "arkeo/ModProvider", nil)
 	cdc.RegisterConcrete(&MsgOpenContract{}, "arkeo/OpenContract", nil)
 	cdc.RegisterConcrete(&MsgCloseContract{}, "arkeo/CloseContract", nil)
+	cdc.RegisterConcrete(&MsgCloseContractByProvider{}, "arkeo/CloseContractByProvider", nil)
 	cdc.RegisterConcrete(&MsgClaimContractIncome{}, "arkeo/ClaimContractIncome", nil)
+	cdc.RegisterConcrete(&MsgSetContractMemo{}, "arkeo/SetContractMemo", nil)
+	cdc.RegisterConcrete(&MsgTransferContract{}, "arkeo/TransferContract", nil)
+	cdc.RegisterConcrete(&MsgSettleContracts{}, "arkeo/SettleContracts", nil)
+	cdc.RegisterConcrete(&MsgProposeRateChange{}, "arkeo/ProposeRateChange", nil)
+	cdc.RegisterConcrete(&MsgAcceptRateChange{}, "arkeo/AcceptRateChange", nil)
+	cdc.RegisterConcrete(&MsgRenegotiateRate{}, "arkeo/RenegotiateRate", nil)
+	cdc.RegisterConcrete(&MsgPauseContract{}, "arkeo/PauseContract", nil)
+	cdc.RegisterConcrete(&MsgResumeContract{}, "arkeo/ResumeContract", nil)
+	cdc.RegisterConcrete(&MsgRenewContract{}, "arkeo/RenewContract", nil)
+	cdc.RegisterConcrete(&MsgDepositContract{}, "arkeo/DepositContract", nil)
+	cdc.RegisterConcrete(&MsgSetConfig{}, "arkeo/SetConfig", nil)
+	cdc.RegisterConcrete(&MsgSetAllowedProvider{}, "arkeo/SetAllowedProvider", nil)
+	cdc.RegisterConcrete(&MsgSetAllowedDenom{}, "arkeo/SetAllowedDenom", nil)
+	cdc.RegisterConcrete(&MsgSlashProvider{}, "arkeo/SlashProvider", nil)
+	cdc.RegisterConcrete(&MsgDisputeContract{}, "arkeo/DisputeContract", nil)
+	cdc.RegisterConcrete(&MsgResolveDispute{}, "arkeo/ResolveDispute", nil)
+	cdc.RegisterConcrete(&MsgSetArbiter{}, "arkeo/SetArbiter", nil)
+	cdc.RegisterConcrete(&MsgSetService{}, "arkeo/SetService", nil)
+	cdc.RegisterConcrete(&MsgSetEmissionSchedule{}, "arkeo/SetEmissionSchedule", nil)
+	cdc.RegisterConcrete(&MsgReportUptime{}, "arkeo/ReportUptime", nil)
+	cdc.RegisterConcrete(&MsgModProviderMetadata{}, "arkeo/ModProviderMetadata", nil)
 	cdc.RegisterConcrete(&MsgSetVersion{}, "arkeo/SetVersion", nil)
+	cdc.RegisterConcrete(&ClaimContractIncomeAuthorization{}, "arkeo/ClaimContractIncomeAuthorization", nil)
+	cdc.RegisterConcrete(&ModProviderAuthorization{}, "arkeo/ModProviderAuthorization", nil)
+	cdc.RegisterConcrete(&OpenContractAuthorization{}, "arkeo/OpenContractAuthorization", nil)
 	// this line is used by starport scaffolding # 2
 }
 
@@ -30,14 +56,86 @@ func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
 	registry.RegisterImplementations((*sdk.Msg)(nil),
 		&MsgCloseContract{},
 	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgCloseContractByProvider{},
+	)
 	registry.RegisterImplementations((*sdk.Msg)(nil),
 		&MsgClaimContractIncome{},
 	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgTransferContract{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSetContractMemo{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSettleContracts{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgProposeRateChange{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgAcceptRateChange{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgRenegotiateRate{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgPauseContract{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgResumeContract{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgRenewContract{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgDepositContract{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSetConfig{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSetAllowedProvider{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSetAllowedDenom{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSlashProvider{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgDisputeContract{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgResolveDispute{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSetArbiter{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgModProviderMetadata{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSetService{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSetEmissionSchedule{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgReportUptime{},
+	)
 	registry.RegisterImplementations((*sdk.Msg)(nil),
 		&MsgSetVersion{},
 	)
 	// this line is used by starport scaffolding # 3
 
+	registry.RegisterImplementations((*authz.Authorization)(nil),
+		&ClaimContractIncomeAuthorization{},
+		&ModProviderAuthorization{},
+		&OpenContractAuthorization{},
+	)
+
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
 }
 
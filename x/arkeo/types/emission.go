@@ -0,0 +1,51 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+)
+
+// MaxEmissionEpochs caps the epochs of the emission schedule
+const MaxEmissionEpochs = 100
+
+// Validate check the epochs are ordered by strictly increasing start heights
+// and each emits a share of the reserve
+func (schedule EmissionSchedule) Validate() error {
+	if len(schedule.Epochs) > MaxEmissionEpochs {
+		return errors.Wrapf(ErrInvalidEmissionSchedule, "too many epochs (%d/%d)", len(schedule.Epochs), MaxEmissionEpochs)
+	}
+	for i, epoch := range schedule.Epochs {
+		if epoch.StartHeight < 0 {
+			return errors.Wrapf(ErrInvalidEmissionSchedule, "epoch %d has a negative start height (%d)", i, epoch.StartHeight)
+		}
+		if i > 0 && epoch.StartHeight <= schedule.Epochs[i-1].StartHeight {
+			return errors.Wrapf(ErrInvalidEmissionSchedule, "epoch %d does not start after the previous epoch (%d)", i, epoch.StartHeight)
+		}
+		if epoch.EmissionCurve <= 0 {
+			return errors.Wrapf(ErrInvalidEmissionSchedule, "epoch %d has an invalid emission curve (%d)", i, epoch.EmissionCurve)
+		}
+		if epoch.HalvingInterval < 0 {
+			return errors.Wrapf(ErrInvalidEmissionSchedule, "epoch %d has a negative halving interval (%d)", i, epoch.HalvingInterval)
+		}
+	}
+	return nil
+}
+
+// EpochAt returns the epoch in effect at the given height, false when the
+// first epoch has yet to start
+func (schedule EmissionSchedule) EpochAt(height int64) (EmissionEpoch, bool) {
+	for i := len(schedule.Epochs) - 1; i >= 0; i-- {
+		if schedule.Epochs[i].StartHeight <= height {
+			return schedule.Epochs[i], true
+		}
+	}
+	return EmissionEpoch{}, false
+}
+
+// Halvings returns the number of times the block reward has halved in the
+// epoch by the given height
+func (epoch EmissionEpoch) Halvings(height int64) int64 {
+	if epoch.HalvingInterval <= 0 || height < epoch.StartHeight {
+		return 0
+	}
+	return (height - epoch.StartHeight) / epoch.HalvingInterval
+}
@@ -41,4 +41,52 @@ var (
 	ErrInvariantMaxSupply                     = errors.Register(ModuleName, 32, "max supply invariant")
 	ErrInvalidAuthorization                   = errors.Register(ModuleName, 33, "invalid authorization")
 	ErrInvalidVersion                         = errors.Register(ModuleName, 34, "version cannot be zero or lower")
+	ErrOpenContractMaxTotalDeposit            = errors.Register(ModuleName, 35, "client max total deposit exceeded")
+	ErrSelfContract                           = errors.Register(ModuleName, 36, "provider cannot be its own client")
+	ErrUnsupportedService                     = errors.Register(ModuleName, 37, "unsupported service")
+	ErrInvalidContractMemo                    = errors.Register(ModuleName, 38, "invalid contract memo")
+	ErrSetContractMemoUnauthorized            = errors.Register(ModuleName, 39, "unauthorized to set contract memo")
+	ErrInvalidSettleContractsBatch            = errors.Register(ModuleName, 40, "invalid settle contracts batch")
+	ErrInvalidConfig                          = errors.Register(ModuleName, 41, "invalid config")
+	ErrInvalidAuthority                       = errors.Register(ModuleName, 42, "invalid authority")
+	ErrContractOpeningHalted                  = errors.Register(ModuleName, 43, "contract opening halted")
+	ErrProviderNotAllowed                     = errors.Register(ModuleName, 44, "provider not allowed")
+	ErrRateChangeUnauthorized                 = errors.Register(ModuleName, 46, "unauthorized to change contract rate")
+	ErrNoRateChangeProposal                   = errors.Register(ModuleName, 47, "no rate change proposal")
+	ErrRateChangeMismatch                     = errors.Register(ModuleName, 48, "rate change mismatch")
+	ErrInsufficientDeposit                    = errors.Register(ModuleName, 49, "insufficient deposit")
+	ErrPauseContractUnauthorized              = errors.Register(ModuleName, 50, "unauthorized to pause contract")
+	ErrPauseContractType                      = errors.Register(ModuleName, 51, "only subscription contracts can be paused")
+	ErrContractPaused                         = errors.Register(ModuleName, 52, "contract is paused")
+	ErrContractNotPaused                      = errors.Register(ModuleName, 53, "contract is not paused")
+	ErrInvalidRefundAddress                   = errors.Register(ModuleName, 54, "invalid refund address")
+	ErrInvalidDenom                           = errors.Register(ModuleName, 55, "invalid denom")
+	ErrDenomNotAllowed                        = errors.Register(ModuleName, 56, "denom not allowed")
+	ErrProviderCloseContractUnauthorized      = errors.Register(ModuleName, 57, "only the provider can close the contract")
+	ErrTransferContractUnauthorized           = errors.Register(ModuleName, 58, "only the client can transfer the contract")
+	ErrTransferContractSameClient             = errors.Register(ModuleName, 59, "contract already belongs to the client")
+	ErrTransferContractType                   = errors.Register(ModuleName, 60, "pay-as-you-go contracts cannot be transferred")
+	ErrInvalidSlash                           = errors.Register(ModuleName, 61, "invalid slash")
+	ErrInvalidDispute                         = errors.Register(ModuleName, 62, "invalid dispute")
+	ErrDisputeUnauthorized                    = errors.Register(ModuleName, 63, "unauthorized to dispute contract")
+	ErrResolveDisputeUnauthorized             = errors.Register(ModuleName, 64, "only gov or an arbiter can resolve a dispute")
+	ErrInvalidSettlementInterval              = errors.Register(ModuleName, 65, "invalid settlement interval")
+	ErrInvalidMetadataHash                    = errors.Register(ModuleName, 66, "invalid metadata hash")
+	ErrInvalidMetadataNonce                   = errors.Register(ModuleName, 67, "invalid metadata nonce")
+	ErrClaimContractIncomeRateExceeded        = errors.Register(ModuleName, 68, "claim exceeds the contract's queries per minute")
+	ErrRenewContract                          = errors.Register(ModuleName, 69, "contract cannot be renewed")
+	ErrInvalidServiceInfo                     = errors.Register(ModuleName, 70, "invalid service info")
+	ErrInvalidEmissionSchedule                = errors.Register(ModuleName, 72, "invalid emission schedule")
+	ErrInvariantVestingModule                 = errors.Register(ModuleName, 73, "vesting module invariant")
+	ErrExcessiveDeposit                       = errors.Register(ModuleName, 74, "excessive deposit")
+	ErrDepositContract                        = errors.Register(ModuleName, 75, "contract cannot be topped up")
+	ErrRateRenegotiation                      = errors.Register(ModuleName, 76, "invalid rate renegotiation")
+	ErrInvalidModProviderFreeTier             = errors.Register(ModuleName, 77, "invalid free tier")
+	ErrInvalidAffiliate                       = errors.Register(ModuleName, 78, "invalid affiliate")
+	ErrInvalidUptimeReport                    = errors.Register(ModuleName, 79, "invalid uptime report")
+	ErrOpenContractCapacity                   = errors.Register(ModuleName, 80, "provider has no capacity for more contracts")
+	ErrInvalidModProviderMaxOpenContracts     = errors.Register(ModuleName, 81, "invalid max open contracts")
+	ErrClaimContractIncomeMismatch            = errors.Register(ModuleName, 82, "claim does not match the contract's provider or service")
+	ErrOpenContractMemoSignature              = errors.Register(ModuleName, 83, "invalid transfer memo signature")
+	ErrOpenContractMaxCost                    = errors.Register(ModuleName, 84, "open contract cost is above the maximum")
 )
@@ -1,16 +1,45 @@
 package types
 
 import (
+	"github.com/arkeonetwork/arkeo/common"
 	"github.com/arkeonetwork/arkeo/common/cosmos"
 )
 
 const (
-	EventTypeBondProvider    = "arkeo.arkeo.EventBondProvider"
-	EventTypeModProvider     = "arkeo.arkeo.EventModProvider"
-	EventTypeOpenContract    = "arkeo.arkeo.EventOpenContract"
-	EventTypeSettleContract  = "arkeo.arkeo.EventSettleContract"
-	EventTypeCloseContract   = "arkeo.arkeo.EventCloseContract"
-	EventTypeValidatorPayout = "arkeo.arkeo.EventValidatorPayout"
+	EventTypeBondProvider            = "arkeo.arkeo.EventBondProvider"
+	EventTypeModProvider             = "arkeo.arkeo.EventModProvider"
+	EventTypeOpenContract            = "arkeo.arkeo.EventOpenContract"
+	EventTypeSettleContract          = "arkeo.arkeo.EventSettleContract"
+	EventTypeCloseContract           = "arkeo.arkeo.EventCloseContract"
+	EventTypeCloseContractByProvider = "arkeo.arkeo.EventCloseContractByProvider"
+	EventTypeValidatorPayout         = "arkeo.arkeo.EventValidatorPayout"
+	EventTypeConfigChange            = "arkeo.arkeo.EventConfigChange"
+	EventTypeContractHalt            = "arkeo.arkeo.EventContractOpeningHalt"
+	EventTypeAllowedProvider         = "arkeo.arkeo.EventAllowedProvider"
+	EventTypeContractRenewal         = "arkeo.arkeo.EventContractRenewal"
+	EventTypeRateChangeProposal      = "arkeo.arkeo.EventRateChangeProposal"
+	EventTypeRateChange              = "arkeo.arkeo.EventRateChange"
+	EventTypeReserveTaxSplit         = "arkeo.arkeo.EventReserveTaxSplit"
+	EventTypePauseContract           = "arkeo.arkeo.EventPauseContract"
+	EventTypeResumeContract          = "arkeo.arkeo.EventResumeContract"
+	EventTypeAllowedDenom            = "arkeo.arkeo.EventAllowedDenom"
+	EventTypeTransferContract        = "arkeo.arkeo.EventTransferContract"
+	EventTypeSlashProvider           = "arkeo.arkeo.EventSlashProvider"
+	EventTypeDisputeContract         = "arkeo.arkeo.EventDisputeContract"
+	EventTypeResolveDispute          = "arkeo.arkeo.EventResolveDispute"
+	EventTypeReleaseEscrow           = "arkeo.arkeo.EventReleaseEscrow"
+	EventTypeArbiter                 = "arkeo.arkeo.EventArbiter"
+	EventTypeModProviderMetadata     = "arkeo.arkeo.EventModProviderMetadata"
+	EventTypeContractGracePeriod     = "arkeo.arkeo.EventContractGracePeriod"
+	EventTypeService                 = "arkeo.arkeo.EventService"
+	EventTypePruneContract           = "arkeo.arkeo.EventPruneContract"
+	EventTypeEmissionSchedule        = "arkeo.arkeo.EventEmissionSchedule"
+	EventTypeReleaseVestedRewards    = "arkeo.arkeo.EventReleaseVestedRewards"
+	EventTypeProviderIncentive       = "arkeo.arkeo.EventProviderIncentive"
+	EventTypeDepositContract         = "arkeo.arkeo.EventDepositContract"
+	EventTypeAffiliatePayment        = "arkeo.arkeo.EventAffiliatePayment"
+	EventTypeProviderUptime          = "arkeo.arkeo.EventProviderUptime"
+	EventTypeReserveFees             = "arkeo.arkeo.EventReserveFees"
 )
 
 func NewOpenContractEvent(openCost int64, contract *Contract) EventOpenContract {
@@ -29,10 +58,15 @@ func NewOpenContractEvent(openCost int64, contract *Contract) EventOpenContract
 		SettlementDuration: contract.SettlementDuration,
 		Authorization:      contract.Authorization,
 		QueriesPerMinute:   contract.QueriesPerMinute,
+		RateTiers:          contract.RateTiers,
+		SettlementInterval: contract.SettlementInterval,
+		MaxQueries:         contract.MaxQueries,
+		Affiliate:          contract.Affiliate,
+		AffiliateFee:       contract.AffiliateFee,
 	}
 }
 
-func NewContractSettlementEvent(debt, valIncome cosmos.Int, contract *Contract) EventSettleContract {
+func NewContractSettlementEvent(debt, valIncome, refunded cosmos.Int, closed bool, contract *Contract) EventSettleContract {
 	return EventSettleContract{
 		Provider:   contract.Provider,
 		ContractId: contract.Id,
@@ -44,6 +78,9 @@ func NewContractSettlementEvent(debt, valIncome cosmos.Int, contract *Contract)
 		Height:     contract.Height,
 		Paid:       debt,
 		Reserve:    valIncome,
+		Denom:      contract.Rate.Denom,
+		Refunded:   refunded,
+		Closed:     closed,
 	}
 }
 
@@ -57,6 +94,26 @@ func NewCloseContractEvent(contract *Contract) EventCloseContract {
 	}
 }
 
+func NewCloseContractByProviderEvent(penalty cosmos.Int, contract *Contract) EventCloseContractByProvider {
+	return EventCloseContractByProvider{
+		ContractId: contract.Id,
+		Provider:   contract.Provider,
+		Service:    contract.Service.String(),
+		Client:     contract.Client,
+		Penalty:    penalty,
+	}
+}
+
+func NewTransferContractEvent(oldClient common.PubKey, contract *Contract) EventTransferContract {
+	return EventTransferContract{
+		ContractId: contract.Id,
+		Provider:   contract.Provider,
+		Service:    contract.Service.String(),
+		OldClient:  oldClient,
+		NewClient:  contract.Client,
+	}
+}
+
 func NewBondProviderEvent(bond cosmos.Int, msg *MsgBondProvider) EventBondProvider {
 	return EventBondProvider{
 		Provider: msg.Provider,
@@ -66,9 +123,264 @@ func NewBondProviderEvent(bond cosmos.Int, msg *MsgBondProvider) EventBondProvid
 	}
 }
 
-func NewValidatorPayoutEvent(acc cosmos.AccAddress, reward cosmos.Int) EventValidatorPayout {
+func NewModProviderEvent(msg *MsgModProvider, provider *Provider) EventModProvider {
+	return EventModProvider{
+		Creator:               msg.Creator,
+		Provider:              provider.PubKey,
+		Service:               provider.Service.String(),
+		MetadataUri:           provider.MetadataUri,
+		MetadataNonce:         provider.MetadataNonce,
+		Status:                provider.Status,
+		MinContractDuration:   provider.MinContractDuration,
+		MaxContractDuration:   provider.MaxContractDuration,
+		SubscriptionRate:      provider.SubscriptionRate,
+		PayAsYouGoRate:        provider.PayAsYouGoRate,
+		Bond:                  provider.Bond,
+		SettlementDuration:    provider.SettlementDuration,
+		SubscriptionRateTiers: provider.SubscriptionRateTiers,
+		PayAsYouGoRateTiers:   provider.PayAsYouGoRateTiers,
+		FreeTier:              provider.FreeTier,
+		MaxOpenContracts:      provider.MaxOpenContracts,
+	}
+}
+
+func NewValidatorPayoutEvent(acc cosmos.AccAddress, denom string, reward, delegatorsReward cosmos.Int) EventValidatorPayout {
 	return EventValidatorPayout{
-		Validator: acc,
-		Reward:    reward,
+		Validator:        acc,
+		Reward:           reward,
+		Denom:            denom,
+		DelegatorsReward: delegatorsReward,
+	}
+}
+
+func NewConfigChangeEvent(name string, oldValue, newValue int64) EventConfigChange {
+	return EventConfigChange{
+		Name:     name,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}
+}
+
+func NewContractOpeningHaltEvent(halted bool) EventContractOpeningHalt {
+	return EventContractOpeningHalt{
+		Halted: halted,
+	}
+}
+
+func NewAllowedProviderEvent(provider common.PubKey, allowed bool) EventAllowedProvider {
+	return EventAllowedProvider{
+		Provider: provider,
+		Allowed:  allowed,
+	}
+}
+
+func NewAllowedDenomEvent(denom string, allowed bool) EventAllowedDenom {
+	return EventAllowedDenom{
+		Denom:   denom,
+		Allowed: allowed,
+	}
+}
+
+func NewServiceEvent(service string, supported bool) EventService {
+	return EventService{
+		Service:   service,
+		Supported: supported,
+	}
+}
+
+func NewEmissionScheduleEvent(schedule EmissionSchedule) EventEmissionSchedule {
+	return EventEmissionSchedule{
+		Epochs: schedule.Epochs,
+	}
+}
+
+func NewSlashProviderEvent(slash ProviderSlash) EventSlashProvider {
+	return EventSlashProvider{
+		Provider:  slash.PubKey,
+		Service:   slash.Service.String(),
+		Amount:    slash.Amount,
+		Burned:    slash.Burned,
+		Recipient: slash.Recipient,
+		Reason:    slash.Reason,
+	}
+}
+
+func NewDisputeContractEvent(escrow ContractEscrow) EventDisputeContract {
+	return EventDisputeContract{
+		ContractId: escrow.ContractId,
+		Provider:   escrow.Provider,
+		Service:    escrow.Service.String(),
+		Client:     escrow.Client,
+		Amount:     escrow.Amount,
+		Reason:     escrow.Reason,
+		Deadline:   escrow.ReleaseHeight,
+	}
+}
+
+func NewResolveDisputeEvent(escrow ContractEscrow, providerAmount, clientAmount cosmos.Coin, arbiter string) EventResolveDispute {
+	return EventResolveDispute{
+		ContractId:     escrow.ContractId,
+		Provider:       escrow.Provider,
+		Service:        escrow.Service.String(),
+		Client:         escrow.Client,
+		ProviderAmount: providerAmount,
+		ClientAmount:   clientAmount,
+		Arbiter:        arbiter,
+	}
+}
+
+func NewReleaseEscrowEvent(escrow ContractEscrow, amount cosmos.Coin) EventReleaseEscrow {
+	return EventReleaseEscrow{
+		ContractId: escrow.ContractId,
+		Provider:   escrow.Provider,
+		Service:    escrow.Service.String(),
+		Amount:     amount,
+	}
+}
+
+func NewReleaseVestedRewardsEvent(addr cosmos.AccAddress, amount cosmos.Coins) EventReleaseVestedRewards {
+	return EventReleaseVestedRewards{
+		Address: addr,
+		Amount:  amount,
+	}
+}
+
+func NewProviderIncentiveEvent(provider *Provider, openContracts int64, reward cosmos.Coin) EventProviderIncentive {
+	return EventProviderIncentive{
+		Provider:      provider.PubKey,
+		Service:       provider.Service.String(),
+		Bond:          provider.Bond,
+		OpenContracts: openContracts,
+		Reward:        reward,
+	}
+}
+
+func NewArbiterEvent(arbiter cosmos.AccAddress, allowed bool) EventArbiter {
+	return EventArbiter{
+		Arbiter: arbiter,
+		Allowed: allowed,
+	}
+}
+
+func NewContractRenewalEvent(deposit cosmos.Int, contract *Contract) EventContractRenewal {
+	return EventContractRenewal{
+		ContractId: contract.Id,
+		Provider:   contract.Provider,
+		Service:    contract.Service.String(),
+		Client:     contract.Client,
+		Deposit:    deposit,
+		Expiration: contract.Expiration(),
+	}
+}
+
+func NewDepositContractEvent(deposit cosmos.Int, contract *Contract) EventDepositContract {
+	return EventDepositContract{
+		ContractId: contract.Id,
+		Provider:   contract.Provider,
+		Service:    contract.Service.String(),
+		Client:     contract.Client,
+		Deposit:    deposit,
+		Expiration: contract.Expiration(),
+	}
+}
+
+func NewAffiliatePaymentEvent(paid cosmos.Coin, contract *Contract) EventAffiliatePayment {
+	return EventAffiliatePayment{
+		ContractId: contract.Id,
+		Provider:   contract.Provider,
+		Service:    contract.Service.String(),
+		Affiliate:  contract.Affiliate,
+		Paid:       paid,
+	}
+}
+
+func NewEscrowAffiliatePaymentEvent(paid cosmos.Coin, escrow ContractEscrow) EventAffiliatePayment {
+	return EventAffiliatePayment{
+		ContractId: escrow.ContractId,
+		Provider:   escrow.Provider,
+		Service:    escrow.Service.String(),
+		Affiliate:  escrow.Affiliate,
+		Paid:       paid,
+	}
+}
+
+func NewProviderUptimeEvent(online bool, uptime *ProviderUptime) EventProviderUptime {
+	return EventProviderUptime{
+		Provider:       uptime.PubKey,
+		Service:        uptime.Service.String(),
+		Online:         online,
+		CyclesObserved: uptime.CyclesObserved,
+		CyclesOnline:   uptime.CyclesOnline,
+	}
+}
+
+func NewContractGracePeriodEvent(contract *Contract) EventContractGracePeriod {
+	return EventContractGracePeriod{
+		ContractId: contract.Id,
+		Provider:   contract.Provider,
+		Service:    contract.Service.String(),
+		Client:     contract.Client,
+		GraceEnd:   contract.GraceEnd,
+	}
+}
+
+func NewRateChangeProposalEvent(contract *Contract) EventRateChangeProposal {
+	return EventRateChangeProposal{
+		ContractId: contract.Id,
+		Provider:   contract.Provider,
+		Rate:       contract.ProposedRate,
+	}
+}
+
+func NewRateChangeEvent(contract *Contract) EventRateChange {
+	return EventRateChange{
+		ContractId: contract.Id,
+		Client:     contract.Client,
+		PriorRate:  contract.PriorRate,
+		Rate:       contract.Rate,
+		Height:     contract.RateChangeHeight,
+	}
+}
+
+func NewPauseContractEvent(contract *Contract) EventPauseContract {
+	return EventPauseContract{
+		ContractId: contract.Id,
+		Provider:   contract.Provider,
+		Height:     contract.PausedHeight,
+		ByClient:   contract.PausedByClient,
+	}
+}
+
+func NewResumeContractEvent(contract *Contract) EventResumeContract {
+	return EventResumeContract{
+		ContractId:   contract.Id,
+		Provider:     contract.Provider,
+		PausedBlocks: contract.PausedBlocks,
+		Expiration:   contract.Expiration(),
+	}
+}
+
+func NewReserveTaxSplitEvent(denom string, reserve, communityPool cosmos.Int, contract *Contract) EventReserveTaxSplit {
+	return EventReserveTaxSplit{
+		ContractId:    contract.Id,
+		Denom:         denom,
+		Reserve:       reserve,
+		CommunityPool: communityPool,
+	}
+}
+
+func NewReserveFeesEvent(fees cosmos.Coins) EventReserveFees {
+	return EventReserveFees{
+		Fees: fees,
+	}
+}
+
+func NewModProviderMetadataEvent(provider *Provider) EventModProviderMetadata {
+	return EventModProviderMetadata{
+		Provider:      provider.PubKey,
+		Service:       provider.Service.String(),
+		MetadataUri:   provider.MetadataUri,
+		MetadataHash:  provider.MetadataHash,
+		MetadataNonce: provider.MetadataNonce,
 	}
 }
@@ -1,5 +1,7 @@
 package types
 
+import "fmt"
+
 // this line is used by starport scaffolding # genesis/types/import
 
 // DefaultIndex is the default global index
@@ -21,5 +23,44 @@ func DefaultGenesis() *GenesisState {
 func (gs GenesisState) Validate() error {
 	// this line is used by starport scaffolding # genesis/types/validate
 
+	// contracts opened after the import would otherwise overwrite the
+	// imported contracts with the same id
+	seen := make(map[uint64]bool, len(gs.Contracts))
+	for _, contract := range gs.Contracts {
+		if seen[contract.Id] {
+			return fmt.Errorf("duplicate contract id %d", contract.Id)
+		}
+		seen[contract.Id] = true
+		if contract.Id >= gs.NextContractId {
+			return fmt.Errorf("contract id %d is not below the next contract id %d", contract.Id, gs.NextContractId)
+		}
+	}
+
+	// as with contracts, providers bonded after the import would otherwise be
+	// assigned the ids of imported providers
+	seenProviders := make(map[uint64]bool, len(gs.Providers))
+	for _, provider := range gs.Providers {
+		if provider.Id == 0 {
+			continue
+		}
+		if seenProviders[provider.Id] {
+			return fmt.Errorf("duplicate provider id %d", provider.Id)
+		}
+		seenProviders[provider.Id] = true
+		if provider.Id >= gs.NextProviderId {
+			return fmt.Errorf("provider id %d is not below the next provider id %d", provider.Id, gs.NextProviderId)
+		}
+	}
+
+	for _, snapshot := range gs.ReserveSnapshots {
+		if snapshot.Height <= 0 {
+			return fmt.Errorf("reserve snapshot has an invalid height %d", snapshot.Height)
+		}
+	}
+
+	if err := gs.EmissionSchedule.Validate(); err != nil {
+		return err
+	}
+
 	return gs.Params.Validate()
 }
@@ -27,6 +27,60 @@ func TestGenesisState_Validate(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			desc: "contract ids below the next contract id",
+			genState: &types.GenesisState{
+				Contracts:      []types.Contract{{Id: 0}, {Id: 1}},
+				NextContractId: 2,
+			},
+			valid: true,
+		},
+		{
+			desc: "contract id not below the next contract id",
+			genState: &types.GenesisState{
+				Contracts:      []types.Contract{{Id: 0}, {Id: 2}},
+				NextContractId: 2,
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicate contract id",
+			genState: &types.GenesisState{
+				Contracts:      []types.Contract{{Id: 1}, {Id: 1}},
+				NextContractId: 2,
+			},
+			valid: false,
+		},
+		{
+			desc: "provider id not below the next provider id",
+			genState: &types.GenesisState{
+				Providers:      []types.Provider{{Id: 1}, {Id: 0}},
+				NextProviderId: 1,
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicate provider id",
+			genState: &types.GenesisState{
+				Providers:      []types.Provider{{Id: 1}, {Id: 1}},
+				NextProviderId: 2,
+			},
+			valid: false,
+		},
+		{
+			desc: "invalid reserve snapshot height",
+			genState: &types.GenesisState{
+				ReserveSnapshots: []types.ReserveSnapshot{{Height: 0}},
+			},
+			valid: false,
+		},
+		{
+			desc: "invalid emission schedule",
+			genState: &types.GenesisState{
+				EmissionSchedule: types.EmissionSchedule{Epochs: []types.EmissionEpoch{{StartHeight: 10, EmissionCurve: 0}}},
+			},
+			valid: false,
+		},
 		// this line is used by starport scaffolding # types/genesis/testcase
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+)
+
+// ContractHooks are called by the arkeo keeper as a contract moves through its
+// lifecycle, so other modules can react to contracts without forking the
+// manager. An error returned by a hook fails the action that triggered it.
+type ContractHooks interface {
+	// AfterContractOpened is called once a new contract has been saved
+	AfterContractOpened(ctx cosmos.Context, contract Contract) error
+	// AfterContractSettled is called once a contract has been finally
+	// settled, its provider paid and the remaining deposit refunded
+	AfterContractSettled(ctx cosmos.Context, contract Contract) error
+	// AfterContractClosed is called once a contract has been closed early,
+	// by either its client or its provider
+	AfterContractClosed(ctx cosmos.Context, contract Contract) error
+}
+
+// MultiContractHooks combines multiple contract hooks, all hooks are run in
+// the order they are given
+type MultiContractHooks []ContractHooks
+
+var _ ContractHooks = MultiContractHooks{}
+
+func NewMultiContractHooks(hooks ...ContractHooks) MultiContractHooks {
+	return hooks
+}
+
+func (h MultiContractHooks) AfterContractOpened(ctx cosmos.Context, contract Contract) error {
+	for i := range h {
+		if err := h[i].AfterContractOpened(ctx, contract); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h MultiContractHooks) AfterContractSettled(ctx cosmos.Context, contract Contract) error {
+	for i := range h {
+		if err := h[i].AfterContractSettled(ctx, contract); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h MultiContractHooks) AfterContractClosed(ctx cosmos.Context, contract Contract) error {
+	for i := range h {
+		if err := h[i].AfterContractClosed(ctx, contract); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	fmt "fmt"
 	"strconv"
+	"time"
 
 	"github.com/arkeonetwork/arkeo/common"
 	"github.com/arkeonetwork/arkeo/common/cosmos"
@@ -23,14 +24,164 @@ func (provider Provider) Key() string {
 	return fmt.Sprintf("%s/%s", provider.PubKey, provider.Service)
 }
 
+func NewProviderStats(pubkey common.PubKey, service common.Service) ProviderStats {
+	return ProviderStats{
+		PubKey:        pubkey,
+		Service:       service,
+		SettledAmount: cosmos.ZeroInt(),
+	}
+}
+
+func NewProviderUptime(pubkey common.PubKey, service common.Service) ProviderUptime {
+	return ProviderUptime{
+		PubKey:  pubkey,
+		Service: service,
+	}
+}
+
+func (uptime ProviderUptime) Key() string {
+	return fmt.Sprintf("%s/%s", uptime.PubKey, uptime.Service)
+}
+
+// HasVoted returns whether the validator already reported on the provider in
+// the current cycle
+func (uptime ProviderUptime) HasVoted(val cosmos.ValAddress) bool {
+	for _, voter := range uptime.OnlineVotes {
+		if voter.Equals(val) {
+			return true
+		}
+	}
+	for _, voter := range uptime.OfflineVotes {
+		if voter.Equals(val) {
+			return true
+		}
+	}
+	return false
+}
+
+// Score returns the share of the observed cycles the provider was online, one
+// until it has been observed for minObservations cycles so new providers are
+// not penalised
+func (uptime ProviderUptime) Score(minObservations int64) cosmos.Dec {
+	if uptime.CyclesObserved == 0 || int64(uptime.CyclesObserved) < minObservations {
+		return cosmos.NewDec(1)
+	}
+	return cosmos.NewDec(int64(uptime.CyclesOnline)).QuoInt64(int64(uptime.CyclesObserved))
+}
+
+func NewClientStats(pubkey common.PubKey) ClientStats {
+	return ClientStats{
+		PubKey:     pubkey,
+		TotalSpent: cosmos.NewCoins(),
+	}
+}
+
+func NewProviderEarning(pubkey common.PubKey, service common.Service, contractId uint64, height int64, denom string) ProviderEarning {
+	return ProviderEarning{
+		PubKey:     pubkey,
+		Service:    service,
+		ContractId: contractId,
+		Height:     height,
+		Amount:     cosmos.NewCoin(denom, cosmos.ZeroInt()),
+		ReserveTax: cosmos.NewCoin(denom, cosmos.ZeroInt()),
+	}
+}
+
+func NewProviderSlash(pubkey common.PubKey, service common.Service, height int64, reason string) ProviderSlash {
+	return ProviderSlash{
+		PubKey:  pubkey,
+		Service: service,
+		Height:  height,
+		Amount:  cosmos.ZeroInt(),
+		Reason:  reason,
+	}
+}
+
+func NewContractEscrow(contract Contract, refundAddress cosmos.AccAddress) ContractEscrow {
+	return ContractEscrow{
+		ContractId:    contract.Id,
+		Provider:      contract.Provider,
+		Service:       contract.Service,
+		Client:        contract.Client,
+		RefundAddress: refundAddress,
+		Amount:        cosmos.NewCoin(contract.Rate.Denom, cosmos.ZeroInt()),
+		Affiliate:     contract.Affiliate,
+		AffiliateFee:  contract.AffiliateFee,
+	}
+}
+
+func (escrow ContractEscrow) IsEmpty() bool {
+	return escrow.Provider.IsEmpty()
+}
+
+// NextReleaseHeight returns the height the earliest tranche is released at,
+// zero when there are none
+func (escrow ContractEscrow) NextReleaseHeight() int64 {
+	var height int64
+	for _, tranche := range escrow.Tranches {
+		if height == 0 || tranche.ReleaseHeight < height {
+			height = tranche.ReleaseHeight
+		}
+	}
+	return height
+}
+
+func NewRewardVesting(addr cosmos.AccAddress) RewardVesting {
+	return RewardVesting{
+		Address:  addr,
+		Unvested: cosmos.NewCoins(),
+	}
+}
+
+// Vested returns the unvested rewards that have vested by the given height,
+// the rewards vest linearly from the last height to the end height
+func (vesting RewardVesting) Vested(height int64) cosmos.Coins {
+	if height >= vesting.EndHeight {
+		return vesting.Unvested
+	}
+	elapsed := height - vesting.LastHeight
+	if elapsed <= 0 {
+		return cosmos.NewCoins()
+	}
+	remaining := vesting.EndHeight - vesting.LastHeight
+	vested := cosmos.NewCoins()
+	for _, coin := range vesting.Unvested {
+		vested = vested.Add(cosmos.NewCoin(coin.Denom, coin.Amount.MulRaw(elapsed).QuoRaw(remaining)))
+	}
+	return vested
+}
+
+func NewBondUnits(validator cosmos.ValAddress) BondUnits {
+	return BondUnits{
+		Validator: validator,
+		Bond:      cosmos.ZeroInt(),
+		Units:     cosmos.ZeroInt(),
+	}
+}
+
+func (stats ProviderStats) Key() string {
+	return fmt.Sprintf("%s/%s", stats.PubKey, stats.Service)
+}
+
+// Score returns the share of the provider's finished contracts that were
+// served to the end, zero when it has no history yet
+func (stats ProviderStats) Score() cosmos.Dec {
+	total := stats.ContractsServed + stats.Breaches
+	if total == 0 {
+		return cosmos.ZeroDec()
+	}
+	return cosmos.NewDec(int64(stats.ContractsServed)).QuoInt64(int64(total))
+}
+
 func NewContract(provider common.PubKey, service common.Service, client common.PubKey) Contract {
 	return Contract{
-		Provider: provider,
-		Service:  service,
-		Client:   client,
-		Delegate: common.EmptyPubKey,
-		Deposit:  cosmos.ZeroInt(),
-		Paid:     cosmos.ZeroInt(),
+		Provider:            provider,
+		Service:             service,
+		Client:              client,
+		Delegate:            common.EmptyPubKey,
+		Deposit:             cosmos.ZeroInt(),
+		Paid:                cosmos.ZeroInt(),
+		AccruedAtRateChange: cosmos.ZeroInt(),
 	}
 }
 
@@ -49,8 +200,19 @@ func (contract Contract) GetSpender() common.PubKey {
 // Open -> Expired -> Settled
 // for Subscription contracts, they expire and settle on the same block
 // for PayAsYouGo contracts, they can expire and settle on different blocks, based on the settlement duration
+// blocks spent paused push the expiration back, once the contract is resumed
 func (contract Contract) Expiration() int64 {
-	return contract.Height + contract.Duration
+	return contract.Height + contract.Duration + contract.PausedBlocks
+}
+
+// ExpirationAt returns the expiration of the contract as of the given height.
+// A paused contract does not expire, its expiration is pushed back for as long
+// as the pause lasts.
+func (contract Contract) ExpirationAt(height int64) int64 {
+	if contract.IsPaused() && height > contract.PausedHeight {
+		return contract.Expiration() + height - contract.PausedHeight
+	}
+	return contract.Expiration()
 }
 
 // SettlementPeriodEnd returns the end of the settlement period
@@ -65,6 +227,23 @@ func (contract Contract) SettlementPeriodEnd() int64 {
 	return contract.Expiration()
 }
 
+// ClosingHeight returns the height ContractEndBlock closes the contract at,
+// the end of its grace period while it is in one, otherwise the end of its
+// settlement period
+func (contract Contract) ClosingHeight() int64 {
+	if contract.GraceEnd > 0 {
+		return contract.GraceEnd
+	}
+	return contract.SettlementPeriodEnd()
+}
+
+// RenewalPeriod returns the number of blocks the contract renews for, its
+// original duration. Duration accumulates with each renewal and deposit top
+// up, neither of which count towards the period.
+func (contract Contract) RenewalPeriod() int64 {
+	return (contract.Duration - contract.DepositBlocks) / (contract.Renewals + 1)
+}
+
 func (contract Contract) IsPayAsYouGo() bool {
 	return contract.Type == ContractType_PAY_AS_YOU_GO
 }
@@ -85,7 +264,7 @@ func (contract Contract) IsOpen(height int64) bool {
 	if contract.IsEmpty() {
 		return false
 	}
-	if contract.Expiration() < height {
+	if contract.ExpirationAt(height) < height {
 		return false
 	}
 	if contract.SettlementHeight > 0 && contract.SettlementHeight < height {
@@ -117,6 +296,53 @@ func (contract Contract) IsSettlementPeriod(height int64) bool {
 	return contract.Expiration() < height && contract.SettlementPeriodEnd() > height
 }
 
+// AllowedQueries returns the most queries the contract's queries per minute
+// allow from its last claim, or its start, up to the given height, at the
+// given average block time in milliseconds. Queries can only be made while
+// the contract is open. It returns a negative number when the contract has
+// no rate to enforce.
+func (contract Contract) AllowedQueries(height, blockTime int64) cosmos.Int {
+	if contract.QueriesPerMinute <= 0 || blockTime <= 0 {
+		return cosmos.NewInt(-1)
+	}
+	from := contract.Height
+	if contract.NonceHeight > from {
+		from = contract.NonceHeight
+	}
+	to := height
+	if expiration := contract.Expiration(); expiration < to {
+		to = expiration
+	}
+	// the block of the last claim is counted again, queries made in it
+	// may land after the claim
+	blocks := to - from + 1
+	if blocks < 1 {
+		blocks = 1
+	}
+	return MaxQueries(blocks, blockTime, contract.QueriesPerMinute)
+}
+
+// MaxQueries returns the most queries that can be made over the given number
+// of blocks at the given queries per minute and average block time in
+// milliseconds
+func MaxQueries(blocks, blockTime, queriesPerMinute int64) cosmos.Int {
+	millis := cosmos.NewInt(blocks).MulRaw(blockTime).MulRaw(queriesPerMinute)
+	// round up, a partial minute still allows a query
+	return millis.AddRaw(time.Minute.Milliseconds() - 1).QuoRaw(time.Minute.Milliseconds())
+}
+
+// HasProposedRate returns true when the provider has proposed a rate change
+// that the client has not accepted yet
+func (contract Contract) HasProposedRate() bool {
+	return contract.ProposedRate.Denom != "" && !contract.ProposedRate.Amount.IsNil() && contract.ProposedRate.Amount.IsPositive()
+}
+
+// IsPaused returns true while the provider or client has the subscription
+// paused
+func (contract Contract) IsPaused() bool {
+	return contract.PausedHeight > 0
+}
+
 func (contract Contract) IsEmpty() bool {
 	return contract.Height == 0
 }
@@ -129,6 +355,15 @@ func (contract Contract) ClientAddress() cosmos.AccAddress {
 	return addr
 }
 
+// RefundTo returns the account the rest of the deposit is refunded to,
+// the client unless the contract was opened with a refund address
+func (contract Contract) RefundTo() (cosmos.AccAddress, error) {
+	if !contract.RefundAddress.Empty() {
+		return contract.RefundAddress, nil
+	}
+	return contract.Client.GetMyAddress()
+}
+
 func (contractType *ContractType) UnmarshalJSON(b []byte) error {
 	var item interface{}
 	if err := json.Unmarshal(b, &item); err != nil {
@@ -147,6 +382,17 @@ func (exp *ContractExpirationSet) Append(id uint64) {
 	exp.ContractSet.ContractIds = append(exp.ContractSet.ContractIds, id)
 }
 
+// Remove drops the given contract from the expiration set
+func (exp *ContractExpirationSet) Remove(id uint64) {
+	ids := make([]uint64, 0, len(exp.ContractSet.ContractIds))
+	for _, contractId := range exp.ContractSet.ContractIds {
+		if contractId != id {
+			ids = append(ids, contractId)
+		}
+	}
+	exp.ContractSet.ContractIds = ids
+}
+
 func (contractAuth *ContractAuthorization) UnmarshalJSON(b []byte) error {
 	var item interface{}
 	if err := json.Unmarshal(b, &item); err != nil {
@@ -6,6 +6,7 @@ const (
 	ReserveName  = "arkeo-reserve"
 	ProviderName = "providers"
 	ContractName = "contracts"
+	VestingName  = "arkeo-vesting"
 
 	// StoreKey defines the primary module store key
 	StoreKey = ModuleName
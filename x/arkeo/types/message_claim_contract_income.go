@@ -5,6 +5,7 @@ import (
 
 	"cosmossdk.io/errors"
 
+	"github.com/arkeonetwork/arkeo/common"
 	"github.com/arkeonetwork/arkeo/common/cosmos"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -62,5 +63,11 @@ func (msg *MsgClaimContractIncome) ValidateBasic() error {
 		return errors.Wrap(ErrClaimContractIncomeBadNonce, "")
 	}
 
+	if msg.Service != "" {
+		if _, err := common.NewService(msg.Service); err != nil {
+			return errors.Wrapf(ErrInvalidService, "invalid service (%s): %s", msg.Service, err)
+		}
+	}
+
 	return nil
 }
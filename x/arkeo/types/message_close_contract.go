@@ -40,3 +40,39 @@ func (msg *MsgCloseContract) GetSignBytes() []byte {
 func (msg *MsgCloseContract) ValidateBasic() error {
 	return nil
 }
+
+const TypeMsgCloseContractByProvider = "close_contract_by_provider"
+
+var _ sdk.Msg = &MsgCloseContractByProvider{}
+
+func NewMsgCloseContractByProvider(creator cosmos.AccAddress, contractId uint64) *MsgCloseContractByProvider {
+	return &MsgCloseContractByProvider{
+		Creator:    creator,
+		ContractId: contractId,
+	}
+}
+
+func (msg *MsgCloseContractByProvider) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgCloseContractByProvider) Type() string {
+	return TypeMsgCloseContractByProvider
+}
+
+func (msg *MsgCloseContractByProvider) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgCloseContractByProvider) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgCloseContractByProvider) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgCloseContractByProvider) ValidateBasic() error {
+	return nil
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgDepositContract = "deposit_contract"
+
+var _ sdk.Msg = &MsgDepositContract{}
+
+func NewMsgDepositContract(creator cosmos.AccAddress, contractId uint64, deposit cosmos.Int) *MsgDepositContract {
+	return &MsgDepositContract{
+		Creator:    creator,
+		ContractId: contractId,
+		Deposit:    deposit,
+	}
+}
+
+func (msg *MsgDepositContract) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgDepositContract) Type() string {
+	return TypeMsgDepositContract
+}
+
+func (msg *MsgDepositContract) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgDepositContract) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgDepositContract) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgDepositContract) ValidateBasic() error {
+	if msg.Deposit.IsNil() || !msg.Deposit.IsPositive() {
+		return errors.Wrapf(ErrInsufficientDeposit, "deposit must be greater than zero")
+	}
+	return nil
+}
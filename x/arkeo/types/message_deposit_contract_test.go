@@ -0,0 +1,18 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositContractValidateBasic(t *testing.T) {
+	acct := GetRandomBech32Addr()
+
+	msg := NewMsgDepositContract(acct, 1, cosmos.ZeroInt())
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInsufficientDeposit)
+
+	msg = NewMsgDepositContract(acct, 1, cosmos.NewInt(100))
+	require.NoError(t, msg.ValidateBasic())
+}
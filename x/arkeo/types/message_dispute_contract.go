@@ -0,0 +1,55 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgDisputeContract = "dispute_contract"
+
+// MaxDisputeReasonLength is the max size, in bytes, of the reason given for a
+// dispute
+const MaxDisputeReasonLength = 256
+
+var _ sdk.Msg = &MsgDisputeContract{}
+
+func NewMsgDisputeContract(creator cosmos.AccAddress, contractId uint64, reason string) *MsgDisputeContract {
+	return &MsgDisputeContract{
+		Creator:    creator,
+		ContractId: contractId,
+		Reason:     reason,
+	}
+}
+
+func (msg *MsgDisputeContract) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgDisputeContract) Type() string {
+	return TypeMsgDisputeContract
+}
+
+func (msg *MsgDisputeContract) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgDisputeContract) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgDisputeContract) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgDisputeContract) ValidateBasic() error {
+	if msg.Reason == "" {
+		return errors.Wrapf(ErrInvalidDispute, "a dispute must give a reason")
+	}
+	if len(msg.Reason) > MaxDisputeReasonLength {
+		return errors.Wrapf(ErrInvalidDispute, "reason is %d bytes, max is %d", len(msg.Reason), MaxDisputeReasonLength)
+	}
+	return nil
+}
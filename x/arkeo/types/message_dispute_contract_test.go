@@ -0,0 +1,18 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisputeContractValidateBasic(t *testing.T) {
+	msg := NewMsgDisputeContract(GetRandomBech32Addr(), 1, "provider served stale blocks")
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.Reason = ""
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidDispute)
+	msg.Reason = strings.Repeat("a", MaxDisputeReasonLength+1)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidDispute)
+}
@@ -119,5 +119,24 @@ func (msg *MsgModProvider) ValidateBasic() error {
 		return errors.Wrapf(ErrInvalidModProviderRate, "all pay-as-you-go rates must be positive")
 	}
 
+	if err := ValidateRateTiers(msg.SubscriptionRateTiers, subRate); err != nil {
+		return errors.Wrapf(err, "invalid subscription rate tiers")
+	}
+
+	if err := ValidateRateTiers(msg.PayAsYouGoRateTiers, payRate); err != nil {
+		return errors.Wrapf(err, "invalid pay-as-you-go rate tiers")
+	}
+
+	if msg.MaxOpenContracts < 0 {
+		return errors.Wrapf(ErrInvalidModProviderMaxOpenContracts, "max open contracts cannot be negative")
+	}
+
+	if msg.FreeTier.MaxDuration < 0 || msg.FreeTier.MaxQueries < 0 {
+		return errors.Wrapf(ErrInvalidModProviderFreeTier, "free tier limits cannot be negative")
+	}
+	if msg.FreeTier.MaxDuration > msg.MaxContractDuration {
+		return errors.Wrapf(ErrInvalidModProviderFreeTier, "free tier duration is longer than the max contract duration (%d/%d)", msg.FreeTier.MaxDuration, msg.MaxContractDuration)
+	}
+
 	return nil
 }
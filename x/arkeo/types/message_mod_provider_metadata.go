@@ -0,0 +1,110 @@
+package types
+
+import (
+	"crypto/sha256"
+	"strings"
+	"unicode"
+
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TypeMsgModProviderMetadata = "mod_provider_metadata"
+
+	// MaxMetadataURILength keeps metadata URIs from bloating the chain
+	MaxMetadataURILength = 100
+)
+
+// metadataURISchemes are the schemes a metadata document may be served from
+var metadataURISchemes = []string{"https://", "http://", "ipfs://"}
+
+var _ sdk.Msg = &MsgModProviderMetadata{}
+
+func NewMsgModProviderMetadata(creator cosmos.AccAddress, provider common.PubKey, service, metadataUri string, metadataHash []byte, metadataNonce uint64) *MsgModProviderMetadata {
+	return &MsgModProviderMetadata{
+		Creator:       creator,
+		Provider:      provider,
+		Service:       service,
+		MetadataUri:   metadataUri,
+		MetadataHash:  metadataHash,
+		MetadataNonce: metadataNonce,
+	}
+}
+
+func (msg *MsgModProviderMetadata) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgModProviderMetadata) Type() string {
+	return TypeMsgModProviderMetadata
+}
+
+func (msg *MsgModProviderMetadata) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgModProviderMetadata) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgModProviderMetadata) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgModProviderMetadata) ValidateBasic() error {
+	if _, err := common.NewPubKey(msg.Provider.String()); err != nil {
+		return errors.Wrapf(ErrInvalidPubKey, "invalid provider pubkey (%s): %s", msg.Provider, err)
+	}
+
+	if _, err := common.NewService(msg.Service); err != nil {
+		return errors.Wrapf(ErrInvalidService, "invalid service (%s): %s", msg.Service, err)
+	}
+
+	signer := msg.MustGetSigner()
+	provider, err := msg.Provider.GetMyAddress()
+	if err != nil {
+		return err
+	}
+	if !signer.Equals(provider) {
+		return errors.Wrapf(ErrProviderBadSigner, "Signer: %s, Provider Address: %s", msg.GetSigners(), provider)
+	}
+
+	if err := ValidateMetadataURI(msg.MetadataUri); err != nil {
+		return err
+	}
+
+	if len(msg.MetadataHash) != sha256.Size {
+		return errors.Wrapf(ErrInvalidMetadataHash, "must be a %d byte sha256 hash, got %d bytes", sha256.Size, len(msg.MetadataHash))
+	}
+
+	if msg.MetadataNonce == 0 {
+		return errors.Wrapf(ErrInvalidMetadataNonce, "metadata nonce cannot be zero")
+	}
+
+	return nil
+}
+
+// ValidateMetadataURI checks a metadata URI is short, has no whitespace or
+// control characters, and uses a known scheme. It is checked by hand rather
+// than with net/url, whose parsing has changed between go versions.
+func ValidateMetadataURI(uri string) error {
+	if len(uri) > MaxMetadataURILength {
+		return errors.Wrapf(ErrInvalidModProviderMetdataURI, "length is too long (%d/%d)", len(uri), MaxMetadataURILength)
+	}
+	for _, r := range uri {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return errors.Wrapf(ErrInvalidModProviderMetdataURI, "uri cannot contain whitespace or control characters")
+		}
+	}
+	for _, scheme := range metadataURISchemes {
+		if strings.HasPrefix(strings.ToLower(uri), scheme) && len(uri) > len(scheme) {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrInvalidModProviderMetdataURI, "uri (%s) must start with one of %s", uri, strings.Join(metadataURISchemes, ", "))
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModProviderMetadataValidateBasic(t *testing.T) {
+	pubkey := GetRandomPubKey()
+	acct, err := pubkey.GetMyAddress()
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte(`{"version":"1.0"}`))
+
+	// happy path
+	msg := NewMsgModProviderMetadata(acct, pubkey, common.BTCService.String(), "https://mad.hatter.net/metadata.json", hash[:], 1)
+	require.NoError(t, msg.ValidateBasic())
+
+	// signer must be the provider
+	msg.Creator, err = GetRandomPubKey().GetMyAddress()
+	require.NoError(t, err)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrProviderBadSigner)
+	msg.Creator = acct
+
+	// hash must be sha256
+	msg.MetadataHash = hash[:16]
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidMetadataHash)
+	msg.MetadataHash = hash[:]
+
+	msg.MetadataNonce = 0
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidMetadataNonce)
+	msg.MetadataNonce = 1
+
+	// content addressed documents are fine too
+	msg.MetadataUri = "ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+	require.NoError(t, msg.ValidateBasic())
+
+	for _, uri := range []string{
+		"",
+		"mad.hatter.net/metadata.json",
+		"ftp://mad.hatter.net/metadata.json",
+		"https://",
+		"https://mad.hatter.net/meta data.json",
+		"https://mad.hatter.net/" + strings.Repeat("a", MaxMetadataURILength),
+	} {
+		msg.MetadataUri = uri
+		require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidModProviderMetdataURI, uri)
+	}
+}
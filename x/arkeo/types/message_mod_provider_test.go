@@ -31,6 +31,26 @@ func TestModProviderValidateBasic(t *testing.T) {
 	err = msg.ValidateBasic()
 	require.NoError(t, err)
 
+	// free tier cannot outlast the provider's contracts
+	msg.FreeTier = FreeTier{MaxDuration: 31, MaxQueries: 100}
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidModProviderFreeTier)
+
+	msg.FreeTier = FreeTier{MaxDuration: 30, MaxQueries: -1}
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidModProviderFreeTier)
+
+	msg.FreeTier = FreeTier{MaxDuration: 30, MaxQueries: 100}
+	err = msg.ValidateBasic()
+	require.NoError(t, err)
+
+	msg.MaxOpenContracts = -1
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidModProviderMaxOpenContracts)
+	msg.MaxOpenContracts = 10
+	err = msg.ValidateBasic()
+	require.NoError(t, err)
+
 	// URI is too long
 	msg.MetadataUri = "http://mad.hatter.net/testsdkfjlsdkfjlsdfjsldfjkdsljflsdjfkdsjflsdjkfsdjlfsdjkfldsjflksjdfljsdlkfjsdlkfjdsklfjsdlkfjsdkljflksdjfklsdjflskdjflksdjflksdjfldsjflksdjfldskjflsdkfjsdlkjfksdljflskdjfsdlkjfdksljflsdkjfkldsjfsdlkfjlksdjfklsdjflkdsjfklsdjfsdkljflksdjflksdfjdklsjfl?foo=baz"
 	err = msg.ValidateBasic()
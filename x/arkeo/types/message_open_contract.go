@@ -7,6 +7,7 @@ import (
 
 	"github.com/arkeonetwork/arkeo/common"
 	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	types "github.com/cosmos/cosmos-sdk/types"
@@ -80,6 +81,17 @@ func (msg *MsgOpenContract) ValidateBasic() error {
 		return errors.Wrapf(ErrInvalidPubKey, "invalid pubkey (%s)", err)
 	}
 
+	// verify delegate, whose signatures are accepted for claims in place of
+	// the client's so the client key can stay cold
+	if !msg.Delegate.IsEmpty() {
+		if _, err := common.NewPubKey(msg.Delegate.String()); err != nil {
+			return errors.Wrapf(ErrInvalidPubKey, "invalid delegate pubkey (%s)", err)
+		}
+		if msg.Delegate.Equals(msg.Client) {
+			return errors.Wrapf(ErrInvalidPubKey, "delegate cannot be the client")
+		}
+	}
+
 	signer := msg.MustGetSigner()
 	client, err := msg.Client.GetMyAddress()
 	if err != nil {
@@ -101,8 +113,10 @@ func (msg *MsgOpenContract) ValidateBasic() error {
 		return fmt.Errorf("queries per minute must be greater than zero")
 	}
 
-	if !msg.Rate.Amount.IsPositive() {
-		return errors.Wrapf(ErrOpenContractRate, "contract rate cannot be zero")
+	// a zero rate is only open to a provider's pay-as-you-go free tier, whose
+	// limits are enforced on chain
+	if !msg.Rate.Amount.IsPositive() && msg.ContractType != ContractType_PAY_AS_YOU_GO {
+		return errors.Wrapf(ErrOpenContractRate, "only pay-as-you-go contracts may have a zero rate")
 	}
 
 	if msg.SettlementDuration < 0 {
@@ -118,5 +132,41 @@ func (msg *MsgOpenContract) ValidateBasic() error {
 		return errors.Wrapf(ErrInvalidAuthorization, "pay-as-you-go contract cannot use open authorization")
 	}
 
+	if err := ValidateContractMemo(msg.Memo); err != nil {
+		return err
+	}
+
+	if msg.AutoRenew && msg.ContractType != ContractType_SUBSCRIPTION {
+		return errors.Wrapf(ErrInvalidContractType, "only subscription contracts can auto renew")
+	}
+
+	if msg.SettlementInterval < 0 {
+		return errors.Wrapf(ErrInvalidSettlementInterval, "settlement interval cannot be negative")
+	}
+	if msg.SettlementInterval > 0 && msg.ContractType != ContractType_SUBSCRIPTION {
+		return errors.Wrapf(ErrInvalidSettlementInterval, "only subscription contracts settle periodically")
+	}
+
+	if len(msg.RefundAddress) > 0 {
+		if err := sdk.VerifyAddressFormat(msg.RefundAddress); err != nil {
+			return errors.Wrapf(ErrInvalidRefundAddress, "%s", err)
+		}
+	}
+
+	if msg.AffiliateFee < 0 || msg.AffiliateFee > configs.MaxBasisPoints {
+		return errors.Wrapf(ErrInvalidAffiliate, "affiliate fee must be between 0 and %d basis points", configs.MaxBasisPoints)
+	}
+	if len(msg.Affiliate) > 0 {
+		if err := sdk.VerifyAddressFormat(msg.Affiliate); err != nil {
+			return errors.Wrapf(ErrInvalidAffiliate, "%s", err)
+		}
+	} else if msg.AffiliateFee > 0 {
+		return errors.Wrapf(ErrInvalidAffiliate, "affiliate fee set without an affiliate")
+	}
+
+	if !msg.MaxOpenCost.IsNil() && msg.MaxOpenCost.IsNegative() {
+		return errors.Wrapf(ErrOpenContractMaxCost, "max open cost cannot be negative")
+	}
+
 	return nil
 }
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/arkeonetwork/arkeo/common"
@@ -40,4 +41,71 @@ func TestBondProviderValidateBasic(t *testing.T) {
 	msg.ContractType = ContractType_PAY_AS_YOU_GO
 	err = msg.ValidateBasic()
 	require.ErrorIs(t, err, ErrInvalidAuthorization)
+
+	msg.Authorization = ContractAuthorization_STRICT
+	msg.Memo = strings.Repeat("a", MaxContractMemoLength+1)
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidContractMemo)
+
+	// only subscriptions can auto renew
+	msg.Memo = ""
+	msg.AutoRenew = true
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidContractType)
+
+	msg.ContractType = ContractType_SUBSCRIPTION
+	err = msg.ValidateBasic()
+	require.NoError(t, err)
+
+	// refunds may go to a third party
+	msg.RefundAddress = cosmos.AccAddress("bogus")
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidRefundAddress)
+
+	msg.RefundAddress, err = GetRandomPubKey().GetMyAddress()
+	require.NoError(t, err)
+	err = msg.ValidateBasic()
+	require.NoError(t, err)
+
+	// an affiliate fee needs an affiliate to pay
+	msg.AffiliateFee = 500
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidAffiliate)
+
+	msg.Affiliate = GetRandomBech32Addr()
+	msg.AffiliateFee = 10001
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidAffiliate)
+
+	msg.AffiliateFee = 500
+	err = msg.ValidateBasic()
+	require.NoError(t, err)
+
+	// only subscriptions settle periodically
+	msg.SettlementInterval = -1
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidSettlementInterval)
+
+	msg.SettlementInterval = 100
+	err = msg.ValidateBasic()
+	require.NoError(t, err)
+
+	msg.AutoRenew = false
+	msg.ContractType = ContractType_PAY_AS_YOU_GO
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidSettlementInterval)
+
+	// a delegate signs claims in place of the client
+	msg.SettlementInterval = 0
+	msg.Delegate = GetRandomPubKey()
+	err = msg.ValidateBasic()
+	require.NoError(t, err)
+
+	msg.Delegate = msg.Client
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidPubKey)
+
+	msg.Delegate = common.PubKey("bogus")
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidPubKey)
 }
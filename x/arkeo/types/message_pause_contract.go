@@ -0,0 +1,80 @@
+package types
+
+import (
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TypeMsgPauseContract  = "pause_contract"
+	TypeMsgResumeContract = "resume_contract"
+)
+
+var (
+	_ sdk.Msg = &MsgPauseContract{}
+	_ sdk.Msg = &MsgResumeContract{}
+)
+
+func NewMsgPauseContract(creator cosmos.AccAddress, contractId uint64) *MsgPauseContract {
+	return &MsgPauseContract{
+		Creator:    creator,
+		ContractId: contractId,
+	}
+}
+
+func (msg *MsgPauseContract) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgPauseContract) Type() string {
+	return TypeMsgPauseContract
+}
+
+func (msg *MsgPauseContract) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgPauseContract) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgPauseContract) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgPauseContract) ValidateBasic() error {
+	return nil
+}
+
+func NewMsgResumeContract(creator cosmos.AccAddress, contractId uint64) *MsgResumeContract {
+	return &MsgResumeContract{
+		Creator:    creator,
+		ContractId: contractId,
+	}
+}
+
+func (msg *MsgResumeContract) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgResumeContract) Type() string {
+	return TypeMsgResumeContract
+}
+
+func (msg *MsgResumeContract) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgResumeContract) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgResumeContract) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgResumeContract) ValidateBasic() error {
+	return nil
+}
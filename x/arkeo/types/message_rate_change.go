@@ -0,0 +1,155 @@
+package types
+
+import (
+	"fmt"
+
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TypeMsgProposeRateChange = "propose_rate_change"
+	TypeMsgAcceptRateChange  = "accept_rate_change"
+	TypeMsgRenegotiateRate   = "renegotiate_rate"
+)
+
+var (
+	_ sdk.Msg = &MsgProposeRateChange{}
+	_ sdk.Msg = &MsgAcceptRateChange{}
+	_ sdk.Msg = &MsgRenegotiateRate{}
+)
+
+func NewMsgProposeRateChange(creator cosmos.AccAddress, contractId uint64, rate cosmos.Coin) *MsgProposeRateChange {
+	return &MsgProposeRateChange{
+		Creator:    creator,
+		ContractId: contractId,
+		Rate:       rate,
+	}
+}
+
+func (msg *MsgProposeRateChange) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgProposeRateChange) Type() string {
+	return TypeMsgProposeRateChange
+}
+
+func (msg *MsgProposeRateChange) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgProposeRateChange) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgProposeRateChange) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgProposeRateChange) ValidateBasic() error {
+	return validateRateChange(msg.Rate)
+}
+
+func NewMsgAcceptRateChange(creator cosmos.AccAddress, contractId uint64, rate cosmos.Coin) *MsgAcceptRateChange {
+	return &MsgAcceptRateChange{
+		Creator:    creator,
+		ContractId: contractId,
+		Rate:       rate,
+	}
+}
+
+func (msg *MsgAcceptRateChange) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgAcceptRateChange) Type() string {
+	return TypeMsgAcceptRateChange
+}
+
+func (msg *MsgAcceptRateChange) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgAcceptRateChange) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgAcceptRateChange) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgAcceptRateChange) ValidateBasic() error {
+	return validateRateChange(msg.Rate)
+}
+
+func NewMsgRenegotiateRate(creator cosmos.AccAddress, contractId uint64, rate cosmos.Coin, deadline int64) *MsgRenegotiateRate {
+	return &MsgRenegotiateRate{
+		Creator:    creator,
+		ContractId: contractId,
+		Rate:       rate,
+		Deadline:   deadline,
+	}
+}
+
+func (msg *MsgRenegotiateRate) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgRenegotiateRate) Type() string {
+	return TypeMsgRenegotiateRate
+}
+
+func (msg *MsgRenegotiateRate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgRenegotiateRate) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgRenegotiateRate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetBytesToSign returns the bytes the client and provider sign to agree the
+// new rate. The height of the contract's last rate change is included, so the
+// signatures cannot be replayed once the rate has changed.
+func (msg *MsgRenegotiateRate) GetBytesToSign(rateChangeHeight int64) []byte {
+	return GetRateChangeBytesToSign(msg.ContractId, msg.Rate, rateChangeHeight, msg.Deadline)
+}
+
+func GetRateChangeBytesToSign(contractId uint64, rate cosmos.Coin, rateChangeHeight, deadline int64) []byte {
+	return []byte(fmt.Sprintf("%d:%s:%d:%d", contractId, rate, rateChangeHeight, deadline))
+}
+
+func (msg *MsgRenegotiateRate) ValidateBasic() error {
+	if err := validateRateChange(msg.Rate); err != nil {
+		return err
+	}
+	if msg.Deadline <= 0 {
+		return errors.Wrapf(ErrRateRenegotiation, "deadline must be greater than zero")
+	}
+	if len(msg.ClientSignature) == 0 || len(msg.ClientSignature) > 100 {
+		return errors.Wrapf(ErrRateRenegotiation, "bad client signature")
+	}
+	if len(msg.ProviderSignature) == 0 || len(msg.ProviderSignature) > 100 {
+		return errors.Wrapf(ErrRateRenegotiation, "bad provider signature")
+	}
+	return nil
+}
+
+func validateRateChange(rate cosmos.Coin) error {
+	if err := rate.Validate(); err != nil {
+		return errors.Wrapf(err, "invalid rate")
+	}
+	if !rate.Amount.IsPositive() {
+		return errors.Wrapf(ErrOpenContractRate, "contract rate cannot be zero")
+	}
+	return nil
+}
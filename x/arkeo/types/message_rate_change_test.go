@@ -0,0 +1,45 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateChangeValidateBasic(t *testing.T) {
+	// setup
+	pubkey := GetRandomPubKey()
+	acct, err := pubkey.GetMyAddress()
+	require.NoError(t, err)
+
+	propose := MsgProposeRateChange{
+		Creator:    acct,
+		ContractId: 50,
+		Rate:       cosmos.NewInt64Coin("uarkeo", 15),
+	}
+	require.NoError(t, propose.ValidateBasic())
+
+	propose.Rate = cosmos.NewInt64Coin("uarkeo", 0)
+	err = propose.ValidateBasic()
+	require.ErrorIs(t, err, ErrOpenContractRate)
+
+	accept := MsgAcceptRateChange{
+		Creator:    acct,
+		ContractId: 50,
+		Rate:       cosmos.NewInt64Coin("uarkeo", 15),
+	}
+	require.NoError(t, accept.ValidateBasic())
+
+	accept.Rate = cosmos.Coin{Denom: "uarkeo", Amount: cosmos.NewInt(-1)}
+	require.Error(t, accept.ValidateBasic())
+
+	renegotiate := NewMsgRenegotiateRate(acct, 50, cosmos.NewInt64Coin("uarkeo", 15), 100)
+	require.ErrorIs(t, renegotiate.ValidateBasic(), ErrRateRenegotiation)
+	renegotiate.ClientSignature = []byte("client")
+	renegotiate.ProviderSignature = []byte("provider")
+	require.NoError(t, renegotiate.ValidateBasic())
+
+	renegotiate.Deadline = 0
+	require.ErrorIs(t, renegotiate.ValidateBasic(), ErrRateRenegotiation)
+}
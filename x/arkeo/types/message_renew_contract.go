@@ -0,0 +1,42 @@
+package types
+
+import (
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgRenewContract = "renew_contract"
+
+var _ sdk.Msg = &MsgRenewContract{}
+
+func NewMsgRenewContract(creator cosmos.AccAddress, contractId uint64) *MsgRenewContract {
+	return &MsgRenewContract{
+		Creator:    creator,
+		ContractId: contractId,
+	}
+}
+
+func (msg *MsgRenewContract) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgRenewContract) Type() string {
+	return TypeMsgRenewContract
+}
+
+func (msg *MsgRenewContract) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgRenewContract) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgRenewContract) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgRenewContract) ValidateBasic() error {
+	return nil
+}
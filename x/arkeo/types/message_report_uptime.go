@@ -0,0 +1,72 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TypeMsgReportUptime = "report_uptime"
+
+	// MaxUptimeObservations bounds the providers a single report may cover
+	MaxUptimeObservations = 100
+)
+
+var _ sdk.Msg = &MsgReportUptime{}
+
+func NewMsgReportUptime(creator cosmos.AccAddress, observations []UptimeObservation) *MsgReportUptime {
+	return &MsgReportUptime{
+		Creator:      creator,
+		Observations: observations,
+	}
+}
+
+func (msg *MsgReportUptime) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgReportUptime) Type() string {
+	return TypeMsgReportUptime
+}
+
+func (msg *MsgReportUptime) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgReportUptime) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgReportUptime) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgReportUptime) ValidateBasic() error {
+	if err := sdk.VerifyAddressFormat(msg.Creator); err != nil {
+		return errors.Wrapf(ErrInvalidUptimeReport, "invalid creator (%s)", err)
+	}
+	if len(msg.Observations) == 0 || len(msg.Observations) > MaxUptimeObservations {
+		return errors.Wrapf(ErrInvalidUptimeReport, "must report on between 1 and %d providers", MaxUptimeObservations)
+	}
+
+	seen := make(map[string]bool, len(msg.Observations))
+	for _, obs := range msg.Observations {
+		if _, err := common.NewPubKey(obs.Provider.String()); err != nil {
+			return errors.Wrapf(ErrInvalidPubKey, "invalid provider pubkey (%s): %s", obs.Provider, err)
+		}
+		service, err := common.NewService(obs.Service)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidService, "invalid service (%s): %s", obs.Service, err)
+		}
+		key := NewProviderUptime(obs.Provider, service).Key()
+		if seen[key] {
+			return errors.Wrapf(ErrInvalidUptimeReport, "provider %s reported more than once", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportUptimeValidateBasic(t *testing.T) {
+	acct := GetRandomBech32Addr()
+	provider := GetRandomPubKey()
+
+	msg := NewMsgReportUptime(acct, nil)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidUptimeReport)
+
+	msg.Observations = []UptimeObservation{{Provider: provider, Service: "bogus", Online: true}}
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidService)
+
+	// a provider can only be reported once per message
+	msg.Observations = []UptimeObservation{
+		{Provider: provider, Service: common.BTCService.String(), Online: true},
+		{Provider: provider, Service: common.BTCService.String(), Online: false},
+	}
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidUptimeReport)
+
+	msg.Observations[1].Service = common.ETHService.String()
+	require.NoError(t, msg.ValidateBasic())
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgResolveDispute = "resolve_dispute"
+
+var _ sdk.Msg = &MsgResolveDispute{}
+
+func NewMsgResolveDispute(authority string, contractId uint64, providerBasisPoints int64) *MsgResolveDispute {
+	return &MsgResolveDispute{
+		Authority:           authority,
+		ContractId:          contractId,
+		ProviderBasisPoints: providerBasisPoints,
+	}
+}
+
+func (msg *MsgResolveDispute) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgResolveDispute) Type() string {
+	return TypeMsgResolveDispute
+}
+
+func (msg *MsgResolveDispute) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.MustAccAddressFromBech32(msg.Authority)}
+}
+
+func (msg *MsgResolveDispute) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgResolveDispute) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrapf(ErrInvalidAuthority, "invalid authority address (%s)", err)
+	}
+	if msg.ProviderBasisPoints < 0 || msg.ProviderBasisPoints > configs.MaxBasisPoints {
+		return errors.Wrapf(ErrInvalidDispute, "provider basis points must be between 0 and %d, got %d", configs.MaxBasisPoints, msg.ProviderBasisPoints)
+	}
+	return nil
+}
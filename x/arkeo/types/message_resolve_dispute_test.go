@@ -0,0 +1,23 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDisputeValidateBasic(t *testing.T) {
+	authority := GetRandomBech32Addr().String()
+	msg := NewMsgResolveDispute(authority, 1, 0)
+	require.NoError(t, msg.ValidateBasic())
+	msg.ProviderBasisPoints = 10_000
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.ProviderBasisPoints = -1
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidDispute)
+	msg.ProviderBasisPoints = 10_001
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidDispute)
+
+	msg = NewMsgResolveDispute("bogus", 1, 5_000)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidAuthority)
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgSetAllowedDenom = "set_allowed_denom"
+
+var _ sdk.Msg = &MsgSetAllowedDenom{}
+
+func NewMsgSetAllowedDenom(authority, denom string, allowed bool) *MsgSetAllowedDenom {
+	return &MsgSetAllowedDenom{
+		Authority: authority,
+		Denom:     denom,
+		Allowed:   allowed,
+	}
+}
+
+func (msg *MsgSetAllowedDenom) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSetAllowedDenom) Type() string {
+	return TypeMsgSetAllowedDenom
+}
+
+func (msg *MsgSetAllowedDenom) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.MustAccAddressFromBech32(msg.Authority)}
+}
+
+func (msg *MsgSetAllowedDenom) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgSetAllowedDenom) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrapf(ErrInvalidAuthority, "invalid authority address (%s)", err)
+	}
+	if err := sdk.ValidateDenom(msg.Denom); err != nil {
+		return errors.Wrapf(ErrInvalidDenom, "invalid denom (%s): %s", msg.Denom, err)
+	}
+	return nil
+}
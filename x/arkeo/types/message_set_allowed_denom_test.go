@@ -0,0 +1,19 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAllowedDenomValidateBasic(t *testing.T) {
+	ibcDenom := "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+	msg := NewMsgSetAllowedDenom(GetRandomBech32Addr().String(), ibcDenom, true)
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.Denom = "!bogus"
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidDenom)
+
+	msg = NewMsgSetAllowedDenom("bogus", ibcDenom, true)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidAuthority)
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgSetAllowedProvider = "set_allowed_provider"
+
+var _ sdk.Msg = &MsgSetAllowedProvider{}
+
+func NewMsgSetAllowedProvider(authority string, provider common.PubKey, allowed bool) *MsgSetAllowedProvider {
+	return &MsgSetAllowedProvider{
+		Authority: authority,
+		Provider:  provider,
+		Allowed:   allowed,
+	}
+}
+
+func (msg *MsgSetAllowedProvider) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSetAllowedProvider) Type() string {
+	return TypeMsgSetAllowedProvider
+}
+
+func (msg *MsgSetAllowedProvider) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.MustAccAddressFromBech32(msg.Authority)}
+}
+
+func (msg *MsgSetAllowedProvider) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgSetAllowedProvider) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrapf(ErrInvalidAuthority, "invalid authority address (%s)", err)
+	}
+	if _, err := common.NewPubKey(msg.Provider.String()); err != nil {
+		return errors.Wrapf(ErrInvalidPubKey, "invalid pubkey (%s): %s", msg.Provider, err)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAllowedProviderValidateBasic(t *testing.T) {
+	msg := NewMsgSetAllowedProvider(GetRandomBech32Addr().String(), GetRandomPubKey(), true)
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.Provider = "bogus"
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidPubKey)
+
+	msg = NewMsgSetAllowedProvider("bogus", GetRandomPubKey(), true)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidAuthority)
+}
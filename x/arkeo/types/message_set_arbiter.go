@@ -0,0 +1,47 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgSetArbiter = "set_arbiter"
+
+var _ sdk.Msg = &MsgSetArbiter{}
+
+func NewMsgSetArbiter(authority string, arbiter cosmos.AccAddress, allowed bool) *MsgSetArbiter {
+	return &MsgSetArbiter{
+		Authority: authority,
+		Arbiter:   arbiter,
+		Allowed:   allowed,
+	}
+}
+
+func (msg *MsgSetArbiter) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSetArbiter) Type() string {
+	return TypeMsgSetArbiter
+}
+
+func (msg *MsgSetArbiter) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.MustAccAddressFromBech32(msg.Authority)}
+}
+
+func (msg *MsgSetArbiter) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgSetArbiter) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrapf(ErrInvalidAuthority, "invalid authority address (%s)", err)
+	}
+	if err := sdk.VerifyAddressFormat(msg.Arbiter); err != nil {
+		return errors.Wrapf(ErrInvalidAuthority, "invalid arbiter address (%s)", err)
+	}
+	return nil
+}
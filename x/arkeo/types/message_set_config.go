@@ -0,0 +1,87 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgSetConfig = "set_config"
+
+var _ sdk.Msg = &MsgSetConfig{}
+
+func NewMsgSetConfig(authority, name string, value int64) *MsgSetConfig {
+	return &MsgSetConfig{
+		Authority: authority,
+		Name:      name,
+		Value:     value,
+	}
+}
+
+func (msg *MsgSetConfig) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSetConfig) Type() string {
+	return TypeMsgSetConfig
+}
+
+func (msg *MsgSetConfig) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.MustAccAddressFromBech32(msg.Authority)}
+}
+
+func (msg *MsgSetConfig) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgSetConfig) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrapf(ErrInvalidAuthority, "invalid authority address (%s)", err)
+	}
+	name, ok := configs.GetConfigName(msg.Name)
+	if !ok {
+		return errors.Wrapf(ErrInvalidConfig, "unknown config (%s)", msg.Name)
+	}
+	return ValidateConfigOverride(name, msg.Value)
+}
+
+// ValidateConfigOverride checks the config can be changed by governance, and
+// that the new value is within its bounds
+func ValidateConfigOverride(name configs.ConfigName, value int64) error {
+	switch name {
+	case configs.ReserveTax, configs.CommunityTaxShare, configs.ProviderCancelPenalty, configs.MaxAffiliateFee, configs.MinProviderUptime, configs.UptimeSlash, configs.FeeReserveShare:
+		if value < 0 || value > configs.MaxBasisPoints {
+			return errors.Wrapf(ErrInvalidConfig, "%s must be between 0 and %d basis points", name, configs.MaxBasisPoints)
+		}
+	case configs.EmissionCurve:
+		// zero stops the reserve from paying out validators
+		if value < 0 {
+			return errors.Wrapf(ErrInvalidConfig, "%s cannot be negative", name)
+		}
+	case configs.ProviderIncentive, configs.ProviderIncentiveEnd:
+		// zero disables the incentive, or runs it with no end height
+		if value < 0 {
+			return errors.Wrapf(ErrInvalidConfig, "%s cannot be negative", name)
+		}
+	case configs.DisputeWindow, configs.DisputeTimeout:
+		// zero pays providers straight away, or lets disputes wait on an
+		// arbiter for as long as it takes
+		if value < 0 {
+			return errors.Wrapf(ErrInvalidConfig, "%s cannot be negative", name)
+		}
+	case configs.ValidatorPayoutCycle, configs.ProviderIncentiveCycle:
+		// rewards are paid every cycle blocks, the cycle cannot be turned off
+		if value < 1 {
+			return errors.Wrapf(ErrInvalidConfig, "%s must be at least 1", name)
+		}
+	case configs.HaltContractOpening, configs.PermissionedProviders:
+		if value != 0 && value != 1 {
+			return errors.Wrapf(ErrInvalidConfig, "%s must be 0 or 1", name)
+		}
+	default:
+		return errors.Wrapf(ErrInvalidConfig, "%s cannot be changed by governance", name)
+	}
+	return nil
+}
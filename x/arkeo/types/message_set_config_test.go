@@ -0,0 +1,81 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetConfigValidateBasic(t *testing.T) {
+	authority := GetRandomBech32Addr().String()
+
+	msg := NewMsgSetConfig(authority, configs.ReserveTax.String(), 500)
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.Value = 0
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.Value = configs.MaxBasisPoints
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.Value = configs.MaxBasisPoints + 1
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	msg.Value = -1
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	msg = NewMsgSetConfig(authority, configs.CommunityTaxShare.String(), configs.MaxBasisPoints)
+	require.NoError(t, msg.ValidateBasic())
+	msg.Value = configs.MaxBasisPoints + 1
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	msg = NewMsgSetConfig(authority, configs.EmissionCurve.String(), 0)
+	require.NoError(t, msg.ValidateBasic())
+	msg.Value = -1
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	msg = NewMsgSetConfig(authority, configs.ValidatorPayoutCycle.String(), 10)
+	require.NoError(t, msg.ValidateBasic())
+	msg.Value = 0
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	msg = NewMsgSetConfig(authority, configs.ProviderIncentive.String(), 1000)
+	require.NoError(t, msg.ValidateBasic())
+	msg.Value = 0
+	require.NoError(t, msg.ValidateBasic())
+	msg.Value = -1
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	msg = NewMsgSetConfig(authority, configs.ProviderIncentiveEnd.String(), 0)
+	require.NoError(t, msg.ValidateBasic())
+	msg.Value = -1
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	msg = NewMsgSetConfig(authority, configs.DisputeWindow.String(), 14400)
+	require.NoError(t, msg.ValidateBasic())
+	msg.Value = 0
+	require.NoError(t, msg.ValidateBasic())
+	msg.Value = -1
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	msg = NewMsgSetConfig(authority, configs.DisputeTimeout.String(), 0)
+	require.NoError(t, msg.ValidateBasic())
+	msg.Value = -1
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	msg = NewMsgSetConfig(authority, configs.ProviderIncentiveCycle.String(), 14400)
+	require.NoError(t, msg.ValidateBasic())
+	msg.Value = 0
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	// only governable configs can be changed
+	msg = NewMsgSetConfig(authority, configs.MaxSupply.String(), 1)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	msg = NewMsgSetConfig(authority, "NotAConfig", 1)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidConfig)
+
+	msg = NewMsgSetConfig("bogus", configs.ReserveTax.String(), 1)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidAuthority)
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgSetContractMemo = "set_contract_memo"
+
+// MaxContractMemoLength is the max size, in bytes, of a contract memo
+const MaxContractMemoLength = 256
+
+var _ sdk.Msg = &MsgSetContractMemo{}
+
+func NewMsgSetContractMemo(creator cosmos.AccAddress, contractId uint64, memo string) *MsgSetContractMemo {
+	return &MsgSetContractMemo{
+		Creator:    creator,
+		ContractId: contractId,
+		Memo:       memo,
+	}
+}
+
+func (msg *MsgSetContractMemo) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSetContractMemo) Type() string {
+	return TypeMsgSetContractMemo
+}
+
+func (msg *MsgSetContractMemo) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgSetContractMemo) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgSetContractMemo) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgSetContractMemo) ValidateBasic() error {
+	return ValidateContractMemo(msg.Memo)
+}
+
+// ValidateContractMemo ensures the memo doesn't exceed the max memo length
+func ValidateContractMemo(memo string) error {
+	if len(memo) > MaxContractMemoLength {
+		return errors.Wrapf(ErrInvalidContractMemo, "memo is %d bytes, max is %d", len(memo), MaxContractMemoLength)
+	}
+	return nil
+}
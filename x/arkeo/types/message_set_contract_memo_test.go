@@ -0,0 +1,33 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetContractMemoValidateBasic(t *testing.T) {
+	// setup
+	pubkey := GetRandomPubKey()
+	acct, err := pubkey.GetMyAddress()
+	require.NoError(t, err)
+
+	msg := MsgSetContractMemo{
+		Creator:    acct,
+		ContractId: 50,
+		Memo:       "my contract",
+	}
+	require.NoError(t, msg.ValidateBasic())
+
+	// empty memo clears the memo
+	msg.Memo = ""
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.Memo = strings.Repeat("a", MaxContractMemoLength)
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.Memo = strings.Repeat("a", MaxContractMemoLength+1)
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidContractMemo)
+}
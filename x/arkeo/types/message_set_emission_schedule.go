@@ -0,0 +1,42 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgSetEmissionSchedule = "set_emission_schedule"
+
+var _ sdk.Msg = &MsgSetEmissionSchedule{}
+
+func NewMsgSetEmissionSchedule(authority string, epochs []EmissionEpoch) *MsgSetEmissionSchedule {
+	return &MsgSetEmissionSchedule{
+		Authority: authority,
+		Epochs:    epochs,
+	}
+}
+
+func (msg *MsgSetEmissionSchedule) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSetEmissionSchedule) Type() string {
+	return TypeMsgSetEmissionSchedule
+}
+
+func (msg *MsgSetEmissionSchedule) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.MustAccAddressFromBech32(msg.Authority)}
+}
+
+func (msg *MsgSetEmissionSchedule) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgSetEmissionSchedule) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrapf(ErrInvalidAuthority, "invalid authority address (%s)", err)
+	}
+	return EmissionSchedule{Epochs: msg.Epochs}.Validate()
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TypeMsgSetService = "set_service"
+
+	MaxServiceDescriptionLength = 250
+)
+
+var _ sdk.Msg = &MsgSetService{}
+
+func NewMsgSetService(authority, service, description string, defaultPort uint32, querySchema string, supported bool) *MsgSetService {
+	return &MsgSetService{
+		Authority:   authority,
+		Service:     service,
+		Description: description,
+		DefaultPort: defaultPort,
+		QuerySchema: querySchema,
+		Supported:   supported,
+	}
+}
+
+func (msg *MsgSetService) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSetService) Type() string {
+	return TypeMsgSetService
+}
+
+func (msg *MsgSetService) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.MustAccAddressFromBech32(msg.Authority)}
+}
+
+func (msg *MsgSetService) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgSetService) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrapf(ErrInvalidAuthority, "invalid authority address (%s)", err)
+	}
+	if _, err := common.NewService(msg.Service); err != nil {
+		return errors.Wrapf(ErrInvalidService, "invalid service (%s): %s", msg.Service, err)
+	}
+	if len(msg.Description) > MaxServiceDescriptionLength {
+		return errors.Wrapf(ErrInvalidServiceInfo, "description is too long (%d/%d)", len(msg.Description), MaxServiceDescriptionLength)
+	}
+	if msg.DefaultPort > 65535 {
+		return errors.Wrapf(ErrInvalidServiceInfo, "invalid default port (%d)", msg.DefaultPort)
+	}
+	if len(msg.QuerySchema) > 0 {
+		if err := ValidateMetadataURI(msg.QuerySchema); err != nil {
+			return errors.Wrapf(ErrInvalidServiceInfo, "invalid query schema: %s", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetServiceValidateBasic(t *testing.T) {
+	msg := NewMsgSetService(GetRandomBech32Addr().String(), "btc-mainnet-fullnode", "bitcoin full node", 8332, "https://example.com/btc.json", true)
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.QuerySchema = ""
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.QuerySchema = "not a uri"
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidServiceInfo)
+
+	msg.QuerySchema = ""
+	msg.DefaultPort = 70000
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidServiceInfo)
+
+	msg.DefaultPort = 8332
+	msg.Description = strings.Repeat("a", MaxServiceDescriptionLength+1)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidServiceInfo)
+
+	msg.Description = ""
+	msg.Service = "bogus"
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidService)
+
+	msg = NewMsgSetService("bogus", "btc-mainnet-fullnode", "", 0, "", true)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidAuthority)
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgSettleContracts = "settle_contracts"
+
+var _ sdk.Msg = &MsgSettleContracts{}
+
+func NewMsgSettleContracts(creator cosmos.AccAddress, claims []ContractClaim) *MsgSettleContracts {
+	return &MsgSettleContracts{
+		Creator: creator,
+		Claims:  claims,
+	}
+}
+
+func (msg *MsgSettleContracts) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSettleContracts) Type() string {
+	return TypeMsgSettleContracts
+}
+
+func (msg *MsgSettleContracts) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgSettleContracts) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgSettleContracts) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ClaimContractIncomeMsg converts a claim of the batch into the equivalent
+// single contract claim message
+func (msg *MsgSettleContracts) ClaimContractIncomeMsg(claim ContractClaim) *MsgClaimContractIncome {
+	return NewMsgClaimContractIncome(msg.Creator, claim.ContractId, claim.Nonce, claim.Signature)
+}
+
+func (msg *MsgSettleContracts) ValidateBasic() error {
+	// individual claims are validated as they are settled, so that a bad
+	// claim doesn't fail the whole batch
+	if len(msg.Claims) == 0 {
+		return errors.Wrap(ErrInvalidSettleContractsBatch, "no claims")
+	}
+	return nil
+}
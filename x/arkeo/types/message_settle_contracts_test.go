@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettleContractsValidateBasic(t *testing.T) {
+	// setup
+	pubkey := GetRandomPubKey()
+	acct, err := pubkey.GetMyAddress()
+	require.NoError(t, err)
+
+	msg := MsgSettleContracts{
+		Creator: acct,
+	}
+	err = msg.ValidateBasic()
+	require.ErrorIs(t, err, ErrInvalidSettleContractsBatch)
+
+	// a bad claim doesn't invalidate the batch
+	msg.Claims = []ContractClaim{
+		{ContractId: 1, Nonce: 10},
+		{ContractId: 2, Nonce: 0},
+	}
+	require.NoError(t, msg.ValidateBasic())
+}
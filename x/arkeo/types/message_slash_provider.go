@@ -0,0 +1,69 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/arkeonetwork/arkeo/x/arkeo/configs"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgSlashProvider = "slash_provider"
+
+// MaxSlashReasonLength is the max size, in bytes, of the reason given for a
+// slash
+const MaxSlashReasonLength = 256
+
+var _ sdk.Msg = &MsgSlashProvider{}
+
+func NewMsgSlashProvider(authority string, provider common.PubKey, service string, basisPoints int64, burn bool, recipient cosmos.AccAddress, reason string) *MsgSlashProvider {
+	return &MsgSlashProvider{
+		Authority:   authority,
+		Provider:    provider,
+		Service:     service,
+		BasisPoints: basisPoints,
+		Burn:        burn,
+		Recipient:   recipient,
+		Reason:      reason,
+	}
+}
+
+func (msg *MsgSlashProvider) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgSlashProvider) Type() string {
+	return TypeMsgSlashProvider
+}
+
+func (msg *MsgSlashProvider) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.MustAccAddressFromBech32(msg.Authority)}
+}
+
+func (msg *MsgSlashProvider) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgSlashProvider) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errors.Wrapf(ErrInvalidAuthority, "invalid authority address (%s)", err)
+	}
+	if _, err := common.NewPubKey(msg.Provider.String()); err != nil {
+		return errors.Wrapf(ErrInvalidPubKey, "invalid pubkey (%s): %s", msg.Provider, err)
+	}
+	if _, err := common.NewService(msg.Service); err != nil {
+		return errors.Wrapf(ErrInvalidService, "invalid service (%s): %s", msg.Service, err)
+	}
+	if msg.BasisPoints <= 0 || msg.BasisPoints > configs.MaxBasisPoints {
+		return errors.Wrapf(ErrInvalidSlash, "basis points must be between 1 and %d, got %d", configs.MaxBasisPoints, msg.BasisPoints)
+	}
+	if msg.Burn && !msg.Recipient.Empty() {
+		return errors.Wrapf(ErrInvalidSlash, "a burned slash cannot have a recipient")
+	}
+	if len(msg.Reason) > MaxSlashReasonLength {
+		return errors.Wrapf(ErrInvalidSlash, "reason is %d bytes, max is %d", len(msg.Reason), MaxSlashReasonLength)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashProviderValidateBasic(t *testing.T) {
+	authority := GetRandomBech32Addr().String()
+	msg := NewMsgSlashProvider(authority, GetRandomPubKey(), "btc-mainnet-fullnode", 500, false, nil, "served stale blocks")
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.Recipient = GetRandomBech32Addr()
+	require.NoError(t, msg.ValidateBasic())
+
+	// a burned slash goes nowhere
+	msg.Burn = true
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidSlash)
+	msg.Recipient = nil
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.BasisPoints = 0
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidSlash)
+	msg.BasisPoints = 10_001
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidSlash)
+	msg.BasisPoints = 10_000
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.Reason = strings.Repeat("a", MaxSlashReasonLength+1)
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidSlash)
+	msg.Reason = ""
+	require.NoError(t, msg.ValidateBasic())
+
+	msg.Service = "bogus"
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidService)
+
+	msg = NewMsgSlashProvider("bogus", GetRandomPubKey(), "btc-mainnet-fullnode", 500, true, nil, "")
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidAuthority)
+}
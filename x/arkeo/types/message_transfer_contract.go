@@ -0,0 +1,49 @@
+package types
+
+import (
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgTransferContract = "transfer_contract"
+
+var _ sdk.Msg = &MsgTransferContract{}
+
+func NewMsgTransferContract(creator cosmos.AccAddress, contractId uint64, newClient common.PubKey) *MsgTransferContract {
+	return &MsgTransferContract{
+		Creator:    creator,
+		ContractId: contractId,
+		NewClient:  newClient,
+	}
+}
+
+func (msg *MsgTransferContract) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgTransferContract) Type() string {
+	return TypeMsgTransferContract
+}
+
+func (msg *MsgTransferContract) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgTransferContract) MustGetSigner() sdk.AccAddress {
+	return msg.Creator
+}
+
+func (msg *MsgTransferContract) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgTransferContract) ValidateBasic() error {
+	if _, err := common.NewPubKey(msg.NewClient.String()); err != nil {
+		return errors.Wrapf(ErrInvalidPubKey, "invalid pubkey (%s)", err)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferContractValidateBasic(t *testing.T) {
+	acct := GetRandomBech32Addr()
+
+	msg := NewMsgTransferContract(acct, 1, common.PubKey("bogus"))
+	require.ErrorIs(t, msg.ValidateBasic(), ErrInvalidPubKey)
+
+	msg = NewMsgTransferContract(acct, 1, GetRandomPubKey())
+	require.NoError(t, msg.ValidateBasic())
+}
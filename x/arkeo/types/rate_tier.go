@@ -0,0 +1,140 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"cosmossdk.io/errors"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+)
+
+// ParseRateTiers parses rate tiers written as comma separated
+// threshold:rate pairs, such as "1000:8uarkeo,5000:5uarkeo"
+func ParseRateTiers(s string) ([]RateTier, error) {
+	var tiers []RateTier
+	if strings.TrimSpace(s) == "" {
+		return tiers, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		fields := strings.SplitN(strings.TrimSpace(part), ":", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("rate tier (%s) must be threshold:rate", part)
+		}
+		threshold, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate tier threshold (%s): %w", fields[0], err)
+		}
+		rate, err := cosmos.ParseCoin(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate tier rate (%s): %w", fields[1], err)
+		}
+		tiers = append(tiers, RateTier{Threshold: threshold, Rate: rate})
+	}
+	return tiers, nil
+}
+
+// ValidateRateTiers checks the tiers are discounts on the given base rates.
+// The tiers of a denom must have increasing thresholds, and each must charge
+// less than the tier, or base rate, before it.
+func ValidateRateTiers(tiers []RateTier, rates cosmos.Coins) error {
+	prev := make(map[string]RateTier)
+	for _, tier := range tiers {
+		if tier.Threshold <= 0 {
+			return errors.Wrapf(ErrInvalidModProviderRate, "rate tier threshold must be positive, got %d", tier.Threshold)
+		}
+		if err := tier.Rate.Validate(); err != nil {
+			return errors.Wrapf(ErrInvalidModProviderRate, "invalid rate tier rate (%s): %s", tier.Rate, err)
+		}
+		if !tier.Rate.IsPositive() {
+			return errors.Wrapf(ErrInvalidModProviderRate, "rate tier rate must be positive")
+		}
+		denom := tier.Rate.Denom
+		last, ok := prev[denom]
+		if !ok {
+			base := rates.AmountOf(denom)
+			if base.IsZero() {
+				return errors.Wrapf(ErrInvalidModProviderRate, "rate tier in %s has no base rate", denom)
+			}
+			last = RateTier{Rate: cosmos.NewCoin(denom, base)}
+		}
+		if tier.Threshold <= last.Threshold {
+			return errors.Wrapf(ErrInvalidModProviderRate, "rate tier thresholds in %s must increase, %d follows %d", denom, tier.Threshold, last.Threshold)
+		}
+		if !tier.Rate.Amount.LT(last.Rate.Amount) {
+			return errors.Wrapf(ErrInvalidModProviderRate, "rate tier at %d must charge less than %s", tier.Threshold, last.Rate)
+		}
+		prev[denom] = tier
+	}
+	return nil
+}
+
+// RateTiersOf returns the tiers in the given denom
+func RateTiersOf(tiers []RateTier, denom string) []RateTier {
+	var result []RateTier
+	for _, tier := range tiers {
+		if tier.Rate.Denom == denom {
+			result = append(result, tier)
+		}
+	}
+	return result
+}
+
+// TieredCost returns the cost of the given units, blocks or queries, at the
+// contract's rate and then at the rate of each tier they reach
+func (contract Contract) TieredCost(units int64) cosmos.Int {
+	cost := cosmos.ZeroInt()
+	rate := contract.Rate.Amount
+	var from int64
+	for _, tier := range contract.RateTiers {
+		if units <= tier.Threshold {
+			break
+		}
+		cost = cost.Add(rate.MulRaw(tier.Threshold - from))
+		from = tier.Threshold
+		rate = tier.Rate.Amount
+	}
+	if units > from {
+		cost = cost.Add(rate.MulRaw(units - from))
+	}
+	return cost
+}
+
+// TieredUnits returns the most units, following the first from units, the
+// given amount pays for at the contract's rate and then at the rate of each
+// tier they reach, along with what those units cost. It is the inverse of
+// TieredCost, the cost is TieredCost(from+units) - TieredCost(from).
+func (contract Contract) TieredUnits(from int64, amount cosmos.Int) (int64, cosmos.Int) {
+	rate := contract.Rate.Amount
+	next := 0
+	for next < len(contract.RateTiers) && contract.RateTiers[next].Threshold <= from {
+		rate = contract.RateTiers[next].Rate.Amount
+		next++
+	}
+
+	at := from
+	spent := cosmos.ZeroInt()
+	for rate.IsPositive() {
+		affordable := amount.Sub(spent).Quo(rate)
+		if next < len(contract.RateTiers) {
+			// the units left at this rate, before the next tier is reached
+			left := contract.RateTiers[next].Threshold - at
+			if affordable.GTE(cosmos.NewInt(left)) {
+				spent = spent.Add(rate.MulRaw(left))
+				at += left
+				rate = contract.RateTiers[next].Rate.Amount
+				next++
+				continue
+			}
+		}
+		if !affordable.IsInt64() || affordable.Int64() > math.MaxInt64-at {
+			affordable = cosmos.NewInt(math.MaxInt64 - at)
+		}
+		spent = spent.Add(rate.Mul(affordable))
+		at += affordable.Int64()
+		break
+	}
+	return at - from, spent
+}
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateTiers(t *testing.T) {
+	tiers, err := ParseRateTiers("")
+	require.NoError(t, err)
+	require.Empty(t, tiers)
+
+	tiers, err = ParseRateTiers("1000:8uarkeo, 5000:5uarkeo")
+	require.NoError(t, err)
+	require.Len(t, tiers, 2)
+	require.EqualValues(t, 5000, tiers[1].Threshold)
+	require.Equal(t, cosmos.NewInt64Coin("uarkeo", 5), tiers[1].Rate)
+
+	_, err = ParseRateTiers("1000")
+	require.Error(t, err)
+	_, err = ParseRateTiers("x:8uarkeo")
+	require.Error(t, err)
+	_, err = ParseRateTiers("1000:8")
+	require.Error(t, err)
+}
+
+func TestValidateRateTiers(t *testing.T) {
+	rates := cosmos.NewCoins(cosmos.NewInt64Coin("uarkeo", 10), cosmos.NewInt64Coin("uatom", 4))
+	tier := func(threshold, amount int64, denom string) RateTier {
+		return RateTier{Threshold: threshold, Rate: cosmos.NewInt64Coin(denom, amount)}
+	}
+
+	require.NoError(t, ValidateRateTiers(nil, rates))
+	require.NoError(t, ValidateRateTiers([]RateTier{
+		tier(100, 8, "uarkeo"),
+		tier(50, 3, "uatom"),
+		tier(500, 5, "uarkeo"),
+	}, rates))
+
+	// each denom's tiers are discounts on its base rate
+	require.ErrorIs(t, ValidateRateTiers([]RateTier{tier(100, 10, "uarkeo")}, rates), ErrInvalidModProviderRate)
+	require.ErrorIs(t, ValidateRateTiers([]RateTier{tier(100, 8, "uarkeo"), tier(500, 9, "uarkeo")}, rates), ErrInvalidModProviderRate)
+	require.ErrorIs(t, ValidateRateTiers([]RateTier{tier(100, 8, "uarkeo"), tier(100, 5, "uarkeo")}, rates), ErrInvalidModProviderRate)
+	require.ErrorIs(t, ValidateRateTiers([]RateTier{tier(0, 8, "uarkeo")}, rates), ErrInvalidModProviderRate)
+	require.ErrorIs(t, ValidateRateTiers([]RateTier{tier(100, 0, "uarkeo")}, rates), ErrInvalidModProviderRate)
+	require.ErrorIs(t, ValidateRateTiers([]RateTier{tier(100, 1, "uosmo")}, rates), ErrInvalidModProviderRate)
+}
+
+func TestTieredCost(t *testing.T) {
+	contract := Contract{
+		Rate: cosmos.NewInt64Coin("uarkeo", 10),
+		RateTiers: []RateTier{
+			{Threshold: 100, Rate: cosmos.NewInt64Coin("uarkeo", 8)},
+			{Threshold: 500, Rate: cosmos.NewInt64Coin("uarkeo", 5)},
+		},
+	}
+	require.True(t, contract.TieredCost(0).IsZero())
+	require.Equal(t, int64(500), contract.TieredCost(50).Int64())
+	require.Equal(t, int64(1000), contract.TieredCost(100).Int64())
+	require.Equal(t, int64(1000+3200), contract.TieredCost(500).Int64())
+	require.Equal(t, int64(1000+3200+500), contract.TieredCost(600).Int64())
+
+	// without tiers the contract's rate applies throughout
+	contract.RateTiers = nil
+	require.Equal(t, int64(6000), contract.TieredCost(600).Int64())
+}
+
+func TestTieredUnits(t *testing.T) {
+	contract := Contract{
+		Rate: cosmos.NewInt64Coin("uarkeo", 10),
+		RateTiers: []RateTier{
+			{Threshold: 100, Rate: cosmos.NewInt64Coin("uarkeo", 8)},
+			{Threshold: 500, Rate: cosmos.NewInt64Coin("uarkeo", 5)},
+		},
+	}
+	units, cost := contract.TieredUnits(0, cosmos.NewInt(500))
+	require.Equal(t, int64(50), units)
+	require.Equal(t, int64(500), cost.Int64())
+
+	// across both tiers, the remainder buys no whole unit
+	units, cost = contract.TieredUnits(50, cosmos.NewInt(500+3200+100+3))
+	require.Equal(t, int64(470), units)
+	require.Equal(t, int64(3800), cost.Int64())
+	require.Equal(t, contract.TieredCost(520).Sub(contract.TieredCost(50)), cost)
+
+	// starting in a tier
+	units, cost = contract.TieredUnits(200, cosmos.NewInt(800))
+	require.Equal(t, int64(100), units)
+	require.Equal(t, int64(800), cost.Int64())
+
+	units, cost = contract.TieredUnits(0, cosmos.ZeroInt())
+	require.Zero(t, units)
+	require.True(t, cost.IsZero())
+
+	// without tiers the contract's rate applies throughout
+	contract.RateTiers = nil
+	units, cost = contract.TieredUnits(600, cosmos.NewInt(6005))
+	require.Equal(t, int64(600), units)
+	require.Equal(t, int64(6000), cost.Int64())
+}
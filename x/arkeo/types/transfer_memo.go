@@ -0,0 +1,163 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/arkeonetwork/arkeo/common"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+)
+
+// TransferMemo is the memo of an ICS-20 transfer into arkeo. Memos without an
+// arkeo entry are left to other middleware.
+type TransferMemo struct {
+	Arkeo *ArkeoTransferMemo `json:"arkeo,omitempty"`
+}
+
+type ArkeoTransferMemo struct {
+	OpenContract *OpenContractMemo `json:"open_contract,omitempty"`
+}
+
+// OpenContractMemo opens a contract paid for with the transferred tokens. The
+// rate is an amount in the denom the tokens are received as, and the
+// transfer, less any open cost, is the deposit. The transfer receiver must be
+// the client's account. As anyone can send a transfer to that account, the
+// client signs the memo along with a nonce greater than that of its last
+// memo, see GetBytesToSign.
+type OpenContractMemo struct {
+	Provider           string                `json:"provider"`
+	Service            string                `json:"service"`
+	Client             string                `json:"client"`
+	Delegate           string                `json:"delegate,omitempty"`
+	ContractType       ContractType          `json:"contract_type"`
+	Duration           int64                 `json:"duration"`
+	Rate               string                `json:"rate"`
+	Denom              string                `json:"denom"`
+	SettlementDuration int64                 `json:"settlement_duration,omitempty"`
+	Authorization      ContractAuthorization `json:"authorization,omitempty"`
+	QueriesPerMinute   int64                 `json:"queries_per_minute"`
+	Memo               string                `json:"memo,omitempty"`
+	AutoRenew          bool                  `json:"auto_renew,omitempty"`
+	RefundAddress      string                `json:"refund_address,omitempty"`
+	SettlementInterval int64                 `json:"settlement_interval,omitempty"`
+	Nonce              int64                 `json:"nonce"`
+	Signature          []byte                `json:"signature,omitempty"`
+}
+
+// openContractSignDoc is what the client signs, every field of the memo but
+// the signature, and the chain the contract is opened on
+type openContractSignDoc struct {
+	ChainID            string `json:"chain_id"`
+	Provider           string `json:"provider"`
+	Service            string `json:"service"`
+	Client             string `json:"client"`
+	Delegate           string `json:"delegate"`
+	ContractType       string `json:"contract_type"`
+	Duration           int64  `json:"duration"`
+	Rate               string `json:"rate"`
+	Denom              string `json:"denom"`
+	SettlementDuration int64  `json:"settlement_duration"`
+	Authorization      string `json:"authorization"`
+	QueriesPerMinute   int64  `json:"queries_per_minute"`
+	Memo               string `json:"memo"`
+	AutoRenew          bool   `json:"auto_renew"`
+	RefundAddress      string `json:"refund_address"`
+	SettlementInterval int64  `json:"settlement_interval"`
+	Nonce              int64  `json:"nonce"`
+}
+
+// GetBytesToSign returns the bytes the client signs to agree to the contract
+// on the given chain, the memo as sorted json with every field present and
+// enums by name
+func (memo OpenContractMemo) GetBytesToSign(chainID string) []byte {
+	bz, err := json.Marshal(openContractSignDoc{
+		ChainID:            chainID,
+		Provider:           memo.Provider,
+		Service:            memo.Service,
+		Client:             memo.Client,
+		Delegate:           memo.Delegate,
+		ContractType:       memo.ContractType.String(),
+		Duration:           memo.Duration,
+		Rate:               memo.Rate,
+		Denom:              memo.Denom,
+		SettlementDuration: memo.SettlementDuration,
+		Authorization:      memo.Authorization.String(),
+		QueriesPerMinute:   memo.QueriesPerMinute,
+		Memo:               memo.Memo,
+		AutoRenew:          memo.AutoRenew,
+		RefundAddress:      memo.RefundAddress,
+		SettlementInterval: memo.SettlementInterval,
+		Nonce:              memo.Nonce,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// ParseTransferMemo parses the memo of an ICS-20 transfer. Returns false when
+// the memo has nothing for arkeo, including memos that are not json.
+func ParseTransferMemo(memo string) (TransferMemo, bool, error) {
+	var result TransferMemo
+	memo = strings.TrimSpace(memo)
+	if !strings.HasPrefix(memo, "{") {
+		return result, false, nil
+	}
+	// other middleware may use the memo, only a memo with an arkeo entry has
+	// to be valid
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(memo), &entries); err != nil {
+		return result, false, nil
+	}
+	if _, ok := entries["arkeo"]; !ok {
+		return result, false, nil
+	}
+	if err := json.Unmarshal([]byte(memo), &result); err != nil {
+		return result, true, fmt.Errorf("invalid arkeo transfer memo: %w", err)
+	}
+	if result.Arkeo == nil || result.Arkeo.OpenContract == nil {
+		return result, true, fmt.Errorf("arkeo transfer memo has no action")
+	}
+	return result, true, nil
+}
+
+// Msg returns the message that opens the contract, with the given deposit
+// in the given denom
+func (memo OpenContractMemo) Msg(denom string, deposit cosmos.Int) (*MsgOpenContract, error) {
+	provider, err := common.NewPubKey(memo.Provider)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidPubKey, "invalid provider pubkey (%s)", err)
+	}
+	client, err := common.NewPubKey(memo.Client)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidPubKey, "invalid client pubkey (%s)", err)
+	}
+	delegate, err := common.NewPubKey(memo.Delegate)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidPubKey, "invalid delegate pubkey (%s)", err)
+	}
+	creator, err := client.GetMyAddress()
+	if err != nil {
+		return nil, err
+	}
+	rate, ok := cosmos.NewIntFromString(memo.Rate)
+	if !ok || !rate.IsPositive() {
+		return nil, errors.Wrapf(ErrOpenContractRate, "invalid rate (%s)", memo.Rate)
+	}
+
+	msg := NewMsgOpenContract(creator, provider, memo.Service, client, delegate, memo.ContractType, memo.Duration, memo.SettlementDuration, cosmos.NewCoin(denom, rate), deposit, memo.Authorization, memo.QueriesPerMinute)
+	msg.Memo = memo.Memo
+	msg.AutoRenew = memo.AutoRenew
+	msg.SettlementInterval = memo.SettlementInterval
+	if memo.RefundAddress != "" {
+		msg.RefundAddress, err = cosmos.AccAddressFromBech32(memo.RefundAddress)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidRefundAddress, "%s", err)
+		}
+	}
+	return msg, msg.ValidateBasic()
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransferMemo(t *testing.T) {
+	ibcDenom := "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+
+	// memos for others are left alone
+	for _, memo := range []string{"", "thanks!", `{"wasm":{"contract":"x"}}`, "{bogus"} {
+		_, ok, err := ParseTransferMemo(memo)
+		require.NoError(t, err, memo)
+		require.False(t, ok, memo)
+	}
+
+	_, ok, err := ParseTransferMemo(`{"arkeo":{}}`)
+	require.True(t, ok)
+	require.Error(t, err)
+	_, ok, err = ParseTransferMemo(`{"arkeo":{"open_contract":"x"}}`)
+	require.True(t, ok)
+	require.Error(t, err)
+
+	provider := GetRandomPubKey()
+	client := GetRandomPubKey()
+	memo, ok, err := ParseTransferMemo(fmt.Sprintf(`{"arkeo":{"open_contract":{"provider":"%s","service":"btc-mainnet-fullnode","client":"%s","contract_type":"PAY_AS_YOU_GO","duration":100,"rate":"5","denom":"%s","queries_per_minute":10,"nonce":3,"signature":"c2lnbmVk"}}}`, provider, client, ibcDenom))
+	require.NoError(t, err)
+	require.True(t, ok)
+	open := memo.Arkeo.OpenContract
+	require.Equal(t, ContractType_PAY_AS_YOU_GO, open.ContractType)
+	require.Equal(t, ibcDenom, open.Denom)
+	require.Equal(t, int64(3), open.Nonce)
+	require.Equal(t, []byte("signed"), open.Signature)
+
+	// every field of the memo is signed, along with the chain
+	signBytes := open.GetBytesToSign("arkeo")
+	require.Contains(t, string(signBytes), `"chain_id":"arkeo"`)
+	require.Contains(t, string(signBytes), `"contract_type":"PAY_AS_YOU_GO"`)
+	require.NotEqual(t, signBytes, open.GetBytesToSign("arkeo-testnet"))
+	tampered := *open
+	tampered.Delegate = GetRandomPubKey().String()
+	require.NotEqual(t, signBytes, tampered.GetBytesToSign("arkeo"))
+	tampered = *open
+	tampered.RefundAddress = GetRandomBech32Addr().String()
+	require.NotEqual(t, signBytes, tampered.GetBytesToSign("arkeo"))
+	tampered = *open
+	tampered.Signature = []byte("other")
+	require.Equal(t, signBytes, tampered.GetBytesToSign("arkeo"))
+
+	msg, err := open.Msg(ibcDenom, cosmos.NewInt(500))
+	require.NoError(t, err)
+	clientAcct, err := client.GetMyAddress()
+	require.NoError(t, err)
+	require.True(t, msg.Creator.Equals(clientAcct))
+	require.Equal(t, provider, msg.Provider)
+	require.Equal(t, cosmos.NewInt64Coin(ibcDenom, 5), msg.Rate)
+	require.Equal(t, int64(500), msg.Deposit.Int64())
+
+	open.Rate = "-5"
+	_, err = open.Msg(ibcDenom, cosmos.NewInt(500))
+	require.ErrorIs(t, err, ErrOpenContractRate)
+	open.Rate = "5"
+	open.Client = "bogus"
+	_, err = open.Msg(ibcDenom, cosmos.NewInt(500))
+	require.ErrorIs(t, err, ErrInvalidPubKey)
+}
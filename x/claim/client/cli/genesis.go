@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	"github.com/spf13/cobra"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+)
+
+const (
+	FlagSnapshotFormat = "format"
+	FlagRound          = "round"
+	FlagMerkle         = "merkle"
+	FlagProofsOutput   = "proofs-output"
+)
+
+// GetGenesisCmd returns the commands preparing the claim module's genesis
+func GetGenesisCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("%s genesis subcommands", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(CmdImportAirdrop(defaultNodeHome))
+
+	return cmd
+}
+
+// merkleProofs is the output of a merkle round import, what each recipient
+// needs to claim from the round
+type merkleProofs struct {
+	Round  uint64        `json:"round"`
+	Root   string        `json:"root"`
+	Claims []merkleProof `json:"claims"`
+}
+
+type merkleProof struct {
+	EthAddress     string   `json:"eth_address"`
+	AmountClaim    string   `json:"amount_claim"`
+	AmountVote     string   `json:"amount_vote"`
+	AmountDelegate string   `json:"amount_delegate"`
+	Proof          []string `json:"proof"`
+}
+
+func CmdImportAirdrop(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-airdrop [snapshot-file]",
+		Short: "Import an airdrop snapshot into the claim genesis",
+		Long: `Import an airdrop snapshot into the claim module's genesis state.
+
+The snapshot is a csv file with a chain,address,amount_claim,amount_vote,amount_delegate
+row per recipient, or a json array of objects with those fields. Chains are
+arkeo, ethereum or thorchain.
+
+The recipients are added as claim records of the given round. With --merkle only
+the merkle root of the recipients is saved on the round, and the proofs they
+claim with are written to --proofs-output, or printed.
+
+The account funding the round must hold enough, in the genesis bank balances,
+to pay out the snapshot along with the claim records it already funds.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			cdc := clientCtx.Codec
+
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			config := serverCtx.Config
+			config.SetRoot(clientCtx.HomeDir)
+
+			format, _ := cmd.Flags().GetString(FlagSnapshotFormat)
+			if format == "" {
+				format = strings.TrimPrefix(filepath.Ext(args[0]), ".")
+			}
+			round, _ := cmd.Flags().GetUint64(FlagRound)
+			merkle, _ := cmd.Flags().GetBool(FlagMerkle)
+			proofsOutput, _ := cmd.Flags().GetString(FlagProofsOutput)
+			if merkle && round == 0 {
+				return fmt.Errorf("the initial airdrop cannot be a merkle round, set --%s", FlagRound)
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			entries, err := types.ParseAirdropSnapshot(file, types.AirdropSnapshotFormat(strings.ToLower(format)))
+			if err != nil {
+				return fmt.Errorf("failed to parse airdrop snapshot: %w", err)
+			}
+
+			genFile := config.GenesisFile()
+			appState, genDoc, err := genutiltypes.GenesisStateFromGenFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
+			var claimGenState types.GenesisState
+			cdc.MustUnmarshalJSON(appState[types.ModuleName], &claimGenState)
+
+			var proofs *merkleProofs
+			if merkle {
+				proofs, err = importMerkleRound(&claimGenState, entries, round)
+			} else {
+				err = importClaimRecords(&claimGenState, entries, round)
+			}
+			if err != nil {
+				return err
+			}
+			if err := validateAirdropFunding(cdc, appState, claimGenState, entries, round, merkle); err != nil {
+				return err
+			}
+			if err := claimGenState.Validate(); err != nil {
+				return fmt.Errorf("invalid claim genesis state: %w", err)
+			}
+
+			appState[types.ModuleName] = cdc.MustMarshalJSON(&claimGenState)
+			genDoc.AppState, err = json.Marshal(appState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal genesis state: %w", err)
+			}
+			if err := genutil.ExportGenesisFile(genDoc, genFile); err != nil {
+				return err
+			}
+
+			if proofs == nil {
+				return nil
+			}
+			bz, err := json.MarshalIndent(proofs, "", "  ")
+			if err != nil {
+				return err
+			}
+			if proofsOutput == "" {
+				return clientCtx.PrintBytes(bz)
+			}
+			return os.WriteFile(proofsOutput, bz, 0o600)
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(FlagSnapshotFormat, "", "Format of the snapshot, csv or json (defaults to the file extension)")
+	cmd.Flags().Uint64(FlagRound, 0, "Airdrop round the snapshot is for, zero for the initial airdrop")
+	cmd.Flags().Bool(FlagMerkle, false, "Save the merkle root of the snapshot on the round instead of claim records")
+	cmd.Flags().String(FlagProofsOutput, "", "File to write the merkle proofs to, printed when empty")
+
+	return cmd
+}
+
+// importClaimRecords adds the recipients as claim records of the round
+func importClaimRecords(genState *types.GenesisState, entries []types.AirdropEntry, round uint64) error {
+	if round > 0 {
+		r, ok := genState.Params.GetMerkleRound(round)
+		if !ok {
+			return fmt.Errorf("airdrop round %d is not in the claim params", round)
+		}
+		if r.HasMerkleRoot() {
+			return fmt.Errorf("airdrop round %d is committed to by a merkle root, import it with --%s", round, FlagMerkle)
+		}
+	}
+
+	existing := make(map[string]bool, len(genState.ClaimRecords))
+	for _, record := range genState.ClaimRecords {
+		existing[claimRecordKey(record.Round, record.Chain, record.Address)] = true
+	}
+
+	denom := genState.Params.ClaimDenom
+	for _, entry := range entries {
+		if existing[claimRecordKey(round, entry.Chain, entry.Address)] {
+			return fmt.Errorf("%s address %s already has a claim record in round %d", entry.Chain, entry.Address, round)
+		}
+		genState.ClaimRecords = append(genState.ClaimRecords, entry.ClaimRecord(denom, round))
+	}
+
+	// an exported genesis carries its airdrop totals, a new genesis has them
+	// computed from the claim records at init
+	if !genState.AirdropTotals.GenesisTotal.Empty() {
+		genState.AirdropTotals.GenesisTotal = genState.AirdropTotals.GenesisTotal.Add(sdk.NewCoin(denom, types.AirdropTotal(entries)))
+	}
+	return nil
+}
+
+func claimRecordKey(round uint64, chain types.Chain, address string) string {
+	if normalized, err := types.NormalizeAddress(address, chain); err == nil {
+		address = normalized
+	}
+	return fmt.Sprintf("%d/%s/%s", round, chain, address)
+}
+
+// importMerkleRound saves the merkle root of the recipients on the round,
+// adding the round when it is not in the params yet
+func importMerkleRound(genState *types.GenesisState, entries []types.AirdropEntry, round uint64) (*merkleProofs, error) {
+	for _, record := range genState.ClaimRecords {
+		if record.Round == round {
+			return nil, fmt.Errorf("airdrop round %d already has claim records, it cannot be a merkle round", round)
+		}
+	}
+
+	root, leafProofs, err := types.AirdropMerkleTree(entries)
+	if err != nil {
+		return nil, err
+	}
+	rootHex := "0x" + hex.EncodeToString(root)
+
+	found := false
+	for i := range genState.Params.MerkleRounds {
+		if genState.Params.MerkleRounds[i].Round == round {
+			genState.Params.MerkleRounds[i].Root = rootHex
+			found = true
+		}
+	}
+	if !found {
+		genState.Params.MerkleRounds = append(genState.Params.MerkleRounds, types.MerkleRound{Round: round, Root: rootHex})
+	}
+
+	proofs := &merkleProofs{Round: round, Root: rootHex, Claims: make([]merkleProof, len(entries))}
+	for i, entry := range entries {
+		proof := make([]string, len(leafProofs[i]))
+		for j, sibling := range leafProofs[i] {
+			proof[j] = "0x" + hex.EncodeToString(sibling)
+		}
+		proofs.Claims[i] = merkleProof{
+			EthAddress:     entry.Address,
+			AmountClaim:    entry.AmountClaim.String(),
+			AmountVote:     entry.AmountVote.String(),
+			AmountDelegate: entry.AmountDelegate.String(),
+			Proof:          proof,
+		}
+	}
+	return proofs, nil
+}
+
+// validateAirdropFunding checks the account funding the round holds enough,
+// in the genesis bank balances, to pay out the snapshot along with every
+// claim record it funds
+func validateAirdropFunding(cdc codec.JSONCodec, appState map[string]json.RawMessage, genState types.GenesisState, entries []types.AirdropEntry, round uint64, merkle bool) error {
+	account := types.ModuleName
+	if r, ok := genState.Params.GetMerkleRound(round); ok {
+		account = r.GetFundingAccount()
+	}
+	denom := genState.Params.ClaimDenom
+
+	required := sdk.ZeroInt()
+	if merkle {
+		required = types.AirdropTotal(entries)
+	}
+	for i := range genState.ClaimRecords {
+		record := genState.ClaimRecords[i]
+		funding := types.ModuleName
+		if r, ok := genState.Params.GetMerkleRound(record.Round); ok {
+			funding = r.GetFundingAccount()
+		}
+		if funding == account {
+			required = required.Add(record.Outstanding().AmountOf(denom))
+		}
+	}
+
+	address := authtypes.NewModuleAddress(account).String()
+	balance := sdk.ZeroInt()
+	for _, b := range banktypes.GetGenesisStateFromAppState(cdc, appState).Balances {
+		if b.Address == address {
+			balance = b.Coins.AmountOf(denom)
+		}
+	}
+	if balance.LT(required) {
+		return fmt.Errorf("%s module account %s holds %s%s, the airdrop requires %s%s", account, address, balance, denom, required, denom)
+	}
+	return nil
+}
@@ -23,6 +23,10 @@ func GetQueryCmd(queryRoute string) *cobra.Command {
 
 	cmd.AddCommand(CmdQueryParams())
 	cmd.AddCommand(CmdClaimRecord())
+	cmd.AddCommand(CmdClaimMapping())
+	cmd.AddCommand(CmdClaimStatus())
+	cmd.AddCommand(CmdListClaimRecord())
+	cmd.AddCommand(CmdEthClaimTypedData())
 
 	// this line is used by starport scaffolding # 1
 
@@ -41,9 +41,15 @@ func CmdClaimRecord() *cobra.Command {
 
 			queryClient := types.NewQueryClient(clientCtx)
 
+			round, err := cmd.Flags().GetUint64(flagRound)
+			if err != nil {
+				return err
+			}
+
 			params := &types.QueryClaimRecordRequest{
 				Chain:   chain,
 				Address: reqAddress,
+				Round:   round,
 			}
 
 			res, err := queryClient.ClaimRecord(cmd.Context(), params)
@@ -55,6 +61,7 @@ func CmdClaimRecord() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Uint64(flagRound, 0, "airdrop round of the claim record, zero for the initial airdrop")
 	flags.AddQueryFlagsToCmd(cmd)
 
 	return cmd
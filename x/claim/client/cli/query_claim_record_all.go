@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+)
+
+const flagCSV = "csv"
+
+func CmdListClaimRecord() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-claim-record [chain]",
+		Short: "list all claim records of a chain, ordered by address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, err := types.ChainFromString(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid chain %s", args[0])
+			}
+
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			params := &types.QueryAllClaimRecordRequest{
+				Chain:      chain,
+				Pagination: pageReq,
+			}
+
+			res, err := queryClient.ClaimRecordAll(cmd.Context(), params)
+			if err != nil {
+				return err
+			}
+
+			asCSV, err := cmd.Flags().GetBool(flagCSV)
+			if err != nil {
+				return err
+			}
+			if !asCSV {
+				return clientCtx.PrintProto(res)
+			}
+
+			w := csv.NewWriter(cmd.OutOrStdout())
+			if err := w.Write([]string{"address", "amount", "claimed", "completed_actions"}); err != nil {
+				return err
+			}
+			for _, record := range res.ClaimRecords {
+				actions := make([]string, len(record.CompletedActions))
+				for i, action := range record.CompletedActions {
+					actions[i] = action.String()
+				}
+				row := []string{
+					record.Address,
+					record.Amount.String(),
+					fmt.Sprintf("%t", record.Claimed),
+					strings.Join(actions, ";"),
+				}
+				if err := w.Write(row); err != nil {
+					return err
+				}
+			}
+			w.Flush()
+			return w.Error()
+		},
+	}
+
+	cmd.Flags().Bool(flagCSV, false, "print the records as csv, for audits")
+	flags.AddPaginationFlagsToCmd(cmd, cmd.Use)
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+)
+
+func CmdClaimStatus() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-status [address] [chain]",
+		Short: "Query the claim status of an address on a chain, including ethereum and thorchain addresses",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqAddress := args[0]
+			reqChain := args[1]
+
+			// validate chain
+			chainId, ok := types.Chain_value[strings.ToUpper(reqChain)]
+			if !ok {
+				return fmt.Errorf("invalid chain %s", reqChain)
+			}
+			chain := types.Chain(chainId)
+
+			// validate address if valid based on chain
+			if !types.IsValidAddress(reqAddress, chain) {
+				return fmt.Errorf("invalid address %s", reqAddress)
+			}
+
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			round, err := cmd.Flags().GetUint64(flagRound)
+			if err != nil {
+				return err
+			}
+
+			params := &types.QueryClaimStatusRequest{
+				Chain:   chain,
+				Address: reqAddress,
+				Round:   round,
+			}
+
+			res, err := queryClient.ClaimStatus(cmd.Context(), params)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().Uint64(flagRound, 0, "airdrop round to query, zero for the initial airdrop")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+)
+
+const flagAmount = "amount"
+
+func CmdEthClaimTypedData() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "eth-claim-typed-data [eth-address] [arkeo-address]",
+		Short: "Print the EIP-712 typed data an ethereum address signs to claim",
+		Long: `Print the EIP-712 typed data an ethereum address signs to claim its airdrop
+to an arkeo address, as passed to eth_signTypedData_v4 by wallets such as MetaMask.
+The amount is read from the address's claim record, the chain id from --chain-id.
+Claims from a round with a merkle root sign the sum of the amounts of their leaf,
+passed with --amount.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ethAddress := args[0]
+			if !types.IsValidEthAddress(ethAddress) {
+				return fmt.Errorf("invalid eth address %s", ethAddress)
+			}
+			arkeoAddress, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid arkeo address %s: %w", args[1], err)
+			}
+
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			if clientCtx.ChainID == "" {
+				return fmt.Errorf("--%s is required", flags.FlagChainID)
+			}
+
+			amount, err := cmd.Flags().GetString(flagAmount)
+			if err != nil {
+				return err
+			}
+			if amount == "" {
+				round, err := cmd.Flags().GetUint64(flagRound)
+				if err != nil {
+					return err
+				}
+				res, err := types.NewQueryClient(clientCtx).ClaimRecord(cmd.Context(), &types.QueryClaimRecordRequest{
+					Chain:   types.ETHEREUM,
+					Address: ethAddress,
+					Round:   round,
+				})
+				if err != nil {
+					return err
+				}
+				record := res.ClaimRecord
+				if record == nil || record.IsEmpty() {
+					return fmt.Errorf("no claim record for %s", ethAddress)
+				}
+				amount = record.AmountClaim.Add(record.AmountVote).Add(record.AmountDelegate).Amount.String()
+			}
+
+			typedData := types.NewClaimTypedData(ethAddress, arkeoAddress.String(), clientCtx.ChainID, amount)
+			bz, err := json.MarshalIndent(typedData, "", "  ")
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+
+	cmd.Flags().Uint64(flagRound, 0, "airdrop round of the claim record, zero for the initial airdrop")
+	cmd.Flags().String(flagAmount, "", "amount to sign instead of the claim record's, for merkle round claims")
+	cmd.Flags().String(flags.FlagChainID, "", "arkeo chain id the claim is bound to")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -27,6 +27,8 @@ func GetTxCmd() *cobra.Command {
 	cmd.AddCommand(CmdClaimArkeo())
 	cmd.AddCommand(CmdTransferClaim())
 	cmd.AddCommand(CmdAddClaim())
+	cmd.AddCommand(CmdClaimAll())
+	cmd.AddCommand(CmdClaimThorchain())
 	// this line is used by starport scaffolding # 1
 
 	return cmd
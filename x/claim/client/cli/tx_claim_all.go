@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cobra"
+)
+
+func CmdClaimAll() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-all",
+		Short: "Broadcast message claim-all",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgClaimAll(
+				clientCtx.GetFromAddress(),
+			)
+			msg.Round, err = cmd.Flags().GetUint64(flagRound)
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().Uint64(flagRound, 0, "airdrop round to claim from, zero for the initial airdrop")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
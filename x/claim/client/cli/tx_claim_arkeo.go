@@ -22,6 +22,10 @@ func CmdClaimArkeo() *cobra.Command {
 			msg := types.NewMsgClaimArkeo(
 				clientCtx.GetFromAddress(),
 			)
+			msg.Round, err = cmd.Flags().GetUint64(flagRound)
+			if err != nil {
+				return err
+			}
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -29,6 +33,7 @@ func CmdClaimArkeo() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Uint64(flagRound, 0, "airdrop round to claim from, zero for the initial airdrop")
 	flags.AddTxFlagsToCmd(cmd)
 
 	return cmd
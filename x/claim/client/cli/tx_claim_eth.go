@@ -1,18 +1,31 @@
 package cli
 
 import (
+	"fmt"
+
 	"github.com/arkeonetwork/arkeo/x/claim/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
 )
 
+const (
+	flagRound          = "round"
+	flagProof          = "proof"
+	flagAmountClaim    = "amount-claim"
+	flagAmountVote     = "amount-vote"
+	flagAmountDelegate = "amount-delegate"
+)
+
 func CmdClaimEth() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "claim-eth [eth-address] [signature]",
 		Short: "Broadcast message claim-eth",
-		Args:  cobra.ExactArgs(2),
+		Long: `Claim the airdrop of an ethereum address. Claims from a round with a merkle
+root pass the round, the amounts of the address's leaf and the proof of the leaf.`,
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			argEthAdress := args[0]
 			argSignature := args[1]
@@ -27,6 +40,30 @@ func CmdClaimEth() *cobra.Command {
 				argEthAdress,
 				argSignature,
 			)
+
+			msg.Round, err = cmd.Flags().GetUint64(flagRound)
+			if err != nil {
+				return err
+			}
+			if msg.Round > 0 {
+				msg.Proof, err = cmd.Flags().GetStringSlice(flagProof)
+				if err != nil {
+					return err
+				}
+				amounts := []*sdk.Int{&msg.AmountClaim, &msg.AmountVote, &msg.AmountDelegate}
+				for i, flag := range []string{flagAmountClaim, flagAmountVote, flagAmountDelegate} {
+					value, err := cmd.Flags().GetString(flag)
+					if err != nil {
+						return err
+					}
+					amount, ok := sdk.NewIntFromString(value)
+					if !ok {
+						return fmt.Errorf("invalid %s: %s", flag, value)
+					}
+					*amounts[i] = amount
+				}
+			}
+
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -34,6 +71,11 @@ func CmdClaimEth() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Uint64(flagRound, 0, "airdrop round to claim from, zero for the initial airdrop")
+	cmd.Flags().StringSlice(flagProof, nil, "hex encoded merkle proof of the leaf, comma separated")
+	cmd.Flags().String(flagAmountClaim, "0", "claim action amount of the leaf")
+	cmd.Flags().String(flagAmountVote, "0", "vote action amount of the leaf")
+	cmd.Flags().String(flagAmountDelegate, "0", "delegate action amount of the leaf")
 	flags.AddTxFlagsToCmd(cmd)
 
 	return cmd
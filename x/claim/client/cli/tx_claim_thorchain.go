@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagPubKey           = "pub-key"
+	flagSignature        = "signature"
+	flagTxID             = "tx-id"
+	flagOracleSignatures = "oracle-signatures"
+)
+
+func CmdClaimThorchain() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-thorchain [thor-address]",
+		Short: "Broadcast message claim-thorchain",
+		Long: `Claim the airdrop of a thorchain address. The claim is either signed by the
+thorchain address, passing its public key and signature, or proven by a thorchain
+transaction carrying the claim memo, passing its tx id and the oracle signatures
+attesting to it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgClaimThorchain(clientCtx.GetFromAddress(), args[0])
+
+			msg.Round, err = cmd.Flags().GetUint64(flagRound)
+			if err != nil {
+				return err
+			}
+			msg.TxId, err = cmd.Flags().GetString(flagTxID)
+			if err != nil {
+				return err
+			}
+			for flag, value := range map[string]*[]byte{flagPubKey: &msg.PubKey, flagSignature: &msg.Signature} {
+				str, err := cmd.Flags().GetString(flag)
+				if err != nil {
+					return err
+				}
+				if *value, err = hex.DecodeString(str); err != nil {
+					return fmt.Errorf("invalid %s: %w", flag, err)
+				}
+			}
+			sigs, err := cmd.Flags().GetStringSlice(flagOracleSignatures)
+			if err != nil {
+				return err
+			}
+			for _, sig := range sigs {
+				bz, err := hex.DecodeString(sig)
+				if err != nil {
+					return fmt.Errorf("invalid %s: %w", flagOracleSignatures, err)
+				}
+				msg.OracleSignatures = append(msg.OracleSignatures, bz)
+			}
+
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().Uint64(flagRound, 0, "airdrop round to claim from, zero for the initial airdrop")
+	cmd.Flags().String(flagPubKey, "", "hex encoded compressed public key of the thorchain address")
+	cmd.Flags().String(flagSignature, "", "hex encoded signature of the claim by the thorchain address")
+	cmd.Flags().String(flagTxID, "", "hash of the thorchain transaction carrying the claim memo")
+	cmd.Flags().StringSlice(flagOracleSignatures, nil, "hex encoded oracle signatures attesting to the transaction, comma separated")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -14,6 +14,22 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState)
 	if err != nil {
 		panic(err) // if genesis fails we should panic
 	}
+
+	// an exported genesis carries its airdrop totals, otherwise this is the
+	// initial airdrop and the total is whatever the claim records hold
+	totals := genState.AirdropTotals
+	if totals.GenesisTotal.Empty() {
+		totals.GenesisTotal, err = k.GetOutstandingClaims(ctx)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if err := k.SetAirdropTotals(ctx, totals); err != nil {
+		panic(err)
+	}
+	if err := k.SetMerkleClaims(ctx, genState.MerkleClaims); err != nil {
+		panic(err)
+	}
 }
 
 // ExportGenesis returns the module's exported genesis
@@ -26,5 +42,10 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 		panic(err)
 	}
 	genesis.ClaimRecords = claimRecords
+	genesis.AirdropTotals, err = k.GetAirdropTotals(ctx)
+	if err != nil {
+		panic(err)
+	}
+	genesis.MerkleClaims = k.GetMerkleClaims(ctx)
 	return genesis
 }
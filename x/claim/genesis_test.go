@@ -77,7 +77,7 @@ func TestGenesis(t *testing.T) {
 		AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 1500000000),
 	})
 
-	claimableAmount, err := testKeeepers.ClaimKeeper.GetClaimableAmountForAction(ctx, addr2, types.ACTION_VOTE, types.ARKEO)
+	claimableAmount, err := testKeeepers.ClaimKeeper.GetClaimableAmountForAction(ctx, 0, addr2, types.ACTION_VOTE, types.ARKEO)
 	require.NoError(t, err)
 	require.Equal(t, claimableAmount, sdk.NewInt64Coin(types.DefaultClaimDenom, 1500000000))
 
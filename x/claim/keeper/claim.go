@@ -1,36 +1,54 @@
 package keeper
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/arkeonetwork/arkeo/x/claim/types"
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/pkg/errors"
 )
 
 // SetClaimRecord sets a claim record for an address in store
 func (k Keeper) SetClaimRecord(ctx sdk.Context, claimRecord types.ClaimRecord) error {
-	// validate address if valid based on chain
-	if !types.IsValidAddress(claimRecord.Address, claimRecord.Chain) {
-		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid address for chain %s", claimRecord.Chain.String())
+	// keyed by the normalized address, which also validates it for the chain
+	addr, err := types.GetClaimRecordKey(claimRecord.Address, claimRecord.Chain)
+	if err != nil {
+		return err
 	}
 
 	store := ctx.KVStore(k.storeKey)
-	prefixStore := prefix.NewStore(store, chainToStorePrefix(claimRecord.Chain))
+	prefixStore := prefix.NewStore(store, claimRecordStorePrefix(claimRecord.Round, claimRecord.Chain))
 
 	bz, err := k.cdc.Marshal(&claimRecord)
 	if err != nil {
 		return err
 	}
 
-	addr := []byte(strings.ToLower(claimRecord.Address))
-
 	prefixStore.Set(addr, bz)
 	return nil
 }
 
+// DeleteClaimRecord removes the claim record of an address in the initial
+// airdrop from the store
+func (k Keeper) DeleteClaimRecord(ctx sdk.Context, addr string, chain types.Chain) error {
+	return k.DeleteRoundClaimRecord(ctx, 0, addr, chain)
+}
+
+// DeleteRoundClaimRecord removes the claim record of an address in an airdrop
+// round from the store
+func (k Keeper) DeleteRoundClaimRecord(ctx sdk.Context, round uint64, addr string, chain types.Chain) error {
+	addrBytes, err := types.GetClaimRecordKey(addr, chain)
+	if err != nil {
+		return err
+	}
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, claimRecordStorePrefix(round, chain))
+	prefixStore.Delete(addrBytes)
+	return nil
+}
+
 // SetClaimables set claimable amount from balances object
 func (k Keeper) SetClaimRecords(ctx sdk.Context, claimRecords []types.ClaimRecord) error {
 	for _, claimRecord := range claimRecords {
@@ -42,10 +60,20 @@ func (k Keeper) SetClaimRecords(ctx sdk.Context, claimRecords []types.ClaimRecor
 	return nil
 }
 
-// GetClaimables get claimables for genesis export
+// GetClaimRecords returns the claim records of a chain in the initial airdrop
 func (k Keeper) GetClaimRecords(ctx sdk.Context, chain types.Chain) ([]types.ClaimRecord, error) {
+	return k.GetRoundClaimRecords(ctx, 0, chain)
+}
+
+// GetRoundClaimRecords returns the claim records of a chain in an airdrop
+// round
+func (k Keeper) GetRoundClaimRecords(ctx sdk.Context, round uint64, chain types.Chain) ([]types.ClaimRecord, error) {
+	return k.getClaimRecords(ctx, claimRecordStorePrefix(round, chain))
+}
+
+func (k Keeper) getClaimRecords(ctx sdk.Context, storePrefix []byte) ([]types.ClaimRecord, error) {
 	store := ctx.KVStore(k.storeKey)
-	prefixStore := prefix.NewStore(store, chainToStorePrefix(chain))
+	prefixStore := prefix.NewStore(store, storePrefix)
 
 	iterator := prefixStore.Iterator(nil, nil)
 	defer iterator.Close()
@@ -64,40 +92,58 @@ func (k Keeper) GetClaimRecords(ctx sdk.Context, chain types.Chain) ([]types.Cla
 	return claimRecords, nil
 }
 
+// GetAllClaimRecords returns the claim records of every chain in every
+// airdrop round, for genesis export. Chains are in enum order, so the export
+// is deterministic.
 func (k Keeper) GetAllClaimRecords(ctx sdk.Context) ([]types.ClaimRecord, error) {
 	claimRecords := []types.ClaimRecord{}
-	for chain := range types.Chain_name {
-		records, err := k.GetClaimRecords(ctx, types.Chain(chain))
+	for i := 0; i < len(types.Chain_name); i++ {
+		records, err := k.GetClaimRecords(ctx, types.Chain(i))
 		if err != nil {
 			return nil, err
 		}
 		claimRecords = append(claimRecords, records...)
 	}
-	return claimRecords, nil
+	records, err := k.getClaimRecords(ctx, types.KeyPrefix(types.RoundClaimRecordsStorePrefix))
+	if err != nil {
+		return nil, err
+	}
+	return append(claimRecords, records...), nil
 }
 
-// GetClaimRecord returns the claim record for a specific address
+// GetClaimRecord returns the claim record for a specific address in the
+// initial airdrop
 func (k Keeper) GetClaimRecord(ctx sdk.Context, addr string, chain types.Chain) (types.ClaimRecord, error) {
+	return k.GetRoundClaimRecord(ctx, 0, addr, chain)
+}
+
+// GetRoundClaimRecord returns the claim record for a specific address in an
+// airdrop round
+func (k Keeper) GetRoundClaimRecord(ctx sdk.Context, round uint64, addr string, chain types.Chain) (types.ClaimRecord, error) {
 	store := ctx.KVStore(k.storeKey)
-	prefixStore := prefix.NewStore(store, chainToStorePrefix(chain))
-	addrBytes := []byte(strings.ToLower(addr))
+	prefixStore := prefix.NewStore(store, claimRecordStorePrefix(round, chain))
+	addrBytes, err := types.GetClaimRecordKey(addr, chain)
+	if err != nil {
+		// no record can be stored under an invalid address
+		return types.ClaimRecord{}, nil
+	}
 	if !prefixStore.Has(addrBytes) {
 		return types.ClaimRecord{}, nil
 	}
 	bz := prefixStore.Get(addrBytes)
 
 	claimRecord := types.ClaimRecord{}
-	err := k.cdc.Unmarshal(bz, &claimRecord)
-	if err != nil {
+	if err := k.cdc.Unmarshal(bz, &claimRecord); err != nil {
 		return types.ClaimRecord{}, err
 	}
 
 	return claimRecord, nil
 }
 
-// GetUserTotalClaimable returns the total claimable amount for an address across all actions
-func (k Keeper) GetUserTotalClaimable(ctx sdk.Context, addr string, chain types.Chain) (sdk.Coin, error) {
-	claimRecord, err := k.GetClaimRecord(ctx, addr, chain)
+// GetUserTotalClaimable returns the total claimable amount for an address in
+// an airdrop round across all actions
+func (k Keeper) GetUserTotalClaimable(ctx sdk.Context, round uint64, addr string, chain types.Chain) (sdk.Coin, error) {
+	claimRecord, err := k.GetRoundClaimRecord(ctx, round, addr, chain)
 	if err != nil {
 		return sdk.Coin{}, err
 	}
@@ -107,7 +153,7 @@ func (k Keeper) GetUserTotalClaimable(ctx sdk.Context, addr string, chain types.
 
 	totalClaimable := sdk.NewCoin(claimRecord.AmountClaim.Denom, sdk.ZeroInt())
 	for action := range types.Action_name {
-		claimableForAction, err := k.GetClaimableAmountForAction(ctx, addr, types.Action(action), chain)
+		claimableForAction, err := k.GetClaimableAmountForAction(ctx, round, addr, types.Action(action), chain)
 		if err != nil {
 			return sdk.Coin{}, err
 		}
@@ -119,9 +165,10 @@ func (k Keeper) GetUserTotalClaimable(ctx sdk.Context, addr string, chain types.
 	return totalClaimable, nil
 }
 
-// GetClaimable returns claimable amount for a specific action done by an address
-func (k Keeper) GetClaimableAmountForAction(ctx sdk.Context, addr string, action types.Action, chain types.Chain) (sdk.Coin, error) {
-	claimRecord, err := k.GetClaimRecord(ctx, addr, chain)
+// GetClaimable returns claimable amount for a specific action done by an
+// address in an airdrop round
+func (k Keeper) GetClaimableAmountForAction(ctx sdk.Context, round uint64, addr string, action types.Action, chain types.Chain) (sdk.Coin, error) {
+	claimRecord, err := k.GetRoundClaimRecord(ctx, round, addr, chain)
 	if err != nil {
 		return sdk.Coin{}, err
 	}
@@ -130,40 +177,84 @@ func (k Keeper) GetClaimableAmountForAction(ctx sdk.Context, addr string, action
 		return sdk.Coin{}, nil
 	}
 
+	initalClaimableAmount := getInitialClaimableAmount(claimRecord, action)
+	if initalClaimableAmount.IsNil() || initalClaimableAmount.Amount.IsZero() {
+		return sdk.Coin{}, nil
+	}
+
+	claimablePercent, err := k.getClaimablePercent(ctx, round)
+	if err != nil {
+		return sdk.Coin{}, errors.Wrapf(err, "airdrop claim for %s", addr)
+	}
+	if claimablePercent.IsZero() {
+		return sdk.Coin{}, nil
+	}
+	if claimablePercent.Equal(sdk.OneDec()) {
+		return initalClaimableAmount, nil
+	}
+
+	claimableAmount := initalClaimableAmount.Amount.Mul(claimablePercent.Mul(sdk.NewDec(10000)).RoundInt()).QuoRaw(10000)
+	claimableCoin := sdk.NewCoin(initalClaimableAmount.Denom, claimableAmount)
+
+	return claimableCoin, nil
+}
+
+// getClaimablePercent returns the share of its initial amounts a claim record
+// of the round can claim at the current block, zero before the round starts.
+// The initial airdrop decays over time while later rounds decay over blocks.
+func (k Keeper) getClaimablePercent(ctx sdk.Context, round uint64) (sdk.Dec, error) {
 	params := k.GetParams(ctx)
 
+	if round > 0 {
+		r, ok := params.GetMerkleRound(round)
+		if !ok {
+			return sdk.ZeroDec(), errors.Wrapf(types.ErrMerkleRoundNotFound, "round %d", round)
+		}
+		if ctx.BlockHeight() < r.StartHeight {
+			return sdk.ZeroDec(), nil
+		}
+		elapsed := ctx.BlockHeight() - r.StartHeight
+		if !r.HasDecay() || elapsed <= r.BlocksUntilDecay {
+			return sdk.OneDec(), nil
+		}
+		if r.HasEnded(ctx.BlockHeight()) {
+			return sdk.ZeroDec(), errors.Wrapf(types.ErrAirdropEnded, "round %d has ended", round)
+		}
+		decayPercent := sdk.NewDec(elapsed - r.BlocksUntilDecay).QuoInt64(r.BlocksOfDecay)
+		return sdk.OneDec().Sub(decayPercent), nil
+	}
+
 	// If we are before the start time, do nothing.
 	// This case _shouldn't_ occur on chain, since the
 	// start time ought to be chain start time.
 	if ctx.BlockTime().Before(params.AirdropStartTime) {
-		return sdk.Coin{}, nil
-	}
-
-	initalClaimableAmount := getInitialClaimableAmount(claimRecord, action)
-	if initalClaimableAmount.IsNil() || initalClaimableAmount.Amount.IsZero() {
-		return sdk.Coin{}, nil
+		return sdk.ZeroDec(), nil
 	}
 
 	elapsedAirdropTime := ctx.BlockTime().Sub(params.AirdropStartTime)
 	// Are we early enough in the airdrop s.t. theres no decay?
 	if elapsedAirdropTime <= params.DurationUntilDecay {
-		return initalClaimableAmount, nil
+		return sdk.OneDec(), nil
 	}
 
 	// The entire airdrop has completed
 	if elapsedAirdropTime > params.DurationUntilDecay+params.DurationOfDecay {
-		return sdk.Coin{}, types.ErrAirdropEnded
+		return sdk.ZeroDec(), errors.Wrap(types.ErrAirdropEnded, "airdrop has expired")
 	}
 
 	// Positive, since goneTime > params.DurationUntilDecay
 	decayTime := elapsedAirdropTime - params.DurationUntilDecay
 	decayPercent := sdk.NewDec(decayTime.Nanoseconds()).QuoInt64(params.DurationOfDecay.Nanoseconds())
-	claimablePercent := sdk.OneDec().Sub(decayPercent)
-
-	claimableAmount := initalClaimableAmount.Amount.Mul(claimablePercent.Mul(sdk.NewDec(10000)).RoundInt()).QuoRaw(10000)
-	claimableCoin := sdk.NewCoin(initalClaimableAmount.Denom, claimableAmount)
+	return sdk.OneDec().Sub(decayPercent), nil
+}
 
-	return claimableCoin, nil
+// getFundingAccount returns the module account an airdrop round is paid out
+// from
+func (k Keeper) getFundingAccount(ctx sdk.Context, round uint64) string {
+	if r, ok := k.GetParams(ctx).GetMerkleRound(round); ok {
+		return r.GetFundingAccount()
+	}
+	return types.ModuleName
 }
 
 // GetModuleAccountBalance gets the airdrop coin balance of module account
@@ -178,9 +269,10 @@ func (k Keeper) GetModuleAccountBalance(ctx sdk.Context) sdk.Coin {
 	return k.bankKeeper.GetBalance(ctx, moduleAccAddr, params.ClaimDenom)
 }
 
-// ClaimCoins remove claimable amount entry and transfer it to user's account
-func (k Keeper) ClaimCoinsForAction(ctx sdk.Context, addr string, action types.Action) (sdk.Coin, error) {
-	claimableAmount, err := k.GetClaimableAmountForAction(ctx, addr, action, types.ARKEO)
+// ClaimCoins remove claimable amount entry of an airdrop round and transfer it
+// to user's account
+func (k Keeper) ClaimCoinsForAction(ctx sdk.Context, round uint64, addr string, action types.Action) (sdk.Coin, error) {
+	claimableAmount, err := k.releaseCoinsForAction(ctx, round, addr, action)
 	if err != nil {
 		return claimableAmount, err
 	}
@@ -189,7 +281,76 @@ func (k Keeper) ClaimCoinsForAction(ctx sdk.Context, addr string, action types.A
 		return claimableAmount, nil
 	}
 
-	claimRecord, err := k.GetClaimRecord(ctx, addr, types.ARKEO)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeClaim,
+			sdk.NewAttribute(sdk.AttributeKeySender, addr),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, claimableAmount.String()),
+			sdk.NewAttribute(types.AttributeKeyRound, strconv.FormatUint(round, 10)),
+		),
+	})
+
+	return claimableAmount, nil
+}
+
+// ClaimCoinsForActions releases the claimable amount of each of the given
+// actions in an airdrop round in a single transfer and emits one aggregated
+// claim event. Actions with nothing left to claim are skipped, so calling it
+// again is a no-op.
+func (k Keeper) ClaimCoinsForActions(ctx sdk.Context, round uint64, addr string, actions []types.Action) (sdk.Coin, []types.Action, error) {
+	var total sdk.Coin
+	settled := make([]types.Action, 0, len(actions))
+	for _, action := range actions {
+		claimableAmount, err := k.releaseCoinsForAction(ctx, round, addr, action)
+		if err != nil {
+			return sdk.Coin{}, nil, err
+		}
+		if claimableAmount.IsNil() || claimableAmount.IsZero() {
+			continue
+		}
+		if total.IsNil() {
+			total = claimableAmount
+		} else {
+			total = total.Add(claimableAmount)
+		}
+		settled = append(settled, action)
+	}
+
+	if len(settled) == 0 {
+		return sdk.NewCoin(k.GetParams(ctx).ClaimDenom, sdk.ZeroInt()), settled, nil
+	}
+
+	actionNames := make([]string, len(settled))
+	for i, action := range settled {
+		actionNames[i] = action.String()
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeClaim,
+			sdk.NewAttribute(sdk.AttributeKeySender, addr),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, total.String()),
+			sdk.NewAttribute(types.AttributeKeyActions, strings.Join(actionNames, ",")),
+			sdk.NewAttribute(types.AttributeKeyRound, strconv.FormatUint(round, 10)),
+		),
+	})
+
+	return total, settled, nil
+}
+
+// releaseCoinsForAction transfers the claimable amount of an action to the
+// user's account from the round's funding account and marks the action as
+// completed, without emitting events
+func (k Keeper) releaseCoinsForAction(ctx sdk.Context, round uint64, addr string, action types.Action) (sdk.Coin, error) {
+	claimableAmount, err := k.GetClaimableAmountForAction(ctx, round, addr, action, types.ARKEO)
+	if err != nil {
+		return claimableAmount, err
+	}
+
+	if claimableAmount.IsNil() || claimableAmount.IsZero() {
+		return claimableAmount, nil
+	}
+
+	claimRecord, err := k.GetRoundClaimRecord(ctx, round, addr, types.ARKEO)
 	if err != nil {
 		return sdk.Coin{}, err
 	}
@@ -198,28 +359,114 @@ func (k Keeper) ClaimCoinsForAction(ctx sdk.Context, addr string, action types.A
 	if err != nil {
 		return sdk.Coin{}, err
 	}
-	err = k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, accountAddress, sdk.NewCoins(claimableAmount))
+	err = k.bankKeeper.SendCoinsFromModuleToAccount(ctx, k.getFundingAccount(ctx, round), accountAddress, sdk.NewCoins(claimableAmount))
 	if err != nil {
 		return sdk.Coin{}, err
 	}
 
+	// the full initial amount leaves the claim record, including any part
+	// that was lost to decay
+	initialAmount := getInitialClaimableAmount(claimRecord, action)
 	claimRecord = setClaimableAmountForAction(claimRecord, action, sdk.Coin{}) // set to nil/zero to mark as completed.
 	err = k.SetClaimRecord(ctx, claimRecord)
 	if err != nil {
 		return sdk.Coin{}, err
 	}
 
-	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			types.EventTypeClaim,
-			sdk.NewAttribute(sdk.AttributeKeySender, addr),
-			sdk.NewAttribute(sdk.AttributeKeyAmount, claimableAmount.String()),
-		),
-	})
+	if err := k.addClaimed(ctx, initialAmount); err != nil {
+		return sdk.Coin{}, err
+	}
 
 	return claimableAmount, nil
 }
 
+// GetAirdropTotals returns the airdrop totals used to check claim records are
+// conserved
+func (k Keeper) GetAirdropTotals(ctx sdk.Context) (types.AirdropTotals, error) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.KeyPrefix(types.AirdropTotalsKey))
+	if bz == nil {
+		return types.AirdropTotals{}, nil
+	}
+
+	totals := types.AirdropTotals{}
+	if err := k.cdc.Unmarshal(bz, &totals); err != nil {
+		return types.AirdropTotals{}, errors.Wrap(err, "failed to unmarshal airdrop totals")
+	}
+	return totals, nil
+}
+
+// SetAirdropTotals sets the airdrop totals in store
+func (k Keeper) SetAirdropTotals(ctx sdk.Context, totals types.AirdropTotals) error {
+	bz, err := k.cdc.Marshal(&totals)
+	if err != nil {
+		return err
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.KeyPrefix(types.AirdropTotalsKey), bz)
+	return nil
+}
+
+// GetOutstandingClaims returns the total still claimable across all claim
+// records
+func (k Keeper) GetOutstandingClaims(ctx sdk.Context) (sdk.Coins, error) {
+	records, err := k.GetAllClaimRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outstanding := sdk.NewCoins()
+	for _, record := range records {
+		outstanding = outstanding.Add(record.Outstanding()...)
+	}
+	return outstanding, nil
+}
+
+// GetOutstandingClaimsByAccount returns the total still claimable across all
+// claim records, by the module account paying them out
+func (k Keeper) GetOutstandingClaimsByAccount(ctx sdk.Context) (map[string]sdk.Coins, error) {
+	records, err := k.GetAllClaimRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outstanding := make(map[string]sdk.Coins)
+	for _, record := range records {
+		account := k.getFundingAccount(ctx, record.Round)
+		outstanding[account] = outstanding[account].Add(record.Outstanding()...)
+	}
+	return outstanding, nil
+}
+
+// addClaimed adds an amount removed from a claim record to the claimed total
+func (k Keeper) addClaimed(ctx sdk.Context, amount sdk.Coin) error {
+	if amount.IsNil() || amount.Denom == "" || !amount.IsPositive() {
+		return nil
+	}
+
+	totals, err := k.GetAirdropTotals(ctx)
+	if err != nil {
+		return err
+	}
+	totals.Claimed = totals.Claimed.Add(amount)
+	return k.SetAirdropTotals(ctx, totals)
+}
+
+// addGenesisTotal adds an amount entering claim records after genesis, such as
+// a claim proven against a merkle round, to the genesis total
+func (k Keeper) addGenesisTotal(ctx sdk.Context, amount sdk.Coin) error {
+	if amount.IsNil() || amount.Denom == "" || !amount.IsPositive() {
+		return nil
+	}
+
+	totals, err := k.GetAirdropTotals(ctx)
+	if err != nil {
+		return err
+	}
+	totals.GenesisTotal = totals.GenesisTotal.Add(amount)
+	return k.SetAirdropTotals(ctx, totals)
+}
+
 // // FundRemainingsToCommunity fund remainings to the community when airdrop period end
 // func (k Keeper) fundRemainingsToCommunity(ctx sdk.Context) error {
 // 	moduleAccAddr := k.GetModuleAccountAddress(ctx)
@@ -227,12 +474,25 @@ func (k Keeper) ClaimCoinsForAction(ctx sdk.Context, addr string, action types.A
 // 	return k.distrKeeper.FundCommunityPool(ctx, sdk.NewCoins(amt), moduleAccAddr)
 // }
 
+// claimRecordStorePrefix returns the store prefix of the claim records of a
+// chain in an airdrop round. The initial airdrop keeps the prefixes it had
+// before there were rounds.
+func claimRecordStorePrefix(round uint64, chain types.Chain) []byte {
+	if round == 0 {
+		return chainToStorePrefix(chain)
+	}
+	storePrefix := append(types.KeyPrefix(types.RoundClaimRecordsStorePrefix), sdk.Uint64ToBigEndian(round)...)
+	return append(storePrefix, chainToStorePrefix(chain)...)
+}
+
 func chainToStorePrefix(chain types.Chain) []byte {
 	switch chain {
 	case types.ARKEO:
 		return []byte(types.ClaimRecordsArkeoStorePrefix)
 	case types.ETHEREUM:
 		return []byte(types.ClaimRecordsEthStorePrefix)
+	case types.THORCHAIN:
+		return []byte(types.ClaimRecordsThorchainStorePrefix)
 	default:
 		return []byte{}
 	}
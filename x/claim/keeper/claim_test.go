@@ -1,6 +1,7 @@
 package keeper_test
 
 import (
+	"strings"
 	"testing"
 
 	testkeeper "github.com/arkeonetwork/arkeo/testutil/keeper"
@@ -37,15 +38,15 @@ func TestGetClaimRecordForArkeo(t *testing.T) {
 	err := keepers.ClaimKeeper.SetClaimRecords(ctx, claimRecords)
 	require.NoError(t, err)
 
-	coins1, err := keepers.ClaimKeeper.GetUserTotalClaimable(ctx, addr1, types.ARKEO)
+	coins1, err := keepers.ClaimKeeper.GetUserTotalClaimable(ctx, 0, addr1, types.ARKEO)
 	require.NoError(t, err)
 	require.Equal(t, "300", coins1.Amount.String())
 
-	coins2, err := keepers.ClaimKeeper.GetUserTotalClaimable(ctx, addr2, types.ARKEO)
+	coins2, err := keepers.ClaimKeeper.GetUserTotalClaimable(ctx, 0, addr2, types.ARKEO)
 	require.NoError(t, err)
 	require.Equal(t, "600", coins2.Amount.String())
 
-	coins3, err := keepers.ClaimKeeper.GetUserTotalClaimable(ctx, addr3, types.ARKEO)
+	coins3, err := keepers.ClaimKeeper.GetUserTotalClaimable(ctx, 0, addr3, types.ARKEO)
 	require.NoError(t, err)
 	require.Equal(t, coins3, sdk.Coin{})
 
@@ -54,7 +55,7 @@ func TestGetClaimRecordForArkeo(t *testing.T) {
 	require.Equal(t, claimRecord, types.ClaimRecord{})
 
 	// get rewards amount per action
-	coins4, err := keepers.ClaimKeeper.GetClaimableAmountForAction(ctx, addr1, types.ACTION_VOTE, types.ARKEO)
+	coins4, err := keepers.ClaimKeeper.GetClaimableAmountForAction(ctx, 0, addr1, types.ACTION_VOTE, types.ARKEO)
 	require.NoError(t, err)
 	require.Equal(t, coins4.String(), sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 100)).String())
 }
@@ -84,21 +85,21 @@ func TestGetClaimRecordForMutlipleChains(t *testing.T) {
 	err := keepers.ClaimKeeper.SetClaimRecords(ctx, claimRecords)
 	require.NoError(t, err)
 
-	coins1, err := keepers.ClaimKeeper.GetUserTotalClaimable(ctx, addr1, types.ARKEO)
+	coins1, err := keepers.ClaimKeeper.GetUserTotalClaimable(ctx, 0, addr1, types.ARKEO)
 	require.NoError(t, err)
 	require.Equal(t, "300", coins1.Amount.String())
 
 	// user 1 should have no eth claim with an arkeo addy nor thor claims
-	coins1, err = keepers.ClaimKeeper.GetUserTotalClaimable(ctx, addr1, types.ETHEREUM)
+	coins1, err = keepers.ClaimKeeper.GetUserTotalClaimable(ctx, 0, addr1, types.ETHEREUM)
 	require.NoError(t, err)
 	require.Equal(t, coins1, sdk.Coin{})
 
 	// user 2 should have no arkeo claim nor thor claims, only eth
-	coins2, err := keepers.ClaimKeeper.GetUserTotalClaimable(ctx, addr2, types.ETHEREUM)
+	coins2, err := keepers.ClaimKeeper.GetUserTotalClaimable(ctx, 0, addr2, types.ETHEREUM)
 	require.NoError(t, err)
 	require.Equal(t, "600", coins2.Amount.String())
 
-	coins2, err = keepers.ClaimKeeper.GetUserTotalClaimable(ctx, addr2, types.ARKEO)
+	coins2, err = keepers.ClaimKeeper.GetUserTotalClaimable(ctx, 0, addr2, types.ARKEO)
 	require.NoError(t, err)
 	require.Equal(t, coins2, sdk.Coin{})
 }
@@ -178,6 +179,16 @@ func TestGetAllClaimRecords(t *testing.T) {
 	claims, err := keepers.ClaimKeeper.GetAllClaimRecords(ctx)
 	require.NoError(t, err)
 	require.Equal(t, len(claims), len(claimRecords))
+
+	// chains are exported in enum order, so every export is the same
+	for i := 1; i < len(claims); i++ {
+		require.LessOrEqual(t, claims[i-1].Chain, claims[i].Chain)
+	}
+	for i := 0; i < 10; i++ {
+		again, err := keepers.ClaimKeeper.GetAllClaimRecords(ctx)
+		require.NoError(t, err)
+		require.Equal(t, claims, again)
+	}
 }
 
 func TestClaimFlow(t *testing.T) {
@@ -356,3 +367,49 @@ func TestClaimDecay(t *testing.T) {
 	balanceAfter3 := keepers.BankKeeper.GetBalance(sdkCtx, addrArkeo3, types.DefaultClaimDenom)
 	require.Equal(t, balanceAfter3.Sub(balanceBefore3), sdk.NewInt64Coin(types.DefaultClaimDenom, 0))
 }
+
+func TestClaimRecordAddressCasing(t *testing.T) {
+	keepers, ctx := testkeeper.CreateTestClaimKeepers(t)
+
+	checksummed := "0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5"
+	claimRecord := types.ClaimRecord{
+		Chain:          types.ETHEREUM,
+		Address:        checksummed,
+		AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+	}
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecord(ctx, claimRecord))
+
+	// any casing of the eth address finds the same record
+	for _, addr := range []string{
+		checksummed,
+		strings.ToLower(checksummed),
+		"0x" + strings.ToUpper(checksummed[2:]),
+		"0xdafea492d9C6733AE3D56B7ed1adb60692C98bC5",
+	} {
+		record, err := keepers.ClaimKeeper.GetClaimRecord(ctx, addr, types.ETHEREUM)
+		require.NoError(t, err)
+		require.Equal(t, claimRecord, record, addr)
+	}
+
+	// writing with another casing overwrites the record rather than adding one
+	claimRecord.Address = strings.ToLower(checksummed)
+	claimRecord.AmountClaim = sdk.NewInt64Coin(types.DefaultClaimDenom, 50)
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecord(ctx, claimRecord))
+	records, err := keepers.ClaimKeeper.GetClaimRecords(ctx, types.ETHEREUM)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	record, err := keepers.ClaimKeeper.GetClaimRecord(ctx, checksummed, types.ETHEREUM)
+	require.NoError(t, err)
+	require.Equal(t, int64(50), record.AmountClaim.Amount.Int64())
+
+	// arkeo addresses are matched regardless of case too
+	arkeoAddr := utils.GetRandomArkeoAddress().String()
+	claimRecord.Chain = types.ARKEO
+	claimRecord.Address = strings.ToUpper(arkeoAddr)
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecord(ctx, claimRecord))
+	record, err = keepers.ClaimKeeper.GetClaimRecord(ctx, arkeoAddr, types.ARKEO)
+	require.NoError(t, err)
+	require.Equal(t, claimRecord, record)
+}
@@ -0,0 +1,156 @@
+package keeper
+
+import (
+	"strconv"
+
+	arkeotypes "github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// clawbackBatchSize bounds the claim records swept in a single block, large
+// airdrops are swept over as many blocks as it takes
+const clawbackBatchSize = 100
+
+// EndBlocker sweeps the claim records left over once the initial airdrop, or
+// an airdrop round, has ended
+func (k Keeper) EndBlocker(ctx sdk.Context) error {
+	params := k.GetParams(ctx)
+	if ctx.BlockTime().After(params.AirdropEndTime()) {
+		if err := k.ClawbackExpiredClaims(ctx, clawbackBatchSize); err != nil {
+			return err
+		}
+	}
+	for _, round := range params.MerkleRounds {
+		if !round.HasEnded(ctx.BlockHeight()) {
+			continue
+		}
+		if err := k.ClawbackExpiredRoundClaims(ctx, round, clawbackBatchSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClawbackExpiredClaims removes up to limit claim records of the initial
+// airdrop and sends whatever was left on them to the reserve. Once no claim
+// records remain, the rest of the module's claim denom balance, from decayed
+// claims or merkle leaves never claimed, follows it, unless an airdrop round
+// paid out from the module is still running.
+func (k Keeper) ClawbackExpiredClaims(ctx sdk.Context, limit int) error {
+	records, err := k.getClaimRecordsBatch(ctx, 0, limit)
+	if err != nil {
+		return err
+	}
+	if err := k.clawbackClaimRecords(ctx, records, types.ModuleName); err != nil {
+		return err
+	}
+
+	if len(records) == limit || k.hasRunningRounds(ctx, types.ModuleName) {
+		return nil
+	}
+
+	balance := k.GetModuleAccountBalance(ctx)
+	if !balance.IsPositive() {
+		return nil
+	}
+	if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, arkeotypes.ReserveName, sdk.NewCoins(balance)); err != nil {
+		return errors.Wrapf(err, "failed to claw back module balance %s", balance)
+	}
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeClawback,
+			sdk.NewAttribute(sdk.AttributeKeySender, k.GetModuleAccountAddress(ctx).String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, balance.String()),
+		),
+	)
+	return nil
+}
+
+// ClawbackExpiredRoundClaims removes up to limit claim records of an airdrop
+// round and sends whatever was left on them from the round's funding account
+// to the reserve. The funding account may pay out other rounds, so the rest of
+// its balance is left alone.
+func (k Keeper) ClawbackExpiredRoundClaims(ctx sdk.Context, round types.MerkleRound, limit int) error {
+	records, err := k.getClaimRecordsBatch(ctx, round.Round, limit)
+	if err != nil {
+		return err
+	}
+	return k.clawbackClaimRecords(ctx, records, round.GetFundingAccount())
+}
+
+// clawbackClaimRecords removes the claim records and sends whatever was left
+// on them from the funding account to the reserve
+func (k Keeper) clawbackClaimRecords(ctx sdk.Context, records []types.ClaimRecord, fundingAccount string) error {
+	for _, record := range records {
+		if err := k.DeleteRoundClaimRecord(ctx, record.Round, record.Address, record.Chain); err != nil {
+			return errors.Wrapf(err, "failed to delete claim record for %s", record.Address)
+		}
+
+		outstanding := record.Outstanding()
+		if outstanding.IsZero() {
+			continue
+		}
+		if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, fundingAccount, arkeotypes.ReserveName, outstanding); err != nil {
+			return errors.Wrapf(err, "failed to claw back %s from %s", outstanding, record.Address)
+		}
+		if err := k.addClawedBack(ctx, outstanding); err != nil {
+			return err
+		}
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeClawback,
+				sdk.NewAttribute(sdk.AttributeKeySender, record.Address),
+				sdk.NewAttribute(types.AttributeKeyChain, record.Chain.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, outstanding.String()),
+				sdk.NewAttribute(types.AttributeKeyRound, strconv.FormatUint(record.Round, 10)),
+			),
+		)
+	}
+	return nil
+}
+
+// hasRunningRounds returns whether an airdrop round paid out from the given
+// module account has yet to end
+func (k Keeper) hasRunningRounds(ctx sdk.Context, fundingAccount string) bool {
+	for _, round := range k.GetParams(ctx).MerkleRounds {
+		if round.GetFundingAccount() == fundingAccount && !round.HasEnded(ctx.BlockHeight()) {
+			return true
+		}
+	}
+	return false
+}
+
+// getClaimRecordsBatch returns up to limit claim records of an airdrop round
+// across all chains
+func (k Keeper) getClaimRecordsBatch(ctx sdk.Context, round uint64, limit int) ([]types.ClaimRecord, error) {
+	records := []types.ClaimRecord{}
+	for _, chain := range []types.Chain{types.ARKEO, types.ETHEREUM, types.THORCHAIN} {
+		prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), claimRecordStorePrefix(round, chain))
+		iterator := prefixStore.Iterator(nil, nil)
+		for ; iterator.Valid() && len(records) < limit; iterator.Next() {
+			record := types.ClaimRecord{}
+			if err := k.cdc.Unmarshal(iterator.Value(), &record); err != nil {
+				iterator.Close()
+				return nil, errors.Wrap(err, "failed to unmarshal claim record")
+			}
+			records = append(records, record)
+		}
+		iterator.Close()
+	}
+	return records, nil
+}
+
+// addClawedBack adds an amount swept from a claim record to the clawed back
+// total
+func (k Keeper) addClawedBack(ctx sdk.Context, amount sdk.Coins) error {
+	totals, err := k.GetAirdropTotals(ctx)
+	if err != nil {
+		return err
+	}
+	totals.ClawedBack = totals.ClawedBack.Add(amount...)
+	return k.SetAirdropTotals(ctx, totals)
+}
@@ -0,0 +1,95 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	testkeeper "github.com/arkeonetwork/arkeo/testutil/keeper"
+	"github.com/arkeonetwork/arkeo/testutil/utils"
+	arkeotypes "github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/arkeonetwork/arkeo/x/claim/keeper"
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClawbackExpiredClaims(t *testing.T) {
+	keepers, ctx := testkeeper.CreateTestClaimKeepers(t)
+	k := keepers.ClaimKeeper
+
+	claimRecords := []types.ClaimRecord{
+		{
+			Chain:          types.ARKEO,
+			Address:        utils.GetRandomArkeoAddress().String(),
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		},
+		{
+			// fully claimed records are swept without a clawback
+			Chain:          types.ARKEO,
+			Address:        utils.GetRandomArkeoAddress().String(),
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+		},
+		{
+			Chain:          types.ETHEREUM,
+			Address:        "0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5",
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 200),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		},
+	}
+	require.NoError(t, k.SetClaimRecords(ctx, claimRecords))
+	outstanding, err := k.GetOutstandingClaims(ctx)
+	require.NoError(t, err)
+	require.NoError(t, k.SetAirdropTotals(ctx, types.AirdropTotals{GenesisTotal: outstanding}))
+	require.NoError(t, keepers.BankKeeper.MintCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 1000))))
+
+	params := k.GetParams(ctx)
+	params.AirdropStartTime = ctx.BlockTime()
+	k.SetParams(ctx, params)
+
+	// nothing is swept while the airdrop is running
+	require.NoError(t, k.EndBlocker(ctx))
+	records, err := k.GetAllClaimRecords(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	ctx = ctx.WithBlockTime(params.AirdropEndTime().Add(time.Second)).WithEventManager(sdk.NewEventManager())
+	reserve := keepers.AccountKeeper.GetModuleAddress(arkeotypes.ReserveName)
+
+	// a partial batch leaves the module balance alone
+	require.NoError(t, k.ClawbackExpiredClaims(ctx, 2))
+	records, err = k.GetAllClaimRecords(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	_, broken := keeper.ClaimConservationInvariant(k)(ctx)
+	require.False(t, broken)
+	_, broken = keeper.ModuleSolvencyInvariant(k)(ctx)
+	require.False(t, broken)
+
+	require.NoError(t, k.EndBlocker(ctx))
+	records, err = k.GetAllClaimRecords(ctx)
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	totals, err := k.GetAirdropTotals(ctx)
+	require.NoError(t, err)
+	require.Equal(t, outstanding, totals.ClawedBack)
+	_, broken = keeper.ClaimConservationInvariant(k)(ctx)
+	require.False(t, broken)
+
+	// the rest of the module balance follows the last claim record
+	require.True(t, k.GetModuleAccountBalance(ctx).IsZero())
+	require.Equal(t, int64(1000), keepers.BankKeeper.GetBalance(ctx, reserve, types.DefaultClaimDenom).Amount.Int64())
+
+	clawbacks := 0
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypeClawback {
+			clawbacks++
+		}
+	}
+	require.Equal(t, 3, clawbacks)
+}
@@ -0,0 +1,16 @@
+package keeper
+
+import (
+	"strings"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetLegacyClaimRecord saves a claim record of the initial airdrop under its
+// lowercased address, as claim records were keyed before consensus version 2
+func (k Keeper) SetLegacyClaimRecord(ctx sdk.Context, claimRecord types.ClaimRecord) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), chainToStorePrefix(claimRecord.Chain))
+	store.Set([]byte(strings.ToLower(claimRecord.Address)), k.cdc.MustMarshal(&claimRecord))
+}
@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInvariants registers the claim module invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "module-solvency", ModuleSolvencyInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "claim-conservation", ClaimConservationInvariant(k))
+}
+
+// ModuleSolvencyInvariant checks the claim module, and the funding account of
+// every airdrop round, holds enough to pay out all actions that have not been
+// claimed yet
+func ModuleSolvencyInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		outstanding, err := k.GetOutstandingClaimsByAccount(ctx)
+		if err != nil {
+			return sdk.FormatInvariant(types.ModuleName, "module-solvency", fmt.Sprintf("unable to get claim records: %s", err)), true
+		}
+
+		accounts := make([]string, 0, len(outstanding))
+		for account := range outstanding {
+			accounts = append(accounts, account)
+		}
+		sort.Strings(accounts)
+
+		var msg string
+		broken := false
+		for _, account := range accounts {
+			balance := k.bankKeeper.SpendableCoins(ctx, k.accountKeeper.GetModuleAddress(account))
+			if !balance.IsAllGTE(outstanding[account]) {
+				broken = true
+			}
+			msg += fmt.Sprintf("\t%s outstanding claims: %s\n\t%s balance: %s\n", account, outstanding[account], account, balance)
+		}
+		return sdk.FormatInvariant(types.ModuleName, "module-solvency", msg), broken
+	}
+}
+
+// ClaimConservationInvariant checks the airdrop total set at genesis equals
+// what has been claimed or clawed back so far plus what is still outstanding
+// on the claim records, catching double claims or claim records being inflated
+func ClaimConservationInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		totals, err := k.GetAirdropTotals(ctx)
+		if err != nil {
+			return sdk.FormatInvariant(types.ModuleName, "claim-conservation", fmt.Sprintf("unable to get airdrop totals: %s", err)), true
+		}
+
+		outstanding, err := k.GetOutstandingClaims(ctx)
+		if err != nil {
+			return sdk.FormatInvariant(types.ModuleName, "claim-conservation", fmt.Sprintf("unable to get claim records: %s", err)), true
+		}
+
+		accounted := totals.Claimed.Add(totals.ClawedBack...).Add(outstanding...)
+		// coins IsEqual panics on mismatched denoms, so compare both ways
+		broken := !accounted.IsAllGTE(totals.GenesisTotal) || !totals.GenesisTotal.IsAllGTE(accounted)
+		return sdk.FormatInvariant(types.ModuleName, "claim-conservation",
+			fmt.Sprintf("\tgenesis total: %s\n\tclaimed: %s\n\tclawed back: %s\n\toutstanding claims: %s\n", totals.GenesisTotal, totals.Claimed, totals.ClawedBack, outstanding)), broken
+	}
+}
@@ -0,0 +1,85 @@
+package keeper_test
+
+import (
+	"testing"
+
+	testkeeper "github.com/arkeonetwork/arkeo/testutil/keeper"
+	"github.com/arkeonetwork/arkeo/testutil/utils"
+	"github.com/arkeonetwork/arkeo/x/claim/keeper"
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleSolvencyInvariant(t *testing.T) {
+	keepers, ctx := testkeeper.CreateTestClaimKeepers(t)
+	invariant := keeper.ModuleSolvencyInvariant(keepers.ClaimKeeper)
+
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+
+	claimRecord := types.ClaimRecord{
+		Chain:          types.ARKEO,
+		Address:        utils.GetRandomArkeoAddress().String(),
+		AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+	}
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecord(ctx, claimRecord))
+
+	// unfunded claims break the invariant
+	_, broken = invariant(ctx)
+	require.True(t, broken)
+
+	require.NoError(t, keepers.BankKeeper.MintCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 300))))
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// completed actions no longer need to be backed
+	_, err := keepers.ClaimKeeper.ClaimCoinsForAction(ctx, 0, claimRecord.Address, types.ACTION_CLAIM)
+	require.NoError(t, err)
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+}
+
+func TestClaimConservationInvariant(t *testing.T) {
+	keepers, ctx := testkeeper.CreateTestClaimKeepers(t)
+	invariant := keeper.ClaimConservationInvariant(keepers.ClaimKeeper)
+
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+
+	claimRecord := types.ClaimRecord{
+		Chain:          types.ARKEO,
+		Address:        utils.GetRandomArkeoAddress().String(),
+		AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+	}
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecord(ctx, claimRecord))
+
+	// records that are not part of the airdrop total break the invariant
+	_, broken = invariant(ctx)
+	require.True(t, broken)
+
+	require.NoError(t, keepers.ClaimKeeper.SetAirdropTotals(ctx, types.AirdropTotals{
+		GenesisTotal: sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 300)),
+	}))
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// claimed amounts are still accounted for
+	require.NoError(t, keepers.BankKeeper.MintCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 300))))
+	_, err := keepers.ClaimKeeper.ClaimCoinsForAction(ctx, 0, claimRecord.Address, types.ACTION_CLAIM)
+	require.NoError(t, err)
+	totals, err := keepers.ClaimKeeper.GetAirdropTotals(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), totals.Claimed.AmountOf(types.DefaultClaimDenom).Int64())
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// restoring a claimed action would allow it to be claimed twice
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecord(ctx, claimRecord))
+	_, broken = invariant(ctx)
+	require.True(t, broken)
+}
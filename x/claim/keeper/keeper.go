@@ -7,6 +7,7 @@ import (
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/arkeonetwork/arkeo/x/claim/types"
@@ -20,6 +21,8 @@ type (
 		paramstore    paramtypes.Subspace
 		accountKeeper types.AccountKeeper
 		bankKeeper    types.BankKeeper
+		stakingKeeper types.StakingKeeper
+		govKeeper     types.GovKeeper
 	}
 )
 
@@ -28,6 +31,8 @@ func NewKeeper(
 	storeKey storetypes.StoreKey,
 	accountKeeper types.AccountKeeper,
 	bankKeeper types.BankKeeper,
+	stakingKeeper types.StakingKeeper,
+	govKeeper types.GovKeeper,
 	memKey storetypes.StoreKey,
 	ps paramtypes.Subspace,
 ) Keeper {
@@ -41,6 +46,8 @@ func NewKeeper(
 		storeKey:      storeKey,
 		accountKeeper: accountKeeper,
 		bankKeeper:    bankKeeper,
+		stakingKeeper: stakingKeeper,
+		govKeeper:     govKeeper,
 		memKey:        memKey,
 		paramstore:    ps,
 	}
@@ -50,17 +57,53 @@ func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
 }
 
+// AfterProposalVote releases the vote amount of the voter in every airdrop
+// round
 func (k Keeper) AfterProposalVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress) {
-	_, err := k.ClaimCoinsForAction(ctx, voterAddr.String(), types.ACTION_VOTE)
-	if err != nil {
-		k.Logger(ctx).Error("failed to claim coins for vote", "error", err.Error())
+	for _, round := range k.getRounds(ctx) {
+		_, err := k.ClaimCoinsForAction(ctx, round, voterAddr.String(), types.ACTION_VOTE)
+		if err != nil {
+			k.Logger(ctx).Error("failed to claim coins for vote", "round", round, "error", err.Error())
+		}
 	}
 }
 
+// AfterDelegationModified releases the delegate amount of the delegator in
+// every airdrop round
 func (k Keeper) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
-	_, err := k.ClaimCoinsForAction(ctx, delAddr.String(), types.ACTION_DELEGATE)
-	if err != nil {
-		k.Logger(ctx).Error("failed to claim coins for delegate", "error", err.Error())
+	for _, round := range k.getRounds(ctx) {
+		_, err := k.ClaimCoinsForAction(ctx, round, delAddr.String(), types.ACTION_DELEGATE)
+		if err != nil {
+			k.Logger(ctx).Error("failed to claim coins for delegate", "round", round, "error", err.Error())
+		}
+	}
+	return nil
+}
+
+// getRounds returns the initial airdrop, round zero, followed by every
+// airdrop round in params
+func (k Keeper) getRounds(ctx sdk.Context) []uint64 {
+	params := k.GetParams(ctx)
+	rounds := make([]uint64, 0, len(params.MerkleRounds)+1)
+	rounds = append(rounds, 0)
+	for _, r := range params.MerkleRounds {
+		rounds = append(rounds, r.Round)
+	}
+	return rounds
+}
+
+// validateRound checks an airdrop round can be claimed from, round zero is
+// the initial airdrop and always can
+func (k Keeper) validateRound(ctx sdk.Context, round uint64) error {
+	if round == 0 {
+		return nil
+	}
+	r, ok := k.GetParams(ctx).GetMerkleRound(round)
+	if !ok {
+		return errors.Wrapf(types.ErrMerkleRoundNotFound, "round %d", round)
+	}
+	if ctx.BlockHeight() < r.StartHeight {
+		return errors.Wrapf(types.ErrRoundNotStarted, "round %d starts at height %d", round, r.StartHeight)
 	}
 	return nil
 }
@@ -0,0 +1,58 @@
+package keeper
+
+import (
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// IsMerkleClaimed returns whether the leaf of an ethereum address in a merkle
+// round has been claimed
+func (k Keeper) IsMerkleClaimed(ctx sdk.Context, round uint64, ethAddress string) (bool, error) {
+	key, err := types.GetMerkleClaimKey(round, ethAddress)
+	if err != nil {
+		return false, err
+	}
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.MerkleClaimsStorePrefix))
+	return store.Has(key), nil
+}
+
+// SetMerkleClaimed marks the leaf of an ethereum address in a merkle round as
+// claimed
+func (k Keeper) SetMerkleClaimed(ctx sdk.Context, round uint64, ethAddress string) error {
+	key, err := types.GetMerkleClaimKey(round, ethAddress)
+	if err != nil {
+		return err
+	}
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.MerkleClaimsStorePrefix))
+	store.Set(key, []byte{1})
+	return nil
+}
+
+// GetMerkleClaims returns the claimed merkle leaves for genesis export
+func (k Keeper) GetMerkleClaims(ctx sdk.Context) []types.MerkleClaim {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefix(types.MerkleClaimsStorePrefix))
+
+	iterator := store.Iterator(nil, nil)
+	defer iterator.Close()
+
+	claims := []types.MerkleClaim{}
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		claims = append(claims, types.MerkleClaim{
+			Round:      sdk.BigEndianToUint64(key[:8]),
+			EthAddress: string(key[8:]),
+		})
+	}
+	return claims
+}
+
+// SetMerkleClaims marks the claimed merkle leaves from genesis
+func (k Keeper) SetMerkleClaims(ctx sdk.Context, claims []types.MerkleClaim) error {
+	for _, claim := range claims {
+		if err := k.SetMerkleClaimed(ctx, claim.Round, claim.EthAddress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
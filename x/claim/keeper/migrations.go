@@ -0,0 +1,81 @@
+package keeper
+
+import (
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// Migrator handles the in-place store migrations of the claim module
+type Migrator struct {
+	keeper Keeper
+}
+
+func NewMigrator(k Keeper) Migrator {
+	return Migrator{keeper: k}
+}
+
+// Migrate1to2 moves the claim records of the initial airdrop from their
+// lowercased address keys to the normalized address keys. Records whose
+// addresses normalize to the same key, such as an ethereum address saved with
+// and without its 0x prefix, are merged. Airdrop rounds and merkle claims are
+// new in this version, so they have nothing to migrate. It is safe to run
+// more than once.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	store := ctx.KVStore(m.keeper.storeKey)
+	for i := 0; i < len(types.Chain_name); i++ {
+		chain := types.Chain(i)
+		prefixStore := prefix.NewStore(store, chainToStorePrefix(chain))
+
+		// collect first, the records are saved again under the prefix being
+		// iterated
+		var keys [][]byte
+		var records []types.ClaimRecord
+		iter := prefixStore.Iterator(nil, nil)
+		for ; iter.Valid(); iter.Next() {
+			var record types.ClaimRecord
+			if err := m.keeper.cdc.Unmarshal(iter.Value(), &record); err != nil {
+				iter.Close()
+				return errors.Wrap(err, "failed to unmarshal claim record")
+			}
+			keys = append(keys, append([]byte(nil), iter.Key()...))
+			records = append(records, record)
+		}
+		iter.Close()
+
+		for _, key := range keys {
+			prefixStore.Delete(key)
+		}
+		for _, record := range records {
+			existing, err := m.keeper.GetClaimRecord(ctx, record.Address, chain)
+			if err != nil {
+				return err
+			}
+			if !existing.IsEmpty() {
+				record.AmountClaim = addClaimAmount(record.AmountClaim, existing.AmountClaim)
+				record.AmountVote = addClaimAmount(record.AmountVote, existing.AmountVote)
+				record.AmountDelegate = addClaimAmount(record.AmountDelegate, existing.AmountDelegate)
+				record.IsTransferable = record.IsTransferable && existing.IsTransferable
+			}
+			record.Chain = chain
+			if err := m.keeper.SetClaimRecord(ctx, record); err != nil {
+				return errors.Wrapf(err, "failed to migrate claim record of %s", record.Address)
+			}
+		}
+	}
+
+	return nil
+}
+
+// addClaimAmount adds two amounts of a claim record, either of which may be
+// unset
+func addClaimAmount(a, b sdk.Coin) sdk.Coin {
+	if a.Denom == "" {
+		return b
+	}
+	if b.Denom == "" {
+		return a
+	}
+	return a.Add(b)
+}
@@ -0,0 +1,59 @@
+package keeper_test
+
+import (
+	"testing"
+
+	testkeeper "github.com/arkeonetwork/arkeo/testutil/keeper"
+	"github.com/arkeonetwork/arkeo/testutil/utils"
+	"github.com/arkeonetwork/arkeo/x/claim/keeper"
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate1to2(t *testing.T) {
+	keepers, ctx := testkeeper.CreateTestClaimKeepers(t)
+	k := keepers.ClaimKeeper
+
+	amount := sdk.NewInt64Coin(types.DefaultClaimDenom, 100)
+	arkeoAddr := utils.GetRandomArkeoAddress().String()
+	ethAddr := "0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5"
+	records := []types.ClaimRecord{
+		{Chain: types.ARKEO, Address: arkeoAddr, AmountClaim: amount, AmountVote: amount, AmountDelegate: amount},
+		// the same eth address with and without its 0x prefix, under two
+		// legacy keys
+		{Chain: types.ETHEREUM, Address: ethAddr, AmountClaim: amount, AmountVote: amount, AmountDelegate: amount, IsTransferable: true},
+		{Chain: types.ETHEREUM, Address: ethAddr[2:], AmountClaim: amount, AmountVote: amount, AmountDelegate: amount, IsTransferable: true},
+	}
+	for _, record := range records {
+		k.SetLegacyClaimRecord(ctx, record)
+	}
+	ethRecords, err := k.GetClaimRecords(ctx, types.ETHEREUM)
+	require.NoError(t, err)
+	require.Len(t, ethRecords, 2)
+
+	m := keeper.NewMigrator(k)
+	require.NoError(t, m.Migrate1to2(ctx))
+	// safe to run again
+	require.NoError(t, m.Migrate1to2(ctx))
+
+	record, err := k.GetClaimRecord(ctx, arkeoAddr, types.ARKEO)
+	require.NoError(t, err)
+	require.Equal(t, amount, record.AmountClaim)
+
+	// both legacy records are merged under the normalized key, found by any
+	// casing of the address
+	ethRecords, err = k.GetClaimRecords(ctx, types.ETHEREUM)
+	require.NoError(t, err)
+	require.Len(t, ethRecords, 1)
+	record, err = k.GetClaimRecord(ctx, ethAddr, types.ETHEREUM)
+	require.NoError(t, err)
+	require.Equal(t, int64(200), record.AmountClaim.Amount.Int64())
+	require.Equal(t, int64(200), record.AmountVote.Amount.Int64())
+	require.Equal(t, int64(200), record.AmountDelegate.Amount.Int64())
+	require.True(t, record.IsTransferable)
+
+	total, err := k.GetUserTotalClaimable(ctx, 0, "0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5", types.ETHEREUM)
+	require.NoError(t, err)
+	require.Equal(t, int64(600), total.Amount.Int64())
+}
@@ -30,8 +30,22 @@ func (k msgServer) AddClaim(goCtx context.Context, msg *types.MsgAddClaim) (*typ
 	if msg.Chain == types.ARKEO {
 		claim.IsTransferable = true
 	}
+	existing, err := k.Keeper.GetClaimRecord(ctx, msg.Address, msg.Chain)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get claim record from db,err: %w", err)
+	}
 	if err := k.Keeper.SetClaimRecord(ctx, claim); err != nil {
 		return nil, fmt.Errorf("fail to save claim record to db,err: %w", err)
 	}
+
+	// keep the airdrop total in line with the overridden record
+	totals, err := k.Keeper.GetAirdropTotals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get airdrop totals from db,err: %w", err)
+	}
+	totals.GenesisTotal = totals.GenesisTotal.Add(claim.Outstanding()...).Sub(existing.Outstanding()...)
+	if err := k.Keeper.SetAirdropTotals(ctx, totals); err != nil {
+		return nil, fmt.Errorf("fail to save airdrop totals to db,err: %w", err)
+	}
 	return &types.MsgAddClaimResponse{}, nil
 }
@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"context"
+	"time"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/pkg/errors"
+)
+
+// maxProposalEndTime is past the voting end of any active proposal, so
+// iterating the active proposal queue up to it covers all of them
+var maxProposalEndTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+func (k msgServer) ClaimAll(goCtx context.Context, msg *types.MsgClaimAll) (*types.MsgClaimAllResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := k.validateRound(ctx, msg.Round); err != nil {
+		return nil, err
+	}
+
+	amount, _, err := k.ClaimCoinsForActions(ctx, msg.Round, msg.Creator.String(), k.completedActionsOf(ctx, msg.Creator))
+	if err != nil {
+		// nothing is releasable once the airdrop is over
+		if errors.Is(err, types.ErrAirdropEnded) {
+			return &types.MsgClaimAllResponse{Amount: sdk.NewCoin(k.GetParams(ctx).ClaimDenom, sdk.ZeroInt())}, nil
+		}
+		return nil, errors.Wrapf(err, "failed to claim coins for %s", msg.Creator)
+	}
+
+	return &types.MsgClaimAllResponse{Amount: amount}, nil
+}
+
+// completedActionsOf returns the actions the address has completed. Signing a
+// claim completes the claim action, the vote and delegate actions are
+// completed by voting on an active proposal and by holding a delegation.
+// Those are released by the gov and staking hooks as they happen, this picks
+// up the ones that happened before the address had a claim record, such as
+// an ethereum claim moved to an address that had already delegated.
+func (k Keeper) completedActionsOf(ctx sdk.Context, addr sdk.AccAddress) []types.Action {
+	actions := []types.Action{types.ACTION_CLAIM}
+	if k.hasVoted(ctx, addr) {
+		actions = append(actions, types.ACTION_VOTE)
+	}
+	if k.hasDelegated(ctx, addr) {
+		actions = append(actions, types.ACTION_DELEGATE)
+	}
+	return actions
+}
+
+// hasVoted checks whether the address has voted on a proposal still in its
+// voting period, votes are removed once a proposal is tallied
+func (k Keeper) hasVoted(ctx sdk.Context, addr sdk.AccAddress) bool {
+	voted := false
+	k.govKeeper.IterateActiveProposalsQueue(ctx, maxProposalEndTime, func(proposal govv1.Proposal) bool {
+		_, voted = k.govKeeper.GetVote(ctx, proposal.Id, addr)
+		return voted
+	})
+	return voted
+}
+
+// hasDelegated checks whether the address holds a delegation
+func (k Keeper) hasDelegated(ctx sdk.Context, addr sdk.AccAddress) bool {
+	return len(k.stakingKeeper.GetDelegatorDelegations(ctx, addr, 1)) > 0
+}
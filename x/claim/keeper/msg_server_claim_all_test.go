@@ -0,0 +1,152 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	testkeeper "github.com/arkeonetwork/arkeo/testutil/keeper"
+	"github.com/arkeonetwork/arkeo/testutil/utils"
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimAll(t *testing.T) {
+	msgServer, keepers, ctx := setupMsgServer(t)
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+	addrArkeo := utils.GetRandomArkeoAddress()
+
+	claimRecord := types.ClaimRecord{
+		Chain:          types.ARKEO,
+		Address:        addrArkeo.String(),
+		AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 200),
+		AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 300),
+	}
+	err := keepers.ClaimKeeper.SetClaimRecord(sdkCtx, claimRecord)
+	require.NoError(t, err)
+
+	// mint coins to module account
+	err = keepers.BankKeeper.MintCoins(sdkCtx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 10000)))
+	require.NoError(t, err)
+
+	balanceBefore := keepers.BankKeeper.GetBalance(sdkCtx, addrArkeo, types.DefaultClaimDenom)
+
+	sdkCtx = sdkCtx.WithEventManager(sdk.NewEventManager())
+	resp, err := msgServer.ClaimAll(sdk.WrapSDKContext(sdkCtx), &types.MsgClaimAll{Creator: addrArkeo})
+	require.NoError(t, err)
+	require.Equal(t, resp.Amount, sdk.NewInt64Coin(types.DefaultClaimDenom, 100))
+
+	// a single aggregated claim event is emitted
+	events := claimEvents(sdkCtx)
+	require.Len(t, events, 1)
+	actions, ok := events[0].GetAttribute(types.AttributeKeyActions)
+	require.True(t, ok)
+	require.Equal(t, actions.Value, types.ACTION_CLAIM.String())
+
+	// the address has neither voted nor delegated yet
+	claimRecord, err = keepers.ClaimKeeper.GetClaimRecord(sdkCtx, addrArkeo.String(), types.ARKEO)
+	require.NoError(t, err)
+	require.True(t, claimRecord.AmountClaim.IsZero())
+	require.Equal(t, claimRecord.AmountVote, sdk.NewInt64Coin(types.DefaultClaimDenom, 200))
+	require.Equal(t, claimRecord.AmountDelegate, sdk.NewInt64Coin(types.DefaultClaimDenom, 300))
+
+	balanceAfter := keepers.BankKeeper.GetBalance(sdkCtx, addrArkeo, types.DefaultClaimDenom)
+	require.Equal(t, balanceAfter.Sub(balanceBefore), sdk.NewInt64Coin(types.DefaultClaimDenom, 100))
+
+	// claiming again is a no-op
+	sdkCtx = sdkCtx.WithEventManager(sdk.NewEventManager())
+	resp, err = msgServer.ClaimAll(sdk.WrapSDKContext(sdkCtx), &types.MsgClaimAll{Creator: addrArkeo})
+	require.NoError(t, err)
+	require.True(t, resp.Amount.IsZero())
+	require.Len(t, claimEvents(sdkCtx), 0)
+	require.Equal(t, keepers.BankKeeper.GetBalance(sdkCtx, addrArkeo, types.DefaultClaimDenom), balanceAfter)
+
+	// an address without a claim record is a no-op too
+	resp, err = msgServer.ClaimAll(ctx, &types.MsgClaimAll{Creator: utils.GetRandomArkeoAddress()})
+	require.NoError(t, err)
+	require.True(t, resp.Amount.IsZero())
+}
+
+func TestClaimCoinsForActions(t *testing.T) {
+	keepers, ctx := testkeeper.CreateTestClaimKeepers(t)
+
+	addrArkeo := utils.GetRandomArkeoAddress()
+	claimRecord := types.ClaimRecord{
+		Chain:          types.ARKEO,
+		Address:        addrArkeo.String(),
+		AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 200),
+		AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+	}
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecord(ctx, claimRecord))
+	require.NoError(t, keepers.BankKeeper.MintCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 10000))))
+
+	total, settled, err := keepers.ClaimKeeper.ClaimCoinsForActions(ctx, 0, addrArkeo.String(), []types.Action{types.ACTION_CLAIM, types.ACTION_VOTE, types.ACTION_DELEGATE})
+	require.NoError(t, err)
+	require.Equal(t, total, sdk.NewInt64Coin(types.DefaultClaimDenom, 300))
+	require.Equal(t, settled, []types.Action{types.ACTION_CLAIM, types.ACTION_VOTE})
+}
+
+func TestClaimAllCompletedActions(t *testing.T) {
+	msgServer, keepers, ctx := setupMsgServer(t)
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	require.NoError(t, keepers.BankKeeper.MintCoins(sdkCtx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 10000))))
+
+	newRecord := func() sdk.AccAddress {
+		addr := utils.GetRandomArkeoAddress()
+		require.NoError(t, keepers.ClaimKeeper.SetClaimRecord(sdkCtx, types.ClaimRecord{
+			Chain:          types.ARKEO,
+			Address:        addr.String(),
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 200),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 300),
+		}))
+		return addr
+	}
+
+	// delegated before the claim record existed, so the staking hook had
+	// nothing to release
+	delegator := newRecord()
+	keepers.StakingKeeper.SetDelegation(sdkCtx, stakingtypes.NewDelegation(delegator, sdk.ValAddress(utils.GetRandomArkeoAddress()), sdk.NewDec(10)))
+	resp, err := msgServer.ClaimAll(ctx, &types.MsgClaimAll{Creator: delegator})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 400), resp.Amount)
+	claimRecord, err := keepers.ClaimKeeper.GetClaimRecord(sdkCtx, delegator.String(), types.ARKEO)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 200), claimRecord.AmountVote)
+	require.True(t, claimRecord.AmountDelegate.IsZero())
+
+	// voted on a proposal still in its voting period
+	voter := newRecord()
+	endTime := sdkCtx.BlockTime().Add(time.Hour)
+	proposal, err := govv1.NewProposal(nil, 1, "", sdkCtx.BlockTime(), endTime)
+	require.NoError(t, err)
+	proposal.Status = govv1.StatusVotingPeriod
+	keepers.GovKeeper.SetProposal(sdkCtx, proposal)
+	keepers.GovKeeper.InsertActiveProposalQueue(sdkCtx, proposal.Id, endTime)
+	keepers.GovKeeper.SetVote(sdkCtx, govv1.NewVote(proposal.Id, voter, govv1.NewNonSplitVoteOption(govv1.OptionYes), ""))
+
+	sdkCtx = sdkCtx.WithEventManager(sdk.NewEventManager())
+	resp, err = msgServer.ClaimAll(sdk.WrapSDKContext(sdkCtx), &types.MsgClaimAll{Creator: voter})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 300), resp.Amount)
+	events := claimEvents(sdkCtx)
+	require.Len(t, events, 1)
+	actions, ok := events[0].GetAttribute(types.AttributeKeyActions)
+	require.True(t, ok)
+	require.Equal(t, types.ACTION_CLAIM.String()+","+types.ACTION_VOTE.String(), actions.Value)
+}
+
+func claimEvents(ctx sdk.Context) sdk.Events {
+	events := sdk.Events{}
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypeClaim {
+			events = append(events, event)
+		}
+	}
+	return events
+}
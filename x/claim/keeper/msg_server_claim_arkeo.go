@@ -11,16 +11,24 @@ import (
 func (k msgServer) ClaimArkeo(goCtx context.Context, msg *types.MsgClaimArkeo) (*types.MsgClaimArkeoResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	arkeoClaim, err := k.GetClaimRecord(ctx, msg.Creator.String(), types.ARKEO)
+	if err := k.validateRound(ctx, msg.Round); err != nil {
+		return nil, err
+	}
+
+	arkeoClaim, err := k.GetRoundClaimRecord(ctx, msg.Round, msg.Creator.String(), types.ARKEO)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get claim record for %s", msg.Creator)
 	}
 
+	if arkeoClaim.Address == "" {
+		return nil, errors.Wrapf(types.ErrClaimRecordNotFound, "no claim record for %s", msg.Creator)
+	}
+
 	if arkeoClaim.IsEmpty() || arkeoClaim.AmountClaim.IsZero() {
-		return nil, errors.Wrapf(types.ErrNoClaimableAmount, "no claimable amount for %s", msg.Creator)
+		return nil, errors.Wrapf(types.ErrAlreadyClaimed, "no claimable amount for %s", msg.Creator)
 	}
 
-	_, err = k.ClaimCoinsForAction(ctx, msg.Creator.String(), types.ACTION_CLAIM)
+	_, err = k.ClaimCoinsForAction(ctx, msg.Round, msg.Creator.String(), types.ACTION_CLAIM)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to claim coins for %s", msg.Creator)
 	}
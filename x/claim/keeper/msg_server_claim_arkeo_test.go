@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/arkeonetwork/arkeo/testutil/utils"
+	arkeotypes "github.com/arkeonetwork/arkeo/x/arkeo/types"
+	"github.com/arkeonetwork/arkeo/x/claim/keeper"
 	"github.com/arkeonetwork/arkeo/x/claim/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
@@ -55,7 +57,7 @@ func TestClaimArkeo(t *testing.T) {
 
 	// attempt to claim again to ensure it fails.
 	_, err = msgServer.ClaimArkeo(ctx, &claimMessage)
-	require.ErrorIs(t, err, types.ErrNoClaimableAmount)
+	require.ErrorIs(t, err, types.ErrAlreadyClaimed)
 
 	// ensure claim Arkeo fails from address with no claim record
 	addrArkeo2 := utils.GetRandomArkeoAddress()
@@ -63,5 +65,94 @@ func TestClaimArkeo(t *testing.T) {
 		Creator: addrArkeo2,
 	}
 	_, err = msgServer.ClaimArkeo(ctx, &claimMessage2)
-	require.ErrorIs(t, err, types.ErrNoClaimableAmount)
+	require.ErrorIs(t, err, types.ErrClaimRecordNotFound)
+}
+
+func TestClaimArkeoRound(t *testing.T) {
+	msgServer, keepers, ctx := setupMsgServer(t)
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	k := keepers.ClaimKeeper
+
+	params := k.GetParams(sdkCtx)
+	params.MerkleRounds = []types.MerkleRound{{
+		Round:            1,
+		StartHeight:      10,
+		BlocksUntilDecay: 10,
+		BlocksOfDecay:    10,
+		FundingAccount:   arkeotypes.ReserveName,
+	}}
+	k.SetParams(sdkCtx, params)
+
+	addrArkeo := utils.GetRandomArkeoAddress()
+	require.NoError(t, k.SetClaimRecords(sdkCtx, []types.ClaimRecord{
+		{
+			Chain:          types.ARKEO,
+			Address:        addrArkeo.String(),
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 50),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 50),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 50),
+		},
+		{
+			Chain:          types.ARKEO,
+			Address:        addrArkeo.String(),
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+			Round:          1,
+		},
+	}))
+	outstanding, err := k.GetOutstandingClaims(sdkCtx)
+	require.NoError(t, err)
+	require.NoError(t, k.SetAirdropTotals(sdkCtx, types.AirdropTotals{GenesisTotal: outstanding}))
+
+	// the initial airdrop is funded by the claim module, the round by the
+	// reserve
+	require.NoError(t, keepers.BankKeeper.MintCoins(sdkCtx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 450))))
+	require.NoError(t, keepers.BankKeeper.SendCoinsFromModuleToModule(sdkCtx, types.ModuleName, arkeotypes.ReserveName, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 300))))
+	reserve := keepers.AccountKeeper.GetModuleAddress(arkeotypes.ReserveName)
+	_, broken := keeper.ModuleSolvencyInvariant(k)(sdkCtx)
+	require.False(t, broken)
+
+	sdkCtx = sdkCtx.WithBlockHeight(5)
+	_, err = msgServer.ClaimArkeo(sdk.WrapSDKContext(sdkCtx), &types.MsgClaimArkeo{Creator: addrArkeo, Round: 1})
+	require.ErrorIs(t, err, types.ErrRoundNotStarted)
+	_, err = msgServer.ClaimArkeo(sdk.WrapSDKContext(sdkCtx), &types.MsgClaimArkeo{Creator: addrArkeo, Round: 2})
+	require.ErrorIs(t, err, types.ErrMerkleRoundNotFound)
+
+	// halfway through the round's decay
+	sdkCtx = sdkCtx.WithBlockHeight(25)
+	balanceBefore := keepers.BankKeeper.GetBalance(sdkCtx, addrArkeo, types.DefaultClaimDenom)
+	_, err = msgServer.ClaimArkeo(sdk.WrapSDKContext(sdkCtx), &types.MsgClaimArkeo{Creator: addrArkeo, Round: 1})
+	require.NoError(t, err)
+	balanceAfter := keepers.BankKeeper.GetBalance(sdkCtx, addrArkeo, types.DefaultClaimDenom)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 50), balanceAfter.Sub(balanceBefore))
+	require.Equal(t, int64(250), keepers.BankKeeper.GetBalance(sdkCtx, reserve, types.DefaultClaimDenom).Amount.Int64())
+
+	// the initial airdrop is untouched
+	claimRecord, err := k.GetClaimRecord(sdkCtx, addrArkeo.String(), types.ARKEO)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 50), claimRecord.AmountClaim)
+	_, broken = keeper.ClaimConservationInvariant(k)(sdkCtx)
+	require.False(t, broken)
+	_, broken = keeper.ModuleSolvencyInvariant(k)(sdkCtx)
+	require.False(t, broken)
+
+	// once the round ends its records are clawed back, the initial airdrop
+	// is still running
+	sdkCtx = sdkCtx.WithBlockHeight(31)
+	_, err = msgServer.ClaimArkeo(sdk.WrapSDKContext(sdkCtx), &types.MsgClaimArkeo{Creator: addrArkeo, Round: 1})
+	require.Error(t, err)
+	require.NoError(t, k.EndBlocker(sdkCtx))
+	records, err := k.GetRoundClaimRecords(sdkCtx, 1, types.ARKEO)
+	require.NoError(t, err)
+	require.Empty(t, records)
+	claimRecord, err = k.GetClaimRecord(sdkCtx, addrArkeo.String(), types.ARKEO)
+	require.NoError(t, err)
+	require.False(t, claimRecord.IsEmpty())
+
+	totals, err := k.GetAirdropTotals(sdkCtx)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 200)), totals.ClawedBack)
+	_, broken = keeper.ClaimConservationInvariant(k)(sdkCtx)
+	require.False(t, broken)
 }
@@ -10,7 +10,6 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/pkg/errors"
 
 	"github.com/arkeonetwork/arkeo/x/claim/types"
@@ -18,22 +17,28 @@ import (
 
 func (k msgServer) ClaimEth(goCtx context.Context, msg *types.MsgClaimEth) (*types.MsgClaimEthResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
-	// get eth claim
-	ethClaim, err := k.GetClaimRecord(ctx, msg.EthAddress, types.ETHEREUM)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get claim record for %s", msg.EthAddress)
+	if err := k.validateRound(ctx, msg.Round); err != nil {
+		return nil, err
 	}
+	round, _ := k.GetParams(ctx).GetMerkleRound(msg.Round)
 
-	if ethClaim.IsEmpty() || ethClaim.AmountClaim.IsZero() {
-		return nil, errors.Wrapf(types.ErrNoClaimableAmount, "no claimable amount for %s", msg.Creator)
+	var ethClaim types.ClaimRecord
+	var err error
+	if round.HasMerkleRoot() {
+		ethClaim, err = k.getMerkleClaimRecord(ctx, msg)
+	} else {
+		ethClaim, err = k.getEthClaimRecord(ctx, msg.Round, msg.EthAddress)
+	}
+	if err != nil {
+		return nil, err
 	}
 	totalAmountClaimable := getInitialClaimableAmountTotal(ethClaim)
 
 	// validate signature
-	isValid, err := IsValidClaimSignature(msg.EthAddress, msg.Creator.String(),
+	isValid, err := IsValidClaimSignature(msg.EthAddress, msg.Creator.String(), ctx.ChainID(),
 		totalAmountClaimable.Amount.String(), msg.Signature)
 	if err != nil {
-		return nil, errors.Wrapf(types.ErrInvalidSignature, "failed to validate signature for %s", msg.EthAddress)
+		return nil, errors.Wrapf(types.ErrInvalidSignature, "failed to validate signature for %s: %s", msg.EthAddress, err)
 	}
 
 	if !isValid {
@@ -48,13 +53,25 @@ func (k msgServer) ClaimEth(goCtx context.Context, msg *types.MsgClaimEth) (*typ
 		AmountClaim:    ethClaim.AmountClaim,
 		AmountVote:     ethClaim.AmountVote,
 		AmountDelegate: ethClaim.AmountDelegate,
+		Round:          msg.Round,
 	}
 
-	// set eth claim to completed
-	ethClaim = setClaimableAmountForAllActions(ethClaim, sdk.Coin{})
-	err = k.SetClaimRecord(ctx, ethClaim)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to set claim record for %s", msg.EthAddress)
+	if round.HasMerkleRoot() {
+		// the leaf only enters state now, so its amounts are added to the
+		// genesis total to keep the claim records conserved
+		if err := k.SetMerkleClaimed(ctx, msg.Round, msg.EthAddress); err != nil {
+			return nil, errors.Wrapf(err, "failed to mark merkle claim for %s", msg.EthAddress)
+		}
+		if err := k.addGenesisTotal(ctx, totalAmountClaimable); err != nil {
+			return nil, err
+		}
+	} else {
+		// set eth claim to completed
+		ethClaim = setClaimableAmountForAllActions(ethClaim, sdk.Coin{})
+		err = k.SetClaimRecord(ctx, ethClaim)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to set claim record for %s", msg.EthAddress)
+		}
 	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
@@ -66,7 +83,7 @@ func (k msgServer) ClaimEth(goCtx context.Context, msg *types.MsgClaimEth) (*typ
 	})
 
 	// see if there is an existing arkeo claim so we can merge it
-	existingArkeoClaim, err := k.GetClaimRecord(ctx, msg.Creator.String(), types.ARKEO)
+	existingArkeoClaim, err := k.GetRoundClaimRecord(ctx, msg.Round, msg.Creator.String(), types.ARKEO)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get arkeo claim record for %s", msg.Creator)
 	}
@@ -82,7 +99,7 @@ func (k msgServer) ClaimEth(goCtx context.Context, msg *types.MsgClaimEth) (*typ
 	}
 
 	// call claim on arkeo to claim arkeo (note: this could CLAIM for all tokens that are now merged)
-	_, err = k.ClaimCoinsForAction(ctx, msg.Creator.String(), types.ACTION_CLAIM)
+	_, err = k.ClaimCoinsForAction(ctx, msg.Round, msg.Creator.String(), types.ACTION_CLAIM)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to claim coins for %s", msg.Creator)
 	}
@@ -90,18 +107,72 @@ func (k msgServer) ClaimEth(goCtx context.Context, msg *types.MsgClaimEth) (*typ
 	return &types.MsgClaimEthResponse{}, nil
 }
 
-func GenerateClaimTypedDataBytes(ethAddress, arkeoAddress, amount string) ([]byte, error) {
-	claimEthAddress := common.HexToAddress(ethAddress)
-	signerTypedData := apitypes.TypedData{
-		Types:       types.EIP712Types,
-		PrimaryType: "Claim",
-		Domain:      types.EIP712Domain,
-		Message: apitypes.TypedDataMessage{
-			"address":      claimEthAddress.String(),
-			"arkeoAddress": arkeoAddress,
-			"amount":       amount,
-		},
+// getEthClaimRecord returns the claim record held in state for an ethereum
+// address in an airdrop round
+func (k msgServer) getEthClaimRecord(ctx sdk.Context, round uint64, ethAddress string) (types.ClaimRecord, error) {
+	ethClaim, err := k.GetRoundClaimRecord(ctx, round, ethAddress, types.ETHEREUM)
+	if err != nil {
+		return types.ClaimRecord{}, errors.Wrapf(err, "failed to get claim record for %s", ethAddress)
+	}
+
+	if ethClaim.Address == "" {
+		return types.ClaimRecord{}, errors.Wrapf(types.ErrClaimRecordNotFound, "no claim record for %s", ethAddress)
+	}
+
+	if ethClaim.IsEmpty() || ethClaim.AmountClaim.IsZero() {
+		return types.ClaimRecord{}, errors.Wrapf(types.ErrAlreadyClaimed, "no claimable amount for %s", ethAddress)
+	}
+	return ethClaim, nil
+}
+
+// getMerkleClaimRecord verifies the leaf of an ethereum address against the
+// root of its merkle round and returns the claim record the leaf commits to
+func (k msgServer) getMerkleClaimRecord(ctx sdk.Context, msg *types.MsgClaimEth) (types.ClaimRecord, error) {
+	params := k.GetParams(ctx)
+	round, ok := params.GetMerkleRound(msg.Round)
+	if !ok {
+		return types.ClaimRecord{}, errors.Wrapf(types.ErrMerkleRoundNotFound, "round %d", msg.Round)
 	}
+
+	claimed, err := k.IsMerkleClaimed(ctx, msg.Round, msg.EthAddress)
+	if err != nil {
+		return types.ClaimRecord{}, errors.Wrapf(err, "invalid eth address %s", msg.EthAddress)
+	}
+	if claimed {
+		return types.ClaimRecord{}, errors.Wrapf(types.ErrAlreadyClaimed, "%s already claimed from round %d", msg.EthAddress, msg.Round)
+	}
+
+	root, err := types.DecodeMerkleHash(round.Root)
+	if err != nil {
+		return types.ClaimRecord{}, errors.Wrapf(err, "invalid root for round %d", msg.Round)
+	}
+	proof, err := msg.MerkleProof()
+	if err != nil {
+		return types.ClaimRecord{}, errors.Wrapf(types.ErrInvalidMerkleProof, "%s", err)
+	}
+	leaf := types.MerkleLeaf(msg.EthAddress, msg.AmountClaim, msg.AmountVote, msg.AmountDelegate)
+	if !types.VerifyMerkleProof(root, leaf, proof) {
+		return types.ClaimRecord{}, errors.Wrapf(types.ErrInvalidMerkleProof, "%s is not in round %d", msg.EthAddress, msg.Round)
+	}
+
+	if !msg.AmountClaim.IsPositive() {
+		return types.ClaimRecord{}, errors.Wrapf(types.ErrNoClaimableAmount, "no claimable amount for %s", msg.EthAddress)
+	}
+
+	return types.ClaimRecord{
+		Chain:          types.ETHEREUM,
+		Address:        msg.EthAddress,
+		AmountClaim:    sdk.NewCoin(params.ClaimDenom, msg.AmountClaim),
+		AmountVote:     sdk.NewCoin(params.ClaimDenom, msg.AmountVote),
+		AmountDelegate: sdk.NewCoin(params.ClaimDenom, msg.AmountDelegate),
+		Round:          msg.Round,
+	}, nil
+}
+
+// GenerateClaimTypedDataBytes returns the EIP-712 encoding of the claim typed
+// data, the bytes whose keccak256 hash is signed
+func GenerateClaimTypedDataBytes(ethAddress, arkeoAddress, arkeoChainID, amount string) ([]byte, error) {
+	signerTypedData := types.NewClaimTypedData(ethAddress, arkeoAddress, arkeoChainID, amount)
 	typedDataHash, err := signerTypedData.HashStruct(signerTypedData.PrimaryType, signerTypedData.Message)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to hash struct")
@@ -114,8 +185,11 @@ func GenerateClaimTypedDataBytes(ethAddress, arkeoAddress, amount string) ([]byt
 	return []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash))), nil
 }
 
-func IsValidClaimSignature(ethAddress, arkeoAdddress, amount, signature string) (bool, error) {
-	rawData, err := GenerateClaimTypedDataBytes(ethAddress, arkeoAdddress, amount)
+// IsValidClaimSignature checks the signature of the claim typed data was made
+// by the ethereum address. Wallets set the recovery id of the signature to 27
+// or 28, as well as 0 or 1, both are accepted.
+func IsValidClaimSignature(ethAddress, arkeoAdddress, arkeoChainID, amount, signature string) (bool, error) {
+	rawData, err := GenerateClaimTypedDataBytes(ethAddress, arkeoAdddress, arkeoChainID, amount)
 	if err != nil {
 		return false, errors.Wrapf(err, "failed to generate claim typed data bytes")
 	}
@@ -129,15 +203,14 @@ func IsValidClaimSignature(ethAddress, arkeoAdddress, amount, signature string)
 		return false, fmt.Errorf("signature must be %d bytes long", crypto.SignatureLength)
 	}
 
-	if len(sigHex) != 65 {
-		return false, fmt.Errorf("invalid signature length: %d", len(sigHex))
+	switch sigHex[crypto.RecoveryIDOffset] {
+	case 0, 1:
+	case 27, 28:
+		sigHex[crypto.RecoveryIDOffset] -= 27
+	default:
+		return false, fmt.Errorf("invalid recovery id: %d", sigHex[crypto.RecoveryIDOffset])
 	}
 
-	// if sigHex[crypto.RecoveryIDOffset] != 27 && sigHex[crypto.RecoveryIDOffset] != 28 {
-	// 	return false, fmt.Errorf("invalid recovery id: %d", sigHex[64])
-	// }
-	// sigHex[64] -= 27
-
 	pubKeyRaw, err := crypto.Ecrecover(rawDataHash.Bytes(), sigHex)
 	if err != nil {
 		return false, errors.Wrapf(err, "failed to recover public key from signature")
@@ -69,11 +69,11 @@ func TestClaimEth(t *testing.T) {
 
 	// attempt to claim again to ensure it fails.
 	_, err = msgServer.ClaimEth(ctx, &claimMessage)
-	require.Error(t, err)
+	require.ErrorIs(t, err, types.ErrAlreadyClaimed)
 
 	// attempt to claim from arkeo should also fail!
 	_, err = msgServer.ClaimArkeo(ctx, &types.MsgClaimArkeo{Creator: addrArkeo})
-	require.Error(t, err)
+	require.ErrorIs(t, err, types.ErrAlreadyClaimed)
 }
 
 func TestClaimEthWithInvalidSignature(t *testing.T) {
@@ -173,11 +173,11 @@ func TestClaimEthWithArkeoClaimRecord(t *testing.T) {
 
 	// attempt to claim again to ensure it fails.
 	_, err = msgServer.ClaimEth(ctx, &claimMessage)
-	require.Error(t, err)
+	require.ErrorIs(t, err, types.ErrAlreadyClaimed)
 
 	// attempt to claim from arkeo should also fail!
 	_, err = msgServer.ClaimArkeo(ctx, &types.MsgClaimArkeo{Creator: addrArkeo})
-	require.Error(t, err)
+	require.ErrorIs(t, err, types.ErrAlreadyClaimed)
 }
 
 func TestClaimEthWithNoClaimRecord(t *testing.T) {
@@ -199,7 +199,91 @@ func TestClaimEthWithNoClaimRecord(t *testing.T) {
 		Signature:  sigString,
 	}
 	_, err = msgServer.ClaimEth(ctx, &claimMessage)
-	require.Error(t, err)
+	require.ErrorIs(t, err, types.ErrClaimRecordNotFound)
+}
+
+func TestClaimEthMerkleProof(t *testing.T) {
+	msgServer, keepers, ctx := setupMsgServer(t)
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+	addrArkeo := utils.GetRandomArkeoAddress()
+	addrEth, sigString, err := generateSignedEthClaim(addrArkeo.String(), "300")
+	require.NoError(t, err)
+
+	// the round commits to the claim alongside other recipients, none of
+	// which are held in state
+	leaves := [][]byte{
+		types.MerkleLeaf("0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5", sdk.NewInt(50), sdk.NewInt(50), sdk.NewInt(50)),
+		types.MerkleLeaf(addrEth, sdk.NewInt(100), sdk.NewInt(100), sdk.NewInt(100)),
+		types.MerkleLeaf("0xbd3afb0bb76683ecb4225f9dbc91f998713c3b01", sdk.NewInt(70), sdk.NewInt(0), sdk.NewInt(0)),
+	}
+	root, proofs := types.MerkleTree(leaves)
+	proof := make([]string, len(proofs[1]))
+	for i, hash := range proofs[1] {
+		proof[i] = hexutil.Encode(hash)
+	}
+
+	params := keepers.ClaimKeeper.GetParams(sdkCtx)
+	params.MerkleRounds = []types.MerkleRound{{Round: 1, Root: hexutil.Encode(root)}}
+	keepers.ClaimKeeper.SetParams(sdkCtx, params)
+
+	err = keepers.BankKeeper.MintCoins(sdkCtx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 10000)))
+	require.NoError(t, err)
+
+	balanceBefore := keepers.BankKeeper.GetBalance(sdkCtx, addrArkeo, types.DefaultClaimDenom)
+
+	claimMessage := types.MsgClaimEth{
+		Creator:        addrArkeo,
+		EthAddress:     addrEth,
+		Signature:      sigString,
+		Round:          2,
+		Proof:          proof,
+		AmountClaim:    sdk.NewInt(100),
+		AmountVote:     sdk.NewInt(100),
+		AmountDelegate: sdk.NewInt(100),
+	}
+	_, err = msgServer.ClaimEth(ctx, &claimMessage)
+	require.ErrorIs(t, err, types.ErrMerkleRoundNotFound)
+
+	// the amounts must match the leaf
+	claimMessage.Round = 1
+	claimMessage.AmountVote = sdk.NewInt(200)
+	_, err = msgServer.ClaimEth(ctx, &claimMessage)
+	require.ErrorIs(t, err, types.ErrInvalidMerkleProof)
+
+	claimMessage.AmountVote = sdk.NewInt(100)
+	_, err = msgServer.ClaimEth(ctx, &claimMessage)
+	require.NoError(t, err)
+
+	balanceAfter := keepers.BankKeeper.GetBalance(sdkCtx, addrArkeo, types.DefaultClaimDenom)
+	require.Equal(t, balanceAfter.Sub(balanceBefore), sdk.NewInt64Coin(types.DefaultClaimDenom, 100))
+
+	// the claim stays in the round it was made from
+	claimRecord, err := keepers.ClaimKeeper.GetClaimRecord(sdkCtx, addrArkeo.String(), types.ARKEO)
+	require.NoError(t, err)
+	require.True(t, claimRecord.IsEmpty())
+	claimRecord, err = keepers.ClaimKeeper.GetRoundClaimRecord(sdkCtx, 1, addrArkeo.String(), types.ARKEO)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), claimRecord.Round)
+	require.True(t, claimRecord.AmountClaim.IsZero())
+	require.Equal(t, claimRecord.AmountVote, sdk.NewInt64Coin(types.DefaultClaimDenom, 100))
+	require.Equal(t, claimRecord.AmountDelegate, sdk.NewInt64Coin(types.DefaultClaimDenom, 100))
+
+	// no ethereum record is written, only the claimed marker
+	claimRecord, err = keepers.ClaimKeeper.GetClaimRecord(sdkCtx, addrEth, types.ETHEREUM)
+	require.NoError(t, err)
+	require.True(t, claimRecord.IsEmpty())
+	claimed, err := keepers.ClaimKeeper.IsMerkleClaimed(sdkCtx, 1, strings.ToLower(addrEth))
+	require.NoError(t, err)
+	require.True(t, claimed)
+	require.Equal(t, []types.MerkleClaim{{Round: 1, EthAddress: strings.ToLower(addrEth)}}, keepers.ClaimKeeper.GetMerkleClaims(sdkCtx))
+
+	// the leaf's amounts are accounted for in the airdrop totals
+	_, broken := keeper.ClaimConservationInvariant(keepers.ClaimKeeper)(sdkCtx)
+	require.False(t, broken)
+
+	_, err = msgServer.ClaimEth(ctx, &claimMessage)
+	require.ErrorIs(t, err, types.ErrAlreadyClaimed)
 }
 
 func TestIsValidClaimSignature(t *testing.T) {
@@ -209,21 +293,37 @@ func TestIsValidClaimSignature(t *testing.T) {
 	require.NoError(t, err)
 
 	// check if signature is valid
-	valid, err := keeper.IsValidClaimSignature(strings.ToLower(addressEth), addrArkeo, "5000", sigString)
+	valid, err := keeper.IsValidClaimSignature(strings.ToLower(addressEth), addrArkeo, "", "5000", sigString)
 	require.NoError(t, err)
 	require.True(t, valid)
 
 	// if we modify the message, signature should be invalid
-	_, err = keeper.IsValidClaimSignature(addressEth, addrArkeo, "5001", sigString)
+	_, err = keeper.IsValidClaimSignature(addressEth, addrArkeo, "", "5001", sigString)
 	require.Error(t, err)
 
 	// if we modify the arkeo address, signature should be invalid
 	addrArkeo2 := utils.GetRandomArkeoAddress().String()
-	_, err = keeper.IsValidClaimSignature(addressEth, addrArkeo2, "5000", sigString)
+	_, err = keeper.IsValidClaimSignature(addressEth, addrArkeo2, "", "5000", sigString)
 	require.Error(t, err)
 
 	// if we modify the eth address, signature should be invalid
-	_, err = keeper.IsValidClaimSignature("0xbd3afb0bb76683ecb4225f9dbc91f998713c3b01", addrArkeo, "5000", sigString)
+	_, err = keeper.IsValidClaimSignature("0xbd3afb0bb76683ecb4225f9dbc91f998713c3b01", addrArkeo, "", "5000", sigString)
+	require.Error(t, err)
+
+	// if we modify the arkeo chain, signature should be invalid
+	_, err = keeper.IsValidClaimSignature(addressEth, addrArkeo, "arkeo-testnet", "5000", sigString)
+	require.Error(t, err)
+
+	// wallets set the recovery id to 27 or 28
+	sig, err := hexutil.Decode(sigString)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27
+	valid, err = keeper.IsValidClaimSignature(addressEth, addrArkeo, "", "5000", hexutil.Encode(sig))
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	sig[crypto.RecoveryIDOffset] = 2
+	_, err = keeper.IsValidClaimSignature(addressEth, addrArkeo, "", "5000", hexutil.Encode(sig))
 	require.Error(t, err)
 }
 
@@ -240,7 +340,7 @@ func generateSignedEthClaim(addrArkeo, amount string) (string, string, error) {
 	}
 
 	addressEth := crypto.PubkeyToAddress(*publicKeyECDSA).Hex()
-	message, err := keeper.GenerateClaimTypedDataBytes(addressEth, addrArkeo, amount)
+	message, err := keeper.GenerateClaimTypedDataBytes(addressEth, addrArkeo, "", amount)
 	if err != nil {
 		return "", "", err
 	}
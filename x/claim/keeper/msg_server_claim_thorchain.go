@@ -0,0 +1,104 @@
+package keeper
+
+import (
+	"context"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+)
+
+func (k msgServer) ClaimThorchain(goCtx context.Context, msg *types.MsgClaimThorchain) (*types.MsgClaimThorchainResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := k.validateRound(ctx, msg.Round); err != nil {
+		return nil, err
+	}
+
+	thorClaim, err := k.GetRoundClaimRecord(ctx, msg.Round, msg.ThorAddress, types.THORCHAIN)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get claim record for %s", msg.ThorAddress)
+	}
+	if thorClaim.Address == "" {
+		return nil, errors.Wrapf(types.ErrClaimRecordNotFound, "no claim record for %s", msg.ThorAddress)
+	}
+	if thorClaim.IsEmpty() || thorClaim.AmountClaim.IsZero() {
+		return nil, errors.Wrapf(types.ErrAlreadyClaimed, "no claimable amount for %s", msg.ThorAddress)
+	}
+
+	if err := k.verifyThorchainProof(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	// create new arkeo claim
+	arkeoClaim := types.ClaimRecord{
+		Address:        msg.Creator.String(),
+		Chain:          types.ARKEO,
+		AmountClaim:    thorClaim.AmountClaim,
+		AmountVote:     thorClaim.AmountVote,
+		AmountDelegate: thorClaim.AmountDelegate,
+		Round:          msg.Round,
+	}
+
+	// set thorchain claim to completed
+	thorClaim = setClaimableAmountForAllActions(thorClaim, sdk.Coin{})
+	if err := k.SetClaimRecord(ctx, thorClaim); err != nil {
+		return nil, errors.Wrapf(err, "failed to set claim record for %s", msg.ThorAddress)
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeClaimFromThor,
+			sdk.NewAttribute(sdk.AttributeKeySender, strings.ToLower(msg.ThorAddress)),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, arkeoClaim.AmountClaim.String()),
+		),
+	})
+
+	// see if there is an existing arkeo claim so we can merge it
+	existingArkeoClaim, err := k.GetRoundClaimRecord(ctx, msg.Round, msg.Creator.String(), types.ARKEO)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get arkeo claim record for %s", msg.Creator)
+	}
+
+	arkeoClaim, err = mergeClaimRecords(existingArkeoClaim, arkeoClaim)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to merge claim records for %s", msg.Creator)
+	}
+
+	if err := k.SetClaimRecord(ctx, arkeoClaim); err != nil {
+		return nil, errors.Wrapf(err, "failed to set claim record for %s", msg.Creator)
+	}
+
+	_, err = k.ClaimCoinsForAction(ctx, msg.Round, msg.Creator.String(), types.ACTION_CLAIM)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to claim coins for %s", msg.Creator)
+	}
+
+	return &types.MsgClaimThorchainResponse{}, nil
+}
+
+// verifyThorchainProof checks the thorchain address bound itself to the
+// creator, either by signing the claim with its own key or through a
+// thorchain transaction attested by enough of the oracle set
+func (k msgServer) verifyThorchainProof(ctx sdk.Context, msg *types.MsgClaimThorchain) error {
+	if !msg.IsAttested() {
+		if err := types.VerifyThorchainSignature(ctx.ChainID(), msg.ThorAddress, msg.Creator.String(), msg.PubKey, msg.Signature); err != nil {
+			return errors.Wrapf(types.ErrInvalidThorchainProof, "%s", err)
+		}
+		return nil
+	}
+
+	params := k.GetParams(ctx)
+	if params.ThorchainOracleThreshold == 0 {
+		return errors.Wrapf(types.ErrInvalidThorchainProof, "thorchain attestations are disabled")
+	}
+	count, err := types.CountThorchainAttestations(params.ThorchainOracles, ctx.ChainID(), msg.TxId, msg.ThorAddress, msg.Creator.String(), msg.OracleSignatures)
+	if err != nil {
+		return errors.Wrapf(types.ErrInvalidThorchainProof, "%s", err)
+	}
+	if count < params.ThorchainOracleThreshold {
+		return errors.Wrapf(types.ErrInvalidThorchainProof, "%d of %d oracle attestations for %s", count, params.ThorchainOracleThreshold, msg.TxId)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package keeper_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/arkeonetwork/arkeo/testutil/utils"
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimThorchain(t *testing.T) {
+	msgServer, keepers, ctx := setupMsgServer(t)
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+	thorKey := secp256k1.GenPrivKey()
+	addrThor, err := bech32.ConvertAndEncode("thor", thorKey.PubKey().Address())
+	require.NoError(t, err)
+	addrArkeo := utils.GetRandomArkeoAddress()
+
+	claimRecord := types.ClaimRecord{
+		Chain:          types.THORCHAIN,
+		Address:        addrThor,
+		AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+	}
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecord(sdkCtx, claimRecord))
+	err = keepers.BankKeeper.MintCoins(sdkCtx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 10000)))
+	require.NoError(t, err)
+
+	msg := types.NewMsgClaimThorchain(addrArkeo, addrThor)
+	msg.PubKey = thorKey.PubKey().Bytes()
+
+	// signed for another arkeo address
+	msg.Signature, err = thorKey.Sign(types.ThorchainClaimSignBytes(sdkCtx.ChainID(), addrThor, utils.GetRandomArkeoAddress().String()))
+	require.NoError(t, err)
+	_, err = msgServer.ClaimThorchain(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidThorchainProof)
+
+	// signed by a key of another thor address
+	otherKey := secp256k1.GenPrivKey()
+	msg.PubKey = otherKey.PubKey().Bytes()
+	msg.Signature, err = otherKey.Sign(types.ThorchainClaimSignBytes(sdkCtx.ChainID(), addrThor, addrArkeo.String()))
+	require.NoError(t, err)
+	_, err = msgServer.ClaimThorchain(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidThorchainProof)
+
+	msg.PubKey = thorKey.PubKey().Bytes()
+	msg.Signature, err = thorKey.Sign(types.ThorchainClaimSignBytes(sdkCtx.ChainID(), addrThor, addrArkeo.String()))
+	require.NoError(t, err)
+	_, err = msgServer.ClaimThorchain(ctx, msg)
+	require.NoError(t, err)
+
+	claimRecord, err = keepers.ClaimKeeper.GetClaimRecord(sdkCtx, addrThor, types.THORCHAIN)
+	require.NoError(t, err)
+	require.True(t, claimRecord.IsEmpty())
+
+	claimRecord, err = keepers.ClaimKeeper.GetClaimRecord(sdkCtx, addrArkeo.String(), types.ARKEO)
+	require.NoError(t, err)
+	require.True(t, claimRecord.AmountClaim.IsZero())
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 100), claimRecord.AmountVote)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 100), keepers.BankKeeper.GetBalance(sdkCtx, addrArkeo, types.DefaultClaimDenom))
+
+	_, err = msgServer.ClaimThorchain(ctx, msg)
+	require.ErrorIs(t, err, types.ErrAlreadyClaimed)
+}
+
+func TestClaimThorchainAttested(t *testing.T) {
+	msgServer, keepers, ctx := setupMsgServer(t)
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+	oracles := []*secp256k1.PrivKey{secp256k1.GenPrivKey(), secp256k1.GenPrivKey()}
+	params := keepers.ClaimKeeper.GetParams(sdkCtx)
+	for _, oracle := range oracles {
+		params.ThorchainOracles = append(params.ThorchainOracles, hex.EncodeToString(oracle.PubKey().Bytes()))
+	}
+	params.ThorchainOracleThreshold = 2
+	keepers.ClaimKeeper.SetParams(sdkCtx, params)
+
+	addrThor, err := bech32.ConvertAndEncode("thor", secp256k1.GenPrivKey().PubKey().Address())
+	require.NoError(t, err)
+	addrArkeo := utils.GetRandomArkeoAddress()
+	claimRecord := types.ClaimRecord{
+		Chain:          types.THORCHAIN,
+		Address:        addrThor,
+		AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+	}
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecord(sdkCtx, claimRecord))
+	err = keepers.BankKeeper.MintCoins(sdkCtx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(types.DefaultClaimDenom, 10000)))
+	require.NoError(t, err)
+
+	txID := "4F1C3A0B9E8D7C6B5A49382716F5E4D3C2B1A09F8E7D6C5B4A39281706F5E4D3"
+	signBytes := types.ThorchainAttestationSignBytes(sdkCtx.ChainID(), txID, addrThor, addrArkeo.String())
+	sig, err := oracles[0].Sign(signBytes)
+	require.NoError(t, err)
+
+	// the same oracle twice is a single attestation
+	msg := types.NewMsgClaimThorchain(addrArkeo, addrThor)
+	msg.TxId = txID
+	msg.OracleSignatures = [][]byte{sig, sig}
+	_, err = msgServer.ClaimThorchain(ctx, msg)
+	require.ErrorIs(t, err, types.ErrInvalidThorchainProof)
+
+	sig2, err := oracles[1].Sign(signBytes)
+	require.NoError(t, err)
+	msg.OracleSignatures = [][]byte{sig, sig2}
+	_, err = msgServer.ClaimThorchain(ctx, msg)
+	require.NoError(t, err)
+
+	claimRecord, err = keepers.ClaimKeeper.GetClaimRecord(sdkCtx, addrThor, types.THORCHAIN)
+	require.NoError(t, err)
+	require.True(t, claimRecord.IsEmpty())
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 100), keepers.BankKeeper.GetBalance(sdkCtx, addrArkeo, types.DefaultClaimDenom))
+}
@@ -18,8 +18,12 @@ func (k msgServer) TransferClaim(goCtx context.Context, msg *types.MsgTransferCl
 		return nil, errors.Wrapf(err, "failed to get claim record for %s", msg.Creator)
 	}
 
+	if originalClaim.Address == "" {
+		return nil, errors.Wrapf(types.ErrClaimRecordNotFound, "no claim record for %s", msg.Creator)
+	}
+
 	if originalClaim.IsEmpty() || originalClaim.AmountClaim.IsZero() {
-		return nil, errors.Wrapf(types.ErrNoClaimableAmount, "no claimable amount for %s", msg.Creator)
+		return nil, errors.Wrapf(types.ErrAlreadyClaimed, "no claimable amount for %s", msg.Creator)
 	}
 
 	if !originalClaim.IsTransferable {
@@ -66,7 +70,7 @@ func (k msgServer) TransferClaim(goCtx context.Context, msg *types.MsgTransferCl
 		return nil, errors.Wrapf(err, "failed to set claim record for %s", msg.ToAddress)
 	}
 
-	_, err = k.ClaimCoinsForAction(ctx, msg.ToAddress.String(), types.ACTION_CLAIM)
+	_, err = k.ClaimCoinsForAction(ctx, 0, msg.ToAddress.String(), types.ACTION_CLAIM)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to claim coins for %s", msg.ToAddress)
 	}
@@ -103,13 +103,13 @@ func TestTransferClaim(t *testing.T) {
 
 	// attempt to transfer it again
 	_, err = msgServer.TransferClaim(ctx, transferClaimMessage)
-	require.ErrorIs(t, types.ErrNoClaimableAmount, err)
+	require.ErrorIs(t, types.ErrAlreadyClaimed, err)
 
 	// attempt to claim from arkeo should also fail!
 	_, err = msgServer.ClaimArkeo(ctx, &types.MsgClaimArkeo{
 		Creator: originalClaimAddr,
 	})
-	require.ErrorIs(t, types.ErrNoClaimableAmount, err)
+	require.ErrorIs(t, types.ErrAlreadyClaimed, err)
 }
 
 func TestOriginalClaimNotExistShouldFail(t *testing.T) {
@@ -132,7 +132,7 @@ func TestOriginalClaimNotExistShouldFail(t *testing.T) {
 	toAddrBalanceBefore := keepers.BankKeeper.GetBalance(sdkCtx, toAddr, types.DefaultClaimDenom)
 
 	_, err = msgServer.TransferClaim(ctx, transferClaimMessage)
-	require.ErrorIs(t, types.ErrNoClaimableAmount, err)
+	require.ErrorIs(t, types.ErrClaimRecordNotFound, err)
 
 	// check if claimrecord is updated
 	claimRecord, err := keepers.ClaimKeeper.GetClaimRecord(sdkCtx, originalClaimAddr.String(), types.ARKEO)
@@ -215,11 +215,11 @@ func TestTransferClaimWithExistingClaimShouldMerge(t *testing.T) {
 
 	// attempt to transfer claim again to ensure it fails.
 	result, err = msgServer.TransferClaim(ctx, &transferClaimMessage)
-	require.ErrorIs(t, types.ErrNoClaimableAmount, err)
+	require.ErrorIs(t, types.ErrAlreadyClaimed, err)
 	require.Nil(t, result)
 
 	// attempt to claim from arkeo should also fail!
 	resp, err := msgServer.ClaimArkeo(ctx, &types.MsgClaimArkeo{Creator: addrArkeo})
-	require.ErrorIs(t, types.ErrNoClaimableAmount, err)
+	require.ErrorIs(t, types.ErrAlreadyClaimed, err)
 	require.Nil(t, resp)
 }
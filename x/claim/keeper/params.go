@@ -9,12 +9,16 @@ import (
 
 // GetParams get all parameters as types.Params
 func (k Keeper) GetParams(ctx sdk.Context) types.Params {
-	return types.NewParams(
+	params := types.NewParams(
 		k.ClaimDenom(ctx),
 		k.AirdropStartTime(ctx),
 		k.DurationUntilDecay(ctx),
 		k.DurationOfDecay(ctx),
 	)
+	params.MerkleRounds = k.MerkleRounds(ctx)
+	params.ThorchainOracles = k.ThorchainOracles(ctx)
+	params.ThorchainOracleThreshold = k.ThorchainOracleThreshold(ctx)
+	return params
 }
 
 // SetParams set the params
@@ -44,3 +48,21 @@ func (k Keeper) ClaimDenom(ctx sdk.Context) (res string) {
 	k.paramstore.Get(ctx, types.KeyClaimDenom, &res)
 	return
 }
+
+// MerkleRounds returns the MerkleRounds param, none when it has never been set
+func (k Keeper) MerkleRounds(ctx sdk.Context) (res []types.MerkleRound) {
+	k.paramstore.GetIfExists(ctx, types.KeyMerkleRounds, &res)
+	return
+}
+
+// ThorchainOracles returns the ThorchainOracles param, none when it has never been set
+func (k Keeper) ThorchainOracles(ctx sdk.Context) (res []string) {
+	k.paramstore.GetIfExists(ctx, types.KeyThorchainOracles, &res)
+	return
+}
+
+// ThorchainOracleThreshold returns the ThorchainOracleThreshold param, zero when it has never been set
+func (k Keeper) ThorchainOracleThreshold(ctx sdk.Context) (res uint32) {
+	k.paramstore.GetIfExists(ctx, types.KeyThorchainOracleThreshold, &res)
+	return
+}
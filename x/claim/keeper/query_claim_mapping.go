@@ -0,0 +1,58 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) ClaimMapping(goCtx context.Context, req *types.QueryClaimMappingRequest) (*types.QueryClaimMappingResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	if _, ok := types.Chain_name[int32(req.Chain)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chain %d", req.Chain)
+	}
+	if !types.IsValidAddress(req.Address, req.Chain) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address %s for chain %s", req.Address, req.Chain.String())
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	params := k.GetParams(ctx)
+	resp := &types.QueryClaimMappingResponse{
+		Amount: sdk.NewCoin(params.ClaimDenom, sdk.ZeroInt()),
+	}
+
+	claimRecord, err := k.GetRoundClaimRecord(ctx, req.Round, req.Address, req.Chain)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	// an address without a claim record gets an empty mapping rather than a
+	// not found error
+	if claimRecord.Address == "" {
+		return resp, nil
+	}
+	// a stored record with no remaining amounts still exists, report it as
+	// such with nothing to claim
+	resp.Exists = true
+	if claimRecord.IsEmpty() {
+		return resp, nil
+	}
+
+	claimable, err := k.GetUserTotalClaimable(ctx, req.Round, req.Address, req.Chain)
+	if err != nil {
+		if errors.Is(err, types.ErrAirdropEnded) {
+			return resp, nil
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !claimable.IsNil() {
+		resp.Amount = claimable
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,77 @@
+package keeper_test
+
+import (
+	"testing"
+
+	testkeeper "github.com/arkeonetwork/arkeo/testutil/keeper"
+	"github.com/arkeonetwork/arkeo/testutil/utils"
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimMapping(t *testing.T) {
+	keepers, ctx := testkeeper.CreateTestClaimKeepers(t)
+
+	addrEth := "0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5" // random eth address
+	addrZero := "0x92E14917A0508Eb56C90C90619f5F9Adbf49f47d"
+	addrMissing := "0x4ddE9F1D4AFB4f2aF1b0F1cCcE1d7A2cC3BC5F7D"
+
+	claimRecords := []types.ClaimRecord{
+		{
+			Chain:          types.ETHEREUM,
+			Address:        addrEth,
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 200),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 200),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 200),
+		},
+		{
+			Chain:          types.ETHEREUM,
+			Address:        addrZero,
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+		},
+	}
+	err := keepers.ClaimKeeper.SetClaimRecords(ctx, claimRecords)
+	require.NoError(t, err)
+
+	resp, err := keepers.ClaimKeeper.ClaimMapping(ctx, &types.QueryClaimMappingRequest{
+		Address: addrEth,
+		Chain:   types.ETHEREUM,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Exists)
+	require.Equal(t, resp.Amount, sdk.NewInt64Coin(types.DefaultClaimDenom, 600))
+
+	// record exists, but nothing left to claim
+	resp, err = keepers.ClaimKeeper.ClaimMapping(ctx, &types.QueryClaimMappingRequest{
+		Address: addrZero,
+		Chain:   types.ETHEREUM,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Exists)
+	require.True(t, resp.Amount.IsZero())
+
+	// no record at all
+	resp, err = keepers.ClaimKeeper.ClaimMapping(ctx, &types.QueryClaimMappingRequest{
+		Address: addrMissing,
+		Chain:   types.ETHEREUM,
+	})
+	require.NoError(t, err)
+	require.False(t, resp.Exists)
+	require.True(t, resp.Amount.IsZero())
+
+	// eth address on the arkeo chain is rejected
+	_, err = keepers.ClaimKeeper.ClaimMapping(ctx, &types.QueryClaimMappingRequest{
+		Address: addrEth,
+		Chain:   types.ARKEO,
+	})
+	require.Error(t, err)
+
+	_, err = keepers.ClaimKeeper.ClaimMapping(ctx, &types.QueryClaimMappingRequest{
+		Address: utils.GetRandomArkeoAddress().String(),
+		Chain:   types.ETHEREUM,
+	})
+	require.Error(t, err)
+}
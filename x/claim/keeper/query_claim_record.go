@@ -15,7 +15,7 @@ func (k Keeper) ClaimRecord(goCtx context.Context, req *types.QueryClaimRecordRe
 	}
 
 	ctx := sdk.UnwrapSDKContext(goCtx)
-	claimRecord, err := k.GetClaimRecord(ctx, req.Address, req.Chain)
+	claimRecord, err := k.GetRoundClaimRecord(ctx, req.Round, req.Address, req.Chain)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -0,0 +1,72 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) ClaimRecordAll(goCtx context.Context, req *types.QueryAllClaimRecordRequest) (*types.QueryAllClaimRecordResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	if _, ok := types.Chain_name[int32(req.Chain)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chain %d", req.Chain)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, chainToStorePrefix(req.Chain))
+
+	// records are keyed by lower cased address, so pages are ordered by
+	// address bytes and stable between calls
+	var summaries []types.ClaimRecordSummary
+	pageRes, err := query.Paginate(prefixStore, req.Pagination, func(key, value []byte) error {
+		var claimRecord types.ClaimRecord
+		if err := k.cdc.Unmarshal(value, &claimRecord); err != nil {
+			return err
+		}
+		summaries = append(summaries, summarizeClaimRecord(claimRecord))
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAllClaimRecordResponse{ClaimRecords: summaries, Pagination: pageRes}, nil
+}
+
+// summarizeClaimRecord amounts are zeroed as actions are completed, so any
+// action without a remaining amount is reported as completed
+func summarizeClaimRecord(claimRecord types.ClaimRecord) types.ClaimRecordSummary {
+	summary := types.ClaimRecordSummary{
+		Address: claimRecord.Address,
+		Amount:  getInitialClaimableAmountTotal(claimRecord),
+	}
+	summary.CompletedActions = completedActions(claimRecord)
+	for _, action := range summary.CompletedActions {
+		if action == types.ACTION_CLAIM {
+			summary.Claimed = true
+		}
+	}
+	return summary
+}
+
+// completedActions returns the actions of the claim record without a
+// remaining amount
+func completedActions(claimRecord types.ClaimRecord) []types.Action {
+	var actions []types.Action
+	for i := 0; i < len(types.Action_name); i++ {
+		action := types.Action(i)
+		amount := getInitialClaimableAmount(claimRecord, action)
+		if amount.IsNil() || amount.IsZero() {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
@@ -0,0 +1,103 @@
+package keeper_test
+
+import (
+	"testing"
+
+	testkeeper "github.com/arkeonetwork/arkeo/testutil/keeper"
+	"github.com/arkeonetwork/arkeo/testutil/utils"
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClaimRecordAll(t *testing.T) {
+	keepers, ctx := testkeeper.CreateTestClaimKeepers(t)
+
+	addrs := []string{
+		"0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5",
+		"0x92e14917a0508eb56c90c90619f5f9adbf49f47d",
+		"0x4dde9f1d4afb4f2af1b0f1ccce1d7a2cc3bc5f7d",
+	}
+	claimRecords := []types.ClaimRecord{
+		{
+			Chain:          types.ETHEREUM,
+			Address:        addrs[0],
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 200),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 300),
+		},
+		{
+			Chain:          types.ETHEREUM,
+			Address:        addrs[1],
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 200),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+		},
+		{
+			Chain:          types.ETHEREUM,
+			Address:        addrs[2],
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 0),
+		},
+	}
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecords(ctx, claimRecords))
+
+	// records on another chain are not included
+	arkeoRecord := types.ClaimRecord{
+		Chain:          types.ARKEO,
+		Address:        utils.GetRandomArkeoAddress().String(),
+		AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+	}
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecord(ctx, arkeoRecord))
+
+	resp, err := keepers.ClaimKeeper.ClaimRecordAll(ctx, &types.QueryAllClaimRecordRequest{Chain: types.ETHEREUM})
+	require.NoError(t, err)
+	require.Len(t, resp.ClaimRecords, 3)
+
+	// ordered by address
+	require.Equal(t, addrs[2], resp.ClaimRecords[0].Address)
+	require.Equal(t, addrs[1], resp.ClaimRecords[1].Address)
+	require.Equal(t, addrs[0], resp.ClaimRecords[2].Address)
+
+	require.True(t, resp.ClaimRecords[0].Claimed)
+	require.True(t, resp.ClaimRecords[0].Amount.IsZero())
+	require.Equal(t, []types.Action{types.ACTION_CLAIM, types.ACTION_VOTE, types.ACTION_DELEGATE}, resp.ClaimRecords[0].CompletedActions)
+
+	require.True(t, resp.ClaimRecords[1].Claimed)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 200), resp.ClaimRecords[1].Amount)
+	require.Equal(t, []types.Action{types.ACTION_CLAIM, types.ACTION_DELEGATE}, resp.ClaimRecords[1].CompletedActions)
+
+	require.False(t, resp.ClaimRecords[2].Claimed)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 600), resp.ClaimRecords[2].Amount)
+	require.Empty(t, resp.ClaimRecords[2].CompletedActions)
+
+	// paging is stable
+	var paged []string
+	var nextKey []byte
+	for {
+		resp, err = keepers.ClaimKeeper.ClaimRecordAll(ctx, &types.QueryAllClaimRecordRequest{
+			Chain:      types.ETHEREUM,
+			Pagination: &query.PageRequest{Key: nextKey, Limit: 2},
+		})
+		require.NoError(t, err)
+		for _, record := range resp.ClaimRecords {
+			paged = append(paged, record.Address)
+		}
+		nextKey = resp.Pagination.NextKey
+		if nextKey == nil {
+			break
+		}
+	}
+	require.Equal(t, []string{addrs[2], addrs[1], addrs[0]}, paged)
+
+	// invalid chain
+	_, err = keepers.ClaimKeeper.ClaimRecordAll(ctx, &types.QueryAllClaimRecordRequest{Chain: types.Chain(5)})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (k Keeper) ClaimStatus(goCtx context.Context, req *types.QueryClaimStatusRequest) (*types.QueryClaimStatusResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	if _, ok := types.Chain_name[int32(req.Chain)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chain %d", req.Chain)
+	}
+	if !types.IsValidAddress(req.Address, req.Chain) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address %s for chain %s", req.Address, req.Chain.String())
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	params := k.GetParams(ctx)
+	round, ok := params.GetMerkleRound(req.Round)
+	if req.Round > 0 && !ok {
+		return nil, status.Errorf(codes.NotFound, "airdrop round %d not found", req.Round)
+	}
+
+	decayStatus, claimablePercent, err := k.getDecayStatus(ctx, req.Round)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &types.QueryClaimStatusResponse{
+		Unclaimed:        sdk.NewCoin(params.ClaimDenom, sdk.ZeroInt()),
+		Claimable:        sdk.NewCoin(params.ClaimDenom, sdk.ZeroInt()),
+		DecayStatus:      decayStatus,
+		ClaimablePercent: claimablePercent,
+	}
+
+	if req.Chain == types.ETHEREUM && round.HasMerkleRoot() {
+		resp.MerkleClaimed, err = k.IsMerkleClaimed(ctx, req.Round, req.Address)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	claimRecord, err := k.GetRoundClaimRecord(ctx, req.Round, req.Address, req.Chain)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if claimRecord.Address == "" {
+		return resp, nil
+	}
+	resp.Exists = true
+	resp.ClaimRecord = &claimRecord
+	resp.CompletedActions = completedActions(claimRecord)
+	for _, action := range resp.CompletedActions {
+		if action == types.ACTION_CLAIM {
+			resp.Claimed = true
+		}
+	}
+	resp.Unclaimed = sdk.NewCoin(params.ClaimDenom, claimRecord.Outstanding().AmountOf(params.ClaimDenom))
+
+	if claimablePercent.IsZero() {
+		return resp, nil
+	}
+	for i := 0; i < len(types.Action_name); i++ {
+		claimable, err := k.GetClaimableAmountForAction(ctx, req.Round, req.Address, types.Action(i), req.Chain)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if claimable.IsNil() || claimable.Denom != params.ClaimDenom {
+			continue
+		}
+		resp.Claimable = resp.Claimable.Add(claimable)
+	}
+
+	return resp, nil
+}
+
+// getDecayStatus returns where the round is in its decay schedule, along with
+// the share of the initial amounts claimable at the current block
+func (k Keeper) getDecayStatus(ctx sdk.Context, round uint64) (types.DecayStatus, sdk.Dec, error) {
+	params := k.GetParams(ctx)
+	started := !ctx.BlockTime().Before(params.AirdropStartTime)
+	if r, ok := params.GetMerkleRound(round); ok && round > 0 {
+		started = ctx.BlockHeight() >= r.StartHeight
+	}
+	if !started {
+		return types.DECAY_NOT_STARTED, sdk.ZeroDec(), nil
+	}
+
+	claimablePercent, err := k.getClaimablePercent(ctx, round)
+	switch {
+	case errors.Is(err, types.ErrAirdropEnded):
+		return types.DECAY_ENDED, sdk.ZeroDec(), nil
+	case err != nil:
+		return types.DECAY_NOT_STARTED, sdk.ZeroDec(), err
+	case claimablePercent.Equal(sdk.OneDec()):
+		return types.DECAY_NONE, claimablePercent, nil
+	default:
+		return types.DECAY_DECAYING, claimablePercent, nil
+	}
+}
@@ -0,0 +1,111 @@
+package keeper_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	testkeeper "github.com/arkeonetwork/arkeo/testutil/keeper"
+	"github.com/arkeonetwork/arkeo/testutil/utils"
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimStatus(t *testing.T) {
+	keepers, ctx := testkeeper.CreateTestClaimKeepers(t)
+
+	addrEth := "0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5" // random eth address
+	addrMissing := "0x4ddE9F1D4AFB4f2aF1b0F1cCcE1d7A2cC3BC5F7D"
+	addrThor := "thor1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5e949nr"
+
+	claimRecords := []types.ClaimRecord{
+		{
+			Chain:          types.ETHEREUM,
+			Address:        addrEth,
+			AmountClaim:    sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 100),
+		},
+		{
+			// the claim action has been completed
+			Chain:          types.THORCHAIN,
+			Address:        addrThor,
+			AmountVote:     sdk.NewInt64Coin(types.DefaultClaimDenom, 50),
+			AmountDelegate: sdk.NewInt64Coin(types.DefaultClaimDenom, 50),
+		},
+	}
+	require.NoError(t, keepers.ClaimKeeper.SetClaimRecords(ctx, claimRecords))
+
+	resp, err := keepers.ClaimKeeper.ClaimStatus(ctx, &types.QueryClaimStatusRequest{Address: addrEth, Chain: types.ETHEREUM})
+	require.NoError(t, err)
+	require.True(t, resp.Exists)
+	require.False(t, resp.Claimed)
+	require.Empty(t, resp.CompletedActions)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 300), resp.Unclaimed)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 300), resp.Claimable)
+	require.Equal(t, types.DECAY_NONE, resp.DecayStatus)
+	require.Equal(t, sdk.OneDec(), resp.ClaimablePercent)
+
+	resp, err = keepers.ClaimKeeper.ClaimStatus(ctx, &types.QueryClaimStatusRequest{Address: addrThor, Chain: types.THORCHAIN})
+	require.NoError(t, err)
+	require.True(t, resp.Exists)
+	require.True(t, resp.Claimed)
+	require.Equal(t, []types.Action{types.ACTION_CLAIM}, resp.CompletedActions)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 100), resp.Unclaimed)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 100), resp.Claimable)
+
+	// no record at all
+	resp, err = keepers.ClaimKeeper.ClaimStatus(ctx, &types.QueryClaimStatusRequest{Address: addrMissing, Chain: types.ETHEREUM})
+	require.NoError(t, err)
+	require.False(t, resp.Exists)
+	require.Nil(t, resp.ClaimRecord)
+	require.True(t, resp.Unclaimed.IsZero())
+
+	// half way through the decay
+	params := keepers.ClaimKeeper.GetParams(ctx)
+	decayCtx := ctx.WithBlockTime(params.AirdropStartTime.Add(params.DurationUntilDecay).Add(params.DurationOfDecay / 2))
+	resp, err = keepers.ClaimKeeper.ClaimStatus(decayCtx, &types.QueryClaimStatusRequest{Address: addrEth, Chain: types.ETHEREUM})
+	require.NoError(t, err)
+	require.Equal(t, types.DECAY_DECAYING, resp.DecayStatus)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 300), resp.Unclaimed)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultClaimDenom, 150), resp.Claimable)
+
+	// the airdrop has ended
+	endCtx := ctx.WithBlockTime(params.AirdropStartTime.Add(params.DurationUntilDecay).Add(params.DurationOfDecay * 2))
+	resp, err = keepers.ClaimKeeper.ClaimStatus(endCtx, &types.QueryClaimStatusRequest{Address: addrEth, Chain: types.ETHEREUM})
+	require.NoError(t, err)
+	require.Equal(t, types.DECAY_ENDED, resp.DecayStatus)
+	require.True(t, resp.Claimable.IsZero())
+
+	// a merkle round leaves no claim record behind once claimed
+	leaf := types.MerkleLeaf(addrEth, sdk.NewInt(1), sdk.NewInt(1), sdk.NewInt(1))
+	params.MerkleRounds = []types.MerkleRound{{
+		Round:       1,
+		Root:        hex.EncodeToString(leaf),
+		StartHeight: 10,
+	}}
+	keepers.ClaimKeeper.SetParams(ctx, params)
+	resp, err = keepers.ClaimKeeper.ClaimStatus(ctx.WithBlockHeight(5), &types.QueryClaimStatusRequest{Address: addrEth, Chain: types.ETHEREUM, Round: 1})
+	require.NoError(t, err)
+	require.Equal(t, types.DECAY_NOT_STARTED, resp.DecayStatus)
+	require.False(t, resp.MerkleClaimed)
+
+	require.NoError(t, keepers.ClaimKeeper.SetMerkleClaimed(ctx, 1, addrEth))
+	resp, err = keepers.ClaimKeeper.ClaimStatus(ctx.WithBlockHeight(10), &types.QueryClaimStatusRequest{Address: addrEth, Chain: types.ETHEREUM, Round: 1})
+	require.NoError(t, err)
+	require.Equal(t, types.DECAY_NONE, resp.DecayStatus)
+	require.True(t, resp.MerkleClaimed)
+	require.False(t, resp.Exists)
+
+	_, err = keepers.ClaimKeeper.ClaimStatus(ctx, &types.QueryClaimStatusRequest{Address: addrEth, Chain: types.ETHEREUM, Round: 2})
+	require.Error(t, err)
+
+	_, err = keepers.ClaimKeeper.ClaimStatus(ctx, &types.QueryClaimStatusRequest{
+		Address: utils.GetRandomArkeoAddress().String(),
+		Chain:   types.ETHEREUM,
+	})
+	require.Error(t, err)
+
+	_, err = keepers.ClaimKeeper.ClaimStatus(ctx, nil)
+	require.Error(t, err)
+}
@@ -42,6 +42,10 @@ const (
 	// TODO: Determine the simulation weight value
 	defaultWeightMsgAddClaim int = 100
 
+	opWeightMsgClaimAll = "op_weight_msg_claim_all"
+	// TODO: Determine the simulation weight value
+	defaultWeightMsgClaimAll int = 100
+
 	// this line is used by starport scaffolding # simapp/module/const
 )
 
@@ -119,6 +123,17 @@ func (am AppModule) WeightedOperations(simState module.SimulationState) []simtyp
 		claimsimulation.SimulateMsgAddClaim(am.accountKeeper, am.bankKeeper, am.keeper),
 	))
 
+	var weightMsgClaimAll int
+	simState.AppParams.GetOrGenerate(simState.Cdc, opWeightMsgClaimAll, &weightMsgClaimAll, nil,
+		func(_ *rand.Rand) {
+			weightMsgClaimAll = defaultWeightMsgClaimAll
+		},
+	)
+	operations = append(operations, simulation.NewWeightedOperation(
+		weightMsgClaimAll,
+		claimsimulation.SimulateMsgClaimAll(am.accountKeeper, am.bankKeeper, am.keeper),
+	))
+
 	// this line is used by starport scaffolding # simapp/module/operation
 
 	return operations
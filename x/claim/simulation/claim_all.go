@@ -0,0 +1,56 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/arkeonetwork/arkeo/x/claim/keeper"
+	"github.com/arkeonetwork/arkeo/x/claim/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+func SimulateMsgClaimAll(
+	ak types.AccountKeeper,
+	bk types.BankKeeper,
+	k keeper.Keeper,
+) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string,
+	) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		msg := &types.MsgClaimAll{}
+
+		// find an account with anything left on its arkeo claim record
+		records, err := k.GetClaimRecords(ctx, types.ARKEO)
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "unable to get claim records"), nil, err
+		}
+		candidates := make([]simtypes.Account, 0)
+		for _, record := range records {
+			if record.IsEmpty() {
+				continue
+			}
+			addr, err := sdk.AccAddressFromBech32(record.Address)
+			if err != nil {
+				continue
+			}
+			if simAccount, found := simtypes.FindAccount(accs, addr); found {
+				candidates = append(candidates, simAccount)
+			}
+		}
+		if len(candidates) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "no claimable accounts"), nil, nil
+		}
+		simAccount := candidates[r.Intn(len(candidates))]
+		msg.Creator = simAccount.Address
+
+		claimable, err := k.GetUserTotalClaimable(ctx, 0, simAccount.Address.String(), types.ARKEO)
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), err.Error()), nil, nil
+		}
+		if !claimable.IsNil() && claimable.IsPositive() && !bk.SpendableCoins(ctx, k.GetModuleAccountAddress(ctx)).IsAllGTE(sdk.NewCoins(claimable)) {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "insufficient module balance"), nil, nil
+		}
+
+		return deliver(r, app, ctx, ak, bk, simAccount, msg)
+	}
+}
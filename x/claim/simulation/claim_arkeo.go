@@ -17,13 +17,40 @@ func SimulateMsgClaimArkeo(
 ) simtypes.Operation {
 	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simtypes.Account, chainID string,
 	) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
-		simAccount, _ := simtypes.RandomAcc(r, accs)
-		msg := &types.MsgClaimArkeo{
-			Creator: simAccount.Address,
+		msg := &types.MsgClaimArkeo{}
+
+		// find an account that still has its claim action outstanding
+		records, err := k.GetClaimRecords(ctx, types.ARKEO)
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "unable to get claim records"), nil, err
+		}
+		candidates := make([]simtypes.Account, 0)
+		for _, record := range records {
+			if record.AmountClaim.IsNil() || record.AmountClaim.IsZero() {
+				continue
+			}
+			addr, err := sdk.AccAddressFromBech32(record.Address)
+			if err != nil {
+				continue
+			}
+			if simAccount, found := simtypes.FindAccount(accs, addr); found {
+				candidates = append(candidates, simAccount)
+			}
+		}
+		if len(candidates) == 0 {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "no claimable accounts"), nil, nil
 		}
+		simAccount := candidates[r.Intn(len(candidates))]
+		msg.Creator = simAccount.Address
 
-		// TODO: Handling the ClaimArkeo simulation
+		claimable, err := k.GetClaimableAmountForAction(ctx, 0, simAccount.Address.String(), types.ACTION_CLAIM, types.ARKEO)
+		if err != nil {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), err.Error()), nil, nil
+		}
+		if !claimable.IsNil() && claimable.IsPositive() && !bk.SpendableCoins(ctx, k.GetModuleAccountAddress(ctx)).IsAllGTE(sdk.NewCoins(claimable)) {
+			return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "insufficient module balance"), nil, nil
+		}
 
-		return simtypes.NoOpMsg(types.ModuleName, msg.Type(), "ClaimArkeo simulation not implemented"), nil, nil
+		return deliver(r, app, ctx, ak, bk, simAccount, msg)
 	}
 }
@@ -1,6 +1,12 @@
 package types
 
-import sdk "github.com/cosmos/cosmos-sdk/types"
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
 
 func IsValidAddress(address string, chain Chain) bool {
 	switch chain {
@@ -9,7 +15,37 @@ func IsValidAddress(address string, chain Chain) bool {
 	case ARKEO:
 		_, err := sdk.AccAddressFromBech32(address)
 		return err == nil
+	case THORCHAIN:
+		return IsValidThorAddress(address)
 	default:
 		return false
 	}
 }
+
+// NormalizeAddress returns the canonical form of an address on the given
+// chain. Ethereum addresses are lowercased hex with a 0x prefix, so checksummed
+// and lowercased forms of the same address are equal. Arkeo addresses are
+// re-encoded as lowercase bech32 with the account prefix, and thorchain
+// addresses as lowercase bech32 with their network's prefix.
+func NormalizeAddress(address string, chain Chain) (string, error) {
+	switch chain {
+	case ETHEREUM:
+		if !IsValidEthAddress(address) {
+			return "", sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid address for chain %s", chain.String())
+		}
+		return strings.ToLower(ethcommon.HexToAddress(address).Hex()), nil
+	case ARKEO:
+		addr, err := sdk.AccAddressFromBech32(strings.ToLower(address))
+		if err != nil {
+			return "", sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid address for chain %s", chain.String())
+		}
+		return addr.String(), nil
+	case THORCHAIN:
+		if !IsValidThorAddress(address) {
+			return "", sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid address for chain %s", chain.String())
+		}
+		return strings.ToLower(address), nil
+	default:
+		return "", sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "unsupported chain %s", chain.String())
+	}
+}
@@ -0,0 +1,162 @@
+package types
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AirdropSnapshotFormat is the encoding of an airdrop snapshot file
+type AirdropSnapshotFormat string
+
+const (
+	AirdropSnapshotCSV  AirdropSnapshotFormat = "csv"
+	AirdropSnapshotJSON AirdropSnapshotFormat = "json"
+)
+
+// airdropSnapshotColumns are the columns of a csv airdrop snapshot, in order,
+// and the fields of each entry of a json airdrop snapshot
+var airdropSnapshotColumns = []string{"chain", "address", "amount_claim", "amount_vote", "amount_delegate"}
+
+// AirdropEntry is an airdrop recipient with the amount it may claim for each
+// action
+type AirdropEntry struct {
+	Chain          Chain
+	Address        string
+	AmountClaim    sdk.Int
+	AmountVote     sdk.Int
+	AmountDelegate sdk.Int
+}
+
+type airdropEntryJSON struct {
+	Chain          string `json:"chain"`
+	Address        string `json:"address"`
+	AmountClaim    string `json:"amount_claim"`
+	AmountVote     string `json:"amount_vote"`
+	AmountDelegate string `json:"amount_delegate"`
+}
+
+// ParseAirdropSnapshot reads the recipients of an airdrop snapshot. A csv
+// snapshot has a chain,address,amount_claim,amount_vote,amount_delegate row
+// per recipient, with an optional header, a json snapshot is an array of
+// objects with those fields. Addresses are normalized, and a recipient may
+// only appear once per chain.
+func ParseAirdropSnapshot(r io.Reader, format AirdropSnapshotFormat) ([]AirdropEntry, error) {
+	var rows []airdropEntryJSON
+	switch format {
+	case AirdropSnapshotCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = len(airdropSnapshotColumns)
+		reader.TrimLeadingSpace = true
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		for i, record := range records {
+			if i == 0 && strings.EqualFold(record[0], airdropSnapshotColumns[0]) {
+				continue
+			}
+			rows = append(rows, airdropEntryJSON{
+				Chain:          record[0],
+				Address:        record[1],
+				AmountClaim:    record[2],
+				AmountVote:     record[3],
+				AmountDelegate: record[4],
+			})
+		}
+	case AirdropSnapshotJSON:
+		if err := json.NewDecoder(r).Decode(&rows); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported airdrop snapshot format %q", format)
+	}
+
+	entries := make([]AirdropEntry, 0, len(rows))
+	seen := make(map[string]bool, len(rows))
+	for i, row := range rows {
+		entry, err := newAirdropEntry(row)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		key := fmt.Sprintf("%s/%s", entry.Chain, entry.Address)
+		if seen[key] {
+			return nil, fmt.Errorf("entry %d: duplicate %s address %s", i+1, entry.Chain, entry.Address)
+		}
+		seen[key] = true
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func newAirdropEntry(row airdropEntryJSON) (AirdropEntry, error) {
+	chain, err := ChainFromString(strings.TrimSpace(row.Chain))
+	if err != nil {
+		return AirdropEntry{}, fmt.Errorf("%w %q", err, row.Chain)
+	}
+	address, err := NormalizeAddress(strings.TrimSpace(row.Address), chain)
+	if err != nil {
+		return AirdropEntry{}, err
+	}
+	entry := AirdropEntry{Chain: chain, Address: address}
+	amounts := []*sdk.Int{&entry.AmountClaim, &entry.AmountVote, &entry.AmountDelegate}
+	for i, value := range []string{row.AmountClaim, row.AmountVote, row.AmountDelegate} {
+		amount, ok := sdk.NewIntFromString(strings.TrimSpace(value))
+		if !ok || amount.IsNegative() {
+			return AirdropEntry{}, fmt.Errorf("invalid %s %q", airdropSnapshotColumns[i+2], value)
+		}
+		*amounts[i] = amount
+	}
+	if entry.Total().IsZero() {
+		return AirdropEntry{}, fmt.Errorf("%s address %s has nothing to claim", chain, address)
+	}
+	return entry, nil
+}
+
+// Total returns the amount the recipient may claim over all actions
+func (e AirdropEntry) Total() sdk.Int {
+	return e.AmountClaim.Add(e.AmountVote).Add(e.AmountDelegate)
+}
+
+// ClaimRecord returns the claim record of the recipient in the given round
+func (e AirdropEntry) ClaimRecord(denom string, round uint64) ClaimRecord {
+	return ClaimRecord{
+		Chain:          e.Chain,
+		Address:        e.Address,
+		AmountClaim:    sdk.NewCoin(denom, e.AmountClaim),
+		AmountVote:     sdk.NewCoin(denom, e.AmountVote),
+		AmountDelegate: sdk.NewCoin(denom, e.AmountDelegate),
+		Round:          round,
+	}
+}
+
+// AirdropTotal returns the amount all recipients may claim together
+func AirdropTotal(entries []AirdropEntry) sdk.Int {
+	total := sdk.ZeroInt()
+	for _, entry := range entries {
+		total = total.Add(entry.Total())
+	}
+	return total
+}
+
+// AirdropMerkleTree returns the merkle root committing to the recipients and
+// the proof of every recipient, in the order given. Only ethereum addresses can
+// claim from a merkle round.
+func AirdropMerkleTree(entries []AirdropEntry) ([]byte, [][][]byte, error) {
+	if len(entries) == 0 {
+		return nil, nil, fmt.Errorf("airdrop snapshot has no recipients")
+	}
+	leaves := make([][]byte, len(entries))
+	for i, entry := range entries {
+		if entry.Chain != ETHEREUM {
+			return nil, nil, fmt.Errorf("%s address %s cannot claim from a merkle round, only %s addresses can", entry.Chain, entry.Address, ETHEREUM)
+		}
+		leaves[i] = MerkleLeaf(entry.Address, entry.AmountClaim, entry.AmountVote, entry.AmountDelegate)
+	}
+	root, proofs := MerkleTree(leaves)
+	return root, proofs, nil
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkeonetwork/arkeo/testutil/sample"
+)
+
+func TestParseAirdropSnapshot(t *testing.T) {
+	ethAddress := "0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5"
+	thorAddress := "thor1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5e949nr"
+	arkeoAddress := sample.AccAddress().String()
+
+	snapshot := fmt.Sprintf(`chain,address,amount_claim,amount_vote,amount_delegate
+ethereum,%s,100,200,300
+THORCHAIN,%s,10,0,0
+arkeo, %s, 1, 1, 1
+`, ethAddress, thorAddress, arkeoAddress)
+	entries, err := ParseAirdropSnapshot(strings.NewReader(snapshot), AirdropSnapshotCSV)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, ETHEREUM, entries[0].Chain)
+	require.Equal(t, strings.ToLower(ethAddress), entries[0].Address)
+	require.Equal(t, sdk.NewInt(600), entries[0].Total())
+	require.Equal(t, THORCHAIN, entries[1].Chain)
+	require.Equal(t, ARKEO, entries[2].Chain)
+	require.Equal(t, arkeoAddress, entries[2].Address)
+	require.Equal(t, sdk.NewInt(613), AirdropTotal(entries))
+
+	record := entries[0].ClaimRecord("uarkeo", 2)
+	require.Equal(t, sdk.NewInt64Coin("uarkeo", 200), record.AmountVote)
+	require.Equal(t, uint64(2), record.Round)
+
+	// the same snapshot as json, the header is optional for csv
+	jsonSnapshot := fmt.Sprintf(`[{"chain":"ethereum","address":"%s","amount_claim":"100","amount_vote":"200","amount_delegate":"300"}]`, ethAddress)
+	jsonEntries, err := ParseAirdropSnapshot(strings.NewReader(jsonSnapshot), AirdropSnapshotJSON)
+	require.NoError(t, err)
+	csvEntries, err := ParseAirdropSnapshot(strings.NewReader(fmt.Sprintf("ethereum,%s,100,200,300", ethAddress)), AirdropSnapshotCSV)
+	require.NoError(t, err)
+	require.Equal(t, entries[:1], jsonEntries)
+	require.Equal(t, entries[:1], csvEntries)
+
+	for _, invalid := range []string{
+		"bitcoin,%s,1,1,1",
+		"ethereum,0x1234,1,1,1",
+		"ethereum,%s,-1,1,1",
+		"ethereum,%s,one,1,1",
+		"ethereum,%s,0,0,0",
+		"ethereum,%s,1,1",
+		"ethereum,%s,1,1,1\nethereum,%s,1,1,1",
+	} {
+		snapshot := strings.ReplaceAll(invalid, "%s", ethAddress)
+		_, err := ParseAirdropSnapshot(strings.NewReader(snapshot), AirdropSnapshotCSV)
+		require.Error(t, err, invalid)
+	}
+
+	_, err = ParseAirdropSnapshot(strings.NewReader(snapshot), AirdropSnapshotFormat("xml"))
+	require.Error(t, err)
+}
+
+func TestAirdropMerkleTree(t *testing.T) {
+	entries, err := ParseAirdropSnapshot(strings.NewReader(`ethereum,0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5,1,2,3
+ethereum,0xbd3afb0bb76683ecb4225f9dbc91f998713c3b01,4,5,6`), AirdropSnapshotCSV)
+	require.NoError(t, err)
+
+	root, proofs, err := AirdropMerkleTree(entries)
+	require.NoError(t, err)
+	for i, entry := range entries {
+		leaf := MerkleLeaf(entry.Address, entry.AmountClaim, entry.AmountVote, entry.AmountDelegate)
+		require.True(t, VerifyMerkleProof(root, leaf, proofs[i]))
+	}
+
+	// only ethereum addresses can claim from a merkle round
+	entries = append(entries, AirdropEntry{Chain: THORCHAIN, Address: "thor1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5e949nr"})
+	_, _, err = AirdropMerkleTree(entries)
+	require.Error(t, err)
+	_, _, err = AirdropMerkleTree(nil)
+	require.Error(t, err)
+}
@@ -3,6 +3,8 @@ package types
 import (
 	"fmt"
 	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 func (claimRecord *ClaimRecord) IsEmpty() bool {
@@ -29,6 +31,19 @@ func (claimRecord *ClaimRecord) IsEmpty() bool {
 	return true
 }
 
+// Outstanding returns the amounts still claimable on the record, completed
+// actions are reset to an empty coin and are skipped
+func (claimRecord *ClaimRecord) Outstanding() sdk.Coins {
+	outstanding := sdk.NewCoins()
+	for _, amount := range []sdk.Coin{claimRecord.AmountClaim, claimRecord.AmountVote, claimRecord.AmountDelegate} {
+		if amount.IsNil() || amount.Denom == "" || !amount.IsPositive() {
+			continue
+		}
+		outstanding = outstanding.Add(amount)
+	}
+	return outstanding
+}
+
 // ChainFromString convert chain string to Chain Enum type
 func ChainFromString(chain string) (Chain, error) {
 	chainID, ok := Chain_value[strings.ToUpper(chain)]
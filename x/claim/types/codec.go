@@ -12,6 +12,8 @@ func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgClaimArkeo{}, "claim/ClaimArkeo", nil)
 	cdc.RegisterConcrete(&MsgTransferClaim{}, "claim/TransferClaim", nil)
 	cdc.RegisterConcrete(&MsgAddClaim{}, "claim/AddClaim", nil)
+	cdc.RegisterConcrete(&MsgClaimAll{}, "claim/ClaimAll", nil)
+	cdc.RegisterConcrete(&MsgClaimThorchain{}, "claim/ClaimThorchain", nil)
 	// this line is used by starport scaffolding # 2
 }
 
@@ -28,6 +30,12 @@ func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
 	registry.RegisterImplementations((*sdk.Msg)(nil),
 		&MsgAddClaim{},
 	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgClaimAll{},
+	)
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgClaimThorchain{},
+	)
 	// this line is used by starport scaffolding # 3
 
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
@@ -9,4 +9,10 @@ var (
 	ErrNoClaimableAmount           = errors.Register(ModuleName, 2, "No Claimable Arkeo")
 	ErrInvalidSignature            = errors.Register(ModuleName, 3, "Invalid signature")
 	ErrClaimRecordNotTransferrable = errors.Register(ModuleName, 4, "Claim record can not be transferred")
+	ErrClaimRecordNotFound         = errors.Register(ModuleName, 5, "Claim record not found")
+	ErrAlreadyClaimed              = errors.Register(ModuleName, 6, "Already claimed")
+	ErrMerkleRoundNotFound         = errors.Register(ModuleName, 7, "Merkle round not found")
+	ErrInvalidMerkleProof          = errors.Register(ModuleName, 8, "Invalid merkle proof")
+	ErrRoundNotStarted             = errors.Register(ModuleName, 9, "Airdrop round has not started")
+	ErrInvalidThorchainProof       = errors.Register(ModuleName, 10, "Invalid thorchain claim proof")
 )
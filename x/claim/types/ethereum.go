@@ -11,6 +11,7 @@ var (
 		"Claim": []apitypes.Type{
 			{Name: "address", Type: "address"},
 			{Name: "arkeoAddress", Type: "string"},
+			{Name: "arkeoChainId", Type: "string"},
 			{Name: "amount", Type: "string"},
 		},
 		"EIP712Domain": []apitypes.Type{
@@ -22,11 +23,29 @@ var (
 
 	EIP712Domain = apitypes.TypedDataDomain{
 		Name:    "ArkdropClaim",
-		Version: "1",
+		Version: "2",
 		ChainId: math.NewHexOrDecimal256(1),
 	}
 )
 
+// NewClaimTypedData returns the EIP-712 typed data an ethereum address signs
+// to claim its airdrop, as passed to eth_signTypedData_v4. It binds the claim
+// to the arkeo address receiving it and to the arkeo chain, so a signature
+// cannot be replayed for another address or on another arkeo network.
+func NewClaimTypedData(ethAddress, arkeoAddress, arkeoChainID, amount string) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types:       EIP712Types,
+		PrimaryType: "Claim",
+		Domain:      EIP712Domain,
+		Message: apitypes.TypedDataMessage{
+			"address":      common.HexToAddress(ethAddress).String(),
+			"arkeoAddress": arkeoAddress,
+			"arkeoChainId": arkeoChainID,
+			"amount":       amount,
+		},
+	}
+}
+
 // isValidEthAddress checks if the provided string is a valid address or not.
 func IsValidEthAddress(address string) bool {
 	return common.IsHexAddress(address)
@@ -1,6 +1,14 @@
 package types
 
 const (
-	EventTypeClaim        = "claim"
-	EventTypeClaimFromEth = "claim_from_eth"
+	EventTypeClaim         = "claim"
+	EventTypeClaimFromEth  = "claim_from_eth"
+	EventTypeClaimFromThor = "claim_from_thorchain"
+	EventTypeClawback      = "clawback"
+)
+
+const (
+	AttributeKeyActions = "actions"
+	AttributeKeyChain   = "chain"
+	AttributeKeyRound   = "round"
 )
@@ -1,7 +1,8 @@
 package types
 
 import (
-// this line is used by starport scaffolding # genesis/types/import
+	"fmt"
+	// this line is used by starport scaffolding # genesis/types/import
 )
 
 // DefaultIndex is the default global index
@@ -20,5 +21,33 @@ func DefaultGenesis() *GenesisState {
 func (gs GenesisState) Validate() error {
 	// this line is used by starport scaffolding # genesis/types/validate
 
+	if err := gs.AirdropTotals.GenesisTotal.Validate(); err != nil {
+		return err
+	}
+	if err := gs.AirdropTotals.Claimed.Validate(); err != nil {
+		return err
+	}
+	if err := gs.AirdropTotals.ClawedBack.Validate(); err != nil {
+		return err
+	}
+
+	for _, record := range gs.ClaimRecords {
+		if record.Round == 0 {
+			continue
+		}
+		if _, ok := gs.Params.GetMerkleRound(record.Round); !ok {
+			return fmt.Errorf("claim record of %s for unknown round %d", record.Address, record.Round)
+		}
+	}
+
+	for _, claim := range gs.MerkleClaims {
+		if _, ok := gs.Params.GetMerkleRound(claim.Round); !ok {
+			return fmt.Errorf("merkle claim for unknown round %d", claim.Round)
+		}
+		if !IsValidEthAddress(claim.EthAddress) {
+			return fmt.Errorf("invalid merkle claim address %s", claim.EthAddress)
+		}
+	}
+
 	return gs.Params.Validate()
 }
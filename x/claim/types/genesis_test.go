@@ -26,6 +26,22 @@ func TestGenesisState_Validate(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			desc: "invalid merkle root",
+			genState: &types.GenesisState{
+				Params: types.Params{
+					MerkleRounds: []types.MerkleRound{{Round: 1, Root: "0x1234"}},
+				},
+			},
+			valid: false,
+		},
+		{
+			desc: "merkle claim for unknown round",
+			genState: &types.GenesisState{
+				MerkleClaims: []types.MerkleClaim{{Round: 1, EthAddress: "0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5"}},
+			},
+			valid: false,
+		},
 		// this line is used by starport scaffolding # types/genesis/testcase
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
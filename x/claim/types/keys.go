@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName defines the module name
 	ModuleName = "claimarkeo" // append arkeo to avoid namespace collisions with comsos claim module
@@ -18,8 +22,45 @@ const (
 
 	// ClaimRecordsStorePrefix defines the store prefix for the claim records (by eth address)
 	ClaimRecordsEthStorePrefix = "claimrecordsethereum"
+
+	// ClaimRecordsThorchainStorePrefix defines the store prefix for the claim
+	// records (by thorchain address)
+	ClaimRecordsThorchainStorePrefix = "claimrecordsthorchain"
+
+	// AirdropTotalsKey defines the store key for the airdrop totals
+	AirdropTotalsKey = "airdroptotals"
+
+	// MerkleClaimsStorePrefix defines the store prefix for the merkle round
+	// leaves that have been claimed
+	MerkleClaimsStorePrefix = "merkleclaims"
+
+	// RoundClaimRecordsStorePrefix defines the store prefix for the claim
+	// records of the airdrop rounds after the initial one
+	RoundClaimRecordsStorePrefix = "roundclaimrecords"
 )
 
 func KeyPrefix(p string) []byte {
 	return []byte(p)
 }
+
+// GetClaimRecordKey returns the key of an address's claim record within the
+// store of its chain. The address is normalized first, so records written
+// from a snapshot and looked up at claim time agree on the key whatever the
+// casing of the address.
+func GetClaimRecordKey(address string, chain Chain) ([]byte, error) {
+	addr, err := NormalizeAddress(address, chain)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(addr), nil
+}
+
+// GetMerkleClaimKey returns the key marking the leaf of an ethereum address in
+// a merkle round as claimed.
+func GetMerkleClaimKey(round uint64, ethAddress string) ([]byte, error) {
+	addr, err := NormalizeAddress(ethAddress, ETHEREUM)
+	if err != nil {
+		return nil, err
+	}
+	return append(sdk.Uint64ToBigEndian(round), []byte(addr)...), nil
+}
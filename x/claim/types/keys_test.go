@@ -0,0 +1,28 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClaimRecordKey(t *testing.T) {
+	expected := []byte("0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5")
+	for _, addr := range []string{
+		"0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5",
+		"0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5",
+		"0xDAFEA492D9C6733AE3D56B7ED1ADB60692C98BC5",
+		"DAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5",
+	} {
+		key, err := GetClaimRecordKey(addr, ETHEREUM)
+		require.NoError(t, err, addr)
+		require.Equal(t, expected, key, addr)
+	}
+
+	_, err := GetClaimRecordKey("0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98", ETHEREUM)
+	require.Error(t, err)
+
+	// an eth address is not a valid arkeo address
+	_, err = GetClaimRecordKey("0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5", ARKEO)
+	require.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MerkleLeaf returns the leaf committing an ethereum address to its airdrop
+// amounts, keccak256 of the abi packed address and uint256 amounts.
+func MerkleLeaf(ethAddress string, amountClaim, amountVote, amountDelegate sdk.Int) []byte {
+	return crypto.Keccak256(
+		ethcommon.HexToAddress(ethAddress).Bytes(),
+		math.U256Bytes(amountClaim.BigInt()),
+		math.U256Bytes(amountVote.BigInt()),
+		math.U256Bytes(amountDelegate.BigInt()),
+	)
+}
+
+// VerifyMerkleProof checks the leaf hashes up to the root through the proof.
+// Pairs are hashed in sorted order, so the proof does not need to carry the
+// position of each sibling.
+func VerifyMerkleProof(root, leaf []byte, proof [][]byte) bool {
+	computed := leaf
+	for _, sibling := range proof {
+		computed = hashMerklePair(computed, sibling)
+	}
+	return bytes.Equal(computed, root)
+}
+
+// MerkleTree returns the root of the tree built from the leaves and the proof
+// of every leaf, in the order given. A node without a sibling is carried up to
+// the next level unchanged.
+func MerkleTree(leaves [][]byte) ([]byte, [][][]byte) {
+	if len(leaves) == 0 {
+		return nil, nil
+	}
+
+	proofs := make([][][]byte, len(leaves))
+	// positions tracks the index of each leaf's ancestor within the level
+	positions := make([]int, len(leaves))
+	for i := range positions {
+		positions[i] = i
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, hashMerklePair(level[i], level[i+1]))
+		}
+		for i, pos := range positions {
+			if sibling := pos ^ 1; sibling < len(level) {
+				proofs[i] = append(proofs[i], level[sibling])
+			}
+			positions[i] = pos / 2
+		}
+		level = next
+	}
+	return level[0], proofs
+}
+
+func hashMerklePair(a, b []byte) []byte {
+	if bytes.Compare(a, b) > 0 {
+		a, b = b, a
+	}
+	return crypto.Keccak256(a, b)
+}
+
+// DecodeMerkleHash decodes a hex encoded 32 byte hash, with or without a 0x
+// prefix.
+func DecodeMerkleHash(s string) ([]byte, error) {
+	bz, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) != 32 {
+		return nil, fmt.Errorf("merkle hash must be 32 bytes, got %d", len(bz))
+	}
+	return bz, nil
+}
+
+// HasMerkleRoot returns whether the round's recipients are committed to by a
+// merkle root rather than held in state as claim records.
+func (r MerkleRound) HasMerkleRoot() bool {
+	return r.Root != ""
+}
+
+// HasDecay returns whether the round follows a decay schedule, a round
+// without one stays claimable in full and never ends.
+func (r MerkleRound) HasDecay() bool {
+	return r.BlocksUntilDecay > 0 || r.BlocksOfDecay > 0
+}
+
+// EndHeight returns the height the claimable amounts of the round have fully
+// decayed at.
+func (r MerkleRound) EndHeight() int64 {
+	return r.StartHeight + r.BlocksUntilDecay + r.BlocksOfDecay
+}
+
+// HasEnded returns whether the round has ended at the given height.
+func (r MerkleRound) HasEnded(height int64) bool {
+	return r.HasDecay() && height > r.EndHeight()
+}
+
+// GetFundingAccount returns the module account the round is paid out from.
+func (r MerkleRound) GetFundingAccount() string {
+	if r.FundingAccount == "" {
+		return ModuleName
+	}
+	return r.FundingAccount
+}
+
+// GetMerkleRound returns the merkle round with the given number.
+func (p Params) GetMerkleRound(round uint64) (MerkleRound, bool) {
+	for _, r := range p.MerkleRounds {
+		if r.Round == round {
+			return r, true
+		}
+	}
+	return MerkleRound{}, false
+}
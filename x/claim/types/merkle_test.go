@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerkleTree(t *testing.T) {
+	addresses := []string{
+		"0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5",
+		"0xbd3afb0bb76683ecb4225f9dbc91f998713c3b01",
+		"0x0000000000000000000000000000000000000001",
+	}
+	leaves := make([][]byte, len(addresses))
+	for i, addr := range addresses {
+		leaves[i] = MerkleLeaf(addr, sdk.NewInt(int64(i+1)), sdk.NewInt(2), sdk.NewInt(3))
+	}
+
+	root, proofs := MerkleTree(leaves)
+	require.Len(t, root, 32)
+	for i, leaf := range leaves {
+		require.True(t, VerifyMerkleProof(root, leaf, proofs[i]), "leaf %d", i)
+	}
+
+	// the leaf commits to the address and every amount
+	require.Equal(t, leaves[0], MerkleLeaf("0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5", sdk.NewInt(1), sdk.NewInt(2), sdk.NewInt(3)))
+	require.False(t, VerifyMerkleProof(root, MerkleLeaf(addresses[0], sdk.NewInt(1), sdk.NewInt(2), sdk.NewInt(4)), proofs[0]))
+	require.False(t, VerifyMerkleProof(root, leaves[0], proofs[1]))
+
+	// a single leaf is its own root
+	root, proofs = MerkleTree(leaves[:1])
+	require.Equal(t, leaves[0], root)
+	require.True(t, VerifyMerkleProof(root, leaves[0], proofs[0]))
+}
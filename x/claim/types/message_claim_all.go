@@ -0,0 +1,37 @@
+package types
+
+import (
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const TypeMsgClaimAll = "claim_all"
+
+var _ sdk.Msg = &MsgClaimAll{}
+
+func NewMsgClaimAll(creator cosmos.AccAddress) *MsgClaimAll {
+	return &MsgClaimAll{
+		Creator: creator,
+	}
+}
+
+func (msg *MsgClaimAll) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgClaimAll) Type() string {
+	return TypeMsgClaimAll
+}
+
+func (msg *MsgClaimAll) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgClaimAll) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg *MsgClaimAll) ValidateBasic() error {
+	return nil
+}
@@ -0,0 +1,8 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestMsgClaimAll_ValidateBasic(t *testing.T) {
+}
@@ -1,8 +1,10 @@
 package types
 
 import (
+	"cosmossdk.io/errors"
 	"github.com/arkeonetwork/arkeo/common/cosmos"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 const TypeMsgClaimEth = "claim_eth"
@@ -14,6 +16,10 @@ func NewMsgClaimEth(creator cosmos.AccAddress, ethAdress, signature string) *Msg
 		Creator:    creator,
 		EthAddress: ethAdress,
 		Signature:  signature,
+		// only a merkle claim carries amounts
+		AmountClaim:    sdk.ZeroInt(),
+		AmountVote:     sdk.ZeroInt(),
+		AmountDelegate: sdk.ZeroInt(),
 	}
 }
 
@@ -35,5 +41,33 @@ func (msg *MsgClaimEth) GetSignBytes() []byte {
 }
 
 func (msg *MsgClaimEth) ValidateBasic() error {
+	if msg.Round == 0 {
+		return nil
+	}
+
+	// a merkle claim carries the amounts of its leaf
+	for _, amount := range []sdk.Int{msg.AmountClaim, msg.AmountVote, msg.AmountDelegate} {
+		if amount.IsNil() || amount.IsNegative() {
+			return errors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid merkle claim amount")
+		}
+	}
+	for _, hash := range msg.Proof {
+		if _, err := DecodeMerkleHash(hash); err != nil {
+			return errors.Wrap(ErrInvalidMerkleProof, err.Error())
+		}
+	}
 	return nil
 }
+
+// MerkleProof returns the decoded proof of a merkle claim.
+func (msg *MsgClaimEth) MerkleProof() ([][]byte, error) {
+	proof := make([][]byte, len(msg.Proof))
+	for i, hash := range msg.Proof {
+		bz, err := DecodeMerkleHash(hash)
+		if err != nil {
+			return nil, err
+		}
+		proof[i] = bz
+	}
+	return proof, nil
+}
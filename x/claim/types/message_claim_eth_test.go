@@ -2,7 +2,76 @@ package types
 
 import (
 	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkeonetwork/arkeo/testutil/sample"
 )
 
 func TestMsgClaimEth_ValidateBasic(t *testing.T) {
+	ethAddress := "0xDAFEA492D9c6733ae3d56b7Ed1ADB60692c98Bc5"
+	hash := "0x4f9c1b8e8a7d0e5c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a39281706"
+	tests := []struct {
+		name string
+		msg  MsgClaimEth
+		err  error
+	}{
+		{
+			name: "claim record",
+			msg: MsgClaimEth{
+				Creator:    sample.AccAddress(),
+				EthAddress: ethAddress,
+			},
+		},
+		{
+			name: "negative merkle amount",
+			msg: MsgClaimEth{
+				Creator:        sample.AccAddress(),
+				EthAddress:     ethAddress,
+				Round:          1,
+				Proof:          []string{hash},
+				AmountClaim:    sdk.NewInt(-1),
+				AmountVote:     sdk.ZeroInt(),
+				AmountDelegate: sdk.ZeroInt(),
+			},
+			err: sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "malformed proof",
+			msg: MsgClaimEth{
+				Creator:        sample.AccAddress(),
+				EthAddress:     ethAddress,
+				Round:          1,
+				Proof:          []string{"0x1234"},
+				AmountClaim:    sdk.NewInt(1),
+				AmountVote:     sdk.ZeroInt(),
+				AmountDelegate: sdk.ZeroInt(),
+			},
+			err: ErrInvalidMerkleProof,
+		},
+		{
+			name: "merkle claim",
+			msg: MsgClaimEth{
+				Creator:        sample.AccAddress(),
+				EthAddress:     ethAddress,
+				Round:          1,
+				Proof:          []string{hash},
+				AmountClaim:    sdk.NewInt(1),
+				AmountVote:     sdk.ZeroInt(),
+				AmountDelegate: sdk.ZeroInt(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
 }
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"encoding/hex"
+
+	"cosmossdk.io/errors"
+	"github.com/arkeonetwork/arkeo/common/cosmos"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const TypeMsgClaimThorchain = "claim_thorchain"
+
+var _ sdk.Msg = &MsgClaimThorchain{}
+
+func NewMsgClaimThorchain(creator cosmos.AccAddress, thorAddress string) *MsgClaimThorchain {
+	return &MsgClaimThorchain{
+		Creator:     creator,
+		ThorAddress: thorAddress,
+	}
+}
+
+func (msg *MsgClaimThorchain) Route() string {
+	return RouterKey
+}
+
+func (msg *MsgClaimThorchain) Type() string {
+	return TypeMsgClaimThorchain
+}
+
+func (msg *MsgClaimThorchain) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+func (msg *MsgClaimThorchain) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// IsAttested returns true when the claim is proven by the oracle set rather
+// than signed by the thorchain address
+func (msg *MsgClaimThorchain) IsAttested() bool {
+	return msg.TxId != ""
+}
+
+func (msg *MsgClaimThorchain) ValidateBasic() error {
+	if !IsValidThorAddress(msg.ThorAddress) {
+		return errors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid thorchain address %s", msg.ThorAddress)
+	}
+
+	if !msg.IsAttested() {
+		if len(msg.PubKey) == 0 || len(msg.Signature) == 0 {
+			return errors.Wrapf(ErrInvalidThorchainProof, "a claim needs either a signature or a tx id")
+		}
+		return nil
+	}
+
+	if len(msg.PubKey) > 0 || len(msg.Signature) > 0 {
+		return errors.Wrapf(ErrInvalidThorchainProof, "an attested claim cannot also be signed")
+	}
+	if bz, err := hex.DecodeString(msg.TxId); err != nil || len(bz) != 32 {
+		return errors.Wrapf(ErrInvalidThorchainProof, "invalid tx id %s", msg.TxId)
+	}
+	if len(msg.OracleSignatures) == 0 {
+		return errors.Wrapf(ErrInvalidThorchainProof, "an attested claim needs oracle signatures")
+	}
+	return nil
+}
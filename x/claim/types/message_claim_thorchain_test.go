@@ -0,0 +1,86 @@
+package types
+
+import (
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arkeonetwork/arkeo/testutil/sample"
+)
+
+func TestMsgClaimThorchain_ValidateBasic(t *testing.T) {
+	thorAddress := "thor1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5e949nr"
+	txID := "4F1C3A0B9E8D7C6B5A49382716F5E4D3C2B1A09F8E7D6C5B4A39281706F5E4D3"
+	tests := []struct {
+		name string
+		msg  MsgClaimThorchain
+		err  error
+	}{
+		{
+			name: "invalid thor address",
+			msg: MsgClaimThorchain{
+				Creator:     sample.AccAddress(),
+				ThorAddress: "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu",
+				PubKey:      []byte{1},
+				Signature:   []byte{1},
+			},
+			err: sdkerrors.ErrInvalidAddress,
+		},
+		{
+			name: "no proof",
+			msg: MsgClaimThorchain{
+				Creator:     sample.AccAddress(),
+				ThorAddress: thorAddress,
+			},
+			err: ErrInvalidThorchainProof,
+		},
+		{
+			name: "signed",
+			msg: MsgClaimThorchain{
+				Creator:     sample.AccAddress(),
+				ThorAddress: thorAddress,
+				PubKey:      []byte{1},
+				Signature:   []byte{1},
+			},
+		},
+		{
+			name: "malformed tx id",
+			msg: MsgClaimThorchain{
+				Creator:          sample.AccAddress(),
+				ThorAddress:      thorAddress,
+				TxId:             "abc",
+				OracleSignatures: [][]byte{{1}},
+			},
+			err: ErrInvalidThorchainProof,
+		},
+		{
+			name: "attested without signatures",
+			msg: MsgClaimThorchain{
+				Creator:     sample.AccAddress(),
+				ThorAddress: thorAddress,
+				TxId:        txID,
+			},
+			err: ErrInvalidThorchainProof,
+		},
+		{
+			name: "attested",
+			msg: MsgClaimThorchain{
+				Creator:          sample.AccAddress(),
+				ThorAddress:      thorAddress,
+				TxId:             txID,
+				OracleSignatures: [][]byte{{1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	fmt "fmt"
+	"strings"
 	time "time"
 
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
@@ -27,6 +28,13 @@ var (
 	DeafultAirdropStartTime time.Time = time.Now().UTC()
 )
 
+var KeyMerkleRounds = []byte("MerkleRounds")
+
+var (
+	KeyThorchainOracles         = []byte("ThorchainOracles")
+	KeyThorchainOracleThreshold = []byte("ThorchainOracleThreshold")
+)
+
 var _ paramtypes.ParamSet = (*Params)(nil)
 
 // ParamKeyTable the param key table for launch module
@@ -54,6 +62,11 @@ func DefaultParams() Params {
 	}
 }
 
+// AirdropEndTime returns the time the claimable amounts have fully decayed
+func (p Params) AirdropEndTime() time.Time {
+	return p.AirdropStartTime.Add(p.DurationUntilDecay).Add(p.DurationOfDecay)
+}
+
 // ParamSetPairs get the params.ParamSet
 func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 	return paramtypes.ParamSetPairs{
@@ -61,11 +74,23 @@ func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 		paramtypes.NewParamSetPair(KeyDurationUntilDecay, &p.DurationUntilDecay, validateDurationUntilDecay),
 		paramtypes.NewParamSetPair(KeyDurationOfDecay, &p.DurationOfDecay, validateDurationOfDecay),
 		paramtypes.NewParamSetPair(KeyClaimDenom, &p.ClaimDenom, validateClaimDenom),
+		paramtypes.NewParamSetPair(KeyMerkleRounds, &p.MerkleRounds, validateMerkleRounds),
+		paramtypes.NewParamSetPair(KeyThorchainOracles, &p.ThorchainOracles, validateThorchainOracles),
+		paramtypes.NewParamSetPair(KeyThorchainOracleThreshold, &p.ThorchainOracleThreshold, validateThorchainOracleThreshold),
 	}
 }
 
 // Validate validates the set of params
 func (p Params) Validate() error {
+	if err := validateMerkleRounds(p.MerkleRounds); err != nil {
+		return err
+	}
+	if err := validateThorchainOracles(p.ThorchainOracles); err != nil {
+		return err
+	}
+	if p.ThorchainOracleThreshold > uint32(len(p.ThorchainOracles)) {
+		return fmt.Errorf("thorchain oracle threshold %d is above the %d oracles", p.ThorchainOracleThreshold, len(p.ThorchainOracles))
+	}
 	return nil
 }
 
@@ -100,3 +125,60 @@ func validateClaimDenom(i interface{}) error {
 	}
 	return nil
 }
+
+func validateMerkleRounds(i interface{}) error {
+	rounds, ok := i.([]MerkleRound)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[uint64]bool, len(rounds))
+	for _, r := range rounds {
+		// round zero is reserved for claims from claim records
+		if r.Round == 0 {
+			return fmt.Errorf("merkle round must be greater than zero")
+		}
+		if seen[r.Round] {
+			return fmt.Errorf("duplicate merkle round %d", r.Round)
+		}
+		seen[r.Round] = true
+		// a round without a root is claimed from its claim records
+		if r.Root != "" {
+			if _, err := DecodeMerkleHash(r.Root); err != nil {
+				return fmt.Errorf("invalid root for merkle round %d: %w", r.Round, err)
+			}
+		}
+		if r.StartHeight < 0 || r.BlocksUntilDecay < 0 || r.BlocksOfDecay < 0 {
+			return fmt.Errorf("merkle round %d cannot have a negative schedule", r.Round)
+		}
+		if strings.TrimSpace(r.FundingAccount) != r.FundingAccount {
+			return fmt.Errorf("invalid funding account for merkle round %d", r.Round)
+		}
+	}
+	return nil
+}
+
+func validateThorchainOracles(i interface{}) error {
+	oracles, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(oracles))
+	for _, oracle := range oracles {
+		if _, err := DecodeThorchainOracle(oracle); err != nil {
+			return fmt.Errorf("invalid thorchain oracle %s: %w", oracle, err)
+		}
+		if seen[strings.ToLower(oracle)] {
+			return fmt.Errorf("duplicate thorchain oracle %s", oracle)
+		}
+		seen[strings.ToLower(oracle)] = true
+	}
+	return nil
+}
+
+func validateThorchainOracleThreshold(i interface{}) error {
+	_, ok := i.(uint32)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
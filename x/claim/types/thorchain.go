@@ -0,0 +1,106 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// ThorchainPrefixes are the bech32 prefixes of thorchain addresses on its
+// mainnet, stagenet and testnet
+var ThorchainPrefixes = []string{"thor", "sthor", "tthor"}
+
+// IsValidThorAddress checks the address is the bech32 encoding of a 20 byte
+// account on one of the thorchain networks
+func IsValidThorAddress(address string) bool {
+	hrp, bz, err := bech32.DecodeAndConvert(address)
+	if err != nil || len(bz) != 20 {
+		return false
+	}
+	for _, prefix := range ThorchainPrefixes {
+		if hrp == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeThorchainOracle decodes the hex encoded compressed secp256k1 public
+// key of a thorchain oracle
+func DecodeThorchainOracle(oracle string) (*secp256k1.PubKey, error) {
+	bz, err := hex.DecodeString(oracle)
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) != secp256k1.PubKeySize {
+		return nil, fmt.Errorf("public key must be %d bytes, got %d", secp256k1.PubKeySize, len(bz))
+	}
+	return &secp256k1.PubKey{Key: bz}, nil
+}
+
+// ThorchainClaimMemo returns the memo a thorchain transaction carries to bind
+// its sender to an arkeo address
+func ThorchainClaimMemo(arkeoAddress string) string {
+	return fmt.Sprintf("ARKEO-CLAIM:%s", arkeoAddress)
+}
+
+// ThorchainClaimSignBytes returns the bytes a thorchain address signs to bind
+// itself to an arkeo address. The chain id keeps the signature from being
+// replayed on another arkeo network.
+func ThorchainClaimSignBytes(chainID, thorAddress, arkeoAddress string) []byte {
+	return []byte(fmt.Sprintf("arkeo-claim:%s:%s:%s", chainID, strings.ToLower(thorAddress), arkeoAddress))
+}
+
+// ThorchainAttestationSignBytes returns the bytes an oracle signs to attest
+// that a thorchain transaction sent by the thorchain address carried the claim
+// memo of the arkeo address
+func ThorchainAttestationSignBytes(chainID, txID, thorAddress, arkeoAddress string) []byte {
+	return []byte(fmt.Sprintf("arkeo-claim-attestation:%s:%s:%s:%s", chainID, strings.ToUpper(txID), strings.ToLower(thorAddress), arkeoAddress))
+}
+
+// VerifyThorchainSignature checks the public key belongs to the thorchain
+// address and signed the claim binding it to the arkeo address
+func VerifyThorchainSignature(chainID, thorAddress, arkeoAddress string, pubKey, signature []byte) error {
+	if len(pubKey) != secp256k1.PubKeySize {
+		return fmt.Errorf("public key must be %d bytes, got %d", secp256k1.PubKeySize, len(pubKey))
+	}
+	pk := &secp256k1.PubKey{Key: pubKey}
+	hrp, _, err := bech32.DecodeAndConvert(thorAddress)
+	if err != nil {
+		return err
+	}
+	addr, err := bech32.ConvertAndEncode(hrp, pk.Address())
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(addr, thorAddress) {
+		return fmt.Errorf("public key is for %s, not %s", addr, thorAddress)
+	}
+	if !pk.VerifySignature(ThorchainClaimSignBytes(chainID, thorAddress, arkeoAddress), signature) {
+		return fmt.Errorf("invalid signature for %s", thorAddress)
+	}
+	return nil
+}
+
+// CountThorchainAttestations returns the number of distinct oracles whose
+// signature attests to the thorchain transaction
+func CountThorchainAttestations(oracles []string, chainID, txID, thorAddress, arkeoAddress string, signatures [][]byte) (uint32, error) {
+	signBytes := ThorchainAttestationSignBytes(chainID, txID, thorAddress, arkeoAddress)
+	var count uint32
+	for _, oracle := range oracles {
+		pk, err := DecodeThorchainOracle(oracle)
+		if err != nil {
+			return 0, err
+		}
+		for _, sig := range signatures {
+			if pk.VerifySignature(signBytes, sig) {
+				count++
+				break
+			}
+		}
+	}
+	return count, nil
+}